@@ -66,6 +66,9 @@ GIT_VERSION := $(subst v,,$(shell \
 # Determine project's git branch.
 GIT_BRANCH ?= $(shell git rev-parse --abbrev-ref HEAD 2>/dev/null)
 
+# Determine project's git commit.
+GIT_COMMIT ?= $(shell git rev-parse HEAD 2>/dev/null)
+
 PUNCH_CONFIG_FILE := $(abspath $(SELF_DIR).punch_config.py)
 PUNCH_VERSION_FILE := $(abspath $(SELF_DIR).punch_version.py)
 # Obtain project's version as tracked by the Punch tool.
@@ -150,9 +153,11 @@ GOFLAGS ?= -trimpath -v
 export GOLDFLAGS_VERSION := -X github.com/oasisprotocol/oasis-core/go/common/version.SoftwareVersion=$(VERSION)
 # Project's git branch as the linker's string value definition.
 GOLDFLAGS_BRANCH := -X github.com/oasisprotocol/oasis-core/go/common/version.GitBranch=$(GIT_BRANCH)
+# Project's git commit as the linker's string value definition.
+GOLDFLAGS_COMMIT := -X github.com/oasisprotocol/oasis-core/go/common/version.GitCommit=$(GIT_COMMIT)
 
 # Go's linker flags.
-export GOLDFLAGS ?= "$(GOLDFLAGS_VERSION) $(GOLDFLAGS_BRANCH)"
+export GOLDFLAGS ?= "$(GOLDFLAGS_VERSION) $(GOLDFLAGS_BRANCH) $(GOLDFLAGS_COMMIT)"
 
 # Helper that ensures the git workspace is clean.
 define ENSURE_GIT_CLEAN =
@@ -1,12 +1,15 @@
 # Metrics
 
 `oasis-node` can report a number of metrics to Prometheus server. By default,
-no metrics are collected and reported. There is one way to enable metrics
+no metrics are collected and reported. There are two ways to enable metrics
 reporting:
 
 * *Pull mode* listens on given address and waits for Prometheus to scrape the
   metrics.
 
+* *Push mode* periodically exports the metrics to a StatsD server or an
+  OpenTelemetry (OTLP) collector, for monitoring stacks that can't scrape.
+
 ## Configuring `oasis-node` in Pull Mode
 
 To run `oasis-node` in *pull mode* set flag `--metrics.mode pull` and provide
@@ -28,6 +31,35 @@ Prometheus:
       - targets: ['localhost:3000']
 ```
 
+## Configuring `oasis-node` in Push Mode
+
+To run `oasis-node` in *push mode* select the metrics backend and the export
+interval in the `metrics` section of the node configuration. Any configured
+`labels` are attached to all exported metrics.
+
+To export metrics to a StatsD server over UDP, use the `statsd` backend and
+provide the server address. Labels are exported as DogStatsD tags.
+
+```yaml
+metrics:
+  mode: push
+  backend: statsd
+  address: 127.0.0.1:8125
+  interval: 10s
+```
+
+To export metrics to an OTLP/HTTP collector, use the `otlp` backend and provide
+the URL of the collector's metrics endpoint. Metrics are sent using the JSON
+encoding.
+
+```yaml
+metrics:
+  mode: push
+  backend: otlp
+  address: http://127.0.0.1:4318/v1/metrics
+  interval: 10s
+```
+
 ## Metrics Reported by `oasis-node`
 
 `oasis-node` reports metrics starting with `oasis_`.
@@ -0,0 +1,269 @@
+// Package hooks implements epoch transition hooks.
+//
+// Instead of each subsystem independently watching for epoch transitions,
+// subsystems register hooks which are executed sequentially in a defined
+// order on each epoch transition.
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/prometheus/client_golang/prometheus"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	cmnBackoff "github.com/oasisprotocol/oasis-core/go/common/backoff"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/service"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+// Order is the order in which a hook is executed. Hooks with a lower order
+// are executed first, hooks with the same order are executed in the order of
+// their names.
+type Order int
+
+const (
+	// OrderLightClient is the order of hooks which update light client trust.
+	OrderLightClient Order = 50
+	// OrderPruning is the order of hooks which prune or trim local state.
+	OrderPruning Order = 100
+	// OrderRegistration is the order of hooks which refresh registrations.
+	OrderRegistration Order = 400
+)
+
+// DefaultTimeout is the default maximum execution time of a hook.
+const DefaultTimeout = time.Minute
+
+// Hook is an epoch transition hook.
+//
+// The context passed to the hook is cancelled once the hook's timeout expires,
+// so that a stuck hook does not delay the hooks ordered after it.
+type Hook func(ctx context.Context, epoch beacon.EpochTime) error
+
+var (
+	hookDuration = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "oasis_epoch_hook_duration",
+			Help: "Epoch transition hook execution time (seconds).",
+		},
+		[]string{"hook"},
+	)
+	hookFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_epoch_hook_failures",
+			Help: "Number of failed epoch transition hook executions.",
+		},
+		[]string{"hook"},
+	)
+
+	hookCollectors = []prometheus.Collector{
+		hookDuration,
+		hookFailures,
+	}
+
+	metricsOnce sync.Once
+
+	_ service.BackgroundService = (*Dispatcher)(nil)
+)
+
+type registeredHook struct {
+	name    string
+	order   Order
+	timeout time.Duration
+	fn      Hook
+}
+
+// Dispatcher executes registered hooks on each epoch transition.
+type Dispatcher struct {
+	sync.Mutex
+
+	consensus consensus.Backend
+
+	hooks []*registeredHook
+
+	ctx       context.Context
+	cancelCtx context.CancelFunc
+	quitCh    chan struct{}
+
+	logger *logging.Logger
+}
+
+// Register registers a new epoch transition hook with the default timeout.
+//
+// Hooks may be registered at any time, a hook registered after the dispatcher
+// has been started will first be executed on the next epoch transition.
+func (d *Dispatcher) Register(name string, order Order, hook Hook) error {
+	return d.RegisterWithTimeout(name, order, DefaultTimeout, hook)
+}
+
+// RegisterWithTimeout registers a new epoch transition hook with the given
+// timeout.
+func (d *Dispatcher) RegisterWithTimeout(name string, order Order, timeout time.Duration, hook Hook) error {
+	if hook == nil {
+		return fmt.Errorf("epoch hooks: hook '%s' is nil", name)
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("epoch hooks: hook '%s' has invalid timeout: %s", name, timeout)
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	for _, h := range d.hooks {
+		if h.name == name {
+			return fmt.Errorf("epoch hooks: hook '%s' already registered", name)
+		}
+	}
+
+	d.hooks = append(d.hooks, &registeredHook{
+		name:    name,
+		order:   order,
+		timeout: timeout,
+		fn:      hook,
+	})
+	sort.SliceStable(d.hooks, func(i, j int) bool {
+		if d.hooks[i].order != d.hooks[j].order {
+			return d.hooks[i].order < d.hooks[j].order
+		}
+		return d.hooks[i].name < d.hooks[j].name
+	})
+
+	return nil
+}
+
+// Name returns the service name.
+func (d *Dispatcher) Name() string {
+	return "epoch hooks"
+}
+
+// Start starts the service.
+func (d *Dispatcher) Start() error {
+	go d.worker()
+	return nil
+}
+
+// Stop halts the service.
+func (d *Dispatcher) Stop() {
+	d.cancelCtx()
+}
+
+// Quit returns a channel that will be closed when the service terminates.
+func (d *Dispatcher) Quit() <-chan struct{} {
+	return d.quitCh
+}
+
+// Cleanup performs the service specific post-termination cleanup.
+func (d *Dispatcher) Cleanup() {
+}
+
+func (d *Dispatcher) worker() {
+	defer close(d.quitCh)
+
+	// Wait for consensus to be synced so that hooks are not triggered for
+	// historic epochs while catching up.
+	select {
+	case <-d.ctx.Done():
+		return
+	case <-d.consensus.Synced():
+	}
+
+	for {
+		// Only the latest epoch is of interest in case hooks take longer than
+		// an epoch to execute.
+		var (
+			ch  <-chan beacon.EpochTime
+			sub pubsub.ClosableSubscription
+		)
+		watch := func() error {
+			var err error
+			ch, sub, err = d.consensus.Beacon().WatchLatestEpoch(d.ctx)
+			if err != nil {
+				d.logger.Error("failed to watch epochs, retrying",
+					"err", err,
+				)
+			}
+			return err
+		}
+		if err := backoff.Retry(watch, backoff.WithContext(cmnBackoff.NewExponentialBackOff(), d.ctx)); err != nil {
+			return
+		}
+
+		d.watch(ch)
+		sub.Close()
+
+		if d.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// watch dispatches hooks on epochs received from the given channel until
+// the channel is closed.
+func (d *Dispatcher) watch(ch <-chan beacon.EpochTime) {
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case epoch, ok := <-ch:
+			if !ok {
+				d.logger.Warn("epoch subscription closed, resubscribing")
+				return
+			}
+			d.dispatch(epoch)
+		}
+	}
+}
+
+func (d *Dispatcher) dispatch(epoch beacon.EpochTime) {
+	d.Lock()
+	hooks := append([]*registeredHook{}, d.hooks...)
+	d.Unlock()
+
+	for _, h := range hooks {
+		if d.ctx.Err() != nil {
+			return
+		}
+
+		start := time.Now()
+		err := d.run(h, epoch)
+		hookDuration.With(prometheus.Labels{"hook": h.name}).Observe(time.Since(start).Seconds())
+		if err != nil {
+			hookFailures.With(prometheus.Labels{"hook": h.name}).Inc()
+			d.logger.Error("epoch hook failed",
+				"err", err,
+				"hook", h.name,
+				"epoch", epoch,
+			)
+		}
+	}
+}
+
+func (d *Dispatcher) run(h *registeredHook, epoch beacon.EpochTime) error {
+	ctx, cancel := context.WithTimeout(d.ctx, h.timeout)
+	defer cancel()
+
+	return h.fn(ctx, epoch)
+}
+
+// New creates a new epoch transition hook dispatcher.
+func New(ctx context.Context, consensus consensus.Backend) *Dispatcher {
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(hookCollectors...)
+	})
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &Dispatcher{
+		consensus: consensus,
+		ctx:       ctx,
+		cancelCtx: cancel,
+		quitCh:    make(chan struct{}),
+		logger:    logging.GetLogger("beacon/hooks"),
+	}
+}
@@ -0,0 +1,121 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+func TestDispatcher(t *testing.T) {
+	require := require.New(t)
+
+	d := New(context.Background(), nil)
+
+	var executed []string
+	hook := func(name string, err error) Hook {
+		return func(_ context.Context, epoch beacon.EpochTime) error {
+			require.EqualValues(42, epoch)
+			executed = append(executed, name)
+			return err
+		}
+	}
+
+	require.NoError(d.Register("registration", OrderRegistration, hook("registration", nil)))
+	require.NoError(d.Register("pruning b", OrderPruning, hook("pruning b", nil)))
+	require.NoError(d.Register("pruning a", OrderPruning, hook("pruning a", fmt.Errorf("failed"))))
+	require.NoError(d.Register("custom", Order(300), hook("custom", nil)))
+	require.NoError(d.Register("light client", OrderLightClient, hook("light client", nil)))
+	require.Error(d.Register("custom", Order(300), hook("custom", nil)), "duplicate hooks should be rejected")
+	require.Error(d.Register("nil", OrderPruning, nil), "nil hooks should be rejected")
+	require.Error(d.RegisterWithTimeout("no timeout", OrderPruning, 0, hook("no timeout", nil)), "invalid timeouts should be rejected")
+
+	d.dispatch(42)
+	require.Equal([]string{"light client", "pruning a", "pruning b", "custom", "registration"}, executed,
+		"hooks should be executed in order even if some fail")
+
+	// Stuck hooks should time out without blocking the following hooks.
+	d = New(context.Background(), nil)
+	executed = nil
+	require.NoError(d.RegisterWithTimeout("stuck", OrderPruning, 10*time.Millisecond, func(ctx context.Context, _ beacon.EpochTime) error {
+		<-ctx.Done()
+		executed = append(executed, "stuck")
+		return ctx.Err()
+	}))
+	require.NoError(d.Register("registration", OrderRegistration, hook("registration", nil)))
+
+	d.dispatch(42)
+	require.Equal([]string{"stuck", "registration"}, executed, "hooks should be executed after a stuck hook times out")
+
+	// No hooks should be executed after the dispatcher is stopped.
+	executed = nil
+	d.Stop()
+	d.dispatch(42)
+	require.Empty(executed)
+}
+
+type testBeacon struct {
+	beacon.Backend
+
+	failures int
+	watches  int
+	epochCh  chan beacon.EpochTime
+}
+
+func (b *testBeacon) WatchLatestEpoch(context.Context) (<-chan beacon.EpochTime, pubsub.ClosableSubscription, error) {
+	b.watches++
+	if b.watches <= b.failures {
+		return nil, nil, fmt.Errorf("not available")
+	}
+	return b.epochCh, pubsub.NewBroker(false).Subscribe(), nil
+}
+
+type testConsensus struct {
+	consensus.Backend
+
+	beacon *testBeacon
+}
+
+func (c *testConsensus) Synced() <-chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}
+
+func (c *testConsensus) Beacon() beacon.Backend {
+	return c.beacon
+}
+
+func TestDispatcherWatchRetry(t *testing.T) {
+	require := require.New(t)
+
+	b := &testBeacon{
+		failures: 2,
+		epochCh:  make(chan beacon.EpochTime),
+	}
+	d := New(context.Background(), &testConsensus{beacon: b})
+
+	epochs := make(chan beacon.EpochTime, 1)
+	require.NoError(d.Register("test", OrderRegistration, func(_ context.Context, epoch beacon.EpochTime) error {
+		epochs <- epoch
+		return nil
+	}))
+	require.NoError(d.Start())
+	defer d.Stop()
+
+	select {
+	case b.epochCh <- 42:
+	case <-d.Quit():
+		t.Fatalf("dispatcher should not terminate when watching epochs fails")
+	case <-time.After(10 * time.Second):
+		t.Fatalf("dispatcher should retry watching epochs")
+	}
+	require.EqualValues(42, <-epochs)
+	require.Equal(3, b.watches, "dispatcher should retry watching epochs until it succeeds")
+}
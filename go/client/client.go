@@ -0,0 +1,263 @@
+// Package client implements a single entry point for Go services that talk to
+// an Oasis node over gRPC.
+//
+// The package wraps the gRPC clients of the individual services and negotiates
+// the consensus protocol version with the node on connect. The service clients
+// are those of the respective service API packages and are not wrapped.
+//
+// The client supports nodes running the current and the previous major version
+// of the consensus protocol. Calls to nodes running the previous version are
+// translated by adapters for methods and types that have changed since. Methods
+// that are not available on the connected node fail with ErrNotSupported
+// instead of an opaque gRPC error.
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	vault "github.com/oasisprotocol/oasis-core/go/vault/api"
+)
+
+// ModuleName is the module name used for the client errors.
+const ModuleName = "client"
+
+var (
+	// ErrUnsupportedVersion is the error returned when the node uses a consensus
+	// protocol version that is not supported by the client.
+	ErrUnsupportedVersion = errors.New(ModuleName, 1, "client: unsupported consensus protocol version")
+
+	// ErrNotSupported is the error returned when the method is not supported by
+	// the node.
+	ErrNotSupported = errors.New(ModuleName, 2, "client: method not supported by node")
+)
+
+// SupportedVersions returns the consensus protocol versions supported by the
+// client, masked to their major version, from newest to oldest.
+//
+// Besides the current version, the previous major version is supported if the
+// client has adapters for it.
+func SupportedVersions() []version.Version {
+	current := version.ConsensusProtocol.MaskNonMajor()
+	versions := []version.Version{current}
+	if current.Major > 0 {
+		previous := version.Version{Major: current.Major - 1}
+		if _, ok := adapters[previous]; ok {
+			versions = append(versions, previous)
+		}
+	}
+	return versions
+}
+
+// negotiateVersion checks whether the consensus protocol version used by the
+// node is supported and returns the supported version it corresponds to.
+func negotiateVersion(nodeVersion version.Version) (version.Version, error) {
+	masked := nodeVersion.MaskNonMajor()
+	for _, v := range SupportedVersions() {
+		if v == masked {
+			return v, nil
+		}
+	}
+	return version.Version{}, fmt.Errorf("%w: %s", ErrUnsupportedVersion, nodeVersion)
+}
+
+// Client is a client for an Oasis node.
+type Client struct {
+	conn *grpc.ClientConn
+
+	version version.Version
+}
+
+// Version returns the negotiated consensus protocol version, masked to its
+// major version.
+func (c *Client) Version() version.Version {
+	return c.version
+}
+
+// Conn returns the underlying gRPC connection.
+func (c *Client) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
+// Close closes the underlying gRPC connection.
+func (c *Client) Close() error {
+	clearConnVersion(c.conn)
+	return c.conn.Close()
+}
+
+// Consensus returns the consensus client.
+func (c *Client) Consensus() *consensus.Client {
+	return consensus.NewClient(c.conn)
+}
+
+// Beacon returns the beacon client.
+func (c *Client) Beacon() *beacon.Client {
+	return beacon.NewClient(c.conn)
+}
+
+// Registry returns the registry client.
+func (c *Client) Registry() *registry.Client {
+	return registry.NewClient(c.conn)
+}
+
+// Staking returns the staking client.
+func (c *Client) Staking() *staking.Client {
+	return staking.NewClient(c.conn)
+}
+
+// Scheduler returns the scheduler client.
+func (c *Client) Scheduler() *scheduler.Client {
+	return scheduler.NewClient(c.conn)
+}
+
+// RootHash returns the roothash client.
+func (c *Client) RootHash() *roothash.Client {
+	return roothash.NewClient(c.conn)
+}
+
+// Governance returns the governance client.
+func (c *Client) Governance() *governance.Client {
+	return governance.NewClient(c.conn)
+}
+
+// Vault returns the vault client.
+func (c *Client) Vault() *vault.Client {
+	return vault.NewClient(c.conn)
+}
+
+// Runtime returns the runtime client.
+func (c *Client) Runtime() *runtimeClient.Client {
+	return runtimeClient.NewClient(c.conn)
+}
+
+// New creates a new client using an existing gRPC connection and negotiates
+// the consensus protocol version with the node.
+//
+// The connection should have been established with Dial or use the dial
+// options returned by DialOptions.
+func New(ctx context.Context, conn *grpc.ClientConn) (*Client, error) {
+	cs, err := consensus.NewClient(conn).GetStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to query consensus status: %w", err)
+	}
+	v, err := negotiateVersion(cs.Version)
+	if err != nil {
+		return nil, err
+	}
+	setConnVersion(conn, v)
+
+	return &Client{
+		conn:    conn,
+		version: v,
+	}, nil
+}
+
+// Dial connects to the node at the given address and negotiates the consensus
+// protocol version.
+func Dial(ctx context.Context, address string, opts ...grpc.DialOption) (*Client, error) {
+	conn, err := cmnGrpc.Dial(address, append(DialOptions(), opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to dial node: %w", err)
+	}
+	c, err := New(ctx, conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// DialOptions returns the gRPC dial options required by the client.
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(unaryCompatInterceptor),
+		grpc.WithChainStreamInterceptor(streamCompatInterceptor),
+	}
+}
+
+// mapCompatError maps errors caused by methods that the node does not
+// implement to ErrNotSupported.
+func mapCompatError(method string, err error) error {
+	if status.Code(err) != codes.Unimplemented {
+		return err
+	}
+	return fmt.Errorf("%w: %s", ErrNotSupported, method)
+}
+
+func unaryCompatInterceptor(
+	ctx context.Context,
+	method string,
+	req, rsp interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	a := adapterFor(cc)
+	if a == nil {
+		return mapCompatError(method, invoker(ctx, method, req, rsp, cc, opts...))
+	}
+	return mapCompatError(method, a.invoke(ctx, method, req, rsp, cc, invoker, opts...))
+}
+
+func streamCompatInterceptor(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	var ra *responseAdapter
+	streamMethod := method
+	if a := adapterFor(cc); a != nil {
+		ra = a.responses[method]
+		streamMethod = a.method(method)
+	}
+
+	stream, err := streamer(ctx, desc, cc, streamMethod, opts...)
+	if err != nil {
+		return nil, mapCompatError(method, err)
+	}
+	return &compatClientStream{
+		ClientStream: stream,
+		method:       method,
+		adapter:      ra,
+	}, nil
+}
+
+// compatClientStream is a client stream that maps errors of methods that the
+// node does not implement, as these are only reported when receiving, and
+// converts received messages of older versions.
+type compatClientStream struct {
+	grpc.ClientStream
+
+	method  string
+	adapter *responseAdapter
+}
+
+func (s *compatClientStream) RecvMsg(m interface{}) error {
+	if s.adapter == nil {
+		return mapCompatError(s.method, s.ClientStream.RecvMsg(m))
+	}
+
+	old := s.adapter.new()
+	if err := s.ClientStream.RecvMsg(old); err != nil {
+		return mapCompatError(s.method, err)
+	}
+	return s.adapter.convert(old, m)
+}
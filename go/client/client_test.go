@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+)
+
+func TestNegotiateVersion(t *testing.T) {
+	require := require.New(t)
+
+	current := version.ConsensusProtocol
+	require.Equal([]version.Version{
+		current.MaskNonMajor(),
+		{Major: current.Major - 1},
+	}, SupportedVersions(), "current and previous major versions should be supported")
+
+	v, err := negotiateVersion(current)
+	require.NoError(err, "current version should be supported")
+	require.Equal(current.MaskNonMajor(), v)
+
+	v, err = negotiateVersion(version.Version{Major: current.Major, Minor: current.Minor + 1})
+	require.NoError(err, "other minor versions should be supported")
+	require.Equal(current.MaskNonMajor(), v)
+
+	previous := version.Version{Major: current.Major - 1, Minor: 3, Patch: 1}
+	v, err = negotiateVersion(previous)
+	require.NoError(err, "previous major version should be supported")
+	require.Equal(previous.MaskNonMajor(), v)
+
+	_, err = negotiateVersion(version.Version{Major: current.Major + 1})
+	require.ErrorIs(err, ErrUnsupportedVersion, "newer major versions should be rejected")
+
+	_, err = negotiateVersion(version.Version{Major: current.Major - 2})
+	require.ErrorIs(err, ErrUnsupportedVersion, "older major versions should be rejected")
+}
+
+func TestCompatInterceptor(t *testing.T) {
+	require := require.New(t)
+
+	invoke := func(err error) error {
+		return unaryCompatInterceptor(context.Background(), "/oasis-core.Test/Method", nil, nil, nil,
+			func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+				return err
+			},
+		)
+	}
+
+	require.NoError(invoke(nil))
+
+	err := invoke(status.Error(codes.Unimplemented, "unknown method"))
+	require.ErrorIs(err, ErrNotSupported, "unimplemented methods should map to ErrNotSupported")
+
+	otherErr := errors.New("other error")
+	require.Equal(otherErr, invoke(otherErr), "other errors should be passed through")
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+// connVersions are the negotiated consensus protocol versions of the connections used by clients.
+var connVersions sync.Map
+
+func setConnVersion(conn *grpc.ClientConn, v version.Version) {
+	connVersions.Store(conn, v)
+}
+
+func clearConnVersion(conn *grpc.ClientConn) {
+	connVersions.Delete(conn)
+}
+
+// adapterFor returns the adapter for the consensus protocol version negotiated on the given
+// connection, if any.
+func adapterFor(conn *grpc.ClientConn) *adapter {
+	v, ok := connVersions.Load(conn)
+	if !ok {
+		return nil
+	}
+	return adapters[v.(version.Version)]
+}
+
+// adapter adapts calls made using the current service APIs to a node running an older major
+// version of the consensus protocol.
+type adapter struct {
+	// methods maps the full names of current methods to the names used by the older version.
+	methods map[string]string
+	// responses maps the full names of current methods to adapters of their response types.
+	responses map[string]*responseAdapter
+}
+
+// responseAdapter converts responses of an older version into the current types.
+type responseAdapter struct {
+	// new returns a new instance of the response type used by the older version.
+	new func() interface{}
+	// convert converts a response of the older version into the given current response.
+	convert func(old, rsp interface{}) error
+}
+
+func (a *adapter) method(method string) string {
+	if m, ok := a.methods[method]; ok {
+		return m
+	}
+	return method
+}
+
+func (a *adapter) invoke(
+	ctx context.Context,
+	method string,
+	req, rsp interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	ra, ok := a.responses[method]
+	if !ok {
+		return invoker(ctx, a.method(method), req, rsp, cc, opts...)
+	}
+
+	old := ra.new()
+	if err := invoker(ctx, a.method(method), req, old, cc, opts...); err != nil {
+		return err
+	}
+	return ra.convert(old, rsp)
+}
+
+// adapters are the adapters for the supported older consensus protocol versions, masked to their
+// major version.
+var adapters = map[version.Version]*adapter{
+	{Major: 6}: adapterV6,
+}
+
+var (
+	consensusServiceName = cmnGrpc.NewServiceName("Consensus")
+	registryServiceName  = cmnGrpc.NewServiceName("Registry")
+
+	// consensusLightServiceNameV6 is the name of the light client service of consensus protocol
+	// version 6, whose methods have since been merged into the consensus service.
+	consensusLightServiceNameV6 = cmnGrpc.NewServiceName("ConsensusLight")
+)
+
+func fullMethodName(sn cmnGrpc.ServiceName, method string) string {
+	return fmt.Sprintf("/%s/%s", sn, method)
+}
+
+var adapterV6 = &adapter{
+	methods: func() map[string]string {
+		methods := make(map[string]string)
+		for _, name := range []string{
+			"GetLightBlock",
+			"GetParameters",
+			"StateSyncGet",
+			"StateSyncGetPrefixes",
+			"StateSyncIterate",
+			"SubmitTxNoWait",
+			"SubmitEvidence",
+		} {
+			methods[fullMethodName(consensusServiceName, name)] = fullMethodName(consensusLightServiceNameV6, name)
+		}
+		return methods
+	}(),
+	responses: map[string]*responseAdapter{
+		fullMethodName(registryServiceName, "GetEvents"): {
+			new: func() interface{} { return &[]*registryEventV6{} },
+			convert: func(old, rsp interface{}) error {
+				oldEvs := *old.(*[]*registryEventV6)
+				evs := make([]*registry.Event, 0, len(oldEvs))
+				for _, ev := range oldEvs {
+					evs = append(evs, ev.toCurrent())
+				}
+				*rsp.(*[]*registry.Event) = evs
+				return nil
+			},
+		},
+		fullMethodName(registryServiceName, "WatchEvents"): {
+			new: func() interface{} { return &registryEventV6{} },
+			convert: func(old, rsp interface{}) error {
+				*rsp.(*registry.Event) = *old.(*registryEventV6).toCurrent()
+				return nil
+			},
+		},
+	},
+}
+
+// registryEventV6 is the registry event of consensus protocol version 6.
+type registryEventV6 struct {
+	Height int64     `json:"height,omitempty"`
+	TxHash hash.Hash `json:"tx_hash,omitempty"`
+
+	// RuntimeEvent has since been renamed to RuntimeStartedEvent.
+	RuntimeEvent      *registry.RuntimeStartedEvent `json:"runtime,omitempty"`
+	EntityEvent       *registry.EntityEvent         `json:"entity,omitempty"`
+	NodeEvent         *registry.NodeEvent           `json:"node,omitempty"`
+	NodeUnfrozenEvent *registry.NodeUnfrozenEvent   `json:"node_unfrozen,omitempty"`
+}
+
+func (ev *registryEventV6) toCurrent() *registry.Event {
+	return &registry.Event{
+		Height:              ev.Height,
+		TxHash:              ev.TxHash,
+		RuntimeStartedEvent: ev.RuntimeEvent,
+		EntityEvent:         ev.EntityEvent,
+		NodeEvent:           ev.NodeEvent,
+		NodeUnfrozenEvent:   ev.NodeUnfrozenEvent,
+	}
+}
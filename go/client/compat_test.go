@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+// testEventV6 is the encoding of a registry runtime event by consensus protocol version 6.
+type testEventV6 struct {
+	Height       int64                         `json:"height,omitempty"`
+	RuntimeEvent *registry.RuntimeStartedEvent `json:"runtime,omitempty"`
+}
+
+var testRuntimeID = common.NewTestNamespaceFromSeed([]byte("client compat"), 0)
+
+func newTestEventV6(height int64) *testEventV6 {
+	return &testEventV6{
+		Height: height,
+		RuntimeEvent: &registry.RuntimeStartedEvent{
+			Runtime: &registry.Runtime{
+				Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+				ID:        testRuntimeID,
+			},
+		},
+	}
+}
+
+func testUnaryHandler(fn func(height int64) interface{}) func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error) {
+	return func(_ interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+		var height int64
+		if err := dec(&height); err != nil {
+			return nil, err
+		}
+		return fn(height), nil
+	}
+}
+
+// startTestNodeV6 starts a gRPC server serving a subset of the API of a node running consensus
+// protocol version 6.
+func startTestNodeV6(t *testing.T) string {
+	server := grpc.NewServer(grpc.ForceServerCodec(&cmnGrpc.CBORCodec{}))
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: string(consensusServiceName),
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "GetStatus",
+				Handler: func(_ interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+					if err := dec(nil); err != nil {
+						return nil, err
+					}
+					return &consensus.Status{Version: version.Version{Major: 6, Minor: 1}}, nil
+				},
+			},
+		},
+	}, struct{}{})
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: string(consensusLightServiceNameV6),
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "GetLightBlock",
+				Handler: testUnaryHandler(func(height int64) interface{} {
+					return &consensus.LightBlock{Height: height}
+				}),
+			},
+		},
+	}, struct{}{})
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: string(registryServiceName),
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "GetEvents",
+				Handler: testUnaryHandler(func(height int64) interface{} {
+					return []*testEventV6{newTestEventV6(height)}
+				}),
+			},
+		},
+		Streams: []grpc.StreamDesc{
+			{
+				StreamName: "WatchEvents",
+				Handler: func(_ interface{}, stream grpc.ServerStream) error {
+					if err := stream.RecvMsg(nil); err != nil {
+						return err
+					}
+					return stream.SendMsg(newTestEventV6(42))
+				},
+				ServerStreams: true,
+			},
+		},
+	}, struct{}{})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "Listen")
+	go func() {
+		_ = server.Serve(ln)
+	}()
+	t.Cleanup(server.Stop)
+
+	return ln.Addr().String()
+}
+
+func TestPreviousVersionNode(t *testing.T) {
+	require := require.New(t)
+
+	address := startTestNodeV6(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	c, err := Dial(ctx, address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(err, "Dial")
+	defer c.Close()
+
+	require.Equal(version.Version{Major: 6}, c.Version(), "previous major version should be negotiated")
+
+	// Methods moved to another service should be called using their old names.
+	lb, err := c.Consensus().GetLightBlock(ctx, 10)
+	require.NoError(err, "GetLightBlock")
+	require.EqualValues(10, lb.Height)
+
+	// Renamed event types should be converted.
+	evs, err := c.Registry().GetEvents(ctx, 11)
+	require.NoError(err, "GetEvents")
+	require.Len(evs, 1)
+	require.EqualValues(11, evs[0].Height)
+	require.NotNil(evs[0].RuntimeStartedEvent, "runtime event should be converted")
+	require.Equal(testRuntimeID, evs[0].RuntimeStartedEvent.Runtime.ID)
+
+	ch, sub, err := c.Registry().WatchEvents(ctx)
+	require.NoError(err, "WatchEvents")
+	defer sub.Close()
+	select {
+	case ev := <-ch:
+		require.EqualValues(42, ev.Height)
+		require.NotNil(ev.RuntimeStartedEvent, "streamed runtime event should be converted")
+		require.Equal(testRuntimeID, ev.RuntimeStartedEvent.Runtime.ID)
+	case <-ctx.Done():
+		t.Fatalf("failed to receive registry event")
+	}
+
+	// Methods not implemented by the node should fail with ErrNotSupported.
+	_, err = c.Consensus().GetChainContext(ctx)
+	require.ErrorIs(err, ErrNotSupported)
+}
@@ -0,0 +1,351 @@
+// Package backup implements node state backup archives.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+)
+
+const (
+	// ManifestVersion is the current backup manifest version.
+	ManifestVersion = 1
+
+	// ManifestName is the name of the manifest entry in a backup archive.
+	ManifestName = "backup-manifest.json"
+
+	// FenceName is the name of the file that marks an incomplete restore.
+	FenceName = ".restore-in-progress"
+)
+
+var (
+	// ErrRestoreInProgress is the error returned when the data directory contains an incomplete
+	// restore.
+	ErrRestoreInProgress = errors.New("backup: data directory contains an incomplete restore")
+
+	// ErrDataDirNotEmpty is the error returned when restoring into a non-empty data directory.
+	ErrDataDirNotEmpty = errors.New("backup: data directory is not empty")
+)
+
+// Manifest describes the node state contained in a backup archive.
+type Manifest struct {
+	// Version is the manifest version.
+	Version uint16 `json:"version"`
+
+	// CreatedAt is the time when the backup was created.
+	CreatedAt time.Time `json:"created_at"`
+
+	// ChainContext is the chain domain separation context (genesis document hash).
+	ChainContext string `json:"chain_context"`
+
+	// Consensus is the state of the consensus layer at the time of the backup.
+	Consensus ConsensusState `json:"consensus"`
+
+	// Runtimes is the state of the runtimes at the time of the backup.
+	Runtimes []RuntimeState `json:"runtimes,omitempty"`
+
+	// Databases is the list of database directories, relative to the data directory.
+	Databases []string `json:"databases"`
+}
+
+// ConsensusState is the consensus layer state recorded in the manifest.
+type ConsensusState struct {
+	// GenesisHeight is the height of the genesis block.
+	GenesisHeight int64 `json:"genesis_height"`
+
+	// Height is the height of the last committed block.
+	Height int64 `json:"height"`
+
+	// StateRoot is the consensus state root hash at the given height.
+	StateRoot hash.Hash `json:"state_root"`
+}
+
+// RuntimeState is the runtime state recorded in the manifest.
+type RuntimeState struct {
+	// ID is the runtime identifier.
+	ID common.Namespace `json:"id"`
+
+	// Round is the last runtime round known to the node.
+	Round uint64 `json:"round"`
+
+	// StateRoot is the runtime state root hash at the given round.
+	StateRoot hash.Hash `json:"state_root"`
+}
+
+// ValidateBasic performs basic manifest validity checks.
+func (m *Manifest) ValidateBasic() error {
+	if m.Version != ManifestVersion {
+		return fmt.Errorf("backup: unsupported manifest version: %d", m.Version)
+	}
+	if m.ChainContext == "" {
+		return fmt.Errorf("backup: manifest is missing the chain context")
+	}
+	if m.Consensus.Height < m.Consensus.GenesisHeight {
+		return fmt.Errorf("backup: consensus height %d is below genesis height %d",
+			m.Consensus.Height,
+			m.Consensus.GenesisHeight,
+		)
+	}
+	for _, db := range m.Databases {
+		if !isLocalPath(db) {
+			return fmt.Errorf("backup: invalid database path: %s", db)
+		}
+	}
+	return nil
+}
+
+// Create writes a backup archive of the given data directory.
+//
+// Databases listed in the manifest are not read from the data directory, but instead from the
+// same relative location in the snapshot directory, which must contain consistent copies of the
+// databases. Any paths listed in exclude are skipped.
+func Create(w io.Writer, dataDir, snapshotDir string, manifest *Manifest, exclude []string) error {
+	if err := manifest.ValidateBasic(); err != nil {
+		return err
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	// The manifest is always the first entry so it can be validated without reading the whole
+	// archive.
+	rawManifest, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("backup: failed to marshal manifest: %w", err)
+	}
+	if err = tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     ManifestName,
+		Mode:     0o600,
+		Size:     int64(len(rawManifest)),
+		ModTime:  manifest.CreatedAt,
+	}); err != nil {
+		return err
+	}
+	if _, err = tw.Write(rawManifest); err != nil {
+		return err
+	}
+
+	skip := make(map[string]bool)
+	for _, path := range exclude {
+		skip[filepath.Clean(path)] = true
+	}
+	for _, db := range manifest.Databases {
+		skip[filepath.Join(dataDir, db)] = true
+	}
+	if err = addDir(tw, dataDir, dataDir, skip); err != nil {
+		return err
+	}
+	for _, db := range manifest.Databases {
+		if err = addDir(tw, snapshotDir, filepath.Join(snapshotDir, db), nil); err != nil {
+			return err
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func addDir(tw *tar.Writer, baseDir, dir string, skip map[string]bool) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if skip[path] {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if path == baseDir {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		// Skip sockets, symlinks and other special files.
+		if !info.Mode().IsRegular() && !info.IsDir() {
+			return nil
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(baseDir, path)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err = tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.CopyN(tw, f, hdr.Size)
+		return err
+	})
+}
+
+// ReadManifest reads and validates the manifest of the given backup archive.
+func ReadManifest(r io.Reader) (*Manifest, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("backup: malformed archive: %w", err)
+	}
+	defer gr.Close()
+
+	return readManifest(tar.NewReader(gr))
+}
+
+func readManifest(tr *tar.Reader) (*Manifest, error) {
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("backup: malformed archive: %w", err)
+	}
+	if hdr.Name != ManifestName {
+		return nil, fmt.Errorf("backup: archive does not start with a manifest")
+	}
+
+	var manifest Manifest
+	if err = json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("backup: malformed manifest: %w", err)
+	}
+	if err = manifest.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// Restore restores the given backup archive into an empty data directory.
+//
+// The manifest is validated using the given validation function before any data is written.
+// While restoring, a fence file is kept in the data directory so that a node cannot be started
+// from a partially restored data directory.
+func Restore(r io.Reader, dataDir string, validate func(*Manifest) error) (*Manifest, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("backup: malformed archive: %w", err)
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+
+	manifest, err := readManifest(tr)
+	if err != nil {
+		return nil, err
+	}
+	if validate != nil {
+		if err = validate(manifest); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := os.ReadDir(dataDir)
+	switch {
+	case err == nil:
+		if len(entries) > 0 {
+			return nil, ErrDataDirNotEmpty
+		}
+	case errors.Is(err, fs.ErrNotExist):
+		if err = common.Mkdir(dataDir); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	fenceFn := filepath.Join(dataDir, FenceName)
+	if err = os.WriteFile(fenceFn, []byte(manifest.CreatedAt.String()), 0o600); err != nil {
+		return nil, fmt.Errorf("backup: failed to create fence: %w", err)
+	}
+
+	for {
+		var hdr *tar.Header
+		hdr, err = tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("backup: malformed archive: %w", err)
+		}
+		if err = extractEntry(tr, hdr, dataDir); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = os.Remove(fenceFn); err != nil {
+		return nil, fmt.Errorf("backup: failed to remove fence: %w", err)
+	}
+	return manifest, nil
+}
+
+func extractEntry(tr *tar.Reader, hdr *tar.Header, dataDir string) error {
+	name := filepath.FromSlash(strings.TrimSuffix(hdr.Name, "/"))
+	if !isLocalPath(name) || name == FenceName {
+		return fmt.Errorf("backup: invalid archive entry: %s", hdr.Name)
+	}
+	path := filepath.Join(dataDir, name)
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(path, 0o700)
+	case tar.TypeReg:
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, hdr.FileInfo().Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err = io.CopyN(f, tr, hdr.Size); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	default:
+		return fmt.Errorf("backup: unsupported archive entry type: %s", hdr.Name)
+	}
+}
+
+// CheckFence returns an error if the given data directory contains an incomplete restore.
+func CheckFence(dataDir string) error {
+	_, err := os.Stat(filepath.Join(dataDir, FenceName))
+	switch {
+	case err == nil:
+		return ErrRestoreInProgress
+	case errors.Is(err, fs.ErrNotExist):
+		return nil
+	default:
+		return err
+	}
+}
+
+func isLocalPath(path string) bool {
+	return path != "" && filepath.IsLocal(path)
+}
@@ -0,0 +1,100 @@
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupRestore(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	snapshotDir := t.TempDir()
+
+	// Prepare a data directory with a database that is taken from the snapshot directory.
+	require.NoError(os.MkdirAll(filepath.Join(dataDir, "consensus", "state"), 0o700))
+	require.NoError(os.WriteFile(filepath.Join(dataDir, "identity.pem"), []byte("identity"), 0o600))
+	require.NoError(os.WriteFile(filepath.Join(dataDir, "consensus", "state", "db"), []byte("live"), 0o600))
+	require.NoError(os.WriteFile(filepath.Join(dataDir, "excluded"), []byte("excluded"), 0o600))
+	require.NoError(os.MkdirAll(filepath.Join(snapshotDir, "consensus", "state"), 0o700))
+	require.NoError(os.WriteFile(filepath.Join(snapshotDir, "consensus", "state", "db"), []byte("snapshot"), 0o600))
+
+	manifest := &Manifest{
+		Version:      ManifestVersion,
+		CreatedAt:    time.Now().UTC().Truncate(time.Second),
+		ChainContext: "test",
+		Consensus: ConsensusState{
+			GenesisHeight: 1,
+			Height:        42,
+		},
+		Databases: []string{filepath.Join("consensus", "state")},
+	}
+
+	var archive bytes.Buffer
+	err := Create(&archive, dataDir, snapshotDir, manifest, []string{filepath.Join(dataDir, "excluded")})
+	require.NoError(err, "Create")
+
+	rm, err := ReadManifest(bytes.NewReader(archive.Bytes()))
+	require.NoError(err, "ReadManifest")
+	require.Equal(manifest, rm)
+
+	// Validation failures should abort the restore before anything is written.
+	restoreDir := filepath.Join(t.TempDir(), "restore")
+	errInvalid := errors.New("invalid")
+	_, err = Restore(bytes.NewReader(archive.Bytes()), restoreDir, func(*Manifest) error {
+		return errInvalid
+	})
+	require.ErrorIs(err, errInvalid)
+	require.NoDirExists(restoreDir)
+
+	rm, err = Restore(bytes.NewReader(archive.Bytes()), restoreDir, nil)
+	require.NoError(err, "Restore")
+	require.Equal(manifest, rm)
+	require.NoError(CheckFence(restoreDir))
+
+	data, err := os.ReadFile(filepath.Join(restoreDir, "identity.pem"))
+	require.NoError(err)
+	require.Equal("identity", string(data))
+	data, err = os.ReadFile(filepath.Join(restoreDir, "consensus", "state", "db"))
+	require.NoError(err)
+	require.Equal("snapshot", string(data), "databases should be restored from the snapshot")
+	require.NoFileExists(filepath.Join(restoreDir, "excluded"))
+
+	// Restoring into a non-empty data directory should fail.
+	_, err = Restore(bytes.NewReader(archive.Bytes()), restoreDir, nil)
+	require.ErrorIs(err, ErrDataDirNotEmpty)
+}
+
+func TestCheckFence(t *testing.T) {
+	require := require.New(t)
+
+	dataDir := t.TempDir()
+	require.NoError(CheckFence(dataDir))
+
+	require.NoError(os.WriteFile(filepath.Join(dataDir, FenceName), nil, 0o600))
+	require.ErrorIs(CheckFence(dataDir), ErrRestoreInProgress)
+}
+
+func TestManifestValidateBasic(t *testing.T) {
+	require := require.New(t)
+
+	m := Manifest{
+		Version:      ManifestVersion,
+		ChainContext: "test",
+		Databases:    []string{"consensus/state"},
+	}
+	require.NoError(m.ValidateBasic())
+
+	m.Version = ManifestVersion + 1
+	require.Error(m.ValidateBasic(), "unsupported version should be rejected")
+	m.Version = ManifestVersion
+
+	m.Databases = []string{"../escape"}
+	require.Error(m.ValidateBasic(), "non-local database paths should be rejected")
+}
@@ -0,0 +1,121 @@
+package badger
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+var snapshotSources = struct {
+	sync.Mutex
+
+	dbs map[*badger.DB]bool
+}{
+	dbs: make(map[*badger.DB]bool),
+}
+
+// RegisterForSnapshots registers an open database so that it is included in node state
+// snapshots. The managed flag must be set for databases opened in managed mode.
+//
+// Closed databases are automatically removed from the set of snapshot sources.
+func RegisterForSnapshots(db *badger.DB, managed bool) {
+	snapshotSources.Lock()
+	defer snapshotSources.Unlock()
+
+	for src := range snapshotSources.dbs {
+		if src.IsClosed() {
+			delete(snapshotSources.dbs, src)
+		}
+	}
+	snapshotSources.dbs[db] = managed
+}
+
+// SnapshotDatabases streams a consistent copy of every registered open database that is located
+// under the source directory into the same relative location under the destination directory.
+//
+// Each database is copied at a single point in time, but different databases are copied at
+// different points in time. Callers must make sure that no writes that need to be consistent
+// across databases happen during the snapshot.
+//
+// Returns the list of database directories relative to the source directory.
+func SnapshotDatabases(ctx context.Context, srcDir, dstDir string) ([]string, error) {
+	snapshotSources.Lock()
+	defer snapshotSources.Unlock()
+
+	var dirs []string
+	for db, managed := range snapshotSources.dbs {
+		if db.IsClosed() {
+			delete(snapshotSources.dbs, db)
+			continue
+		}
+
+		opts := db.Opts()
+		if opts.InMemory {
+			continue
+		}
+		rel, err := filepath.Rel(srcDir, opts.Dir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if opts.ValueDir != opts.Dir {
+			return nil, fmt.Errorf("badger: database with a separate value directory is not supported: %s", opts.Dir)
+		}
+
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err = streamDB(ctx, db, managed, filepath.Join(dstDir, rel)); err != nil {
+			return nil, fmt.Errorf("badger: failed to snapshot database %s: %w", opts.Dir, err)
+		}
+		dirs = append(dirs, rel)
+	}
+	return dirs, nil
+}
+
+func streamDB(ctx context.Context, db *badger.DB, managed bool, dir string) (err error) {
+	opts := db.Opts()
+	opts.Dir = dir
+	opts.ValueDir = dir
+	opts.ReadOnly = false
+
+	var (
+		outDB  *badger.DB
+		stream *badger.Stream
+	)
+	switch managed {
+	case true:
+		outDB, err = badger.OpenManaged(opts)
+		stream = db.NewStreamAt(math.MaxUint64)
+	case false:
+		outDB, err = badger.Open(opts)
+		stream = db.NewStream()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open output database: %w", err)
+	}
+	defer func() {
+		// Closing the output database flushes any pending writes, so the error must not be
+		// ignored as the snapshot could otherwise be incomplete.
+		if cerr := outDB.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to close output database: %w", cerr)
+		}
+	}()
+
+	writer := outDB.NewStreamWriter()
+	if err = writer.Prepare(); err != nil {
+		return fmt.Errorf("failed to prepare stream writer: %w", err)
+	}
+
+	stream.LogPrefix = "snapshot " + dir
+	stream.Send = writer.Write
+	if err = stream.Orchestrate(ctx); err != nil {
+		writer.Cancel()
+		return err
+	}
+	return writer.Flush()
+}
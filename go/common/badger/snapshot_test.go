@@ -0,0 +1,61 @@
+package badger
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotDatabases(t *testing.T) {
+	require := require.New(t)
+
+	srcDir := t.TempDir()
+	dstDir := t.TempDir()
+
+	dbDir := filepath.Join(srcDir, "db")
+	db, err := badger.Open(badger.DefaultOptions(dbDir).WithLogger(nil))
+	require.NoError(err, "Open")
+	defer db.Close()
+	RegisterForSnapshots(db, false)
+
+	err = db.Update(func(tx *badger.Txn) error {
+		return tx.Set([]byte("key"), []byte("value"))
+	})
+	require.NoError(err, "Update")
+
+	dirs, err := SnapshotDatabases(context.Background(), srcDir, dstDir)
+	require.NoError(err, "SnapshotDatabases")
+	require.Equal([]string{"db"}, dirs)
+
+	// Writes after the snapshot should not be visible in the copy.
+	err = db.Update(func(tx *badger.Txn) error {
+		return tx.Set([]byte("key"), []byte("new value"))
+	})
+	require.NoError(err, "Update")
+
+	cdb, err := badger.Open(badger.DefaultOptions(filepath.Join(dstDir, "db")).WithLogger(nil))
+	require.NoError(err, "Open copy")
+	defer cdb.Close()
+
+	err = cdb.View(func(tx *badger.Txn) error {
+		item, txErr := tx.Get([]byte("key"))
+		if txErr != nil {
+			return txErr
+		}
+		value, txErr := item.ValueCopy(nil)
+		if txErr != nil {
+			return txErr
+		}
+		require.Equal("value", string(value))
+		return nil
+	})
+	require.NoError(err, "View copy")
+
+	// Databases outside the source directory should be ignored.
+	dirs, err = SnapshotDatabases(context.Background(), filepath.Join(srcDir, "other"), t.TempDir())
+	require.NoError(err, "SnapshotDatabases")
+	require.Empty(dirs)
+}
@@ -0,0 +1,273 @@
+// Package command provides a signer backed by private keys fetched from an external command.
+//
+// The command is intended to wrap an external secret manager (e.g., fetching a key from Vault or
+// decrypting a cloud KMS envelope) so that plaintext private keys never need to be stored in
+// images or volumes. It is invoked once per role as `<path> [<args>...] <role>` and must write
+// the PEM encoded private key to standard output, in the same format as used by the file signer.
+// For the P2P role, the output must also contain the PEM encoded static entropy.
+//
+// Fetched keys are cached in locked memory for the lifetime of the process.
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519/extra/ecvrf"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+const (
+	privateKeyPemType    = "ED25519 PRIVATE KEY"
+	staticEntropyPemType = "STATIC ENTROPY"
+
+	// SignerName is the name used to identify the command backed signer.
+	SignerName = "command"
+
+	// StaticEntropySize is the size of the provided static entropy.
+	StaticEntropySize = 32
+
+	// DefaultTimeout is the default timeout of a single command invocation.
+	DefaultTimeout = 30 * time.Second
+)
+
+var (
+	_ signature.SignerFactoryCtor     = NewFactory
+	_ signature.SignerFactory         = (*Factory)(nil)
+	_ signature.Signer                = (*Signer)(nil)
+	_ signature.VRFSigner             = (*Signer)(nil)
+	_ signature.StaticEntropyProvider = (*Signer)(nil)
+
+	// ErrGenerateNotSupported is the error returned when trying to generate a key, as keys must
+	// be provisioned in the external secret manager.
+	ErrGenerateNotSupported = errors.New("signature/signer/command: key generation not supported")
+)
+
+// FactoryConfig is the command signer factory configuration.
+type FactoryConfig struct {
+	// Path is the path to the command executable.
+	Path string
+
+	// Args are the arguments passed to the command before the role name.
+	Args []string
+
+	// Timeout is the timeout of a single command invocation (0 = DefaultTimeout).
+	Timeout time.Duration
+}
+
+// NewFactory creates a new factory with the specified roles and configuration.
+func NewFactory(config interface{}, roles ...signature.SignerRole) (signature.SignerFactory, error) {
+	cfg, ok := config.(*FactoryConfig)
+	if !ok {
+		return nil, errors.New("signature/signer/command: invalid command signer configuration provided")
+	}
+	if cfg.Path == "" {
+		return nil, errors.New("signature/signer/command: command path not configured")
+	}
+
+	return &Factory{
+		cfg:     *cfg,
+		roles:   append([]signature.SignerRole{}, roles...),
+		signers: make(map[signature.SignerRole]*Signer),
+	}, nil
+}
+
+// Factory is a command backed SignerFactory.
+type Factory struct {
+	sync.Mutex
+
+	cfg     FactoryConfig
+	roles   []signature.SignerRole
+	signers map[signature.SignerRole]*Signer
+}
+
+// EnsureRole ensures that the SignerFactory is configured for the given
+// role.
+func (fac *Factory) EnsureRole(role signature.SignerRole) error {
+	for _, v := range fac.roles {
+		if v == role {
+			return nil
+		}
+	}
+	return signature.ErrRoleMismatch
+}
+
+// Generate always fails as keys must be provisioned in the external secret manager.
+func (fac *Factory) Generate(signature.SignerRole, io.Reader) (signature.Signer, error) {
+	return nil, ErrGenerateNotSupported
+}
+
+// Load will fetch the private key corresponding to the role, and return a Signer
+// ready for use.
+func (fac *Factory) Load(role signature.SignerRole) (signature.Signer, error) {
+	if err := fac.EnsureRole(role); err != nil {
+		return nil, err
+	}
+
+	fac.Lock()
+	defer fac.Unlock()
+
+	if signer, ok := fac.signers[role]; ok {
+		return signer, nil
+	}
+
+	out, err := fac.run(role)
+	defer zero(out)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := newSigner(role, out)
+	if err != nil {
+		return nil, fmt.Errorf("signature/signer/command: malformed %s key: %w", role, err)
+	}
+	fac.signers[role] = signer
+
+	return signer, nil
+}
+
+func (fac *Factory) run(role signature.SignerRole) ([]byte, error) {
+	timeout := fac.cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	args := append(append([]string{}, fac.cfg.Args...), role.String())
+	cmd := exec.CommandContext(ctx, fac.cfg.Path, args...) // #nosec G204
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		zero(stdout.Bytes())
+		return nil, fmt.Errorf("signature/signer/command: failed to fetch %s key: %w (stderr: %s)",
+			role, err, bytes.TrimSpace(stderr.Bytes()),
+		)
+	}
+	return stdout.Bytes(), nil
+}
+
+// Signer is a command backed Signer.
+type Signer struct {
+	// keyMaterial is the locked memory holding the private key and the static entropy.
+	keyMaterial []byte
+
+	privateKey    ed25519.PrivateKey
+	staticEntropy []byte
+	role          signature.SignerRole
+}
+
+func newSigner(role signature.SignerRole, data []byte) (*Signer, error) {
+	s := &Signer{
+		keyMaterial: make([]byte, ed25519.PrivateKeySize+StaticEntropySize),
+		role:        role,
+	}
+	if err := lockMemory(s.keyMaterial); err != nil {
+		return nil, fmt.Errorf("failed to lock key memory: %w", err)
+	}
+	s.privateKey = ed25519.PrivateKey(s.keyMaterial[:ed25519.PrivateKeySize])
+
+	var hasKey, hasEntropy bool
+	for {
+		var blk *pem.Block
+		blk, data = pem.Decode(data)
+		if blk == nil {
+			break
+		}
+
+		switch blk.Type {
+		case privateKeyPemType:
+			if hasKey || len(blk.Bytes) != ed25519.PrivateKeySize {
+				zero(blk.Bytes)
+				s.Reset()
+				return nil, signature.ErrMalformedPrivateKey
+			}
+			copy(s.privateKey, blk.Bytes)
+			hasKey = true
+		case staticEntropyPemType:
+			if hasEntropy || len(blk.Bytes) != StaticEntropySize {
+				zero(blk.Bytes)
+				s.Reset()
+				return nil, signature.ErrMalformedPrivateKey
+			}
+			s.staticEntropy = s.keyMaterial[ed25519.PrivateKeySize:]
+			copy(s.staticEntropy, blk.Bytes)
+			hasEntropy = true
+		default:
+		}
+		zero(blk.Bytes)
+	}
+
+	switch {
+	case !hasKey:
+		s.Reset()
+		return nil, fmt.Errorf("no %s block", privateKeyPemType)
+	case role == signature.SignerP2P && !hasEntropy:
+		s.Reset()
+		return nil, fmt.Errorf("no %s block", staticEntropyPemType)
+	}
+	return s, nil
+}
+
+// Public returns the PublicKey corresponding to the signer.
+func (s *Signer) Public() signature.PublicKey {
+	var pk signature.PublicKey
+	_ = pk.UnmarshalBinary(s.privateKey.Public().(ed25519.PublicKey))
+	return pk
+}
+
+// ContextSign generates a signature with the private key over the context and
+// message.
+func (s *Signer) ContextSign(context signature.Context, message []byte) ([]byte, error) {
+	data, err := signature.PrepareSignerMessage(context, message)
+	if err != nil {
+		return nil, err
+	}
+
+	return ed25519.Sign(s.privateKey, data), nil
+}
+
+// String returns anything but the actual private key backing the Signer.
+func (s *Signer) String() string {
+	return "[redacted private key]"
+}
+
+// Reset tears down the Signer and obliterates any sensitive state if any.
+func (s *Signer) Reset() {
+	zero(s.keyMaterial)
+	unlockMemory(s.keyMaterial)
+}
+
+// Prove generates a VRF proof with the private key over the alpha.
+func (s *Signer) Prove(alphaString []byte) ([]byte, error) {
+	if s.role != signature.SignerVRF {
+		return nil, signature.ErrInvalidRole
+	}
+	return ecvrf.Prove(s.privateKey, alphaString), nil
+}
+
+// StaticEntropy returns PrivateKeySize bytes of cryptographic entropy that
+// is independent from the Signer's private key.  The value of this entropy
+// is constant for the lifespan of the signer's underlying key pair.
+func (s *Signer) StaticEntropy() ([]byte, error) {
+	if s.role != signature.SignerP2P {
+		return nil, signature.ErrInvalidRole
+	}
+	return s.staticEntropy, nil
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
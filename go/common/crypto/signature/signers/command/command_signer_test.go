@@ -0,0 +1,93 @@
+package command
+
+import (
+	"crypto/rand"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+func TestCommandSigner(t *testing.T) {
+	require := require.New(t)
+
+	tmpDir := t.TempDir()
+	script := filepath.Join(tmpDir, "fetch-key.sh")
+	err := os.WriteFile(script, []byte("#!/bin/sh\ncat \"$1/$2.pem\"\n"), 0o700) // #nosec G306
+	require.NoError(err, "WriteFile(script)")
+
+	writeKey := func(role signature.SignerRole, withEntropy bool) ed25519.PrivateKey {
+		_, privateKey, gerr := ed25519.GenerateKey(rand.Reader)
+		require.NoError(gerr, "GenerateKey")
+
+		data := pem.EncodeToMemory(&pem.Block{Type: privateKeyPemType, Bytes: privateKey})
+		if withEntropy {
+			entropy := make([]byte, StaticEntropySize)
+			_, gerr = rand.Read(entropy)
+			require.NoError(gerr, "rand.Read")
+			data = append(data, pem.EncodeToMemory(&pem.Block{Type: staticEntropyPemType, Bytes: entropy})...)
+		}
+		gerr = os.WriteFile(filepath.Join(tmpDir, role.String()+".pem"), data, 0o600)
+		require.NoError(gerr, "WriteFile(key)")
+		return privateKey
+	}
+
+	entityKey := writeKey(signature.SignerEntity, false)
+	writeKey(signature.SignerP2P, false)
+
+	factory, err := NewFactory(&FactoryConfig{
+		Path: script,
+		Args: []string{tmpDir},
+	}, signature.SignerEntity, signature.SignerP2P, signature.SignerVRF)
+	require.NoError(err, "NewFactory")
+
+	// Keys can't be generated.
+	_, err = factory.Generate(signature.SignerEntity, rand.Reader)
+	require.ErrorIs(err, ErrGenerateNotSupported)
+
+	// Unconfigured roles should be rejected.
+	_, err = factory.Load(signature.SignerNode)
+	require.ErrorIs(err, signature.ErrRoleMismatch)
+
+	// Failing commands should be reported.
+	_, err = factory.Load(signature.SignerVRF)
+	require.Error(err, "Load should fail when the command fails")
+
+	// The P2P role requires static entropy.
+	_, err = factory.Load(signature.SignerP2P)
+	require.Error(err, "Load should fail without static entropy")
+	writeKey(signature.SignerP2P, true)
+	p2pSigner, err := factory.Load(signature.SignerP2P)
+	require.NoError(err, "Load(SignerP2P)")
+	entropy, err := p2pSigner.(signature.StaticEntropyProvider).StaticEntropy()
+	require.NoError(err, "StaticEntropy")
+	require.Len(entropy, StaticEntropySize)
+
+	signer, err := factory.Load(signature.SignerEntity)
+	require.NoError(err, "Load(SignerEntity)")
+	pk := signer.Public()
+	require.EqualValues(entityKey.Public(), pk[:], "loaded key should match")
+	_, err = signer.(signature.StaticEntropyProvider).StaticEntropy()
+	require.ErrorIs(err, signature.ErrInvalidRole)
+
+	// Loaded keys should be cached.
+	require.NoError(os.Remove(filepath.Join(tmpDir, "entity.pem")))
+	signer2, err := factory.Load(signature.SignerEntity)
+	require.NoError(err, "Load(SignerEntity), cached")
+	require.Same(signer, signer2, "Loaded signer should be cached")
+
+	ctx := signature.NewContext("oasis-core/signer: command signer test")
+	msg := []byte("message")
+	sig, err := signer.ContextSign(ctx, msg)
+	require.NoError(err, "ContextSign")
+	require.True(signer.Public().Verify(ctx, msg, sig), "signature should verify")
+
+	// Reset should obliterate the key.
+	signer.Reset()
+	require.Equal(make([]byte, ed25519.PrivateKeySize), []byte(signer.(*Signer).privateKey))
+}
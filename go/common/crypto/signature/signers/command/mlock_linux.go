@@ -0,0 +1,11 @@
+package command
+
+import "syscall"
+
+func lockMemory(b []byte) error {
+	return syscall.Mlock(b)
+}
+
+func unlockMemory(b []byte) {
+	_ = syscall.Munlock(b)
+}
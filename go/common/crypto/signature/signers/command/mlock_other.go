@@ -0,0 +1,10 @@
+//go:build !linux
+
+package command
+
+// Memory locking is only supported on Linux.
+func lockMemory([]byte) error {
+	return nil
+}
+
+func unlockMemory([]byte) {}
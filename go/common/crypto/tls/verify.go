@@ -3,12 +3,17 @@ package tls
 import (
 	"crypto/ed25519"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 )
 
+// ErrBadPublicKey is the error returned when a certificate is not signed by any of the allowed
+// public keys.
+var ErrBadPublicKey = errors.New("tls: bad public key")
+
 // VerifyOptions are the certificate verification options.
 type VerifyOptions struct {
 	// CommonName is the expected certificate common name.
@@ -58,7 +63,7 @@ func VerifyCertificate(rawCerts [][]byte, opts VerifyOptions) error {
 			return fmt.Errorf("tls: bad public key: %w", err)
 		}
 		if !opts.Keys[spk] {
-			return fmt.Errorf("tls: bad public key (%s)", spk)
+			return fmt.Errorf("%w (%s)", ErrBadPublicKey, spk)
 		}
 	}
 
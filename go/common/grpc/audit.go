@@ -0,0 +1,198 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	grpcConfig "github.com/oasisprotocol/oasis-core/go/common/grpc/config"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+// redactedValue replaces the values of redacted request fields.
+const redactedValue = "<redacted>"
+
+// alwaysRedactedFields are the request fields that are never written to the audit log.
+var alwaysRedactedFields = []string{
+	"write_log",
+}
+
+var (
+	auditFilesLock sync.Mutex
+	auditFiles     = make(map[string]*auditFile)
+)
+
+// auditFile is an append-only audit log file shared by all servers.
+type auditFile struct {
+	sync.Mutex
+
+	w io.Writer
+}
+
+func (f *auditFile) append(entry *auditEntry) error {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	raw = append(raw, '\n')
+
+	f.Lock()
+	defer f.Unlock()
+	_, err = f.w.Write(raw)
+	return err
+}
+
+// openAuditFile opens the audit log file at the given path, reusing the file
+// if it has already been opened by another server.
+func openAuditFile(path string) (*auditFile, error) {
+	auditFilesLock.Lock()
+	defer auditFilesLock.Unlock()
+
+	if f, ok := auditFiles[path]; ok {
+		return f, nil
+	}
+	w, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("grpc: failed to open audit log: %w", err)
+	}
+	f := &auditFile{w: w}
+	auditFiles[path] = f
+	return f, nil
+}
+
+// auditEntry is a single audit log entry.
+type auditEntry struct {
+	Time        time.Time   `json:"time"`
+	Server      string      `json:"server"`
+	Method      string      `json:"method"`
+	PeerKey     string      `json:"peer_key,omitempty"`
+	PeerAddress string      `json:"peer_address,omitempty"`
+	RequestHash hash.Hash   `json:"request_hash"`
+	Request     interface{} `json:"request,omitempty"`
+	Code        string      `json:"code"`
+}
+
+// auditLogger logs mutating gRPC calls to an audit log.
+type auditLogger struct {
+	server string
+	redact map[string]bool
+	file   *auditFile
+
+	logger *logging.Logger
+}
+
+func (a *auditLogger) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, err := GetRegisteredMethod(info.FullMethod)
+	if err != nil || !md.IsMutating() {
+		return handler(ctx, req)
+	}
+
+	entry := &auditEntry{
+		Time:        time.Now(),
+		Server:      a.server,
+		Method:      info.FullMethod,
+		RequestHash: hash.NewFrom(req),
+		Request:     a.redactRequest(req),
+	}
+	entry.PeerKey, entry.PeerAddress = auditPeerIdentity(ctx)
+
+	resp, err := handler(ctx, req)
+	entry.Code = status.Code(err).String()
+
+	if aerr := a.file.append(entry); aerr != nil {
+		a.logger.Error("failed to write audit log entry",
+			"method", info.FullMethod,
+			"err", aerr,
+		)
+	}
+
+	return resp, err
+}
+
+// redactRequest returns a generic representation of the request with the
+// values of all redacted fields replaced.
+func (a *auditLogger) redactRequest(req interface{}) interface{} {
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return redactedValue
+	}
+	var v interface{}
+	if err = json.Unmarshal(raw, &v); err != nil {
+		return redactedValue
+	}
+	return a.redactValue(v)
+}
+
+func (a *auditLogger) redactValue(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		for k, fv := range vv {
+			if a.redact[strings.ToLower(k)] {
+				vv[k] = redactedValue
+				continue
+			}
+			vv[k] = a.redactValue(fv)
+		}
+	case []interface{}:
+		for i, ev := range vv {
+			vv[i] = a.redactValue(ev)
+		}
+	}
+	return v
+}
+
+// auditPeerIdentity returns the TLS public key and the address of the peer.
+func auditPeerIdentity(ctx context.Context) (string, string) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", ""
+	}
+	var addr string
+	if p.Addr != nil {
+		addr = p.Addr.String()
+	}
+
+	tlsAuth, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsAuth.State.PeerCertificates) != 1 {
+		return "", addr
+	}
+	pk, ok := tlsAuth.State.PeerCertificates[0].PublicKey.(ed25519.PublicKey)
+	if !ok {
+		return "", addr
+	}
+	var spk signature.PublicKey
+	if err := spk.UnmarshalBinary(pk[:]); err != nil {
+		return "", addr
+	}
+	return spk.String(), addr
+}
+
+func newAuditLogger(server string, cfg *grpcConfig.AuditConfig, file *auditFile, logger *logging.Logger) *auditLogger {
+	redact := make(map[string]bool)
+	for _, field := range alwaysRedactedFields {
+		redact[field] = true
+	}
+	for _, field := range cfg.Redact {
+		redact[strings.ToLower(field)] = true
+	}
+
+	return &auditLogger{
+		server: server,
+		redact: redact,
+		file:   file,
+		logger: logger,
+	}
+}
@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	grpcConfig "github.com/oasisprotocol/oasis-core/go/common/grpc/config"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+type auditTestRequest struct {
+	Round    uint64   `json:"round"`
+	Secret   string   `json:"secret"`
+	WriteLog []string `json:"write_log"`
+}
+
+var (
+	auditServiceName    = NewServiceName("AuditTest")
+	methodAuditMutate   = auditServiceName.NewMethod("Mutate", auditTestRequest{}).WithMutating()
+	methodAuditReadOnly = auditServiceName.NewMethod("Read", auditTestRequest{})
+)
+
+func TestAuditLogger(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	cfg := grpcConfig.AuditConfig{
+		Redact: []string{"Secret"},
+	}
+	audit := newAuditLogger("test", &cfg, &auditFile{w: &buf}, logging.GetLogger("grpc/test"))
+
+	req := &auditTestRequest{
+		Round:    42,
+		Secret:   "hunter2",
+		WriteLog: []string{"value"},
+	}
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		return nil, status.Error(codes.PermissionDenied, "denied")
+	}
+
+	// Read-only methods should not be audited.
+	_, err := audit.unaryInterceptor(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: methodAuditReadOnly.FullName()}, handler)
+	require.Error(err)
+	require.Zero(buf.Len(), "read-only calls should not be audited")
+
+	// Mutating methods should be audited, even if they fail.
+	_, err = audit.unaryInterceptor(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: methodAuditMutate.FullName()}, handler)
+	require.Error(err)
+	require.Equal(codes.PermissionDenied, status.Code(err), "handler errors should be propagated")
+
+	var entry auditEntry
+	err = json.Unmarshal(buf.Bytes(), &entry)
+	require.NoError(err, "audit log entry should be valid JSON")
+	require.Equal("test", entry.Server)
+	require.Equal(methodAuditMutate.FullName(), entry.Method)
+	require.Equal(codes.PermissionDenied.String(), entry.Code)
+	require.Equal(hash.NewFrom(req), entry.RequestHash)
+
+	logged := fmt.Sprintf("%v", entry.Request)
+	require.Contains(logged, "42", "non-redacted fields should be logged")
+	require.NotContains(logged, "hunter2", "configured fields should be redacted")
+	require.NotContains(logged, "value", "write log values should never be logged")
+}
@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"google.golang.org/grpc"
+
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+)
+
+// MethodGetCapabilitiesName is the short name of the capability discovery
+// method exposed by services.
+const MethodGetCapabilitiesName = "GetCapabilities"
+
+// Capabilities describe the protocol version and the optional features
+// supported by a gRPC service.
+type Capabilities struct {
+	// Version is the protocol version implemented by the service.
+	Version version.Version `json:"version"`
+
+	// Features is a list of optional features supported by the service.
+	Features []string `json:"features,omitempty"`
+}
+
+// HasFeature returns true iff the service supports the given optional
+// feature.
+func (c *Capabilities) HasFeature(feature string) bool {
+	return slices.Contains(c.Features, feature)
+}
+
+// IsCompatibleWith returns true iff the service implements a protocol
+// version that is backwards compatible with the given version.
+func (c *Capabilities) IsCompatibleWith(v version.Version) bool {
+	return c.Version.Major == v.Major && !c.Version.Less(v)
+}
+
+// NewCapabilitiesMethod creates a new capability discovery method for the
+// given service, together with the method descriptor that should be added
+// to the service's gRPC descriptor.
+//
+// The method always returns the given capabilities without involving the
+// service backend.
+func (sn ServiceName) NewCapabilitiesMethod(caps Capabilities) (*MethodDesc, grpc.MethodDesc) {
+	md := sn.NewMethod(MethodGetCapabilitiesName, nil)
+
+	handler := func(
+		srv interface{},
+		ctx context.Context,
+		_ func(interface{}) error,
+		interceptor grpc.UnaryServerInterceptor,
+	) (interface{}, error) {
+		if interceptor == nil {
+			return &caps, nil
+		}
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: md.FullName(),
+		}
+		handler := func(context.Context, interface{}) (interface{}, error) {
+			return &caps, nil
+		}
+		return interceptor(ctx, nil, info, handler)
+	}
+
+	return md, grpc.MethodDesc{
+		MethodName: md.ShortName(),
+		Handler:    handler,
+	}
+}
+
+// GetCapabilities queries the capabilities of the given service.
+func GetCapabilities(ctx context.Context, conn *grpc.ClientConn, sn ServiceName) (*Capabilities, error) {
+	var rsp Capabilities
+	method := fmt.Sprintf("/%s/%s", sn, MethodGetCapabilitiesName)
+	if err := conn.Invoke(ctx, method, nil, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+)
+
+type capabilitiesTestService interface{}
+
+func TestCapabilities(t *testing.T) {
+	require := require.New(t)
+
+	caps := Capabilities{
+		Version:  version.Version{Major: 2, Minor: 1, Patch: 3},
+		Features: []string{"foo"},
+	}
+	require.True(caps.HasFeature("foo"), "HasFeature")
+	require.False(caps.HasFeature("bar"), "HasFeature")
+	require.True(caps.IsCompatibleWith(version.Version{Major: 2}), "IsCompatibleWith")
+	require.True(caps.IsCompatibleWith(version.Version{Major: 2, Minor: 1, Patch: 3}), "IsCompatibleWith")
+	require.False(caps.IsCompatibleWith(version.Version{Major: 2, Minor: 2}), "IsCompatibleWith")
+	require.False(caps.IsCompatibleWith(version.Version{Major: 1}), "IsCompatibleWith")
+	require.False(caps.IsCompatibleWith(version.Version{Major: 3}), "IsCompatibleWith")
+}
+
+func TestGetCapabilities(t *testing.T) {
+	require := require.New(t)
+
+	caps := Capabilities{
+		Version:  version.Version{Major: 2, Minor: 1, Patch: 3},
+		Features: []string{"foo", "bar"},
+	}
+	sn := NewServiceName("CapabilitiesTestService")
+	_, desc := sn.NewCapabilitiesMethod(caps)
+
+	// Generate temporary filename for the socket.
+	f, err := os.CreateTemp("", "oasis-grpc-capabilities-test-socket")
+	require.NoError(err, "TempFile")
+	// Remove the file as we only need the name.
+	f.Close()
+	os.Remove(f.Name())
+
+	grpcServer, err := NewServer(&ServerConfig{
+		Path: f.Name(),
+	})
+	require.NoError(err, "NewServer")
+	defer os.Remove(f.Name())
+
+	grpcServer.Server().RegisterService(&grpc.ServiceDesc{
+		ServiceName: string(sn),
+		HandlerType: (*capabilitiesTestService)(nil),
+		Methods:     []grpc.MethodDesc{desc},
+	}, struct{}{})
+
+	err = grpcServer.Start()
+	require.NoError(err, "Failed to start the gRPC server")
+	defer grpcServer.Stop()
+
+	conn, err := Dial("unix:"+f.Name(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(err, "Dial")
+	defer conn.Close()
+
+	rsp, err := GetCapabilities(context.Background(), conn, sn)
+	require.NoError(err, "GetCapabilities")
+	require.EqualValues(&caps, rsp, "GetCapabilities should return the configured capabilities")
+
+	_, err = GetCapabilities(context.Background(), conn, NewServiceName("CapabilitiesUnknownService"))
+	require.Error(err, "GetCapabilities should fail for unknown services")
+}
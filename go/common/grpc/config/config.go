@@ -0,0 +1,102 @@
+// Package config implements global configuration options.
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config is the gRPC configuration structure.
+type Config struct {
+	// Maximum size (in bytes) of a message that can be received.
+	MaxRecvMsgSize int `yaml:"max_recv_msg_size"`
+	// Maximum size (in bytes) of a message that can be sent.
+	MaxSendMsgSize int `yaml:"max_send_msg_size"`
+	// Initial per-stream flow control window size in bytes (0 means use
+	// the gRPC default).
+	InitialWindowSize int32 `yaml:"initial_window_size,omitempty"`
+	// Initial per-connection flow control window size in bytes (0 means
+	// use the gRPC default).
+	InitialConnWindowSize int32 `yaml:"initial_conn_window_size,omitempty"`
+
+	Keepalive KeepaliveConfig `yaml:"keepalive,omitempty"`
+
+	Audit AuditConfig `yaml:"audit,omitempty"`
+}
+
+// KeepaliveConfig is the gRPC keepalive configuration structure.
+type KeepaliveConfig struct {
+	// Duration after which idle server connections are closed.
+	MaxConnectionIdle time.Duration `yaml:"max_connection_idle"`
+	// Interval of keepalive pings on otherwise idle connections (0 means
+	// use the gRPC default).
+	Time time.Duration `yaml:"time,omitempty"`
+	// Time to wait for a keepalive ping acknowledgement before closing the
+	// connection (0 means use the gRPC default).
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// AuditConfig is the gRPC audit log configuration structure.
+type AuditConfig struct {
+	// Path of the append-only file that mutating calls are logged to (empty
+	// disables audit logging).
+	Path string `yaml:"path,omitempty"`
+	// Names of request fields whose values are redacted from the audit log.
+	// Write log values are always redacted.
+	Redact []string `yaml:"redact,omitempty"`
+}
+
+// minWindowSize is the smallest window size accepted by gRPC, smaller
+// values are silently ignored.
+const minWindowSize = 64 * 1024
+
+// Validate validates the configuration settings.
+func (c *Config) Validate() error {
+	if c.MaxRecvMsgSize <= 0 {
+		return fmt.Errorf("max_recv_msg_size must be > 0")
+	}
+	if c.MaxSendMsgSize <= 0 {
+		return fmt.Errorf("max_send_msg_size must be > 0")
+	}
+	if c.InitialWindowSize != 0 && c.InitialWindowSize < minWindowSize {
+		return fmt.Errorf("initial_window_size must be 0 or >= %d", minWindowSize)
+	}
+	if c.InitialConnWindowSize != 0 && c.InitialConnWindowSize < minWindowSize {
+		return fmt.Errorf("initial_conn_window_size must be 0 or >= %d", minWindowSize)
+	}
+	if c.Keepalive.MaxConnectionIdle < 0 {
+		return fmt.Errorf("keepalive.max_connection_idle must be >= 0")
+	}
+	if c.Keepalive.Time < 0 {
+		return fmt.Errorf("keepalive.time must be >= 0")
+	}
+	if c.Keepalive.Timeout < 0 {
+		return fmt.Errorf("keepalive.timeout must be >= 0")
+	}
+	for _, field := range c.Audit.Redact {
+		if field == "" {
+			return fmt.Errorf("audit.redact must not contain empty field names")
+		}
+	}
+
+	return nil
+}
+
+// DefaultConfig returns the default configuration settings.
+func DefaultConfig() Config {
+	return Config{
+		MaxRecvMsgSize:        104857600, // 100 MiB
+		MaxSendMsgSize:        104857600, // 100 MiB
+		InitialWindowSize:     0,
+		InitialConnWindowSize: 0,
+		Keepalive: KeepaliveConfig{
+			MaxConnectionIdle: 600 * time.Second,
+			Time:              0,
+			Timeout:           0,
+		},
+		Audit: AuditConfig{
+			Path:   "",
+			Redact: []string{},
+		},
+	}
+}
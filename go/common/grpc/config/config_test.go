@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		fn    func(*Config)
+		valid bool
+	}{
+		{"Default", func(*Config) {}, true},
+		{"ZeroMaxRecvMsgSize", func(c *Config) { c.MaxRecvMsgSize = 0 }, false},
+		{"ZeroMaxSendMsgSize", func(c *Config) { c.MaxSendMsgSize = 0 }, false},
+		{"SmallInitialWindowSize", func(c *Config) { c.InitialWindowSize = minWindowSize - 1 }, false},
+		{"InitialWindowSize", func(c *Config) { c.InitialWindowSize = minWindowSize }, true},
+		{"SmallInitialConnWindowSize", func(c *Config) { c.InitialConnWindowSize = minWindowSize - 1 }, false},
+		{"InitialConnWindowSize", func(c *Config) { c.InitialConnWindowSize = minWindowSize }, true},
+		{"NegativeMaxConnectionIdle", func(c *Config) { c.Keepalive.MaxConnectionIdle = -time.Second }, false},
+		{"NegativeKeepaliveTime", func(c *Config) { c.Keepalive.Time = -time.Second }, false},
+		{"NegativeKeepaliveTimeout", func(c *Config) { c.Keepalive.Timeout = -time.Second }, false},
+		{"Keepalive", func(c *Config) { c.Keepalive.Time, c.Keepalive.Timeout = time.Minute, time.Second }, true},
+		{"EmptyRedactedField", func(c *Config) { c.Audit.Redact = []string{""} }, false},
+	} {
+		cfg := DefaultConfig()
+		tc.fn(&cfg)
+		err := cfg.Validate()
+		if tc.valid {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.Error(t, err, tc.name)
+		}
+	}
+}
@@ -24,6 +24,7 @@ import (
 
 	cmnTLS "github.com/oasisprotocol/oasis-core/go/common/crypto/tls"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc/auth"
+	grpcConfig "github.com/oasisprotocol/oasis-core/go/common/grpc/config"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/service"
@@ -33,9 +34,6 @@ const (
 	// CfgLogDebug enables verbose gRPC debug output.
 	CfgLogDebug = "grpc.log.debug"
 
-	maxRecvMsgSize = 104857600 // 100 MiB
-	maxSendMsgSize = 104857600 // 100 MiB
-
 	gracefulStopWaitPeriod = 5 * time.Second
 )
 
@@ -98,10 +96,6 @@ var (
 		grpcServerStreamWrites,
 	}
 
-	serverKeepAliveParams = keepalive.ServerParameters{
-		MaxConnectionIdle: 600 * time.Second,
-	}
-
 	_ grpclog.LoggerV2          = (*grpcLogAdapter)(nil)
 	_ service.BackgroundService = (*Server)(nil)
 )
@@ -443,6 +437,9 @@ type ServerConfig struct { // nolint: maligned
 	ClientCommonName string
 	// CustomOptions is an array of extra options for the grpc server.
 	CustomOptions []grpc.ServerOption
+	// Config is the message size, flow control, keepalive and audit configuration of the server.
+	// If not specified, the default configuration will be used.
+	Config *grpcConfig.Config
 }
 
 type listenerConfig struct {
@@ -617,12 +614,28 @@ func NewServer(config *ServerConfig) (*Server, error) {
 		// Default to identity.CommonName.
 		config.ClientCommonName = identity.CommonName
 	}
+	if config.Config == nil {
+		// Default to the default gRPC configuration.
+		defaultCfg := grpcConfig.DefaultConfig()
+		config.Config = &defaultCfg
+	}
 	var wrapper *grpcWrapper
 	unaryInterceptors := []grpc.UnaryServerInterceptor{
 		logAdapter.unaryLogger,
+	}
+	if auditCfg := &config.Config.Audit; auditCfg.Path != "" {
+		// Audit before authentication, so that denied calls are recorded as well.
+		f, err := openAuditFile(auditCfg.Path)
+		if err != nil {
+			return nil, err
+		}
+		audit := newAuditLogger(config.Name, auditCfg, f, svc.Logger)
+		unaryInterceptors = append(unaryInterceptors, audit.unaryInterceptor)
+	}
+	unaryInterceptors = append(unaryInterceptors,
 		serverUnaryErrorMapper,
 		auth.UnaryServerInterceptor(config.AuthFunc),
-	}
+	)
 	streamInterceptors := []grpc.StreamServerInterceptor{
 		logAdapter.streamLogger,
 		serverStreamErrorMapper,
@@ -636,11 +649,9 @@ func NewServer(config *ServerConfig) (*Server, error) {
 	sOpts := []grpc.ServerOption{
 		grpc.ChainUnaryInterceptor(unaryInterceptors...),
 		grpc.ChainStreamInterceptor(streamInterceptors...),
-		grpc.MaxRecvMsgSize(maxRecvMsgSize),
-		grpc.MaxSendMsgSize(maxSendMsgSize),
-		grpc.KeepaliveParams(serverKeepAliveParams),
 		grpc.ForceServerCodec(&CBORCodec{}),
 	}
+	sOpts = append(sOpts, serverLimitOptions(config.Config)...)
 	if config.Identity != nil && config.Identity.TLSCertificate != nil {
 		tlsConfig := &tls.Config{
 			ClientAuth: clientAuthType,
@@ -671,6 +682,61 @@ func NewServer(config *ServerConfig) (*Server, error) {
 	}, nil
 }
 
+// serverLimitOptions returns the server options for message size, flow control
+// and keepalive limits as configured.
+func serverLimitOptions(cfg *grpcConfig.Config) []grpc.ServerOption {
+	opts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSize),
+		grpc.MaxSendMsgSize(cfg.MaxSendMsgSize),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle: cfg.Keepalive.MaxConnectionIdle,
+			Time:              cfg.Keepalive.Time,
+			Timeout:           cfg.Keepalive.Timeout,
+		}),
+	}
+	if cfg.Keepalive.Time != 0 {
+		// Allow clients configured with the same keepalive interval to ping.
+		opts = append(opts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.Keepalive.Time,
+			PermitWithoutStream: true,
+		}))
+	}
+	if cfg.InitialWindowSize != 0 {
+		opts = append(opts, grpc.InitialWindowSize(cfg.InitialWindowSize))
+	}
+	if cfg.InitialConnWindowSize != 0 {
+		opts = append(opts, grpc.InitialConnWindowSize(cfg.InitialConnWindowSize))
+	}
+	return opts
+}
+
+// ClientLimitOptions returns the dial options for message size, flow control
+// and keepalive limits as configured.
+//
+// Dial uses the limits of the default configuration, pass these options to
+// Dial in order to override them.
+func ClientLimitOptions(cfg *grpcConfig.Config) []grpc.DialOption {
+	opts := []grpc.DialOption{
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallSendMsgSize(cfg.MaxSendMsgSize),
+			grpc.MaxCallRecvMsgSize(cfg.MaxRecvMsgSize),
+		),
+	}
+	if cfg.Keepalive.Time != 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    cfg.Keepalive.Time,
+			Timeout: cfg.Keepalive.Timeout,
+		}))
+	}
+	if cfg.InitialWindowSize != 0 {
+		opts = append(opts, grpc.WithInitialWindowSize(cfg.InitialWindowSize))
+	}
+	if cfg.InitialConnWindowSize != 0 {
+		opts = append(opts, grpc.WithInitialConnWindowSize(cfg.InitialConnWindowSize))
+	}
+	return opts
+}
+
 // Dial creates a client connection to the given target.
 func Dial(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	// If debug gRPC logs are enabled, setup the global gRPC logger.
@@ -694,12 +760,12 @@ func Dial(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	dialOpts := []grpc.DialOption{
 		grpc.WithDefaultCallOptions(
 			grpc.ForceCodec(&CBORCodec{}),
-			grpc.MaxCallSendMsgSize(maxSendMsgSize),
-			grpc.MaxCallRecvMsgSize(maxRecvMsgSize),
 		),
 		grpc.WithChainUnaryInterceptor(logAdapter.unaryClientLogger, clientUnaryErrorMapper),
 		grpc.WithChainStreamInterceptor(logAdapter.streamClientLogger, clientStreamErrorMapper),
 	}
+	defaultCfg := grpcConfig.DefaultConfig()
+	dialOpts = append(dialOpts, ClientLimitOptions(&defaultCfg)...)
 	dialOpts = append(dialOpts, opts...)
 	return grpc.NewClient(target, dialOpts...)
 }
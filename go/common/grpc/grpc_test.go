@@ -1,9 +1,17 @@
 package grpc
 
 import (
+	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	grpcConfig "github.com/oasisprotocol/oasis-core/go/common/grpc/config"
 )
 
 func TestIsLocalRPC(t *testing.T) {
@@ -43,3 +51,55 @@ func TestIsLocalRPC(t *testing.T) {
 		require.Equal(t, tc.expected, IsLocalAddress(tc.addr), tc.name+": "+tc.addr)
 	}
 }
+
+func TestMessageSizeLimits(t *testing.T) {
+	// Payload that exceeds the configured limits, but not the default ones.
+	payload := make([]byte, 4096)
+
+	limitedCfg := grpcConfig.DefaultConfig()
+	limitedCfg.MaxRecvMsgSize = 1024
+	limitedCfg.MaxSendMsgSize = 1024
+
+	for _, tc := range []struct {
+		name      string
+		serverCfg *grpcConfig.Config
+		dialOpts  []grpc.DialOption
+	}{
+		{"Server", &limitedCfg, nil},
+		{"Client", nil, ClientLimitOptions(&limitedCfg)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			path := filepath.Join(t.TempDir(), "grpc.sock")
+			grpcServer, err := NewServer(&ServerConfig{
+				Name:   "limits",
+				Path:   path,
+				Config: tc.serverCfg,
+			})
+			require.NoError(err, "NewServer")
+
+			server := &multiPingServer{}
+			grpcServer.Server().RegisterService(&multiServiceDesc, server)
+			err = grpcServer.Start()
+			require.NoError(err, "Start")
+			defer grpcServer.Stop()
+
+			opts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, tc.dialOpts...)
+			conn, err := Dial("unix:"+path, opts...)
+			require.NoError(err, "Dial")
+			defer conn.Close()
+
+			// Messages within limits should go through.
+			err = conn.Invoke(context.Background(), "/MultiPingService/Ping", &MultiPingUnaryRequest{}, &MultiPingUnaryResponse{})
+			require.NoError(err, "Ping")
+			require.EqualValues(1, server.GetPingCount())
+
+			// Messages exceeding the limits should be rejected.
+			err = conn.Invoke(context.Background(), "/MultiPingService/Ping", payload, &MultiPingUnaryResponse{})
+			require.Error(err, "oversized Ping should fail")
+			require.Equal(codes.ResourceExhausted, status.Code(err))
+			require.EqualValues(1, server.GetPingCount())
+		})
+	}
+}
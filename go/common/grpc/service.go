@@ -90,6 +90,13 @@ func (m *MethodDesc) WithAccessControl(f AccessControlFunc) *MethodDesc {
 	return m
 }
 
+// WithMutating tells that the endpoint mutates node or consensus state, so
+// calls to it are recorded in the audit log.
+func (m *MethodDesc) WithMutating() *MethodDesc {
+	m.mutating = true
+	return m
+}
+
 // MethodDesc is a gRPC method descriptor.
 type MethodDesc struct {
 	short       string
@@ -98,6 +105,7 @@ type MethodDesc struct {
 
 	accessControl      AccessControlFunc
 	namespaceExtractor NamespaceExtractorFunc
+	mutating           bool
 }
 
 // ShortName returns the short method name.
@@ -118,6 +126,11 @@ func (m *MethodDesc) IsAccessControlled(req interface{}) (bool, error) {
 	return m.accessControl(req)
 }
 
+// IsMutating returns true iff method mutates node or consensus state.
+func (m *MethodDesc) IsMutating() bool {
+	return m.mutating
+}
+
 // UnmarshalRawMessage unmarshals `cbor.RawMessage` request.
 func (m *MethodDesc) UnmarshalRawMessage(req *cbor.RawMessage) (interface{}, error) {
 	v := reflect.New(reflect.TypeOf(m.requestType)).Interface()
@@ -25,6 +25,20 @@ const (
 	NamespaceKeyManager NamespaceFlag = 1 << 62
 
 	flagsReserved = ^(NamespaceTest | NamespaceKeyManager)
+
+	// NamespaceDerivedVersion is the version of the namespace derivation
+	// scheme implemented by NewDerivedNamespace.
+	NamespaceDerivedVersion uint8 = 1
+
+	// namespaceDerivationContext is the domain separation context used
+	// when deriving namespace identifiers from an owner.
+	namespaceDerivationContext = "oasis-core/namespace: derive"
+
+	// derivedNonceOffset and derivedSuffixOffset are the offsets of the
+	// nonce and of the owner-scoped suffix in the identifier component of
+	// a derived namespace.
+	derivedNonceOffset  = 1
+	derivedSuffixOffset = derivedNonceOffset + 8
 )
 
 var (
@@ -144,6 +158,25 @@ func (n Namespace) IsKeyManager() bool {
 	return n.flags()&NamespaceKeyManager != 0
 }
 
+// Flags returns the namespace flags.
+func (n Namespace) Flags() NamespaceFlag {
+	return n.flags()
+}
+
+// IsDerivedFrom returns true iff the namespace was derived from the given
+// owner via NewDerivedNamespace.
+func (n Namespace) IsDerivedFrom(owner []byte) bool {
+	if n[8] != NamespaceDerivedVersion {
+		return false
+	}
+	nonce := binary.BigEndian.Uint64(n[8+derivedNonceOffset : 8+derivedSuffixOffset])
+	derived, err := NewDerivedNamespace(owner, nonce, n.flags())
+	if err != nil {
+		return false
+	}
+	return n.Equal(&derived)
+}
+
 func (n Namespace) isValid() bool {
 	return n.flags()&flagsReserved == 0
 }
@@ -165,6 +198,37 @@ func NewNamespace(id [NamespaceIDSize]byte, flags NamespaceFlag) (Namespace, err
 	return n, nil
 }
 
+// NewDerivedNamespace returns a new namespace with the identifier component
+// derived from the owner (e.g., an entity public key) and a nonce.
+//
+// The identifier component consists of the derivation scheme version, the
+// nonce and a suffix derived from the owner and the flags. Since owners can
+// only choose the nonce, they are unable to choose (vanity) identifiers and
+// identifiers of different owners cannot collide.
+func NewDerivedNamespace(owner []byte, nonce uint64, flags NamespaceFlag) (Namespace, error) {
+	var rawFlags [8]byte
+	binary.BigEndian.PutUint64(rawFlags[:], uint64(flags))
+	h := hash.NewFromBytes(
+		[]byte(namespaceDerivationContext),
+		[]byte{NamespaceDerivedVersion},
+		rawFlags[:],
+		owner,
+	)
+
+	var id [NamespaceIDSize]byte
+	id[0] = NamespaceDerivedVersion
+	binary.BigEndian.PutUint64(id[derivedNonceOffset:derivedSuffixOffset], nonce)
+	copy(id[derivedSuffixOffset:], h[:])
+
+	return NewNamespace(id, flags)
+}
+
+// MigrateNamespace returns the derived namespace that replaces the given
+// legacy namespace, keeping its flags.
+func MigrateNamespace(legacy Namespace, owner []byte, nonce uint64) (Namespace, error) {
+	return NewDerivedNamespace(owner, nonce, legacy.flags())
+}
+
 // NewTestNamespaceFromSeed returns a test namespace from a seed and flags.
 func NewTestNamespaceFromSeed(seed []byte, flags NamespaceFlag) Namespace {
 	h := hash.NewFromBytes(seed)
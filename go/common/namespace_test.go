@@ -0,0 +1,74 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceFlags(t *testing.T) {
+	require := require.New(t)
+
+	var id [NamespaceIDSize]byte
+	ns, err := NewNamespace(id, NamespaceTest|NamespaceKeyManager)
+	require.NoError(err, "NewNamespace")
+	require.True(ns.IsTest(), "IsTest")
+	require.True(ns.IsKeyManager(), "IsKeyManager")
+	require.Equal(NamespaceTest|NamespaceKeyManager, ns.Flags(), "Flags")
+
+	_, err = NewNamespace(id, 1)
+	require.ErrorIs(err, ErrMalformedNamespace, "NewNamespace should fail with reserved flags")
+}
+
+func TestNewDerivedNamespace(t *testing.T) {
+	require := require.New(t)
+
+	owner := []byte("entity public key")
+	ns, err := NewDerivedNamespace(owner, 0, NamespaceKeyManager)
+	require.NoError(err, "NewDerivedNamespace")
+	require.True(ns.IsKeyManager(), "derived namespace should keep flags")
+	require.False(ns.IsTest(), "derived namespace should keep flags")
+	require.Equal(NamespaceDerivedVersion, ns[8], "derived namespace should contain the version")
+	require.True(ns.IsDerivedFrom(owner), "IsDerivedFrom")
+	require.False(ns.IsDerivedFrom([]byte("other owner")), "IsDerivedFrom should fail for a different owner")
+
+	ns2, err := NewDerivedNamespace(owner, 0, NamespaceKeyManager)
+	require.NoError(err, "NewDerivedNamespace")
+	require.EqualValues(ns, ns2, "derivation should be deterministic")
+
+	ns3, err := NewDerivedNamespace(owner, 1, NamespaceKeyManager)
+	require.NoError(err, "NewDerivedNamespace")
+	require.NotEqualValues(ns, ns3, "different nonces should yield different namespaces")
+	require.True(ns3.IsDerivedFrom(owner), "IsDerivedFrom")
+
+	ns4, err := NewDerivedNamespace(owner, 0, 0)
+	require.NoError(err, "NewDerivedNamespace")
+	require.NotEqualValues(ns[8:], ns4[8:], "different flags should yield different identifiers")
+
+	// Tampering with the owner-scoped suffix or the flags should be detected.
+	tampered := ns3
+	tampered[NamespaceSize-1] ^= 0xff
+	require.False(tampered.IsDerivedFrom(owner), "IsDerivedFrom should fail for a tampered suffix")
+	tampered = ns3
+	tampered[0] ^= byte(NamespaceTest >> 56)
+	require.False(tampered.IsDerivedFrom(owner), "IsDerivedFrom should fail for tampered flags")
+
+	_, err = NewDerivedNamespace(owner, 0, 1)
+	require.ErrorIs(err, ErrMalformedNamespace, "NewDerivedNamespace should fail with reserved flags")
+}
+
+func TestMigrateNamespace(t *testing.T) {
+	require := require.New(t)
+
+	owner := []byte("entity public key")
+	var id [NamespaceIDSize]byte
+	copy(id[:], owner)
+	legacy, err := NewNamespace(id, NamespaceTest)
+	require.NoError(err, "NewNamespace")
+	require.False(legacy.IsDerivedFrom(owner), "legacy namespace should not be derived")
+
+	migrated, err := MigrateNamespace(legacy, owner, 42)
+	require.NoError(err, "MigrateNamespace")
+	require.True(migrated.IsTest(), "migrated namespace should keep flags")
+	require.True(migrated.IsDerivedFrom(owner), "migrated namespace should be derived from the owner")
+}
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"encoding"
 	"errors"
 	"fmt"
@@ -34,9 +35,20 @@ type Address struct {
 	IP   net.IP `json:"IP"`
 	Port int64  `json:"Port"`
 	Zone string `json:"Zone"`
+
+	// Host is the hostname the address refers to. If set, the IP and zone are empty and the
+	// hostname needs to be resolved each time the address is used.
+	Host string `json:"Host,omitempty"`
+}
+
+// IsHostname returns true iff the address refers to a hostname instead of an IP address.
+func (a *Address) IsHostname() bool {
+	return a.Host != ""
 }
 
 // ToTCPAddr returns a net TCP address.
+//
+// For addresses referring to hostnames the returned address has no IP, use Resolve instead.
 func (a *Address) ToTCPAddr() *net.TCPAddr {
 	return &net.TCPAddr{
 		IP:   a.IP,
@@ -56,9 +68,35 @@ func (a *Address) Equal(other *Address) bool {
 	if a.Zone != other.Zone {
 		return false
 	}
+	if a.Host != other.Host {
+		return false
+	}
 	return true
 }
 
+// Resolve resolves the address into TCP addresses.
+//
+// Addresses referring to IP addresses resolve to themselves.
+func (a *Address) Resolve(ctx context.Context) ([]*net.TCPAddr, error) {
+	if !a.IsHostname() {
+		return []*net.TCPAddr{a.ToTCPAddr()}, nil
+	}
+
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, a.Host)
+	if err != nil {
+		return nil, fmt.Errorf("node: failed to resolve hostname: %w", err)
+	}
+	addrs := make([]*net.TCPAddr, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, &net.TCPAddr{
+			IP:   ip.IP,
+			Port: int(a.Port),
+			Zone: ip.Zone,
+		})
+	}
+	return addrs, nil
+}
+
 // MarshalText implements the encoding.TextMarshaler interface.
 func (a *Address) MarshalText() ([]byte, error) {
 	return []byte(a.String()), nil
@@ -90,17 +128,65 @@ func (a *Address) FromIP(ip net.IP, port uint16) error {
 
 	a.Port = int64(port)
 	a.Zone = ""
+	a.Host = ""
+
+	return nil
+}
+
+// FromHostname populates the address from a hostname and port. Unlike UnmarshalText, the
+// hostname is preserved and not resolved.
+func (a *Address) FromHostname(host string, port uint16) error {
+	if !IsValidHostname(host) {
+		return ErrInvalidAddress
+	}
+
+	a.IP = nil
+	a.Port = int64(port)
+	a.Zone = ""
+	a.Host = host
 
 	return nil
 }
 
+// IsValidHostname returns true iff the given string is a valid DNS hostname that is not an IP
+// address.
+func IsValidHostname(host string) bool {
+	if len(host) == 0 || len(host) > 253 || net.ParseIP(host) != nil {
+		return false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if len(label) == 0 || len(label) > 63 {
+			return false
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			switch {
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-':
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // IsRoutable returns true iff the address is likely to be globally routable.
+//
+// Addresses referring to hostnames are assumed to be routable.
 func (a *Address) IsRoutable() bool {
+	if a.IsHostname() {
+		return true
+	}
 	return common.IsProbablyGloballyReachable(a.IP)
 }
 
 // String returns the string representation of an address.
 func (a Address) String() string {
+	if a.IsHostname() {
+		return net.JoinHostPort(a.Host, fmt.Sprintf("%d", a.Port))
+	}
 	ip := a.IP.String()
 	if a.Zone != "" {
 		return net.JoinHostPort(ip+"%"+a.Zone, fmt.Sprintf("%d", a.Port))
@@ -110,6 +196,9 @@ func (a Address) String() string {
 
 // MultiAddressStr returns a multi address string representation of the address.
 func (a Address) MultiAddressStr() string {
+	if a.IsHostname() {
+		return fmt.Sprintf("/dns/%s/tcp/%d", a.Host, a.Port)
+	}
 	version := 4
 	if p4 := a.IP.To4(); len(p4) != net.IPv4len {
 		version = 6
@@ -1,10 +1,14 @@
 package node
 
 import (
+	"context"
+	"encoding/base64"
 	"net"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 )
 
 func TestIsRoutable(t *testing.T) {
@@ -90,3 +94,61 @@ func TestTLSAddress(t *testing.T) {
 		require.Equal(t, testCase.tlsAddress, string(committeeAddrBytes), "marshalled TLS address does not match")
 	}
 }
+
+func TestHostnameAddress(t *testing.T) {
+	require := require.New(t)
+
+	for _, host := range []string{"node.example.com", "localhost", "a-b.example"} {
+		require.True(IsValidHostname(host), "hostname %s should be valid", host)
+	}
+	for _, host := range []string{"", "192.0.2.1", "::1", "-node.example.com", "node..example.com", "node_1.example.com"} {
+		require.False(IsValidHostname(host), "hostname %s should be invalid", host)
+	}
+
+	var address Address
+	require.Error(address.FromHostname("192.0.2.1", 26656), "IP addresses should be rejected")
+	require.NoError(address.FromHostname("node.example.com", 26656))
+	require.True(address.IsHostname())
+	require.True(address.IsRoutable())
+	require.Equal("node.example.com:26656", address.String())
+	require.Equal("/dns/node.example.com/tcp/26656", address.MultiAddressStr())
+
+	var other Address
+	require.NoError(other.FromIP(net.ParseIP("192.0.2.1"), 26656))
+	require.False(other.IsHostname())
+	require.False(address.Equal(&other))
+
+	require.NoError(address.FromHostname("localhost", 8000))
+	addrs, err := address.Resolve(context.Background())
+	require.NoError(err, "Resolve")
+	require.NotEmpty(addrs)
+	for _, addr := range addrs {
+		require.True(addr.IP.IsLoopback(), "localhost should resolve to a loopback address")
+		require.Equal(8000, addr.Port)
+	}
+}
+
+func TestAddressSerialization(t *testing.T) {
+	require := require.New(t)
+
+	var ipAddr, hostAddr Address
+	require.NoError(ipAddr.FromIP(net.ParseIP("127.0.0.1"), 26656))
+	require.NoError(hostAddr.FromHostname("node.example.com", 26656))
+
+	// NOTE: These cases should be synced with tests in runtime/src/consensus/registry.rs.
+	for _, tc := range []struct {
+		addr           Address
+		expectedBase64 string
+	}{
+		{ipAddr, "o2JJUER/AAABZFBvcnQZaCBkWm9uZWA="},
+		{hostAddr, "pGJJUPZkSG9zdHBub2RlLmV4YW1wbGUuY29tZFBvcnQZaCBkWm9uZWA="},
+	} {
+		enc := cbor.Marshal(tc.addr)
+		require.Equal(tc.expectedBase64, base64.StdEncoding.EncodeToString(enc), "serialization should match")
+
+		var dec Address
+		err := cbor.Unmarshal(enc, &dec)
+		require.NoError(err, "Unmarshal")
+		require.EqualValues(tc.addr, dec, "Address serialization should round-trip")
+	}
+}
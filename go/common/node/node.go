@@ -16,6 +16,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 )
 
@@ -63,6 +64,9 @@ const (
 	maxNodeDescriptorVersion = LatestNodeDescriptorVersion
 
 	nodeSoftwareVersionMaxLength = 128
+
+	// nodeBuildCommitMaxLength is the maximum length of the build commit identifier.
+	nodeBuildCommitMaxLength = 64
 )
 
 // Node represents public connectivity information about an Oasis node.
@@ -101,6 +105,13 @@ type Node struct { // nolint: maligned
 
 	// SoftwareVersion is the node's oasis-node software version.
 	SoftwareVersion SoftwareVersion `json:"software_version,omitempty"`
+
+	// Capacity contains optional hints about the node's capacity for hosting
+	// runtimes.
+	Capacity *CapacityInfo `json:"capacity,omitempty"`
+
+	// Build contains optional build metadata of the node's software.
+	Build *BuildInfo `json:"build,omitempty"`
 }
 
 // nodeV2 represents (to be deprecated) V2 version of node descriptors.
@@ -357,6 +368,16 @@ func (n *Node) ValidateBasic(strictVersion bool) error {
 		return err
 	}
 
+	// Validate capacity hints.
+	if err := n.Capacity.ValidateBasic(); err != nil {
+		return err
+	}
+
+	// Validate build metadata.
+	if err := n.Build.ValidateBasic(); err != nil {
+		return err
+	}
+
 	// Make sure that a node has at least one valid role.
 	switch {
 	case n.Roles == 0:
@@ -448,6 +469,77 @@ type Runtime struct {
 	ExtraInfo []byte `json:"extra_info"`
 }
 
+// CapacityInfo contains hints about the node's capacity for hosting runtimes.
+type CapacityInfo struct {
+	// MaxRuntimes is the maximum number of runtimes the node can be
+	// concurrently elected for. Zero means that there is no limit.
+	MaxRuntimes uint16 `json:"max_runtimes,omitempty"`
+
+	// TEEMemory is the amount of TEE memory (in bytes) available for hosting
+	// runtimes. Zero means that the amount is unknown.
+	TEEMemory uint64 `json:"tee_memory,omitempty"`
+}
+
+// ValidateBasic performs basic capacity hint validity checks.
+func (c *CapacityInfo) ValidateBasic() error {
+	if c == nil {
+		return nil
+	}
+	if c.MaxRuntimes == 0 && c.TEEMemory == 0 {
+		return fmt.Errorf("malformed node capacity: no capacity hints specified")
+	}
+	return nil
+}
+
+// HasTEEMemory returns true iff the node has enough TEE memory to host a
+// runtime requiring the given amount of TEE memory, given the amount of TEE
+// memory already required by the runtimes it has been assigned.
+func (c *CapacityInfo) HasTEEMemory(assigned, required uint64) bool {
+	if c == nil || c.TEEMemory == 0 {
+		return true
+	}
+	return assigned <= c.TEEMemory && required <= c.TEEMemory-assigned
+}
+
+// IsSaturated returns true iff the node has been assigned at least as many
+// runtimes as it can concurrently host.
+func (c *CapacityInfo) IsSaturated(assigned int) bool {
+	if c == nil || c.MaxRuntimes == 0 {
+		return false
+	}
+	return assigned >= int(c.MaxRuntimes)
+}
+
+// MaxBuildEnclaves is the maximum number of enclave identities in node build metadata.
+const MaxBuildEnclaves = 128
+
+// BuildInfo contains build metadata of the node's software.
+type BuildInfo struct {
+	// Commit is the git commit the oasis-node binary was built from.
+	Commit string `json:"commit,omitempty"`
+
+	// Toolchain is the version of the Go toolchain the oasis-node binary was built with.
+	Toolchain version.Version `json:"toolchain"`
+
+	// Enclaves are the enclave identities of the runtime components the node is configured
+	// to host, when known at build time.
+	Enclaves []sgx.EnclaveIdentity `json:"enclaves,omitempty"`
+}
+
+// ValidateBasic performs basic build metadata validity checks.
+func (b *BuildInfo) ValidateBasic() error {
+	if b == nil {
+		return nil
+	}
+	if l := len(b.Commit); l > nodeBuildCommitMaxLength {
+		return fmt.Errorf("malformed node build commit: value too big (max length: %d, length: %d)", nodeBuildCommitMaxLength, l)
+	}
+	if l := len(b.Enclaves); l > MaxBuildEnclaves {
+		return fmt.Errorf("malformed node build enclaves: too many enclaves (max: %d, count: %d)", MaxBuildEnclaves, l)
+	}
+	return nil
+}
+
 // TLSInfo contains information for connecting to this node via TLS.
 type TLSInfo struct {
 	// PubKey is the public key used for establishing TLS connections.
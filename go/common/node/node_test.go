@@ -14,6 +14,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 )
 
@@ -117,6 +118,50 @@ func TestNodeDescriptor(t *testing.T) {
 	require.EqualValues(n, n2, "s11n roundtrip")
 }
 
+func TestNodeDescriptorMultipleAddresses(t *testing.T) {
+	require := require.New(t)
+
+	var v4Addr, v6Addr Address
+	require.NoError(v4Addr.FromIP(net.ParseIP("192.0.2.1"), 9200), "FromIP")
+	require.NoError(v6Addr.FromIP(net.ParseIP("2001:db8::1"), 9200), "FromIP")
+
+	var consensusID signature.PublicKey
+	require.NoError(consensusID.UnmarshalHex("0000000000000000000000000000000000000000000000000000000000000001"), "consensus id")
+
+	n := Node{
+		Versioned:  cbor.NewVersioned(LatestNodeDescriptorVersion),
+		Expiration: 42,
+		Roles:      RoleValidator,
+		P2P: P2PInfo{
+			Addresses: []Address{v4Addr, v6Addr},
+		},
+		Consensus: ConsensusInfo{
+			Addresses: []ConsensusAddress{
+				{ID: consensusID, Address: v4Addr},
+				{ID: consensusID, Address: v6Addr},
+			},
+		},
+	}
+
+	b := cbor.Marshal(n)
+	var n2 Node
+	err := cbor.Unmarshal(b, &n2)
+	require.NoError(err, "deserialize descriptor")
+	require.EqualValues(n, n2, "s11n roundtrip")
+
+	require.Len(n2.P2P.Addresses, 2, "all addresses should be preserved")
+	require.True(n2.P2P.Addresses[0].Equal(&v4Addr), "IPv4 address should be preserved")
+	require.True(n2.P2P.Addresses[1].Equal(&v6Addr), "IPv6 address should be preserved")
+	require.Equal("/ip4/192.0.2.1/tcp/9200", n2.P2P.Addresses[0].MultiAddressStr())
+	require.Equal("/ip6/2001:db8::1/tcp/9200", n2.P2P.Addresses[1].MultiAddressStr())
+	require.Len(n2.Consensus.Addresses, 2, "all consensus addresses should be preserved")
+	require.True(n2.Consensus.Addresses[0].Address.Equal(&v4Addr), "IPv4 address should be preserved")
+	require.True(n2.Consensus.Addresses[1].Address.Equal(&v6Addr), "IPv6 address should be preserved")
+
+	err = n2.ValidateBasic(true)
+	require.NoError(err, "ValidateBasic")
+}
+
 func TestReservedRoles(t *testing.T) {
 	require := require.New(t)
 
@@ -480,3 +525,52 @@ func TestNodeSoftwareVersion(t *testing.T) {
 	sw = SoftwareVersion(strings.Repeat("a", 1000))
 	require.Error(sw.ValidateBasic(), "invalid software version")
 }
+
+func TestNodeBuildInfo(t *testing.T) {
+	require := require.New(t)
+
+	var bi *BuildInfo
+	require.NoError(bi.ValidateBasic(), "missing build info is allowed")
+
+	bi = &BuildInfo{
+		Commit:    strings.Repeat("a", 40),
+		Toolchain: version.Toolchain,
+	}
+	require.NoError(bi.ValidateBasic(), "build info is allowed")
+
+	bi.Commit = strings.Repeat("a", 1000)
+	require.Error(bi.ValidateBasic(), "invalid build commit")
+
+	bi.Commit = ""
+	bi.Enclaves = make([]sgx.EnclaveIdentity, MaxBuildEnclaves+1)
+	require.Error(bi.ValidateBasic(), "too many build enclaves")
+}
+
+func TestNodeCapacityInfo(t *testing.T) {
+	require := require.New(t)
+
+	var ci *CapacityInfo
+	require.NoError(ci.ValidateBasic(), "missing capacity info is allowed")
+	require.False(ci.IsSaturated(100), "missing capacity info is never saturated")
+
+	ci = &CapacityInfo{}
+	require.Error(ci.ValidateBasic(), "empty capacity info")
+
+	ci.MaxRuntimes = 2
+	require.NoError(ci.ValidateBasic(), "capacity info is allowed")
+	require.False(ci.IsSaturated(1), "node with spare capacity is not saturated")
+	require.True(ci.IsSaturated(2), "node at capacity is saturated")
+	require.True(ci.HasTEEMemory(1<<40, 1<<40), "unknown TEE memory is never exhausted")
+
+	ci = &CapacityInfo{TEEMemory: 4 << 30}
+	require.NoError(ci.ValidateBasic(), "TEE memory only capacity info is allowed")
+	require.False(ci.IsSaturated(100), "unlimited runtimes are never saturated")
+	require.True(ci.HasTEEMemory(0, 4<<30), "node with enough TEE memory")
+	require.True(ci.HasTEEMemory(2<<30, 2<<30), "node with enough spare TEE memory")
+	require.False(ci.HasTEEMemory(3<<30, 2<<30), "node without enough spare TEE memory")
+	require.False(ci.HasTEEMemory(5<<30, 0), "node with overcommitted TEE memory")
+	require.False(ci.HasTEEMemory(1<<30, ^uint64(0)), "overflowing TEE memory requirement")
+
+	var nilCI *CapacityInfo
+	require.True(nilCI.HasTEEMemory(1<<40, 1<<40), "missing capacity info never runs out of TEE memory")
+}
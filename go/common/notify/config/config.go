@@ -0,0 +1,114 @@
+// Package config implements global notification configuration options.
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	// SinkWebhook is the name of the webhook notification sink.
+	SinkWebhook = "webhook"
+	// SinkStdout is the name of the stdout JSON notification sink.
+	SinkStdout = "stdout"
+)
+
+// Config is the notification configuration structure.
+type Config struct {
+	// Sinks are the notification sinks that events are delivered to. Notifications are disabled
+	// when no sinks are configured.
+	Sinks []SinkConfig `yaml:"sinks,omitempty"`
+
+	// Events contains per event type configuration, keyed by event type. Event types that are
+	// not listed are enabled and use the default rate limit.
+	Events map[string]EventConfig `yaml:"events,omitempty"`
+
+	// RateLimit is the default minimum interval between two notifications of the same type
+	// concerning the same subject.
+	RateLimit time.Duration `yaml:"rate_limit"`
+
+	// CheckInterval is the interval at which the node status is checked for conditions that
+	// warrant a notification (e.g., registration expiring or storage lagging).
+	CheckInterval time.Duration `yaml:"check_interval"`
+
+	// StorageLagRounds is the number of rounds the local storage can lag behind the latest
+	// runtime round before a notification is emitted.
+	StorageLagRounds uint64 `yaml:"storage_lag_rounds"`
+
+	// AttestationExpiryBlocks is the number of consensus blocks before a runtime attestation
+	// expires at which a notification is emitted.
+	AttestationExpiryBlocks uint64 `yaml:"attestation_expiry_blocks"`
+}
+
+// SinkConfig is the notification sink configuration structure.
+type SinkConfig struct {
+	// Type is the sink type (webhook, stdout).
+	Type string `yaml:"type"`
+
+	// URL is the webhook URL.
+	URL string `yaml:"url,omitempty"`
+
+	// Timeout is the webhook request timeout.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// EventConfig is the per event type notification configuration structure.
+type EventConfig struct {
+	// Disabled disables notifications of the given event type.
+	Disabled bool `yaml:"disabled,omitempty"`
+
+	// RateLimit overrides the default rate limit for the given event type.
+	RateLimit time.Duration `yaml:"rate_limit,omitempty"`
+}
+
+// Enabled returns true iff notifications are enabled.
+func (c *Config) Enabled() bool {
+	return len(c.Sinks) > 0
+}
+
+// Validate validates the configuration settings.
+func (c *Config) Validate() error {
+	for i, sink := range c.Sinks {
+		switch sink.Type {
+		case SinkWebhook:
+			u, err := url.Parse(sink.URL)
+			if err != nil {
+				return fmt.Errorf("sinks[%d]: malformed webhook url: %w", i, err)
+			}
+			if u.Scheme != "http" && u.Scheme != "https" {
+				return fmt.Errorf("sinks[%d]: webhook url must use http or https", i)
+			}
+			if sink.Timeout < 0 {
+				return fmt.Errorf("sinks[%d]: timeout must be >= 0", i)
+			}
+		case SinkStdout:
+		default:
+			return fmt.Errorf("sinks[%d]: unknown sink type: %s", i, sink.Type)
+		}
+	}
+	if c.RateLimit < 0 {
+		return fmt.Errorf("rate_limit must be >= 0")
+	}
+	for typ, ev := range c.Events {
+		if ev.RateLimit < 0 {
+			return fmt.Errorf("events.%s.rate_limit must be >= 0", typ)
+		}
+	}
+	if c.Enabled() && c.CheckInterval < time.Second {
+		return fmt.Errorf("check_interval must be >= 1 second")
+	}
+	return nil
+}
+
+// DefaultConfig returns the default configuration settings.
+func DefaultConfig() Config {
+	return Config{
+		Sinks:                   []SinkConfig{},
+		Events:                  map[string]EventConfig{},
+		RateLimit:               10 * time.Minute,
+		CheckInterval:           1 * time.Minute,
+		StorageLagRounds:        10,
+		AttestationExpiryBlocks: 100,
+	}
+}
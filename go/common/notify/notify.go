@@ -0,0 +1,239 @@
+// Package notify implements node operator notifications.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/notify/config"
+)
+
+// eventQueueSize is the maximum number of events waiting to be delivered to sinks.
+const eventQueueSize = 64
+
+// Type is the notification event type.
+type Type string
+
+const (
+	// EventCommitteeElected is emitted when the node is elected to a committee.
+	EventCommitteeElected Type = "committee_elected"
+	// EventRegistrationExpiring is emitted when the node registration is about to expire.
+	EventRegistrationExpiring Type = "registration_expiring"
+	// EventRoundFailed is emitted when a runtime round fails.
+	EventRoundFailed Type = "round_failed"
+	// EventStorageLagging is emitted when the local runtime storage lags behind.
+	EventStorageLagging Type = "storage_lagging"
+	// EventAttestationExpiring is emitted when a runtime TEE attestation is about to expire.
+	EventAttestationExpiring Type = "attestation_expiring"
+)
+
+var knownTypes = map[Type]bool{
+	EventCommitteeElected:     true,
+	EventRegistrationExpiring: true,
+	EventRoundFailed:          true,
+	EventStorageLagging:       true,
+	EventAttestationExpiring:  true,
+}
+
+// Event is a notification event.
+type Event struct {
+	// Type is the event type.
+	Type Type `json:"type"`
+	// Time is the time when the event was emitted.
+	Time time.Time `json:"time"`
+	// NodeID is the identifier of the node emitting the event.
+	NodeID signature.PublicKey `json:"node_id"`
+	// RuntimeID is the identifier of the runtime the event concerns, if any.
+	RuntimeID *common.Namespace `json:"runtime_id,omitempty"`
+	// Message is a human readable event description.
+	Message string `json:"message"`
+	// Details contains event type specific details.
+	Details map[string]interface{} `json:"details,omitempty"`
+	// Suppressed is the number of events of the same type concerning the same subject that have
+	// been suppressed by rate limiting since the last delivered event.
+	Suppressed uint64 `json:"suppressed,omitempty"`
+}
+
+type limitKey struct {
+	typ       Type
+	runtimeID common.Namespace
+}
+
+type limitState struct {
+	lastSent   time.Time
+	suppressed uint64
+}
+
+// Notifier delivers notification events to the configured sinks.
+//
+// Events are rate limited per event type and subject and are delivered asynchronously. A nil
+// notifier is valid and drops all events.
+type Notifier struct {
+	sync.Mutex
+
+	nodeID signature.PublicKey
+	sinks  []Sink
+	events map[Type]config.EventConfig
+
+	rateLimit time.Duration
+	limits    map[limitKey]*limitState
+	now       func() time.Time
+
+	eventCh chan *Event
+	stopCh  chan struct{}
+	quitCh  chan struct{}
+
+	logger *logging.Logger
+}
+
+// Notify emits a notification event of the given type.
+func (n *Notifier) Notify(typ Type, runtimeID *common.Namespace, message string, details map[string]interface{}) {
+	if n == nil {
+		return
+	}
+	evCfg := n.events[typ]
+	if evCfg.Disabled {
+		return
+	}
+	rateLimit := n.rateLimit
+	if evCfg.RateLimit > 0 {
+		rateLimit = evCfg.RateLimit
+	}
+
+	n.Lock()
+	now := n.now()
+	key := limitKey{typ: typ}
+	if runtimeID != nil {
+		key.runtimeID = *runtimeID
+	}
+	st, ok := n.limits[key]
+	if !ok {
+		st = &limitState{}
+		n.limits[key] = st
+	}
+	if !st.lastSent.IsZero() && now.Sub(st.lastSent) < rateLimit {
+		st.suppressed++
+		n.Unlock()
+		return
+	}
+	ev := &Event{
+		Type:       typ,
+		Time:       now.UTC(),
+		NodeID:     n.nodeID,
+		RuntimeID:  runtimeID,
+		Message:    message,
+		Details:    details,
+		Suppressed: st.suppressed,
+	}
+	st.lastSent = now
+	st.suppressed = 0
+	n.Unlock()
+
+	select {
+	case n.eventCh <- ev:
+	default:
+		n.logger.Warn("event queue full, dropping notification",
+			"type", typ,
+		)
+	}
+}
+
+// Name returns the service name.
+func (n *Notifier) Name() string {
+	return "notifier"
+}
+
+// Start starts the service.
+func (n *Notifier) Start() error {
+	go n.worker()
+	return nil
+}
+
+// Stop halts the service.
+func (n *Notifier) Stop() {
+	close(n.stopCh)
+}
+
+// Quit returns a channel that will be closed when the service terminates.
+func (n *Notifier) Quit() <-chan struct{} {
+	return n.quitCh
+}
+
+// Cleanup performs the service specific post-termination cleanup.
+func (n *Notifier) Cleanup() {
+}
+
+func (n *Notifier) worker() {
+	defer close(n.quitCh)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-n.stopCh
+		cancel()
+	}()
+
+	for {
+		select {
+		case <-n.stopCh:
+			return
+		case ev := <-n.eventCh:
+			for _, sink := range n.sinks {
+				if err := sink.Send(ctx, ev); err != nil {
+					n.logger.Error("failed to deliver notification",
+						"err", err,
+						"sink", sink.Name(),
+						"type", ev.Type,
+					)
+				}
+			}
+		}
+	}
+}
+
+// New creates a new notifier from the given configuration.
+//
+// Returns nil when no sinks are configured.
+func New(cfg *config.Config, nodeID signature.PublicKey) (*Notifier, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+
+	for typ := range cfg.Events {
+		if !knownTypes[Type(typ)] {
+			return nil, fmt.Errorf("notify: unknown event type: %s", typ)
+		}
+	}
+
+	var sinks []Sink
+	for _, sinkCfg := range cfg.Sinks {
+		sink, err := newSink(&sinkCfg)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	events := make(map[Type]config.EventConfig, len(cfg.Events))
+	for typ, evCfg := range cfg.Events {
+		events[Type(typ)] = evCfg
+	}
+
+	return &Notifier{
+		nodeID:    nodeID,
+		sinks:     sinks,
+		events:    events,
+		rateLimit: cfg.RateLimit,
+		limits:    make(map[limitKey]*limitState),
+		now:       time.Now,
+		eventCh:   make(chan *Event, eventQueueSize),
+		stopCh:    make(chan struct{}),
+		quitCh:    make(chan struct{}),
+		logger:    logging.GetLogger("common/notify"),
+	}, nil
+}
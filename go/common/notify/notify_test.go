@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/notify/config"
+)
+
+type chanSink struct {
+	ch chan *Event
+}
+
+func (s *chanSink) Name() string {
+	return "chan"
+}
+
+func (s *chanSink) Send(_ context.Context, ev *Event) error {
+	s.ch <- ev
+	return nil
+}
+
+func TestNotifier(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.DefaultConfig()
+	cfg.Sinks = []config.SinkConfig{{Type: config.SinkStdout}}
+	cfg.Events = map[string]config.EventConfig{
+		string(EventStorageLagging): {Disabled: true},
+		string(EventRoundFailed):    {RateLimit: time.Second},
+	}
+	require.NoError(cfg.Validate())
+
+	n, err := New(&cfg, signature.PublicKey{})
+	require.NoError(err, "New")
+	require.NotNil(n)
+
+	sink := &chanSink{ch: make(chan *Event, 10)}
+	n.sinks = []Sink{sink}
+	now := time.Now()
+	n.now = func() time.Time { return now }
+
+	require.NoError(n.Start())
+	defer n.Stop()
+
+	var runtimeID common.Namespace
+	_ = runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+
+	// Disabled event types should be dropped.
+	n.Notify(EventStorageLagging, &runtimeID, "lagging", nil)
+
+	n.Notify(EventRoundFailed, &runtimeID, "failed", map[string]interface{}{"round": 1})
+	ev := <-sink.ch
+	require.Equal(EventRoundFailed, ev.Type)
+	require.Equal(&runtimeID, ev.RuntimeID)
+	require.EqualValues(0, ev.Suppressed)
+
+	// Events within the rate limit should be suppressed, but other subjects are not affected.
+	n.Notify(EventRoundFailed, &runtimeID, "failed", nil)
+	n.Notify(EventRoundFailed, &runtimeID, "failed", nil)
+	n.Notify(EventRoundFailed, nil, "failed", nil)
+	ev = <-sink.ch
+	require.Nil(ev.RuntimeID)
+
+	now = now.Add(time.Second)
+	n.Notify(EventRoundFailed, &runtimeID, "failed", nil)
+	ev = <-sink.ch
+	require.EqualValues(2, ev.Suppressed, "suppressed events should be reported")
+
+	select {
+	case ev = <-sink.ch:
+		t.Fatalf("unexpected event: %+v", ev)
+	default:
+	}
+
+	// A nil notifier should drop all events.
+	var nilNotifier *Notifier
+	nilNotifier.Notify(EventRoundFailed, nil, "failed", nil)
+}
+
+func TestNotifierConfig(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.DefaultConfig()
+	n, err := New(&cfg, signature.PublicKey{})
+	require.NoError(err)
+	require.Nil(n, "notifier should be disabled without sinks")
+
+	cfg.Sinks = []config.SinkConfig{{Type: config.SinkStdout}}
+	cfg.Events = map[string]config.EventConfig{"unknown": {}}
+	_, err = New(&cfg, signature.PublicKey{})
+	require.Error(err, "unknown event types should be rejected")
+
+	cfg.Sinks = []config.SinkConfig{{Type: config.SinkWebhook, URL: "ftp://example.com"}}
+	require.Error(cfg.Validate(), "non-http webhook urls should be rejected")
+}
+
+func TestWebhookSink(t *testing.T) {
+	require := require.New(t)
+
+	evCh := make(chan *Event, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev Event
+		if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		evCh <- &ev
+	}))
+	defer srv.Close()
+
+	sink := NewWebhookSink(srv.URL, 0)
+	err := sink.Send(context.Background(), &Event{Type: EventCommitteeElected, Message: "elected"})
+	require.NoError(err, "Send")
+
+	ev := <-evCh
+	require.Equal(EventCommitteeElected, ev.Type)
+	require.Equal("elected", ev.Message)
+
+	sink = NewWebhookSink(srv.URL+"/missing", 0)
+	srv.Config.Handler = http.NotFoundHandler()
+	err = sink.Send(context.Background(), &Event{Type: EventCommitteeElected})
+	require.Error(err, "non-2xx responses should fail")
+}
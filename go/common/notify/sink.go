@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/notify/config"
+)
+
+// defaultWebhookTimeout is the default webhook request timeout.
+const defaultWebhookTimeout = 10 * time.Second
+
+// Sink is a notification sink.
+type Sink interface {
+	// Name returns the sink name.
+	Name() string
+
+	// Send delivers the given event.
+	Send(ctx context.Context, ev *Event) error
+}
+
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func (s *webhookSink) Name() string {
+	return config.SinkWebhook
+}
+
+func (s *webhookSink) Send(ctx context.Context, ev *Event) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+	_, _ = io.Copy(io.Discard, rsp.Body)
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status: %s", rsp.Status)
+	}
+	return nil
+}
+
+// NewWebhookSink creates a new sink that posts events as JSON to the given URL.
+func NewWebhookSink(url string, timeout time.Duration) Sink {
+	if timeout == 0 {
+		timeout = defaultWebhookTimeout
+	}
+	return &webhookSink{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+type writerSink struct {
+	sync.Mutex
+
+	enc *json.Encoder
+}
+
+func (s *writerSink) Name() string {
+	return config.SinkStdout
+}
+
+func (s *writerSink) Send(_ context.Context, ev *Event) error {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.enc.Encode(ev)
+}
+
+// NewWriterSink creates a new sink that writes events to the given writer, one JSON object per
+// line.
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{
+		enc: json.NewEncoder(w),
+	}
+}
+
+func newSink(cfg *config.SinkConfig) (Sink, error) {
+	switch cfg.Type {
+	case config.SinkWebhook:
+		return NewWebhookSink(cfg.URL, cfg.Timeout), nil
+	case config.SinkStdout:
+		return NewWriterSink(os.Stdout), nil
+	default:
+		return nil, fmt.Errorf("notify: unknown sink type: %s", cfg.Type)
+	}
+}
@@ -60,6 +60,7 @@ func NewCommonStore(dataDir string) (*CommonStore, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to open persistence database: %w", err)
 	}
+	cmnBadger.RegisterForSnapshots(db, false)
 
 	gc := cmnBadger.NewGCWorker(logger, db)
 	gc.Start()
@@ -4,6 +4,7 @@ package pubsub
 import (
 	"context"
 	"errors"
+	"sync"
 
 	"github.com/eapache/channels"
 )
@@ -43,6 +44,65 @@ func NewContextSubscription(ctx context.Context) (context.Context, ClosableSubsc
 	return ctx, contextSubscription{cancel}
 }
 
+// ErrorSubscription is a closable subscription that reports the error which
+// caused its channel to be closed, if any.
+type ErrorSubscription interface {
+	ClosableSubscription
+
+	// Err returns the error that caused the subscription's channel to be
+	// closed, or nil if the channel was closed without an error.
+	//
+	// The result is only meaningful after the channel has been closed.
+	Err() error
+}
+
+// ContextErrorSubscription is a subscription that cancels the context when
+// closed and can record the error that terminated it.
+type ContextErrorSubscription struct {
+	sync.Mutex
+
+	cancel context.CancelFunc
+	err    error
+}
+
+// Close unsubscribes the subscription.
+func (s *ContextErrorSubscription) Close() {
+	s.cancel()
+}
+
+// Err returns the error recorded via SetErr, if any.
+func (s *ContextErrorSubscription) Err() error {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.err
+}
+
+// SetErr records the error that terminated the subscription. It should be
+// called before the subscription's channel is closed.
+func (s *ContextErrorSubscription) SetErr(err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.err = err
+}
+
+// NewContextErrorSubscription creates a subscription that cancels the context
+// when closed and can record the error that terminated it.
+func NewContextErrorSubscription(ctx context.Context) (context.Context, *ContextErrorSubscription) {
+	ctx, cancel := context.WithCancel(ctx)
+	return ctx, &ContextErrorSubscription{cancel: cancel}
+}
+
+// SubscriptionErr returns the error that terminated the given subscription in
+// case it is an ErrorSubscription, and nil otherwise.
+func SubscriptionErr(sub ClosableSubscription) error {
+	if es, ok := sub.(ErrorSubscription); ok {
+		return es.Err()
+	}
+	return nil
+}
+
 // Subscription is a Broker subscription instance.
 type Subscription struct {
 	b  *Broker
@@ -1,6 +1,8 @@
 package pubsub
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -19,6 +21,7 @@ func TestPubSub(t *testing.T) {
 	t.Run("PubLastOnSubscribe", testLastOnSubscribe)
 	t.Run("SubscribeEx", testSubscribeEx)
 	t.Run("NewBrokerEx", testNewBrokerEx)
+	t.Run("ContextErrorSubscription", testContextErrorSubscription)
 }
 
 func testBasicInfinity(t *testing.T) {
@@ -154,3 +157,19 @@ func testNewBrokerEx(t *testing.T) {
 		require.Equal(t, sub.ch, callbackCh, "Callback channel != Subscription, inner channel")
 	}
 }
+
+func testContextErrorSubscription(t *testing.T) {
+	ctx, sub := NewContextErrorSubscription(context.Background())
+	require.NoError(t, SubscriptionErr(sub), "no error should be reported by default")
+
+	errTest := errors.New("test error")
+	sub.SetErr(errTest)
+	require.Equal(t, errTest, SubscriptionErr(sub), "recorded error should be reported")
+
+	sub.Close()
+	require.ErrorIs(t, ctx.Err(), context.Canceled, "closing should cancel the context")
+
+	_, plainSub := NewContextSubscription(context.Background())
+	defer plainSub.Close()
+	require.NoError(t, SubscriptionErr(plainSub), "plain subscriptions should not report errors")
+}
@@ -18,6 +18,10 @@ var (
 	// due to a missing account.
 	ErrInvalidAccount = errors.New("invalid account")
 
+	// ErrOverflow is the error returned when a quantity does not fit
+	// into the requested type.
+	ErrOverflow = errors.New("quantity overflow")
+
 	_ encoding.BinaryMarshaler   = (*Quantity)(nil)
 	_ encoding.BinaryUnmarshaler = (*Quantity)(nil)
 
@@ -96,6 +100,15 @@ func (q *Quantity) ToBigInt() *big.Int {
 	return &tmp
 }
 
+// ToUint64 converts from a Quantity to an uint64, returning an error if
+// the quantity does not fit.
+func (q *Quantity) ToUint64() (uint64, error) {
+	if !q.inner.IsUint64() {
+		return 0, ErrOverflow
+	}
+	return q.inner.Uint64(), nil
+}
+
 // Add adds n to q, returning an error if n < 0 or n == nil.
 func (q *Quantity) Add(n *Quantity) error {
 	if n == nil || !n.IsValid() {
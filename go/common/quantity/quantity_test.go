@@ -77,6 +77,23 @@ func TestFromUint64(t *testing.T) {
 	require.True(q.Cmp(&p) == 0)
 }
 
+func TestToUint64(t *testing.T) {
+	require := require.New(t)
+
+	n, err := fromInt(46).ToUint64()
+	require.NoError(err, "ToUint64(46)")
+	require.EqualValues(46, n, "ToUint64(46) value")
+
+	n, err = NewFromUint64(0xFFFFFFFFFFFFFFFF).ToUint64()
+	require.NoError(err, "ToUint64(0xFFFFFFFFFFFFFFFF)")
+	require.EqualValues(uint64(0xFFFFFFFFFFFFFFFF), n, "ToUint64(0xFFFFFFFFFFFFFFFF) value")
+
+	q := NewFromUint64(0xFFFFFFFFFFFFFFFF)
+	require.NoError(q.Add(NewFromUint64(1)), "Add")
+	_, err = q.ToUint64()
+	require.Equal(ErrOverflow, err, "ToUint64(0xFFFFFFFFFFFFFFFF + 1)")
+}
+
 func TestQuantityBinaryRoundTrip(t *testing.T) {
 	const expected int = 0xdeadbeef
 
@@ -0,0 +1,48 @@
+// Package config implements global telemetry configuration options.
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Config is the telemetry configuration structure.
+type Config struct {
+	// Enabled enables periodic reporting of node health data to the collector.
+	Enabled bool `yaml:"enabled"`
+
+	// Endpoint is the URL of the collector that reports are sent to.
+	Endpoint string `yaml:"endpoint,omitempty"`
+
+	// Interval is the interval between two reports.
+	Interval time.Duration `yaml:"interval"`
+}
+
+// Validate validates the configuration settings.
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	u, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return fmt.Errorf("malformed endpoint: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("endpoint must use http or https")
+	}
+	if c.Interval < time.Minute {
+		return fmt.Errorf("interval must be >= 1 minute")
+	}
+	return nil
+}
+
+// DefaultConfig returns the default configuration settings.
+func DefaultConfig() Config {
+	return Config{
+		Enabled:  false,
+		Endpoint: "",
+		Interval: 1 * time.Hour,
+	}
+}
@@ -0,0 +1,184 @@
+// Package telemetry implements opt-in reporting of signed node health data.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/telemetry/config"
+)
+
+// requestTimeout is the timeout for sending a single report.
+const requestTimeout = 30 * time.Second
+
+// SignatureContext is the context used for signing telemetry reports.
+var SignatureContext = signature.NewContext("oasis-core/node: telemetry report")
+
+// Report is a node health report.
+type Report struct {
+	// Time is the UNIX timestamp of the report.
+	Time int64 `json:"time"`
+
+	// ChainContext is the chain domain separation context.
+	ChainContext string `json:"chain_context"`
+
+	// SoftwareVersion is the oasis-node software version.
+	SoftwareVersion string `json:"software_version"`
+
+	// Mode is the node mode.
+	Mode string `json:"mode"`
+
+	// Roles are the roles the node is registered with.
+	Roles node.RolesMask `json:"roles,omitempty"`
+
+	// ConsensusHeight is the height of the latest consensus block.
+	ConsensusHeight int64 `json:"consensus_height"`
+
+	// Runtimes contains the health data of the runtimes supported by the node.
+	Runtimes []RuntimeReport `json:"runtimes,omitempty"`
+}
+
+// RuntimeReport is the runtime health report.
+type RuntimeReport struct {
+	// ID is the runtime identifier.
+	ID common.Namespace `json:"id"`
+
+	// LatestRound is the round of the latest runtime block.
+	LatestRound uint64 `json:"latest_round"`
+
+	// RoundLag is the number of rounds the local storage is behind the latest round.
+	RoundLag uint64 `json:"round_lag,omitempty"`
+}
+
+// ReportSource returns the current node health report.
+type ReportSource func(ctx context.Context) (*Report, error)
+
+// Reporter periodically sends signed node health reports to a collector.
+type Reporter struct {
+	endpoint string
+	interval time.Duration
+
+	signer signature.Signer
+	source ReportSource
+	client *http.Client
+
+	stopCh chan struct{}
+	quitCh chan struct{}
+
+	logger *logging.Logger
+}
+
+// Name returns the service name.
+func (r *Reporter) Name() string {
+	return "telemetry reporter"
+}
+
+// Start starts the service.
+func (r *Reporter) Start() error {
+	go r.worker()
+	return nil
+}
+
+// Stop halts the service.
+func (r *Reporter) Stop() {
+	close(r.stopCh)
+}
+
+// Quit returns a channel that will be closed when the service terminates.
+func (r *Reporter) Quit() <-chan struct{} {
+	return r.quitCh
+}
+
+// Cleanup performs the service specific post-termination cleanup.
+func (r *Reporter) Cleanup() {
+}
+
+func (r *Reporter) worker() {
+	defer close(r.quitCh)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-r.stopCh
+		cancel()
+	}()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		if err := r.report(ctx); err != nil {
+			r.logger.Warn("failed to send telemetry report",
+				"err", err,
+			)
+		}
+	}
+}
+
+func (r *Reporter) report(ctx context.Context) error {
+	rpt, err := r.source(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to collect report: %w", err)
+	}
+	signed, err := signature.SignSigned(r.signer, SignatureContext, rpt)
+	if err != nil {
+		return fmt.Errorf("failed to sign report: %w", err)
+	}
+	body, err := json.Marshal(signed)
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+	_, _ = io.Copy(io.Discard, rsp.Body)
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return fmt.Errorf("collector returned status: %s", rsp.Status)
+	}
+	return nil
+}
+
+// New creates a new telemetry reporter.
+//
+// Returns nil when telemetry is not enabled.
+func New(cfg *config.Config, signer signature.Signer, source ReportSource) *Reporter {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	return &Reporter{
+		endpoint: cfg.Endpoint,
+		interval: cfg.Interval,
+		signer:   signer,
+		source:   source,
+		client:   &http.Client{Timeout: requestTimeout},
+		stopCh:   make(chan struct{}),
+		quitCh:   make(chan struct{}),
+		logger:   logging.GetLogger("common/telemetry"),
+	}
+}
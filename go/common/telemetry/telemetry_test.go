@@ -0,0 +1,84 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/telemetry/config"
+)
+
+func TestReporter(t *testing.T) {
+	require := require.New(t)
+
+	signer := memorySigner.NewTestSigner("telemetry test")
+
+	signedCh := make(chan *signature.Signed, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var signed signature.Signed
+		if err := json.NewDecoder(r.Body).Decode(&signed); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		signedCh <- &signed
+	}))
+	defer srv.Close()
+
+	cfg := config.DefaultConfig()
+	require.Nil(New(&cfg, signer, nil), "reporter should be disabled by default")
+
+	cfg.Enabled = true
+	cfg.Endpoint = srv.URL
+	require.NoError(cfg.Validate())
+
+	expected := &Report{
+		Time:            time.Now().Unix(),
+		ChainContext:    "test",
+		SoftwareVersion: "1.0.0",
+		Mode:            "client",
+		ConsensusHeight: 42,
+	}
+	r := New(&cfg, signer, func(context.Context) (*Report, error) {
+		return expected, nil
+	})
+	require.NotNil(r)
+
+	err := r.report(context.Background())
+	require.NoError(err, "report")
+
+	signed := <-signedCh
+	require.Equal(signer.Public(), signed.Signature.PublicKey, "report should be signed by the node")
+
+	var rpt Report
+	err = signed.Open(SignatureContext, &rpt)
+	require.NoError(err, "Open")
+	require.Equal(expected, &rpt)
+
+	// Collector errors should be reported.
+	srv.Config.Handler = http.NotFoundHandler()
+	err = r.report(context.Background())
+	require.Error(err, "non-2xx responses should fail")
+}
+
+func TestConfigValidate(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.DefaultConfig()
+	require.NoError(cfg.Validate(), "disabled telemetry should not require an endpoint")
+
+	cfg.Enabled = true
+	require.Error(cfg.Validate(), "missing endpoint should be rejected")
+
+	cfg.Endpoint = "https://collector.example.com/report"
+	require.NoError(cfg.Validate())
+
+	cfg.Interval = time.Second
+	require.Error(cfg.Validate(), "short intervals should be rejected")
+}
@@ -134,6 +134,10 @@ var (
 	// This is mostly used for reporting and metrics.
 	GitBranch = ""
 
+	// GitCommit is the git commit Oasis Core has been built from and should be set by
+	// the linker.
+	GitCommit = ""
+
 	// ConsensusProtocol versions all data structures and processing used by
 	// the epochtime, beacon, registry, roothash, etc. modules that are
 	// backend by consensus.
@@ -0,0 +1,175 @@
+// Package watchdog implements a stall detector for event processing loops.
+package watchdog
+
+import (
+	"bytes"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+// minCheckInterval is the minimum interval between stall checks.
+const minCheckInterval = 1 * time.Second
+
+var (
+	stallCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_event_loop_stall_count",
+			Help: "Number of detected event loop stalls.",
+		},
+		[]string{"loop"},
+	)
+	stalledLoops = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_worker_event_loop_stalled",
+			Help: "Whether the event loop is currently stalled.",
+		},
+		[]string{"loop"},
+	)
+
+	watchdogCollectors = []prometheus.Collector{
+		stallCount,
+		stalledLoops,
+	}
+
+	metricsOnce sync.Once
+)
+
+// Watchdog detects when an event loop has been processing a single event for longer than
+// the configured timeout, which usually indicates a deadlock or a stuck external call.
+//
+// When a stall is detected, stacks of all goroutines are dumped to the log and the stall
+// metrics are updated. A nil watchdog is valid and does nothing.
+type Watchdog struct {
+	sync.Mutex
+
+	name    string
+	timeout time.Duration
+
+	event     string
+	busySince time.Time
+	stalled   bool
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+
+	logger *logging.Logger
+}
+
+// Begin marks the start of processing of the given event.
+func (w *Watchdog) Begin(event string) {
+	if w == nil {
+		return
+	}
+
+	w.Lock()
+	defer w.Unlock()
+
+	w.event = event
+	w.busySince = time.Now()
+}
+
+// End marks the end of processing of the current event.
+func (w *Watchdog) End() {
+	if w == nil {
+		return
+	}
+
+	w.Lock()
+	defer w.Unlock()
+
+	if w.stalled {
+		w.logger.Warn("event loop recovered from stall",
+			"event", w.event,
+			"duration", time.Since(w.busySince),
+		)
+		stalledLoops.WithLabelValues(w.name).Set(0)
+	}
+
+	w.event = ""
+	w.busySince = time.Time{}
+	w.stalled = false
+}
+
+// Start starts the watchdog.
+func (w *Watchdog) Start() {
+	if w == nil {
+		return
+	}
+
+	go w.worker()
+}
+
+// Stop stops the watchdog.
+func (w *Watchdog) Stop() {
+	if w == nil {
+		return
+	}
+
+	w.stopOnce.Do(func() {
+		close(w.stopCh)
+	})
+}
+
+func (w *Watchdog) worker() {
+	ticker := time.NewTicker(max(w.timeout/4, minCheckInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stopCh:
+			return
+		case now := <-ticker.C:
+			w.check(now)
+		}
+	}
+}
+
+// check checks whether the event loop is stalled and returns true iff a new stall has been
+// detected.
+func (w *Watchdog) check(now time.Time) bool {
+	w.Lock()
+	if w.busySince.IsZero() || w.stalled || now.Sub(w.busySince) < w.timeout {
+		w.Unlock()
+		return false
+	}
+	w.stalled = true
+	event, busySince := w.event, w.busySince
+	w.Unlock()
+
+	var stacks bytes.Buffer
+	_ = pprof.Lookup("goroutine").WriteTo(&stacks, 2)
+
+	w.logger.Error("event loop stalled",
+		"event", event,
+		"duration", now.Sub(busySince),
+		"goroutines", stacks.String(),
+	)
+	stallCount.WithLabelValues(w.name).Inc()
+	stalledLoops.WithLabelValues(w.name).Set(1)
+
+	return true
+}
+
+// New creates a new watchdog for the named event loop. If the timeout is zero, stall detection
+// is disabled and nil is returned.
+func New(name string, timeout time.Duration) *Watchdog {
+	if timeout == 0 {
+		return nil
+	}
+
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(watchdogCollectors...)
+	})
+
+	return &Watchdog{
+		name:    name,
+		timeout: timeout,
+		stopCh:  make(chan struct{}),
+		logger:  logging.GetLogger("common/watchdog").With("loop", name),
+	}
+}
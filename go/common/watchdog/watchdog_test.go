@@ -0,0 +1,40 @@
+package watchdog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchdog(t *testing.T) {
+	require := require.New(t)
+
+	w := New("test", time.Minute)
+	now := time.Now()
+
+	// Idle loops are never stalled.
+	require.False(w.check(now.Add(time.Hour)))
+
+	w.Begin("event")
+	require.False(w.check(now.Add(30*time.Second)), "should not detect stall before timeout")
+	require.True(w.check(now.Add(2*time.Minute)), "should detect stall after timeout")
+	require.False(w.check(now.Add(3*time.Minute)), "should only report a stall once")
+	w.End()
+
+	w.Begin("event")
+	require.False(w.check(now.Add(30 * time.Second)))
+	w.End()
+	require.False(w.check(now.Add(time.Hour)))
+}
+
+func TestWatchdogDisabled(t *testing.T) {
+	w := New("test", 0)
+	require.Nil(t, w)
+
+	// All methods should be safe to call on a disabled watchdog.
+	w.Start()
+	w.Begin("event")
+	w.End()
+	w.Stop()
+}
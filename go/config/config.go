@@ -9,6 +9,9 @@ import (
 	"github.com/a8m/envsubst"
 	"gopkg.in/yaml.v3"
 
+	grpc "github.com/oasisprotocol/oasis-core/go/common/grpc/config"
+	notify "github.com/oasisprotocol/oasis-core/go/common/notify/config"
+	telemetry "github.com/oasisprotocol/oasis-core/go/common/telemetry/config"
 	tm "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/config"
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/config"
 	ias "github.com/oasisprotocol/oasis-core/go/ias/config"
@@ -54,6 +57,9 @@ func (m NodeMode) IsClientOnly() bool {
 }
 
 // IsArchive returns true iff the mode is set to archive node mode.
+//
+// Archive nodes serve historical queries from existing state. They never prune any history and
+// never register for committees.
 func (m NodeMode) IsArchive() bool {
 	return m == ModeArchive
 }
@@ -75,14 +81,17 @@ type Config struct {
 	// Oasis node mode (validator, non-validator, compute, keymanager, etc.).
 	Mode NodeMode `yaml:"mode"`
 
-	Common    common.Config  `yaml:"common"`
-	Genesis   genesis.Config `yaml:"genesis"`
-	Consensus tm.Config      `yaml:"consensus"`
-	Runtime   runtime.Config `yaml:"runtime"`
-	P2P       p2p.Config     `yaml:"p2p"`
-	IAS       ias.Config     `yaml:"ias,omitempty"`
-	Pprof     pprof.Config   `yaml:"pprof,omitempty"`
-	Metrics   metrics.Config `yaml:"metrics,omitempty"`
+	Common    common.Config    `yaml:"common"`
+	Genesis   genesis.Config   `yaml:"genesis"`
+	Consensus tm.Config        `yaml:"consensus"`
+	Runtime   runtime.Config   `yaml:"runtime"`
+	P2P       p2p.Config       `yaml:"p2p"`
+	GRPC      grpc.Config      `yaml:"grpc,omitempty"`
+	IAS       ias.Config       `yaml:"ias,omitempty"`
+	Pprof     pprof.Config     `yaml:"pprof,omitempty"`
+	Metrics   metrics.Config   `yaml:"metrics,omitempty"`
+	Notify    notify.Config    `yaml:"notify,omitempty"`
+	Telemetry telemetry.Config `yaml:"telemetry,omitempty"`
 
 	Registration workerRegistration.Config `yaml:"registration,omitempty"`
 	Keymanager   workerKM.Config           `yaml:"keymanager,omitempty"`
@@ -106,6 +115,10 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("unknown node mode: %s", c.Mode)
 	}
 
+	if len(c.Storage.ReplicateFrom) > 0 && c.Mode != ModeClient {
+		return fmt.Errorf("storage replication is only supported in %s mode", ModeClient)
+	}
+
 	if err = c.Common.Validate(); err != nil {
 		return fmt.Errorf("common: %w", err)
 	}
@@ -118,9 +131,15 @@ func (c *Config) Validate() error {
 	if err = c.Runtime.Validate(); err != nil {
 		return fmt.Errorf("runtime: %w", err)
 	}
+	if size := c.Runtime.MaxLogSize; size != "" && ParseSizeInBytes(size) == 0 {
+		return fmt.Errorf("runtime: max_log_size must be greater than zero")
+	}
 	if err = c.P2P.Validate(); err != nil {
 		return fmt.Errorf("p2p: %w", err)
 	}
+	if err = c.GRPC.Validate(); err != nil {
+		return fmt.Errorf("grpc: %w", err)
+	}
 	if err = c.Registration.Validate(); err != nil {
 		return fmt.Errorf("registration: %w", err)
 	}
@@ -142,6 +161,12 @@ func (c *Config) Validate() error {
 	if err = c.Metrics.Validate(); err != nil {
 		return fmt.Errorf("metrics: %w", err)
 	}
+	if err = c.Notify.Validate(); err != nil {
+		return fmt.Errorf("notify: %w", err)
+	}
+	if err = c.Telemetry.Validate(); err != nil {
+		return fmt.Errorf("telemetry: %w", err)
+	}
 
 	return nil
 }
@@ -155,6 +180,7 @@ func DefaultConfig() Config {
 		Consensus:    tm.DefaultConfig(),
 		Runtime:      runtime.DefaultConfig(),
 		P2P:          p2p.DefaultConfig(),
+		GRPC:         grpc.DefaultConfig(),
 		Registration: workerRegistration.DefaultConfig(),
 		Keymanager:   workerKM.DefaultConfig(),
 		Storage:      workerStorage.DefaultConfig(),
@@ -162,29 +188,60 @@ func DefaultConfig() Config {
 		IAS:          ias.DefaultConfig(),
 		Pprof:        pprof.DefaultConfig(),
 		Metrics:      metrics.DefaultConfig(),
+		Notify:       notify.DefaultConfig(),
+		Telemetry:    telemetry.DefaultConfig(),
 	}
 }
 
 // InitConfig initializes the global configuration from the given file.
-func InitConfig(cfgFile string) error {
+//
+// If a network is given, its defaults are applied before the config file so that any of them
+// can be overridden. The config file may be empty in which case only the network defaults are
+// applied.
+func InitConfig(network string, cfgFile string) error {
+	cfg, err := LoadConfig(network, cfgFile)
+	if cfg != nil {
+		GlobalConfig = *cfg
+	}
+	return err
+}
+
+// LoadConfig loads the configuration from the given file without modifying the global
+// configuration.
+//
+// The network defaults and the config file are applied in the same way as by InitConfig. In
+// case validation fails, the loaded configuration is returned together with the error.
+func LoadConfig(network string, cfgFile string) (*Config, error) {
+	// Start with the defaults and apply the network defaults.
+	cfg := DefaultConfig()
+	if network != "" {
+		n, err := GetNetwork(network)
+		if err != nil {
+			return nil, err
+		}
+		n.Apply(&cfg)
+	}
+	if cfgFile == "" {
+		return &cfg, cfg.Validate()
+	}
+
 	// Read the specified config file and substitute environment variables.
-	cfg, err := envsubst.ReadFile(cfgFile)
+	raw, err := envsubst.ReadFile(cfgFile)
 	if err != nil {
-		return fmt.Errorf("unable to read config file '%s': %w", cfgFile, err)
+		return nil, fmt.Errorf("unable to read config file '%s': %w", cfgFile, err)
 	}
 
-	// Reset the global config and apply changes from the config file.
+	// Apply changes from the config file.
 	// Report error if any of the fields from the input file are unknown.
-	GlobalConfig = DefaultConfig()
-	dec := yaml.NewDecoder(bytes.NewReader(cfg))
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
 	dec.KnownFields(true)
-	err = dec.Decode(&GlobalConfig)
+	err = dec.Decode(&cfg)
 	if err != nil && err != io.EOF {
-		return fmt.Errorf("failed to load config file '%s': %w", cfgFile, err)
+		return nil, fmt.Errorf("failed to load config file '%s': %w", cfgFile, err)
 	}
 
 	// Validate config file.
-	return GlobalConfig.Validate()
+	return &cfg, cfg.Validate()
 }
 
 func init() {
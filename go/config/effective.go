@@ -0,0 +1,178 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Source is the source a configuration setting originates from.
+type Source string
+
+const (
+	// SourceDefault is the source of settings that have their default values.
+	SourceDefault Source = "default"
+	// SourceNetwork is the source of settings whose values were changed by the network preset.
+	SourceNetwork Source = "network"
+	// SourceFile is the source of settings whose values were changed by the config file.
+	SourceFile Source = "file"
+)
+
+// Setting is a single resolved configuration setting.
+type Setting struct {
+	// Key is the dotted path of the setting (e.g., "consensus.validator").
+	Key string
+	// Value is the encoded value of the setting.
+	Value string
+	// Source is the source the value originates from.
+	Source Source
+}
+
+// SettingDiff is a difference of a single setting between two configurations.
+type SettingDiff struct {
+	// Key is the dotted path of the setting.
+	Key string
+	// A is the encoded value in the first configuration or nil if not set.
+	A *string
+	// B is the encoded value in the second configuration or nil if not set.
+	B *string
+}
+
+// EffectiveConfig loads the configuration in the same way as LoadConfig and returns all of the
+// resolved settings together with their source, sorted by key.
+func EffectiveConfig(network string, cfgFile string) ([]Setting, error) {
+	cfg, err := LoadConfig(network, cfgFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultCfg := DefaultConfig()
+	defaults, err := Flatten(&defaultCfg)
+	if err != nil {
+		return nil, err
+	}
+	networkDefaults := defaults
+	if network != "" {
+		n, nerr := GetNetwork(network)
+		if nerr != nil {
+			return nil, nerr
+		}
+		n.Apply(&defaultCfg)
+		if networkDefaults, err = Flatten(&defaultCfg); err != nil {
+			return nil, err
+		}
+	}
+	values, err := Flatten(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make([]Setting, 0, len(values))
+	for key, value := range values {
+		source := SourceDefault
+		switch {
+		case !lookupEqual(networkDefaults, key, value):
+			source = SourceFile
+		case !lookupEqual(defaults, key, value):
+			source = SourceNetwork
+		}
+		settings = append(settings, Setting{
+			Key:    key,
+			Value:  value,
+			Source: source,
+		})
+	}
+	sort.Slice(settings, func(i, j int) bool {
+		return settings[i].Key < settings[j].Key
+	})
+	return settings, nil
+}
+
+// Diff returns all settings that differ between the two configurations, sorted by key.
+func Diff(a, b *Config) ([]SettingDiff, error) {
+	aValues, err := Flatten(a)
+	if err != nil {
+		return nil, err
+	}
+	bValues, err := Flatten(b)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]struct{})
+	for key := range aValues {
+		keys[key] = struct{}{}
+	}
+	for key := range bValues {
+		keys[key] = struct{}{}
+	}
+
+	var diffs []SettingDiff
+	for key := range keys {
+		aValue, aOk := aValues[key]
+		bValue, bOk := bValues[key]
+		if aOk == bOk && aValue == bValue {
+			continue
+		}
+
+		diff := SettingDiff{Key: key}
+		if aOk {
+			diff.A = &aValue
+		}
+		if bOk {
+			diff.B = &bValue
+		}
+		diffs = append(diffs, diff)
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Key < diffs[j].Key
+	})
+	return diffs, nil
+}
+
+// Flatten returns all settings of the given configuration keyed by their dotted path.
+//
+// Settings are keyed and encoded as they appear in the config file. Lists are treated as single
+// settings. Settings that are omitted from the encoded config file are not included.
+func Flatten(cfg *Config) (map[string]string, error) {
+	raw, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	var tree map[string]interface{}
+	if err = yaml.Unmarshal(raw, &tree); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	values := make(map[string]string)
+	if err = flatten(values, nil, tree); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func flatten(values map[string]string, path []string, v interface{}) error {
+	if m, ok := v.(map[string]interface{}); ok && (len(m) > 0 || len(path) == 0) {
+		for key, value := range m {
+			if err := flatten(values, append(path, key), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode setting '%s': %w", strings.Join(path, "."), err)
+	}
+	values[strings.Join(path, ".")] = string(encoded)
+	return nil
+}
+
+func lookupEqual(values map[string]string, key, value string) bool {
+	v, ok := values[key]
+	return ok && v == value
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestConfig(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestEffectiveConfig(t *testing.T) {
+	require := require.New(t)
+
+	cfgFile := writeTestConfig(t, "config.yml", `
+mode: client
+common:
+  data_dir: /node/data
+p2p:
+  port: 9300
+`)
+
+	settings, err := EffectiveConfig(NetworkTestnet, cfgFile)
+	require.NoError(err, "EffectiveConfig")
+
+	sources := make(map[string]Source)
+	for _, s := range settings {
+		sources[s.Key] = s.Source
+	}
+	require.Equal(SourceFile, sources["common.data_dir"])
+	require.Equal(SourceFile, sources["p2p.port"])
+	require.Equal(SourceNetwork, sources["genesis.chain_context"])
+	require.Equal(SourceNetwork, sources["p2p.seeds"])
+	require.Equal(SourceDefault, sources["mode"])
+	require.Equal(SourceDefault, sources["consensus.validator"])
+}
+
+func TestDiff(t *testing.T) {
+	require := require.New(t)
+
+	a, err := LoadConfig("", writeTestConfig(t, "a.yml", `
+common:
+  data_dir: /node/a
+`))
+	require.NoError(err, "LoadConfig")
+	b, err := LoadConfig("", writeTestConfig(t, "b.yml", `
+common:
+  data_dir: /node/b
+p2p:
+  port: 9300
+`))
+	require.NoError(err, "LoadConfig")
+
+	diffs, err := Diff(a, b)
+	require.NoError(err, "Diff")
+	require.Len(diffs, 2)
+	require.Equal("common.data_dir", diffs[0].Key)
+	require.Equal(`"/node/a"`, *diffs[0].A)
+	require.Equal(`"/node/b"`, *diffs[0].B)
+	require.Equal("p2p.port", diffs[1].Key)
+	require.Equal("9200", *diffs[1].A)
+	require.Equal("9300", *diffs[1].B)
+
+	diffs, err = Diff(a, a)
+	require.NoError(err, "Diff")
+	require.Empty(diffs, "identical configurations should not differ")
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+const (
+	// NetworkMainnet is the name of the Oasis Mainnet.
+	NetworkMainnet = "mainnet"
+	// NetworkTestnet is the name of the Oasis Testnet.
+	NetworkTestnet = "testnet"
+	// NetworkDev is the name of a local development network.
+	NetworkDev = "dev"
+)
+
+// Network is a well-known network preset, providing default configuration settings for nodes
+// joining that network.
+type Network struct {
+	// Name is the name of the network.
+	Name string
+
+	// ChainContext is the expected chain domain separation context (genesis document hash).
+	// An empty value accepts any genesis document.
+	ChainContext string
+
+	// Seeds is the list of seed node addresses.
+	Seeds []string
+}
+
+// Apply applies the network defaults to the given configuration.
+func (n *Network) Apply(cfg *Config) {
+	cfg.Genesis.ChainContext = n.ChainContext
+	cfg.P2P.Seeds = slices.Clone(n.Seeds)
+}
+
+var networks = map[string]*Network{
+	NetworkMainnet: {
+		Name:         NetworkMainnet,
+		ChainContext: "bb3d748def55bdfb797a2ac53ee6ee141e54cd2ab2dc2375f4a0703a178e6e55",
+		Seeds: []string{
+			"H6u9MtuoWRKn5DKSgarj/dzr2Z9BsjuRHgRAoXITOcU=@35.199.49.168:26656",
+			"H6u9MtuoWRKn5DKSgarj/dzr2Z9BsjuRHgRAoXITOcU=@35.199.49.168:9200",
+		},
+	},
+	NetworkTestnet: {
+		Name:         NetworkTestnet,
+		ChainContext: "0b91b8e4e44b2003a7c5e23ddadb5e14ef5345c0ebcb3ddcae07fa2f244cab76",
+		Seeds: []string{
+			"HcDFrTp/MqRHtju5bCx6TIhIMd6X/0ZQ3lUG73q5898=@34.86.165.6:26656",
+			"HcDFrTp/MqRHtju5bCx6TIhIMd6X/0ZQ3lUG73q5898=@34.86.165.6:9200",
+		},
+	},
+	NetworkDev: {
+		Name: NetworkDev,
+	},
+}
+
+// GetNetwork returns the well-known network with the given name.
+func GetNetwork(name string) (*Network, error) {
+	n, ok := networks[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown network: %s", name)
+	}
+	return n, nil
+}
+
+// NetworkNames returns the names of all well-known networks.
+func NetworkNames() []string {
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
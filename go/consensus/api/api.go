@@ -131,6 +131,16 @@ type ClientBackend interface {
 	// EstimateGas calculates the amount of gas required to execute the given transaction.
 	EstimateGas(ctx context.Context, req *EstimateGasRequest) (transaction.Gas, error)
 
+	// SimulateTx simulates execution of the given signed transaction against the latest
+	// committed state without broadcasting it.
+	//
+	// The transaction is executed in the same simulation mode as used by EstimateGas, but its
+	// signature, size and encoding are verified. Simulation does not check the nonce or the fee
+	// and stops before any state changes are made, so events are generally not reported.
+	//
+	// The returned result contains any execution error and the amount of gas used.
+	SimulateTx(ctx context.Context, tx *transaction.SignedTransaction) (*results.Result, error)
+
 	// MinGasPrice returns the minimum gas price.
 	MinGasPrice(ctx context.Context) (*quantity.Quantity, error)
 
@@ -388,6 +398,12 @@ type Backend interface {
 
 	// RegisterP2PService registers the P2P service used for light client state sync.
 	RegisterP2PService(p2pAPI.Service) error
+
+	// FenceCommits prevents any new blocks from being committed to local state until the
+	// returned release function is called. It waits for any in-progress commit to complete.
+	//
+	// While commits are fenced, the consensus layer stops making progress.
+	FenceCommits(ctx context.Context) (func(), error)
 }
 
 // HaltHook is a function that gets called when consensus needs to halt for some reason.
@@ -10,6 +10,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction/results"
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	keymanager "github.com/oasisprotocol/oasis-core/go/keymanager/api"
@@ -26,15 +27,17 @@ var (
 	serviceName = cmnGrpc.NewServiceName("Consensus")
 
 	// methodSubmitTx is the SubmitTx method.
-	methodSubmitTx = serviceName.NewMethod("SubmitTx", transaction.SignedTransaction{})
+	methodSubmitTx = serviceName.NewMethod("SubmitTx", transaction.SignedTransaction{}).WithMutating()
 	// methodSubmitTxNoWait is the SubmitTxNoWait method.
-	methodSubmitTxNoWait = serviceName.NewMethod("SubmitTxNoWait", transaction.SignedTransaction{})
+	methodSubmitTxNoWait = serviceName.NewMethod("SubmitTxNoWait", transaction.SignedTransaction{}).WithMutating()
 	// methodSubmitTxWithProof is the SubmitTxWithProof method.
-	methodSubmitTxWithProof = serviceName.NewMethod("SubmitTxWithProof", transaction.SignedTransaction{})
+	methodSubmitTxWithProof = serviceName.NewMethod("SubmitTxWithProof", transaction.SignedTransaction{}).WithMutating()
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodEstimateGas is the EstimateGas method.
 	methodEstimateGas = serviceName.NewMethod("EstimateGas", &EstimateGasRequest{})
+	// methodSimulateTx is the SimulateTx method.
+	methodSimulateTx = serviceName.NewMethod("SimulateTx", transaction.SignedTransaction{})
 	// methodMinGasPrice is the MinGasPrice method.
 	methodMinGasPrice = serviceName.NewMethod("MinGasPrice", nil)
 	// methodGetSignerNonce is a GetSignerNonce method.
@@ -68,7 +71,7 @@ var (
 	// methodGetParameters is the GetParameters method.
 	methodGetParameters = serviceName.NewMethod("GetParameters", int64(0))
 	// methodSubmitEvidence is the SubmitEvidence method.
-	methodSubmitEvidence = serviceName.NewMethod("SubmitEvidence", &Evidence{})
+	methodSubmitEvidence = serviceName.NewMethod("SubmitEvidence", &Evidence{}).WithMutating()
 
 	// methodWatchBlocks is the WatchBlocks method.
 	methodWatchBlocks = serviceName.NewMethod("WatchBlocks", nil)
@@ -98,6 +101,10 @@ var (
 				MethodName: methodEstimateGas.ShortName(),
 				Handler:    handlerEstimateGas,
 			},
+			{
+				MethodName: methodSimulateTx.ShortName(),
+				Handler:    handlerSimulateTx,
+			},
 			{
 				MethodName: methodMinGasPrice.ShortName(),
 				Handler:    handlerMinGasPrice,
@@ -292,6 +299,29 @@ func handlerEstimateGas(
 	return interceptor(ctx, rq, info, handler)
 }
 
+func handlerSimulateTx(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	rq := new(transaction.SignedTransaction)
+	if err := dec(rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).SimulateTx(ctx, rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSimulateTx.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).SimulateTx(ctx, req.(*transaction.SignedTransaction))
+	}
+	return interceptor(ctx, rq, info, handler)
+}
+
 func handlerMinGasPrice(
 	srv interface{},
 	ctx context.Context,
@@ -736,6 +766,14 @@ func (c *Client) EstimateGas(ctx context.Context, req *EstimateGasRequest) (tran
 	return gas, nil
 }
 
+func (c *Client) SimulateTx(ctx context.Context, tx *transaction.SignedTransaction) (*results.Result, error) {
+	var rsp results.Result
+	if err := c.conn.Invoke(ctx, methodSimulateTx.FullName(), tx, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *Client) MinGasPrice(ctx context.Context) (*quantity.Quantity, error) {
 	var rsp quantity.Quantity
 	if err := c.conn.Invoke(ctx, methodMinGasPrice.FullName(), nil, &rsp); err != nil {
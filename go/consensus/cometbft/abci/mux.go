@@ -178,6 +178,18 @@ func (a *ApplicationServer) EstimateGas(caller signature.PublicKey, tx *transact
 	return a.mux.EstimateGas(caller, tx)
 }
 
+// SimulateTx simulates execution of the given raw transaction against the latest committed state
+// without making any persistent changes.
+func (a *ApplicationServer) SimulateTx(rawTx []byte) (*types.ResponseDeliverTx, error) {
+	return a.mux.SimulateTx(rawTx)
+}
+
+// FenceCommits prevents any new blocks from being committed to local state until the returned
+// release function is called.
+func (a *ApplicationServer) FenceCommits(ctx context.Context) (func(), error) {
+	return a.mux.state.fenceCommits(ctx)
+}
+
 // State returns the application state.
 func (a *ApplicationServer) State() api.ApplicationQueryState {
 	return a.mux.state
@@ -155,6 +155,9 @@ type applicationState struct { // nolint: maligned
 	checkpointer checkpoint.Checkpointer
 	upgrader     upgrade.Backend
 
+	// commitFence is held while committing a block and while commits are fenced.
+	commitFence chan struct{}
+
 	blockLock   sync.RWMutex
 	blockTime   time.Time
 	blockCtx    *api.BlockContext
@@ -418,7 +421,29 @@ func (s *applicationState) workingStateRoot() (hash.Hash, error) {
 	return stateRootHash, err
 }
 
+// fenceCommits prevents any new blocks from being committed until the returned release function
+// is called. It waits for any in-progress commit to complete first.
+func (s *applicationState) fenceCommits(ctx context.Context) (func(), error) {
+	select {
+	case s.commitFence <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-s.commitFence
+		})
+	}, nil
+}
+
 func (s *applicationState) doCommit() (uint64, error) {
+	s.commitFence <- struct{}{}
+	defer func() {
+		<-s.commitFence
+	}()
+
 	s.blockLock.Lock()
 	defer s.blockLock.Unlock()
 
@@ -745,6 +770,7 @@ func newApplicationState(ctx context.Context, upgrader upgrade.Backend, cfg *App
 		prunerNotifyCh:     channels.NewRingChannel(1),
 		pruneInterval:      cfg.Pruning.PruneInterval,
 		upgrader:           upgrader,
+		commitFence:        make(chan struct{}, 1),
 		blockCtx:           api.NewBlockContext(api.BlockInfo{}),
 		haltEpoch:          cfg.HaltEpoch,
 		haltHeight:         cfg.HaltHeight,
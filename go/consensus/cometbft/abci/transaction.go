@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/cometbft/cometbft/abci/types"
+
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
@@ -188,3 +191,39 @@ func (mux *abciMux) EstimateGas(caller signature.PublicKey, tx *transaction.Tran
 
 	return ctx.Gas().GasUsed(), nil
 }
+
+// SimulateTx simulates execution of the given raw transaction against the latest committed state.
+//
+// The transaction is executed in simulation mode, the same as in EstimateGas, but with the
+// signature, size and encoding of the actual transaction being verified.
+func (mux *abciMux) SimulateTx(rawTx []byte) (*types.ResponseDeliverTx, error) {
+	// Certain modules, in particular the beacon require InitChain or BeginBlock
+	// to have completed before initialization is complete.
+	if mux.state.BlockHeight() == 0 {
+		return nil, consensus.ErrNoCommittedBlocks
+	}
+
+	// As opposed to other transaction dispatch entry points (CheckTx/DeliverTx), this method can
+	// be called in parallel to the consensus layer and to other invocations.
+	ctx := mux.state.NewContext(api.ContextSimulateTx)
+	defer ctx.Close()
+
+	if err := mux.executeTx(ctx, rawTx); err != nil {
+		module, code := errors.Code(err)
+
+		return &types.ResponseDeliverTx{
+			Codespace: module,
+			Code:      code,
+			Log:       err.Error(),
+			Events:    ctx.GetEvents(),
+			GasUsed:   int64(ctx.Gas().GasUsed()),
+		}, nil
+	}
+
+	return &types.ResponseDeliverTx{
+		Code:    types.CodeTypeOK,
+		Data:    cbor.Marshal(ctx.Data()),
+		Events:  ctx.GetEvents(),
+		GasUsed: int64(ctx.Gas().GasUsed()),
+	}, nil
+}
@@ -0,0 +1,221 @@
+package abci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/database"
+)
+
+var (
+	methodSimulateTest = transaction.NewMethodName("simtest", "Test", nil)
+
+	simulateTestKey = []byte("simtest:key")
+	simulateTestOp  = transaction.Op("simtest")
+)
+
+type simulateTestApp struct {
+	simulated bool
+}
+
+func (app *simulateTestApp) Name() string {
+	return "simtest"
+}
+
+func (app *simulateTestApp) ID() uint8 {
+	return 0xff
+}
+
+func (app *simulateTestApp) Methods() []transaction.MethodName {
+	return []transaction.MethodName{methodSimulateTest}
+}
+
+func (app *simulateTestApp) Blessed() bool {
+	return false
+}
+
+func (app *simulateTestApp) Dependencies() []string {
+	return nil
+}
+
+func (app *simulateTestApp) QueryFactory() interface{} {
+	return nil
+}
+
+func (app *simulateTestApp) OnRegister(api.ApplicationState, api.MessageDispatcher) {
+}
+
+func (app *simulateTestApp) OnCleanup() {
+}
+
+func (app *simulateTestApp) ExecuteMessage(*api.Context, interface{}, interface{}) (interface{}, error) {
+	return nil, fmt.Errorf("simtest: unexpected message")
+}
+
+func (app *simulateTestApp) ExecuteTx(ctx *api.Context, _ *transaction.Transaction) error {
+	app.simulated = ctx.IsSimulation()
+
+	ctx.SetGasAccountant(api.NewGasAccountant(1_000))
+	if err := ctx.Gas().UseGas(1, simulateTestOp, transaction.Costs{simulateTestOp: 10}); err != nil {
+		return err
+	}
+	if err := ctx.State().Insert(ctx, simulateTestKey, []byte("value")); err != nil {
+		return err
+	}
+	ctx.EmitData("result")
+
+	return nil
+}
+
+func (app *simulateTestApp) InitChain(*api.Context, types.RequestInitChain, *genesis.Document) error {
+	return nil
+}
+
+func (app *simulateTestApp) BeginBlock(*api.Context) error {
+	return nil
+}
+
+func (app *simulateTestApp) EndBlock(*api.Context) (types.ResponseEndBlock, error) {
+	return types.ResponseEndBlock{}, nil
+}
+
+// simulateTestTimeSource is a time source that always reports the first epoch.
+type simulateTestTimeSource struct {
+	beacon.Backend
+}
+
+func (ts *simulateTestTimeSource) GetEpoch(context.Context, int64) (beacon.EpochTime, error) {
+	return 1, nil
+}
+
+func (ts *simulateTestTimeSource) GetFutureEpoch(context.Context, int64) (*beacon.EpochTimeState, error) {
+	return nil, nil
+}
+
+func TestSimulateTx(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mux, err := NewMockMux(ctx, nil, &ApplicationConfig{
+		DataDir:           t.TempDir(),
+		StorageBackend:    database.BackendNameBadgerDB,
+		MemoryOnlyStorage: true,
+		InitialHeight:     1,
+		MinGasPrice:       1_000,
+		Pruning: PruneConfig{
+			Strategy:      PruneNone,
+			PruneInterval: time.Hour,
+		},
+		Identity: &identity.Identity{
+			NodeSigner: memorySigner.NewTestSigner("consensus/cometbft/abci: SimulateTx node"),
+		},
+	})
+	require.NoError(err, "NewMockMux")
+	defer mux.MockClose()
+
+	app := &simulateTestApp{}
+	err = mux.MockRegisterApp(app)
+	require.NoError(err, "MockRegisterApp")
+	mux.MockSetEpochtime(&simulateTestTimeSource{})
+	err = mux.finishInitialization()
+	require.NoError(err, "finishInitialization")
+
+	now := time.Now()
+	doc := &genesis.Document{
+		Height:  1,
+		Time:    now,
+		ChainID: "simulate-tx-test",
+		Consensus: consensusGenesis.Genesis{
+			Parameters: consensusGenesis.Parameters{
+				MaxTxSize: 1024,
+			},
+		},
+	}
+	signature.SetChainContext(doc.ChainContext())
+
+	signer := memorySigner.NewTestSigner("consensus/cometbft/abci: SimulateTx")
+	sign := func(tx *transaction.Transaction) []byte {
+		sigTx, serr := transaction.Sign(signer, tx)
+		require.NoError(serr, "Sign")
+		return cbor.Marshal(sigTx)
+	}
+	rawTx := sign(transaction.NewTransaction(0, &transaction.Fee{Gas: 100}, methodSimulateTest, nil))
+
+	// Simulation requires a committed block.
+	_, err = mux.SimulateTx(rawTx)
+	require.ErrorIs(err, consensus.ErrNoCommittedBlocks, "SimulateTx should fail without committed blocks")
+
+	// Initialize the chain and commit the first block.
+	appState, err := json.Marshal(doc)
+	require.NoError(err, "json.Marshal")
+	mux.InitChain(types.RequestInitChain{
+		Time:          now,
+		ChainId:       doc.ChainID,
+		InitialHeight: 1,
+		AppStateBytes: appState,
+	})
+	mux.BeginBlock(types.RequestBeginBlock{
+		Header: cmtproto.Header{Height: 1, Time: now},
+	})
+	mux.EndBlock(types.RequestEndBlock{Height: 1})
+	mux.Commit()
+	require.EqualValues(1, mux.state.BlockHeight(), "BlockHeight")
+	stateRoot := mux.state.StateRootHash()
+
+	// Nonce, fee and gas price are not checked during simulation.
+	rsp, err := mux.SimulateTx(rawTx)
+	require.NoError(err, "SimulateTx")
+	require.True(rsp.IsOK(), "simulation should succeed (log: %s)", rsp.Log)
+	require.True(app.simulated, "transaction should be executed in simulation mode")
+	require.EqualValues(10, rsp.GasUsed, "GasUsed")
+	require.Equal(cbor.Marshal("result"), rsp.Data, "Data")
+
+	// Simulation must not make any persistent changes.
+	require.Equal(stateRoot, mux.state.StateRootHash(), "state root should not change")
+	qctx := mux.state.NewContext(api.ContextSimulateTx)
+	defer qctx.Close()
+	value, err := qctx.State().Get(qctx, simulateTestKey)
+	require.NoError(err, "Get")
+	require.Nil(value, "simulated writes should be discarded")
+
+	// Invalid signature.
+	var sigTx transaction.SignedTransaction
+	err = cbor.Unmarshal(rawTx, &sigTx)
+	require.NoError(err, "cbor.Unmarshal")
+	sigTx.Signature.Signature[0] ^= 0xff
+	rsp, err = mux.SimulateTx(cbor.Marshal(sigTx))
+	require.NoError(err, "SimulateTx")
+	require.False(rsp.IsOK(), "simulation with an invalid signature should fail")
+
+	// Oversized transaction.
+	tx := transaction.NewTransaction(0, nil, methodSimulateTest, make([]byte, 2048))
+	rsp, err = mux.SimulateTx(sign(tx))
+	require.NoError(err, "SimulateTx")
+	require.False(rsp.IsOK(), "simulation of an oversized transaction should fail")
+	require.Equal(consensus.ErrOversizedTx.Error(), rsp.Log, "Log")
+
+	// Unknown method.
+	tx = transaction.NewTransaction(0, nil, transaction.MethodName("simtest.Unknown"), nil)
+	rsp, err = mux.SimulateTx(sign(tx))
+	require.NoError(err, "SimulateTx")
+	require.False(rsp.IsOK(), "simulation of an unknown method should fail")
+}
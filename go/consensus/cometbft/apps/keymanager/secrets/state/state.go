@@ -105,6 +105,20 @@ func (st *ImmutableState) Status(ctx context.Context, id common.Namespace) (*sec
 	return &status, nil
 }
 
+// IsActive returns true iff the key manager with the given runtime ID is initialized and has at
+// least one active node.
+func (st *ImmutableState) IsActive(ctx context.Context, id common.Namespace) (bool, error) {
+	status, err := st.Status(ctx, id)
+	switch err {
+	case nil:
+		return status.IsActive(), nil
+	case secrets.ErrNoSuchStatus:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
 func (st *ImmutableState) MasterSecret(ctx context.Context, id common.Namespace) (*secrets.SignedEncryptedMasterSecret, error) {
 	data, err := st.is.Get(ctx, masterSecretKeyFmt.Encode(&id))
 	if err != nil {
@@ -34,6 +34,13 @@ func (app *registryApplication) InitChain(ctx *abciAPI.Context, _ types.RequestI
 		return fmt.Errorf("failed to set consensus parameters: %w", err)
 	}
 
+	// Populate the entity whitelist before any nodes or runtimes are registered.
+	for _, id := range st.EntityWhitelist {
+		if err := state.AddEntityToWhitelist(ctx, id); err != nil {
+			return fmt.Errorf("registry: failed to add genesis entity to whitelist: %w", err)
+		}
+	}
+
 	for i, v := range st.Entities {
 		if v == nil {
 			return fmt.Errorf("registry: genesis entity index %d is nil", i)
@@ -175,6 +182,11 @@ func (rq *registryQuerier) Genesis(ctx context.Context) (*registry.Genesis, erro
 		return nil, err
 	}
 
+	entityWhitelist, err := rq.state.EntityWhitelist(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	gen := registry.Genesis{
 		Parameters:        *params,
 		Entities:          signedEntities,
@@ -182,6 +194,7 @@ func (rq *registryQuerier) Genesis(ctx context.Context) (*registry.Genesis, erro
 		SuspendedRuntimes: suspendedRuntimes,
 		Nodes:             validatorNodes,
 		NodeStatuses:      nodeStatuses,
+		EntityWhitelist:   entityWhitelist,
 	}
 	return &gen, nil
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
@@ -32,6 +33,7 @@ func (app *registryApplication) changeParameters(ctx *api.Context, msg interface
 	if err != nil {
 		return nil, fmt.Errorf("registry: failed to load consensus parameters: %w", err)
 	}
+	enablesWhitelist := params.EntityAdmissionKey == nil
 	if err = changes.SanityCheck(); err != nil {
 		return nil, fmt.Errorf("registry: failed to validate consensus parameter changes: %w", err)
 	}
@@ -41,14 +43,62 @@ func (app *registryApplication) changeParameters(ctx *api.Context, msg interface
 	if err = params.SanityCheck(); err != nil {
 		return nil, fmt.Errorf("registry: failed to validate consensus parameters: %w", err)
 	}
+	enablesWhitelist = enablesWhitelist && params.EntityAdmissionKey != nil
 
 	// Apply changes.
 	if apply {
 		if err = state.SetConsensusParameters(ctx, params); err != nil {
 			return nil, fmt.Errorf("registry: failed to update consensus parameters: %w", err)
 		}
+		if enablesWhitelist {
+			if err = app.whitelistRegisteredEntities(ctx, state); err != nil {
+				return nil, fmt.Errorf("registry: failed to whitelist registered entities: %w", err)
+			}
+		}
 	}
 
 	// Non-nil response signals that changes are valid and were successfully applied (if required).
 	return struct{}{}, nil
 }
+
+// whitelistRegisteredEntities adds all entities with registered nodes or runtimes to the entity
+// whitelist, so that enabling the whitelist doesn't prevent them from re-registering.
+func (app *registryApplication) whitelistRegisteredEntities(ctx *api.Context, state *registryState.MutableState) error {
+	nodes, err := state.Nodes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch nodes: %w", err)
+	}
+	runtimes, err := state.AllRuntimes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch runtimes: %w", err)
+	}
+
+	var entities []signature.PublicKey
+	for _, n := range nodes {
+		entities = append(entities, n.EntityID)
+	}
+	for _, rt := range runtimes {
+		entities = append(entities, rt.EntityID)
+	}
+
+	for _, id := range entities {
+		var whitelisted bool
+		whitelisted, err = state.IsEntityWhitelisted(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to check entity whitelist: %w", err)
+		}
+		if whitelisted {
+			continue
+		}
+		if err = state.AddEntityToWhitelist(ctx, id); err != nil {
+			return fmt.Errorf("failed to add entity to whitelist: %w", err)
+		}
+
+		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.EntityWhitelistEvent{
+			EntityID:      id,
+			IsWhitelisted: true,
+		}))
+	}
+
+	return nil
+}
@@ -5,7 +5,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
@@ -102,3 +104,68 @@ func TestChangeParameters(t *testing.T) {
 		require.EqualError(err, "registry: failed to validate consensus parameters: maximum node expiration not specified")
 	})
 }
+
+func TestChangeParametersEntityWhitelist(t *testing.T) {
+	require := require.New(t)
+
+	// Prepare context.
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	// Setup state with a runtime registered before the whitelist is enabled.
+	state := registryState.NewMutableState(ctx.State())
+	app := &registryApplication{
+		state: appState,
+	}
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{
+		MaxNodeExpiration: 10,
+	})
+	require.NoError(err, "setting consensus parameters should succeed")
+
+	entityID := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: registered entity").Public()
+	err = state.SetRuntime(ctx, &registry.Runtime{
+		ID:       common.NewTestNamespaceFromSeed([]byte("consensus/cometbft/apps/registry: whitelist runtime"), 0),
+		EntityID: entityID,
+	}, false)
+	require.NoError(err, "SetRuntime")
+
+	changeFn := func(changes registry.ConsensusParameterChanges) error {
+		proposal := governance.ChangeParametersProposal{
+			Module:  registry.ModuleName,
+			Changes: cbor.Marshal(changes),
+		}
+		_, err := app.changeParameters(ctx, &proposal, true)
+		return err
+	}
+
+	// Enabling the whitelist should whitelist already registered entities.
+	admissionKey := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: admission key").Public()
+	err = changeFn(registry.ConsensusParameterChanges{EntityAdmissionKey: &admissionKey})
+	require.NoError(err, "enabling the entity whitelist should succeed")
+
+	params, err := state.ConsensusParameters(ctx)
+	require.NoError(err, "fetching consensus parameters should succeed")
+	require.NotNil(params.EntityAdmissionKey, "entity whitelist should be enabled")
+	require.Equal(admissionKey, *params.EntityAdmissionKey)
+
+	whitelisted, err := state.IsEntityWhitelisted(ctx, entityID)
+	require.NoError(err, "IsEntityWhitelisted")
+	require.True(whitelisted, "registered entities should be whitelisted")
+
+	// Setting and disabling the admission key at the same time is invalid.
+	disable := true
+	err = changeFn(registry.ConsensusParameterChanges{
+		EntityAdmissionKey:     &admissionKey,
+		DisableEntityWhitelist: &disable,
+	})
+	require.Error(err, "conflicting entity whitelist changes should fail")
+
+	// Disabling the whitelist should clear the admission key.
+	err = changeFn(registry.ConsensusParameterChanges{DisableEntityWhitelist: &disable})
+	require.NoError(err, "disabling the entity whitelist should succeed")
+
+	params, err = state.ConsensusParameters(ctx)
+	require.NoError(err, "fetching consensus parameters should succeed")
+	require.Nil(params.EntityAdmissionKey, "entity whitelist should be disabled")
+}
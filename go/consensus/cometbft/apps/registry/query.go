@@ -25,6 +25,7 @@ type Query interface {
 	Runtimes(ctx context.Context, includeSuspended bool) ([]*registry.Runtime, error)
 	Genesis(context.Context) (*registry.Genesis, error)
 	ConsensusParameters(context.Context) (*registry.ConsensusParameters, error)
+	EntityWhitelist(context.Context) ([]signature.PublicKey, error)
 }
 
 // QueryFactory is the registry query factory.
@@ -121,6 +122,10 @@ func (rq *registryQuerier) ConsensusParameters(ctx context.Context) (*registry.C
 	return rq.state.ConsensusParameters(ctx)
 }
 
+func (rq *registryQuerier) EntityWhitelist(ctx context.Context) ([]signature.PublicKey, error) {
+	return rq.state.EntityWhitelist(ctx)
+}
+
 func (app *registryApplication) QueryFactory() interface{} {
 	return &QueryFactory{app.state}
 }
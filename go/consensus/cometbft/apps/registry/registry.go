@@ -148,6 +148,20 @@ func (app *registryApplication) ExecuteTx(ctx *api.Context, tx *transaction.Tran
 		}
 		return nil
 
+	case registry.MethodAddEntityToWhitelist:
+		var add registry.AddEntityToWhitelist
+		if err := cbor.Unmarshal(tx.Body, &add); err != nil {
+			return registry.ErrInvalidArgument
+		}
+		return app.updateEntityWhitelist(ctx, state, add.EntityID, true)
+
+	case registry.MethodRemoveEntityFromWhitelist:
+		var remove registry.RemoveEntityFromWhitelist
+		if err := cbor.Unmarshal(tx.Body, &remove); err != nil {
+			return registry.ErrInvalidArgument
+		}
+		return app.updateEntityWhitelist(ctx, state, remove.EntityID, false)
+
 	default:
 		return registry.ErrInvalidArgument
 	}
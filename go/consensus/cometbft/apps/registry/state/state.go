@@ -76,6 +76,10 @@ var (
 	//
 	// Value is empty.
 	runtimeByEntityKeyFmt = consensus.KeyFormat.New(0x19, keyformat.H(&signature.PublicKey{}), keyformat.H(&common.Namespace{}))
+	// entityWhitelistKeyFmt is the key format used for the entity whitelist.
+	//
+	// Value is binary entity public key.
+	entityWhitelistKeyFmt = consensus.KeyFormat.New(0x1a, keyformat.H(&signature.PublicKey{}))
 )
 
 // ImmutableState is the immutable registry state wrapper.
@@ -482,6 +486,39 @@ func (s *ImmutableState) ConsensusParameters(ctx context.Context) (*registry.Con
 	return &params, nil
 }
 
+// IsEntityWhitelisted checks whether an entity is on the entity whitelist.
+func (s *ImmutableState) IsEntityWhitelisted(ctx context.Context, id signature.PublicKey) (bool, error) {
+	raw, err := s.is.Get(ctx, entityWhitelistKeyFmt.Encode(&id))
+	if err != nil {
+		return false, abciAPI.UnavailableStateError(err)
+	}
+	return raw != nil, nil
+}
+
+// EntityWhitelist returns the identifiers of all whitelisted entities.
+func (s *ImmutableState) EntityWhitelist(ctx context.Context) ([]signature.PublicKey, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var whitelist []signature.PublicKey
+	for it.Seek(entityWhitelistKeyFmt.Encode()); it.Valid(); it.Next() {
+		if !entityWhitelistKeyFmt.Decode(it.Key()) {
+			break
+		}
+
+		var id signature.PublicKey
+		if err := id.UnmarshalBinary(it.Value()); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+
+		whitelist = append(whitelist, id)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return whitelist, nil
+}
+
 // NodeBySubKey looks up a specific node by its consensus, P2P or TLS key.
 func (s *ImmutableState) NodeBySubKey(ctx context.Context, key signature.PublicKey) (*node.Node, error) {
 	rawID, err := s.is.Get(ctx, keyMapKeyFmt.Encode(&key))
@@ -696,6 +733,22 @@ func (s *MutableState) SetNodeStatus(ctx context.Context, id signature.PublicKey
 	return abciAPI.UnavailableStateError(err)
 }
 
+// AddEntityToWhitelist adds an entity to the entity whitelist.
+func (s *MutableState) AddEntityToWhitelist(ctx context.Context, id signature.PublicKey) error {
+	rawID, err := id.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	err = s.ms.Insert(ctx, entityWhitelistKeyFmt.Encode(&id), rawID)
+	return abciAPI.UnavailableStateError(err)
+}
+
+// RemoveEntityFromWhitelist removes an entity from the entity whitelist.
+func (s *MutableState) RemoveEntityFromWhitelist(ctx context.Context, id signature.PublicKey) error {
+	err := s.ms.Remove(ctx, entityWhitelistKeyFmt.Encode(&id))
+	return abciAPI.UnavailableStateError(err)
+}
+
 // SetConsensusParameters sets registry consensus parameters.
 //
 // NOTE: This method must only be called from InitChain/EndBlock contexts.
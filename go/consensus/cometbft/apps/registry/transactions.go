@@ -5,10 +5,12 @@ import (
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
+	secretsState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/keymanager/secrets/state"
 	registryApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
@@ -218,6 +220,10 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 		return err
 	}
 
+	if err = checkEntityWhitelisted(ctx, state, params, untrustedNode.EntityID); err != nil {
+		return err
+	}
+
 	epoch, err := app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
 	if err != nil {
 		ctx.Logger().Error("RegisterNode: failed to get epoch",
@@ -443,6 +449,18 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 			}
 		}
 
+		// Only resume a runtime if its key manager is available to avoid having the runtime be
+		// suspended again on the next epoch transition.
+		if rt.KeyManager != nil && params.SuspendRuntimesWithoutKeyManager {
+			var active bool
+			if active, err = secretsState.NewMutableState(ctx.State()).IsActive(ctx, *rt.KeyManager); err != nil {
+				return fmt.Errorf("failed to query key manager status: %w", err)
+			}
+			if !active {
+				continue
+			}
+		}
+
 		err := state.ResumeRuntime(ctx, rt.ID)
 		switch err {
 		case nil:
@@ -587,6 +605,10 @@ func (app *registryApplication) registerRuntime( // nolint: gocyclo
 		return nil, registry.ErrForbidden
 	}
 
+	if err = checkEntityWhitelisted(ctx, state, params, rt.EntityID); err != nil {
+		return nil, err
+	}
+
 	if rt.Kind == registry.KindCompute {
 		if err = registry.VerifyRegisterComputeRuntimeArgs(ctx, ctx.Logger(), rt, state); err != nil {
 			return nil, err
@@ -788,3 +810,93 @@ func (app *registryApplication) proveFreshness(
 
 	return nil
 }
+
+// checkEntityWhitelisted ensures that the given entity is on the entity whitelist in case the
+// whitelist is enforced (an entity admission key is configured).
+func checkEntityWhitelisted(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	params *registry.ConsensusParameters,
+	id signature.PublicKey,
+) error {
+	if params.EntityAdmissionKey == nil {
+		return nil
+	}
+
+	whitelisted, err := state.IsEntityWhitelisted(ctx, id)
+	if err != nil {
+		ctx.Logger().Error("failed to check entity whitelist",
+			"err", err,
+			"entity_id", id,
+		)
+		return err
+	}
+	if !whitelisted {
+		ctx.Logger().Debug("entity is not whitelisted",
+			"entity_id", id,
+		)
+		return registry.ErrForbidden
+	}
+	return nil
+}
+
+func (app *registryApplication) updateEntityWhitelist(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	id signature.PublicKey,
+	whitelisted bool,
+) error {
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("UpdateEntityWhitelist: failed to fetch registry consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+	if err = ctx.Gas().UseGas(1, registry.GasOpUpdateEntityWhitelist, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early if simulating since this is just estimating gas.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	// Make sure that the whitelist is enabled and that the request was signed by
+	// the entity admission key.
+	if params.EntityAdmissionKey == nil {
+		return registry.ErrForbidden
+	}
+	if !ctx.TxSigner().Equal(*params.EntityAdmissionKey) {
+		return registry.ErrIncorrectTxSigner
+	}
+	if !id.IsValid() {
+		return registry.ErrInvalidArgument
+	}
+
+	if whitelisted {
+		err = state.AddEntityToWhitelist(ctx, id)
+	} else {
+		err = state.RemoveEntityFromWhitelist(ctx, id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update entity whitelist: %w", err)
+	}
+
+	ctx.Logger().Debug("UpdateEntityWhitelist: updated",
+		"entity_id", id,
+		"is_whitelisted", whitelisted,
+	)
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.EntityWhitelistEvent{
+		EntityID:      id,
+		IsWhitelisted: whitelisted,
+	}))
+
+	return nil
+}
@@ -17,8 +17,10 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
+	secretsState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/keymanager/secrets/state"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
@@ -107,6 +109,28 @@ func TestRegisterNode(t *testing.T) {
 			false,
 			false,
 		},
+		// A validator node with capacity hints while they are not enabled.
+		{
+			"ValidatorWithCapacity",
+			func(tcd *testCaseData) {
+				tcd.node.AddRoles(node.RoleValidator)
+				tcd.node.Capacity = &node.CapacityInfo{MaxRuntimes: 1}
+			},
+			nil,
+			false,
+			false,
+		},
+		// A validator node with build metadata while it is not enabled.
+		{
+			"ValidatorWithBuildInfo",
+			func(tcd *testCaseData) {
+				tcd.node.AddRoles(node.RoleValidator)
+				tcd.node.Build = &node.BuildInfo{Commit: "0123456789abcdef"}
+			},
+			nil,
+			false,
+			false,
+		},
 		// Validator without enough stake.
 		{
 			"ValidatorWithoutStake",
@@ -893,3 +917,190 @@ func TestProofFreshness(t *testing.T) {
 		require.Equal(registry.ErrInvalidArgument, err)
 	})
 }
+
+func TestEntityWhitelist(t *testing.T) {
+	require := requirePkg.New(t)
+
+	cfg := abciAPI.MockApplicationStateConfig{}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	var md abciAPI.NoopMessageDispatcher
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+
+	admissionSigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: entity admission signer")
+	otherSigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: entity whitelist other signer")
+	entityID := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: whitelisted entity").Public()
+
+	setAdmissionKeyFn := func(key *signature.PublicKey) *registry.ConsensusParameters {
+		params := &registry.ConsensusParameters{
+			EntityAdmissionKey: key,
+		}
+		err := state.SetConsensusParameters(ctx, params)
+		require.NoError(err, "registry.SetConsensusParameters")
+		return params
+	}
+	updateFn := func(signer signature.Signer, whitelisted bool) error {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(signer.Public())
+		return app.updateEntityWhitelist(txCtx, state, entityID, whitelisted)
+	}
+
+	// Whitelist updates are forbidden without an admission key.
+	params := setAdmissionKeyFn(nil)
+	err := updateFn(admissionSigner, true)
+	require.Equal(registry.ErrForbidden, err, "whitelist updates should be forbidden without an admission key")
+	err = checkEntityWhitelisted(ctx, state, params, entityID)
+	require.NoError(err, "all entities should be allowed without an admission key")
+
+	// Only the admission key may update the whitelist.
+	admissionKey := admissionSigner.Public()
+	params = setAdmissionKeyFn(&admissionKey)
+	err = updateFn(otherSigner, true)
+	require.Equal(registry.ErrIncorrectTxSigner, err, "whitelist updates should require the admission key")
+	err = checkEntityWhitelisted(ctx, state, params, entityID)
+	require.Equal(registry.ErrForbidden, err, "non-whitelisted entities should be rejected")
+
+	err = updateFn(admissionSigner, true)
+	require.NoError(err, "AddEntityToWhitelist")
+	err = checkEntityWhitelisted(ctx, state, params, entityID)
+	require.NoError(err, "whitelisted entities should be allowed")
+	whitelist, err := state.EntityWhitelist(ctx)
+	require.NoError(err, "EntityWhitelist")
+	require.Equal([]signature.PublicKey{entityID}, whitelist)
+
+	err = updateFn(admissionSigner, false)
+	require.NoError(err, "RemoveEntityFromWhitelist")
+	err = checkEntityWhitelisted(ctx, state, params, entityID)
+	require.Equal(registry.ErrForbidden, err, "removed entities should be rejected")
+	whitelist, err = state.EntityWhitelist(ctx)
+	require.NoError(err, "EntityWhitelist")
+	require.Empty(whitelist)
+}
+
+func TestRegisterNodeResumeRuntimeWithoutKeyManager(t *testing.T) {
+	require := requirePkg.New(t)
+
+	cfg := abciAPI.MockApplicationStateConfig{}
+	appState := abciAPI.NewMockApplicationState(&cfg)
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	var md abciAPI.NoopMessageDispatcher
+	app := registryApplication{appState, &md}
+	state := registryState.NewMutableState(ctx.State())
+	kmState := secretsState.NewMutableState(ctx.State())
+
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{
+		MaxNodeExpiration:                5,
+		SuspendRuntimesWithoutKeyManager: true,
+	})
+	require.NoError(err, "registry.SetConsensusParameters")
+	err = beaconState.NewMutableState(ctx.State()).SetConsensusParameters(ctx, &beacon.ConsensusParameters{
+		Backend: beacon.BackendInsecure,
+	})
+	require.NoError(err, "beacon.SetConsensusParameters")
+	err = stakingState.NewMutableState(ctx.State()).SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		DebugBypassStake: true,
+	})
+	require.NoError(err, "staking.SetConsensusParameters")
+
+	// Prepare a suspended compute runtime that depends on a key manager.
+	kmID := common.NewTestNamespaceFromSeed([]byte("consensus/cometbft/apps/registry: key manager: ResumeRuntime"), 0)
+	rt := registry.Runtime{
+		Versioned:       cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+		ID:              common.NewTestNamespaceFromSeed([]byte("consensus/cometbft/apps/registry: runtime: ResumeRuntime"), 0),
+		Kind:            registry.KindCompute,
+		KeyManager:      &kmID,
+		GovernanceModel: registry.GovernanceEntity,
+	}
+	err = state.SetRuntime(ctx, &rt, true)
+	require.NoError(err, "SetRuntime")
+
+	// Prepare an entity and a compute node for the runtime.
+	entitySigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: entity signer: ResumeRuntime")
+	nodeSigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: node signer: ResumeRuntime")
+	consensusSigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: consensus signer: ResumeRuntime")
+	p2pSigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: p2p signer: ResumeRuntime")
+	tlsSigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: tls signer: ResumeRuntime")
+	vrfSigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: vrf signer: ResumeRuntime").(signature.VRFSigner)
+
+	ent := entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        entitySigner.Public(),
+		Nodes:     []signature.PublicKey{nodeSigner.Public()},
+	}
+	sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, &ent)
+	require.NoError(err, "SignEntity")
+	err = state.SetEntity(ctx, &ent, sigEnt)
+	require.NoError(err, "SetEntity")
+
+	var address node.Address
+	err = address.UnmarshalText([]byte("8.8.8.8:1234"))
+	require.NoError(err, "address.UnmarshalText")
+
+	n := node.Node{
+		Versioned:  cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+		ID:         nodeSigner.Public(),
+		EntityID:   ent.ID,
+		Expiration: 3,
+		P2P: node.P2PInfo{
+			ID:        p2pSigner.Public(),
+			Addresses: []node.Address{address},
+		},
+		Consensus: node.ConsensusInfo{
+			ID: consensusSigner.Public(),
+			Addresses: []node.ConsensusAddress{
+				{ID: consensusSigner.Public(), Address: address},
+			},
+		},
+		TLS: node.TLSInfo{
+			PubKey: tlsSigner.Public(),
+		},
+		VRF: node.VRFInfo{
+			ID: vrfSigner.Public(),
+		},
+		Roles:    node.RoleComputeWorker,
+		Runtimes: []*node.Runtime{{ID: rt.ID}},
+	}
+	signers := []signature.Signer{nodeSigner, p2pSigner, consensusSigner, tlsSigner, vrfSigner}
+	sigNode, err := node.MultiSignNode(signers, registry.RegisterNodeSignatureContext, &n)
+	require.NoError(err, "MultiSignNode")
+
+	registerFn := func() {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(nodeSigner.Public())
+		err = app.registerNode(txCtx, state, sigNode)
+		require.NoError(err, "node registration should succeed")
+	}
+
+	// The runtime should not be resumed while its key manager has no status.
+	registerFn()
+	_, err = state.SuspendedRuntime(ctx, rt.ID)
+	require.NoError(err, "runtime should remain suspended without a key manager status")
+
+	// The runtime should not be resumed while its key manager has no active nodes.
+	err = kmState.SetStatus(ctx, &secrets.Status{
+		ID:            kmID,
+		IsInitialized: true,
+	})
+	require.NoError(err, "SetStatus")
+	registerFn()
+	_, err = state.SuspendedRuntime(ctx, rt.ID)
+	require.NoError(err, "runtime should remain suspended without active key manager nodes")
+
+	// The runtime should be resumed once its key manager is active.
+	err = kmState.SetStatus(ctx, &secrets.Status{
+		ID:            kmID,
+		IsInitialized: true,
+		Nodes:         []signature.PublicKey{nodeSigner.Public()},
+	})
+	require.NoError(err, "SetStatus")
+	registerFn()
+	_, err = state.Runtime(ctx, rt.ID)
+	require.NoError(err, "runtime should be resumed once the key manager is active")
+}
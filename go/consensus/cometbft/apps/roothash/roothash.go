@@ -11,6 +11,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/governance/api"
+	secretsState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/keymanager/secrets/state"
 	registryApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	roothashApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/api"
@@ -90,12 +91,17 @@ func (app *rootHashApplication) BeginBlock(ctx *tmapi.Context) error {
 func (app *rootHashApplication) onCommitteeChanged(ctx *tmapi.Context, state *roothashState.MutableState, epoch beacon.EpochTime) error {
 	schedState := schedulerState.NewMutableState(ctx.State())
 	regState := registryState.NewMutableState(ctx.State())
+	kmState := secretsState.NewMutableState(ctx.State())
 	runtimes, _ := regState.Runtimes(ctx)
 
 	params, err := state.ConsensusParameters(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get consensus parameters: %w", err)
 	}
+	regParams, err := regState.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get registry consensus parameters: %w", err)
+	}
 
 	var stakeAcc *stakingState.StakeAccumulatorCache
 	if !params.DebugBypassStake {
@@ -175,11 +181,28 @@ func (app *rootHashApplication) onCommitteeChanged(ctx *tmapi.Context, state *ro
 				sufficientStake = false
 			}
 		}
-		suspend := committee == nil || !sufficientStake && !params.DebugDoNotSuspendRuntimes
+
+		// Also suspend the runtime in case its key manager is not available as rounds would fail
+		// anyway. The runtime is resumed once its key manager becomes available again and one of
+		// its nodes re-registers.
+		keyManagerActive := true
+		if committee != nil && rt.KeyManager != nil && regParams.SuspendRuntimesWithoutKeyManager {
+			keyManagerActive, err = kmState.IsActive(ctx, *rt.KeyManager)
+			if err != nil {
+				return fmt.Errorf("failed to query key manager status: %w", err)
+			}
+			if !keyManagerActive {
+				ctx.Logger().Warn("key manager not available for runtime operation",
+					"runtime_id", rt.ID,
+					"key_manager", *rt.KeyManager,
+				)
+			}
+		}
+		suspend := committee == nil || !keyManagerActive || !sufficientStake && !params.DebugDoNotSuspendRuntimes
 
 		switch suspend {
 		case true:
-			ctx.Logger().Debug("suspending runtime, maintenance fees not paid or owner debonded",
+			ctx.Logger().Debug("suspending runtime, maintenance fees not paid, owner debonded or key manager not available",
 				"runtime_id", rt.ID,
 				"epoch", epoch,
 			)
@@ -336,6 +359,18 @@ func (app *rootHashApplication) onNewRuntime(ctx *tmapi.Context, runtime *regist
 		return fmt.Errorf("failed to fetch runtime state: %w", err)
 	}
 
+	// Make sure the runtime identifier has been derived as required. Runtimes existing at genesis
+	// may keep their legacy identifiers.
+	if !ctx.IsInitChain() {
+		var regParams *registry.ConsensusParameters
+		if regParams, err = registryState.NewMutableState(ctx.State()).ConsensusParameters(ctx); err != nil {
+			return fmt.Errorf("failed to get registry consensus parameters: %w", err)
+		}
+		if err = registry.VerifyRuntimeID(regParams, runtime); err != nil {
+			return err
+		}
+	}
+
 	// Create genesis block.
 	now := ctx.Now().Unix()
 	genesisBlock := block.NewGenesisBlock(runtime.ID, uint64(now))
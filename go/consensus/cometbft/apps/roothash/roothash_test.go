@@ -0,0 +1,183 @@
+package roothash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	secretsState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/keymanager/secrets/state"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
+	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/state"
+	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler/state"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+func TestOnCommitteeChangedKeyManager(t *testing.T) {
+	kmID := common.NewTestNamespaceFromSeed([]byte("consensus/cometbft/apps/roothash: key manager"), 0)
+	kmNode := memorySigner.NewTestSigner("consensus/cometbft/apps/roothash: key manager node").Public()
+
+	for _, tc := range []struct {
+		name      string
+		enabled   bool
+		status    *secrets.Status
+		suspended bool
+	}{
+		{"Disabled", false, nil, false},
+		{"NoStatus", true, nil, true},
+		{"NotInitialized", true, &secrets.Status{ID: kmID, Nodes: []signature.PublicKey{kmNode}}, true},
+		{"NoNodes", true, &secrets.Status{ID: kmID, IsInitialized: true}, true},
+		{"Active", true, &secrets.Status{ID: kmID, IsInitialized: true, Nodes: []signature.PublicKey{kmNode}}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+			ctx := appState.NewContext(abciAPI.ContextEndBlock)
+			defer ctx.Close()
+
+			app := &rootHashApplication{
+				state: appState,
+			}
+
+			// Initialize registry state.
+			regState := registryState.NewMutableState(ctx.State())
+			err := regState.SetConsensusParameters(ctx, &registry.ConsensusParameters{
+				SuspendRuntimesWithoutKeyManager: tc.enabled,
+			})
+			require.NoError(err, "registry.SetConsensusParameters")
+			rt := registry.Runtime{
+				Versioned:       cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+				ID:              common.NewTestNamespaceFromSeed([]byte("consensus/cometbft/apps/roothash: runtime"), 0),
+				Kind:            registry.KindCompute,
+				KeyManager:      &kmID,
+				GovernanceModel: registry.GovernanceEntity,
+			}
+			err = regState.SetRuntime(ctx, &rt, false)
+			require.NoError(err, "SetRuntime")
+
+			// Initialize key manager state.
+			if tc.status != nil {
+				err = secretsState.NewMutableState(ctx.State()).SetStatus(ctx, tc.status)
+				require.NoError(err, "SetStatus")
+			}
+
+			// Initialize scheduler state.
+			executorCommittee := scheduler.Committee{
+				RuntimeID: rt.ID,
+				Kind:      scheduler.KindComputeExecutor,
+				Members: []*scheduler.CommitteeNode{
+					{
+						Role:      scheduler.RoleWorker,
+						PublicKey: memorySigner.NewTestSigner("consensus/cometbft/apps/roothash: compute node").Public(),
+					},
+				},
+			}
+			err = schedulerState.NewMutableState(ctx.State()).PutCommittee(ctx, &executorCommittee)
+			require.NoError(err, "PutCommittee")
+
+			// Initialize roothash state.
+			state := roothashState.NewMutableState(ctx.State())
+			err = state.SetConsensusParameters(ctx, &roothash.ConsensusParameters{
+				DebugBypassStake: true,
+			})
+			require.NoError(err, "SetConsensusParameters")
+			blk := block.NewGenesisBlock(rt.ID, 0)
+			err = state.SetRuntimeState(ctx, &roothash.RuntimeState{
+				Runtime:      &rt,
+				GenesisBlock: blk,
+				LastBlock:    blk,
+			})
+			require.NoError(err, "SetRuntimeState")
+
+			err = app.onCommitteeChanged(ctx, state, beacon.EpochTime(1))
+			require.NoError(err, "onCommitteeChanged")
+
+			rtState, err := state.RuntimeState(ctx, rt.ID)
+			require.NoError(err, "RuntimeState")
+			require.Equal(tc.suspended, rtState.Suspended, "runtime state suspension should be correct")
+
+			switch tc.suspended {
+			case true:
+				require.Nil(rtState.Committee, "suspended runtime should not have a committee")
+				require.Equal(block.Suspended, rtState.LastBlock.Header.HeaderType)
+				_, err = regState.SuspendedRuntime(ctx, rt.ID)
+				require.NoError(err, "runtime should be suspended in the registry")
+			case false:
+				require.EqualValues(&executorCommittee, rtState.Committee, "committee should be updated")
+				require.Equal(block.EpochTransition, rtState.LastBlock.Header.HeaderType)
+				_, err = regState.Runtime(ctx, rt.ID)
+				require.NoError(err, "runtime should not be suspended in the registry")
+			}
+		})
+	}
+}
+
+func TestOnNewRuntimeDerivedID(t *testing.T) {
+	entityID := memorySigner.NewTestSigner("consensus/cometbft/apps/roothash: entity").Public()
+	derivedID, err := common.NewDerivedNamespace(entityID[:], 0, 0)
+	require.NoError(t, err, "NewDerivedNamespace")
+	legacyID := common.NewTestNamespaceFromSeed([]byte("consensus/cometbft/apps/roothash: legacy runtime"), 0)
+
+	for _, tc := range []struct {
+		name     string
+		id       common.Namespace
+		required bool
+		valid    bool
+	}{
+		{"LegacyNotRequired", legacyID, false, true},
+		{"Derived", derivedID, true, true},
+		{"Legacy", legacyID, true, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+			ctx := appState.NewContext(abciAPI.ContextEndBlock)
+			defer ctx.Close()
+
+			app := &rootHashApplication{
+				state: appState,
+			}
+
+			err := registryState.NewMutableState(ctx.State()).SetConsensusParameters(ctx, &registry.ConsensusParameters{
+				RequireDerivedRuntimeIDs: tc.required,
+			})
+			require.NoError(err, "registry.SetConsensusParameters")
+			err = roothashState.NewMutableState(ctx.State()).SetConsensusParameters(ctx, &roothash.ConsensusParameters{})
+			require.NoError(err, "SetConsensusParameters")
+
+			// Runtimes are registered by transactions.
+			txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+			defer txCtx.Close()
+
+			rt := &registry.Runtime{
+				Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+				ID:        tc.id,
+				EntityID:  entityID,
+				Kind:      registry.KindCompute,
+			}
+			err = app.onNewRuntime(txCtx, rt, nil, false)
+
+			state := roothashState.NewMutableState(txCtx.State())
+			_, stErr := state.RuntimeState(txCtx, rt.ID)
+			switch tc.valid {
+			case true:
+				require.NoError(err, "onNewRuntime")
+				require.NoError(stErr, "runtime state should be created")
+			case false:
+				require.ErrorIs(err, registry.ErrInvalidArgument, "onNewRuntime")
+				require.ErrorIs(stErr, roothash.ErrInvalidRuntime, "runtime state should not be created")
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
+	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler/state"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+var electionDrifts = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Name: "oasis_scheduler_election_drifts",
+		Help: "Number of elections where the locally recomputed result diverged from the consensus state.",
+	},
+)
+
+// electionResult is the outcome of an election as recorded in the consensus state.
+type electionResult struct {
+	Committees        []*scheduler.Committee                       `json:"committees"`
+	PendingValidators map[signature.PublicKey]*scheduler.Validator `json:"pending_validators"`
+}
+
+func getElectionResult(ctx *api.Context) (*electionResult, error) {
+	state := schedulerState.NewMutableState(ctx.State())
+	committees, err := state.AllCommittees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query committees: %w", err)
+	}
+	pendingValidators, err := state.PendingValidators(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending validators: %w", err)
+	}
+	return &electionResult{
+		Committees:        committees,
+		PendingValidators: pendingValidators,
+	}, nil
+}
+
+// electChecked elects the validators and all committees for the given epoch and verifies that
+// the election is deterministic and consistent with the epoch reported by the consensus state.
+//
+// The election is first run on a discarded copy of the state and the outcome is compared with
+// the one of the actual election.
+func (app *schedulerApplication) electChecked(ctx *api.Context, epoch beacon.EpochTime, epochChanged bool) error {
+	expected, err := app.simulateElection(ctx, epoch, epochChanged)
+	if err != nil {
+		return err
+	}
+
+	if err = app.elect(ctx, epoch, epochChanged); err != nil {
+		return err
+	}
+
+	actual, err := getElectionResult(ctx)
+	if err != nil {
+		return fmt.Errorf("cometbft/scheduler: %w", err)
+	}
+	if err = checkElection(ctx, epoch, expected, actual); err != nil {
+		electionDrifts.Inc()
+		ctx.Logger().Error("election drift detected",
+			"err", err,
+			"height", ctx.BlockHeight(),
+			"epoch", epoch,
+		)
+		if app.cfg.HaltOnDrift {
+			return fmt.Errorf("cometbft/scheduler: election drift detected: %w", err)
+		}
+	}
+	return nil
+}
+
+// simulateElection runs the election without persisting any state changes and returns its outcome.
+func (app *schedulerApplication) simulateElection(ctx *api.Context, epoch beacon.EpochTime, epochChanged bool) (*electionResult, error) {
+	txCtx := ctx.NewTransaction()
+	defer txCtx.Close()
+	simCtx := txCtx.WithSimulation()
+	defer simCtx.Close()
+
+	if err := app.elect(simCtx, epoch, epochChanged); err != nil {
+		return nil, err
+	}
+	result, err := getElectionResult(simCtx)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft/scheduler: %w", err)
+	}
+	return result, nil
+}
+
+// checkElection compares the outcome of the simulated and the actual election and verifies that
+// no elected committee is valid for an epoch after the one reported by the consensus state.
+func checkElection(ctx *api.Context, epoch beacon.EpochTime, expected, actual *electionResult) error {
+	stateEpoch, _, err := beaconState.NewMutableState(ctx.State()).GetEpoch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query epoch: %w", err)
+	}
+	if stateEpoch != epoch {
+		return fmt.Errorf("computed epoch %d does not match consensus state epoch %d", epoch, stateEpoch)
+	}
+
+	for _, committee := range actual.Committees {
+		if committee.ValidFor > epoch {
+			return fmt.Errorf("%s committee of runtime %s is valid for future epoch %d (current: %d)",
+				committee.Kind,
+				committee.RuntimeID,
+				committee.ValidFor,
+				epoch,
+			)
+		}
+	}
+
+	if !bytes.Equal(cbor.Marshal(expected.Committees), cbor.Marshal(actual.Committees)) {
+		return fmt.Errorf("recomputed committees do not match elected committees")
+	}
+	if !bytes.Equal(cbor.Marshal(expected.PendingValidators), cbor.Marshal(actual.PendingValidators)) {
+		return fmt.Errorf("recomputed validators do not match elected validators")
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+func TestCheckElection(t *testing.T) {
+	require := require.New(t)
+
+	appState := api.NewMockApplicationState(&api.MockApplicationStateConfig{})
+	ctx := appState.NewContext(api.ContextBeginBlock)
+	defer ctx.Close()
+
+	err := beaconState.NewMutableState(ctx.State()).SetEpoch(ctx, 5, 100)
+	require.NoError(err, "SetEpoch")
+
+	rtID := common.NewTestNamespaceFromSeed([]byte("runtime"), 0)
+	nodeID := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001")
+	newResult := func(validFor uint64, nodeID signature.PublicKey) *electionResult {
+		return &electionResult{
+			Committees: []*scheduler.Committee{
+				{
+					Kind:      scheduler.KindComputeExecutor,
+					Members:   []*scheduler.CommitteeNode{{Role: scheduler.RoleWorker, PublicKey: nodeID}},
+					RuntimeID: rtID,
+					ValidFor:  beacon.EpochTime(validFor),
+				},
+			},
+		}
+	}
+
+	err = checkElection(ctx, 5, newResult(5, nodeID), newResult(5, nodeID))
+	require.NoError(err, "matching elections should pass")
+
+	err = checkElection(ctx, 6, newResult(6, nodeID), newResult(6, nodeID))
+	require.Error(err, "epoch not matching the consensus state should be detected")
+
+	err = checkElection(ctx, 5, newResult(6, nodeID), newResult(6, nodeID))
+	require.Error(err, "committees valid for future epochs should be detected")
+
+	otherID := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000002")
+	err = checkElection(ctx, 5, newResult(5, nodeID), newResult(5, otherID))
+	require.Error(err, "diverging committees should be detected")
+}
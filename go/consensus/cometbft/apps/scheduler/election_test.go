@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
+	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	schedulerTests "github.com/oasisprotocol/oasis-core/go/scheduler/tests"
+)
+
+// electForScenario elects the executor committee for the given scenario on a fresh state.
+func electForScenario(t *testing.T, sc *schedulerTests.ElectionScenario) *scheduler.Committee {
+	require := require.New(t)
+
+	appState := api.NewMockApplicationState(&api.MockApplicationStateConfig{})
+	ctx := appState.NewContext(api.ContextBeginBlock)
+	defer ctx.Close()
+
+	app := &schedulerApplication{
+		state: appState,
+	}
+
+	beaconState := beaconState.NewMutableState(ctx.State())
+	err := beaconState.DebugForceSetBeacon(ctx, sc.Beacon)
+	require.NoError(err, "DebugForceSetBeacon")
+	err = beaconState.SetEpoch(ctx, sc.Epoch, 1)
+	require.NoError(err, "SetEpoch")
+
+	nodes := make([]*nodeWithStatus, 0, len(sc.Nodes))
+	for _, n := range sc.Nodes {
+		nodes = append(nodes, &nodeWithStatus{n, &registry.NodeStatus{}})
+	}
+
+	err = app.electCommittee(
+		ctx,
+		&scheduler.ConsensusParameters{},
+		beaconState,
+		&beacon.ConsensusParameters{Backend: beacon.BackendInsecure},
+		&registry.ConsensusParameters{},
+		nil,
+		nil,
+		nil,
+		sc.Runtime,
+		nodes,
+		newNodeAssignments(),
+		scheduler.KindComputeExecutor,
+	)
+	require.NoError(err, "electCommittee")
+
+	committee, err := schedulerState.NewMutableState(ctx.State()).Committee(ctx, scheduler.KindComputeExecutor, sc.Runtime.ID)
+	require.NoError(err, "Committee")
+	return committee
+}
+
+func TestElectionScenarios(t *testing.T) {
+	seed := []byte("TestElectionScenarios")
+	rt := schedulerTests.NewElectionRuntime(seed, 2, 1)
+	nodes := schedulerTests.GenerateNodes(seed, 5, rt)
+	beacons := schedulerTests.GenerateBeacons(seed, 2)
+
+	member := func(role scheduler.Role, idx int) *scheduler.CommitteeNode {
+		return &scheduler.CommitteeNode{Role: role, PublicKey: nodes[idx].ID}
+	}
+
+	schedulerTests.ElectionScenarioTests(t, electForScenario, []*schedulerTests.ElectionScenario{
+		{
+			Name:    "NoNodes",
+			Beacon:  beacons[0],
+			Epoch:   1,
+			Runtime: rt,
+		},
+		{
+			Name:    "NotEnoughNodes",
+			Beacon:  beacons[0],
+			Epoch:   1,
+			Runtime: rt,
+			Nodes:   nodes[:1],
+		},
+		{
+			Name:    "Beacon0",
+			Beacon:  beacons[0],
+			Epoch:   1,
+			Runtime: rt,
+			Nodes:   nodes,
+			Expected: []*scheduler.CommitteeNode{
+				member(scheduler.RoleWorker, 1),
+				member(scheduler.RoleWorker, 2),
+				member(scheduler.RoleBackupWorker, 3),
+			},
+		},
+		{
+			Name:    "Beacon1",
+			Beacon:  beacons[1],
+			Epoch:   2,
+			Runtime: rt,
+			Nodes:   nodes,
+			Expected: []*scheduler.CommitteeNode{
+				member(scheduler.RoleWorker, 2),
+				member(scheduler.RoleWorker, 4),
+				member(scheduler.RoleBackupWorker, 2),
+			},
+		},
+	})
+}
+
+func TestElectionFairness(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping election fairness test in short mode")
+	}
+
+	seed := []byte("TestElectionFairness")
+	rt := schedulerTests.NewElectionRuntime(seed, 25, 0)
+	nodes := schedulerTests.GenerateNodes(seed, 500, rt)
+
+	schedulerTests.ElectionFairnessTests(t, electForScenario, rt, nodes, 2000, 0.5)
+}
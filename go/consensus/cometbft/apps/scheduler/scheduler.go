@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"math/rand"
 	"sort"
+	"sync"
 
 	"github.com/cometbft/cometbft/abci/types"
+	"github.com/prometheus/client_golang/prometheus"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
@@ -42,11 +44,47 @@ var (
 
 	RNGContextRoleWorker       = []byte("Worker")
 	RNGContextRoleBackupWorker = []byte("Backup-Worker")
+
+	saturatedNodes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_scheduler_saturated_nodes",
+			Help: "Number of nodes elected for as many runtimes as their advertised capacity in the last election.",
+		},
+		[]string{"kind"},
+	)
+	capacityExclusions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_scheduler_capacity_exclusions",
+			Help: "Number of times a node was excluded from a runtime committee election due to its advertised capacity.",
+		},
+		[]string{"runtime"},
+	)
+
+	schedulerCollectors = []prometheus.Collector{
+		saturatedNodes,
+		capacityExclusions,
+		electionDrifts,
+	}
+
+	metricsOnce sync.Once
 )
 
+// Config is the scheduler application configuration.
+type Config struct {
+	// CheckElections enables re-running the elections at each epoch transition to detect
+	// nondeterministic election results.
+	CheckElections bool
+
+	// HaltOnDrift halts the node when the election checks detect a divergence, otherwise it is
+	// only logged and reported via metrics.
+	HaltOnDrift bool
+}
+
 type schedulerApplication struct {
 	state api.ApplicationState
 	md    api.MessageDispatcher
+
+	cfg Config
 }
 
 func (app *schedulerApplication) Name() string {
@@ -110,143 +148,152 @@ func (app *schedulerApplication) BeginBlock(ctx *api.Context) error {
 			return nil
 		}
 
-		state := schedulerState.NewMutableState(ctx.State())
-		params, err := state.ConsensusParameters(ctx)
-		if err != nil {
-			ctx.Logger().Error("failed to fetch consensus parameters",
-				"err", err,
-			)
-			return err
+		if app.cfg.CheckElections {
+			return app.electChecked(ctx, epoch, epochChanged)
 		}
+		return app.elect(ctx, epoch, epochChanged)
+	}
+	return nil
+}
 
-		beaconState := beaconState.NewMutableState(ctx.State())
-		beaconParameters, err := beaconState.ConsensusParameters(ctx)
-		if err != nil {
-			return fmt.Errorf("cometbft/scheduler: couldn't get beacon parameters: %w", err)
-		}
-		// If weak alphas are allowed then skip the eligibility check as
-		// well because the byzantine node and associated tests are extremely
-		// fragile, and breaks in hard-to-debug ways if timekeeping isn't
-		// exactly how it expects.
-		filterCommitteeNodes := beaconParameters.Backend == beacon.BackendVRF && !params.DebugAllowWeakAlpha
-
-		regState := registryState.NewMutableState(ctx.State())
-		registryParameters, err := regState.ConsensusParameters(ctx)
+// elect elects the validators and all committees for the given epoch.
+func (app *schedulerApplication) elect(ctx *api.Context, epoch beacon.EpochTime, epochChanged bool) error {
+	state := schedulerState.NewMutableState(ctx.State())
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("failed to fetch consensus parameters",
+			"err", err,
+		)
+		return err
+	}
+
+	beaconState := beaconState.NewMutableState(ctx.State())
+	beaconParameters, err := beaconState.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("cometbft/scheduler: couldn't get beacon parameters: %w", err)
+	}
+	// If weak alphas are allowed then skip the eligibility check as
+	// well because the byzantine node and associated tests are extremely
+	// fragile, and breaks in hard-to-debug ways if timekeeping isn't
+	// exactly how it expects.
+	filterCommitteeNodes := beaconParameters.Backend == beacon.BackendVRF && !params.DebugAllowWeakAlpha
+
+	regState := registryState.NewMutableState(ctx.State())
+	registryParameters, err := regState.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("cometbft/scheduler: couldn't get registry parameters: %w", err)
+	}
+	runtimes, err := regState.Runtimes(ctx)
+	if err != nil {
+		return fmt.Errorf("cometbft/scheduler: couldn't get runtimes: %w", err)
+	}
+	allNodes, err := regState.Nodes(ctx)
+	if err != nil {
+		return fmt.Errorf("cometbft/scheduler: couldn't get nodes: %w", err)
+	}
+
+	// Filter nodes.
+	var (
+		nodes          []*node.Node
+		committeeNodes []*nodeWithStatus
+	)
+	for _, node := range allNodes {
+		var status *registry.NodeStatus
+		status, err = regState.NodeStatus(ctx, node.ID)
 		if err != nil {
-			return fmt.Errorf("cometbft/scheduler: couldn't get registry parameters: %w", err)
+			return fmt.Errorf("cometbft/scheduler: couldn't get node status: %w", err)
 		}
-		runtimes, err := regState.Runtimes(ctx)
-		if err != nil {
-			return fmt.Errorf("cometbft/scheduler: couldn't get runtimes: %w", err)
+
+		// Nodes which are currently frozen cannot be scheduled.
+		if status.IsFrozen() {
+			continue
 		}
-		allNodes, err := regState.Nodes(ctx)
-		if err != nil {
-			return fmt.Errorf("cometbft/scheduler: couldn't get nodes: %w", err)
+		// Expired nodes cannot be scheduled (nodes can be expired and not yet removed).
+		if node.IsExpired(uint64(epoch)) {
+			continue
 		}
 
-		// Filter nodes.
-		var (
-			nodes          []*node.Node
-			committeeNodes []*nodeWithStatus
-		)
-		for _, node := range allNodes {
-			var status *registry.NodeStatus
-			status, err = regState.NodeStatus(ctx, node.ID)
-			if err != nil {
-				return fmt.Errorf("cometbft/scheduler: couldn't get node status: %w", err)
-			}
-
-			// Nodes which are currently frozen cannot be scheduled.
-			if status.IsFrozen() {
-				continue
-			}
-			// Expired nodes cannot be scheduled (nodes can be expired and not yet removed).
-			if node.IsExpired(uint64(epoch)) {
-				continue
-			}
-
-			nodes = append(nodes, node)
-			if !filterCommitteeNodes || (status.ElectionEligibleAfter != beacon.EpochInvalid && epoch > status.ElectionEligibleAfter) {
-				committeeNodes = append(committeeNodes, &nodeWithStatus{node, status})
-			}
+		nodes = append(nodes, node)
+		if !filterCommitteeNodes || (status.ElectionEligibleAfter != beacon.EpochInvalid && epoch > status.ElectionEligibleAfter) {
+			committeeNodes = append(committeeNodes, &nodeWithStatus{node, status})
 		}
+	}
 
-		var stakeAcc *stakingState.StakeAccumulatorCache
-		if !params.DebugBypassStake {
-			stakeAcc, err = stakingState.NewStakeAccumulatorCache(ctx)
-			if err != nil {
-				return fmt.Errorf("cometbft/scheduler: failed to create stake accumulator cache: %w", err)
-			}
-			defer stakeAcc.Discard()
+	var stakeAcc *stakingState.StakeAccumulatorCache
+	if !params.DebugBypassStake {
+		stakeAcc, err = stakingState.NewStakeAccumulatorCache(ctx)
+		if err != nil {
+			return fmt.Errorf("cometbft/scheduler: failed to create stake accumulator cache: %w", err)
 		}
+		defer stakeAcc.Discard()
+	}
 
-		var entitiesEligibleForReward map[staking.Address]bool
-		if epochChanged {
-			// For elections on epoch changes, distribute rewards to entities with any eligible nodes.
-			entitiesEligibleForReward = make(map[staking.Address]bool)
-		}
+	var entitiesEligibleForReward map[staking.Address]bool
+	if epochChanged {
+		// For elections on epoch changes, distribute rewards to entities with any eligible nodes.
+		entitiesEligibleForReward = make(map[staking.Address]bool)
+	}
+
+	// Handle the validator election first, because no consensus is
+	// catastrophic, while failing to elect other committees is not.
+	var validatorEntities map[staking.Address]bool
+	if validatorEntities, err = app.electValidators(
+		ctx,
+		app.state,
+		beaconState,
+		beaconParameters,
+		stakeAcc,
+		entitiesEligibleForReward,
+		nodes,
+		params,
+	); err != nil {
+		// It is unclear what the behavior should be if the validator
+		// election fails.  The system can not ensure integrity, so
+		// presumably manual intervention is required...
+		return fmt.Errorf("cometbft/scheduler: couldn't elect validators: %w", err)
+	}
 
-		// Handle the validator election first, because no consensus is
-		// catastrophic, while failing to elect other committees is not.
-		var validatorEntities map[staking.Address]bool
-		if validatorEntities, err = app.electValidators(
+	kinds := []scheduler.CommitteeKind{
+		scheduler.KindComputeExecutor,
+	}
+	for _, kind := range kinds {
+		if err = app.electAllCommittees(
 			ctx,
-			app.state,
+			params,
 			beaconState,
 			beaconParameters,
+			registryParameters,
 			stakeAcc,
 			entitiesEligibleForReward,
-			nodes,
-			params,
+			validatorEntities,
+			runtimes,
+			committeeNodes,
+			kind,
 		); err != nil {
-			// It is unclear what the behavior should be if the validator
-			// election fails.  The system can not ensure integrity, so
-			// presumably manual intervention is required...
-			return fmt.Errorf("cometbft/scheduler: couldn't elect validators: %w", err)
-		}
-
-		kinds := []scheduler.CommitteeKind{
-			scheduler.KindComputeExecutor,
+			return fmt.Errorf("cometbft/scheduler: couldn't elect %s committees: %w", kind, err)
 		}
-		for _, kind := range kinds {
-			if err = app.electAllCommittees(
-				ctx,
-				params,
-				beaconState,
-				beaconParameters,
-				registryParameters,
-				stakeAcc,
-				entitiesEligibleForReward,
-				validatorEntities,
-				runtimes,
-				committeeNodes,
-				kind,
-			); err != nil {
-				return fmt.Errorf("cometbft/scheduler: couldn't elect %s committees: %w", kind, err)
-			}
-		}
-		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&scheduler.ElectedEvent{Kinds: kinds}))
-
-		var kindNames []string
-		for _, kind := range kinds {
-			kindNames = append(kindNames, kind.String())
-		}
-		var runtimeIDs []string
-		for _, rt := range runtimes {
-			runtimeIDs = append(runtimeIDs, rt.ID.String())
-		}
-		ctx.Logger().Debug("finished electing committees",
-			"epoch", epoch,
-			"kinds", kindNames,
-			"runtimes", runtimeIDs,
-		)
+	}
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&scheduler.ElectedEvent{Kinds: kinds}))
 
-		if entitiesEligibleForReward != nil {
-			accountAddrs := stakingAddressMapToSortedSlice(entitiesEligibleForReward)
-			stakingSt := stakingState.NewMutableState(ctx.State())
-			if err = stakingSt.AddRewards(ctx, epoch, &params.RewardFactorEpochElectionAny, accountAddrs); err != nil {
-				return fmt.Errorf("cometbft/scheduler: failed to add rewards: %w", err)
-			}
+	var kindNames []string
+	for _, kind := range kinds {
+		kindNames = append(kindNames, kind.String())
+	}
+	var runtimeIDs []string
+	for _, rt := range runtimes {
+		runtimeIDs = append(runtimeIDs, rt.ID.String())
+	}
+	ctx.Logger().Debug("finished electing committees",
+		"epoch", epoch,
+		"kinds", kindNames,
+		"runtimes", runtimeIDs,
+	)
+
+	if entitiesEligibleForReward != nil {
+		accountAddrs := stakingAddressMapToSortedSlice(entitiesEligibleForReward)
+		stakingSt := stakingState.NewMutableState(ctx.State())
+		if err = stakingSt.AddRewards(ctx, epoch, &params.RewardFactorEpochElectionAny, accountAddrs); err != nil {
+			return fmt.Errorf("cometbft/scheduler: failed to add rewards: %w", err)
 		}
 	}
 	return nil
@@ -409,6 +456,23 @@ func GetPerm(beacon []byte, runtimeID common.Namespace, rngCtx []byte, nrNodes i
 	return rng.Perm(nrNodes), nil
 }
 
+// nodeAssignments tracks the runtimes nodes have been elected for during
+// committee elections.
+type nodeAssignments struct {
+	// runtimes is the number of runtimes each node has been elected for.
+	runtimes map[signature.PublicKey]int
+	// teeMemory is the amount of TEE memory (in bytes) required by the
+	// runtimes each node has been elected for.
+	teeMemory map[signature.PublicKey]uint64
+}
+
+func newNodeAssignments() *nodeAssignments {
+	return &nodeAssignments{
+		runtimes:  make(map[signature.PublicKey]int),
+		teeMemory: make(map[signature.PublicKey]uint64),
+	}
+}
+
 // Operates on consensus connection.
 func (app *schedulerApplication) electAllCommittees(
 	ctx *api.Context,
@@ -423,6 +487,9 @@ func (app *schedulerApplication) electAllCommittees(
 	nodeList []*nodeWithStatus,
 	kind scheduler.CommitteeKind,
 ) error {
+	// Track the runtimes each node has been elected for, so that nodes are
+	// not assigned more runtimes than their advertised capacity.
+	assignments := newNodeAssignments()
 	for _, runtime := range runtimes {
 		if err := app.electCommittee(
 			ctx,
@@ -435,11 +502,23 @@ func (app *schedulerApplication) electAllCommittees(
 			validatorEntities,
 			runtime,
 			nodeList,
+			assignments,
 			kind,
 		); err != nil {
 			return err
 		}
 	}
+
+	var saturated int
+	for _, n := range nodeList {
+		if registryParameters.EnableNodeCapacity && n.node.Capacity.IsSaturated(assignments.runtimes[n.node.ID]) {
+			saturated++
+		}
+	}
+	if !ctx.IsSimulation() {
+		saturatedNodes.With(prometheus.Labels{"kind": kind.String()}).Set(float64(saturated))
+	}
+
 	return nil
 }
 
@@ -631,6 +710,12 @@ func stakingAddressMapToSortedSlice(m map[staking.Address]bool) []staking.Addres
 }
 
 // New constructs a new scheduler application instance.
-func New() api.Application {
-	return &schedulerApplication{}
+func New(cfg Config) api.Application {
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(schedulerCollectors...)
+	})
+
+	return &schedulerApplication{
+		cfg: cfg,
+	}
 }
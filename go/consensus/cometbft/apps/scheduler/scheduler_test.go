@@ -106,7 +106,9 @@ func TestElectCommittee(t *testing.T) {
 		Backend: beacon.BackendInsecure,
 	}
 
-	registryParameters := &registry.ConsensusParameters{}
+	registryParameters := &registry.ConsensusParameters{
+		EnableNodeCapacity: true,
+	}
 
 	rtID1 := common.NewTestNamespaceFromSeed([]byte("runtime 1"), 0)
 	rtID2 := common.NewTestNamespaceFromSeed([]byte("runtime 2"), 0)
@@ -779,6 +781,168 @@ func TestElectCommittee(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"executor: saturated nodes are ineligible",
+			scheduler.KindComputeExecutor,
+			[]*node.Node{
+				{
+					ID:       nodeID1,
+					EntityID: entityID1,
+					Runtimes: []*node.Runtime{
+						{ID: rtID1}, // Matching runtime ID.
+					},
+					Roles:    node.RoleComputeWorker,
+					Capacity: &node.CapacityInfo{MaxRuntimes: 1}, // Already assigned one runtime.
+				},
+				{
+					ID:       nodeID3,
+					EntityID: entityID1,
+					Runtimes: []*node.Runtime{
+						{ID: rtID1}, // Matching runtime ID.
+					},
+					Roles: node.RoleComputeWorker,
+				},
+			},
+			map[signature.PublicKey]*registry.NodeStatus{},
+			map[staking.Address]bool{},
+			registry.Runtime{
+				ID:   rtID1,
+				Kind: registry.KindCompute,
+				Executor: registry.ExecutorParameters{
+					GroupSize:       2,
+					GroupBackupSize: 0,
+				},
+				Deployments: []*registry.VersionInfo{
+					{},
+				},
+			},
+			false,
+		},
+		{
+			"executor: nodes with spare capacity are eligible",
+			scheduler.KindComputeExecutor,
+			[]*node.Node{
+				{
+					ID:       nodeID1,
+					EntityID: entityID1,
+					Runtimes: []*node.Runtime{
+						{ID: rtID1}, // Matching runtime ID.
+					},
+					Roles:    node.RoleComputeWorker,
+					Capacity: &node.CapacityInfo{MaxRuntimes: 2}, // Already assigned one runtime.
+				},
+				{
+					ID:       nodeID3,
+					EntityID: entityID1,
+					Runtimes: []*node.Runtime{
+						{ID: rtID1}, // Matching runtime ID.
+					},
+					Roles: node.RoleComputeWorker,
+				},
+			},
+			map[signature.PublicKey]*registry.NodeStatus{},
+			map[staking.Address]bool{},
+			registry.Runtime{
+				ID:   rtID1,
+				Kind: registry.KindCompute,
+				Executor: registry.ExecutorParameters{
+					GroupSize:       2,
+					GroupBackupSize: 0,
+				},
+				Deployments: []*registry.VersionInfo{
+					{},
+				},
+			},
+			true,
+		},
+		{
+			"executor: nodes without enough spare TEE memory are ineligible",
+			scheduler.KindComputeExecutor,
+			[]*node.Node{
+				{
+					ID:       nodeID1,
+					EntityID: entityID1,
+					Runtimes: []*node.Runtime{
+						{ID: rtID1}, // Matching runtime ID.
+					},
+					Roles:    node.RoleComputeWorker,
+					Capacity: &node.CapacityInfo{TEEMemory: 3 << 30}, // Already assigned 2 GiB.
+				},
+				{
+					ID:       nodeID3,
+					EntityID: entityID1,
+					Runtimes: []*node.Runtime{
+						{ID: rtID1}, // Matching runtime ID.
+					},
+					Roles: node.RoleComputeWorker,
+				},
+			},
+			map[signature.PublicKey]*registry.NodeStatus{},
+			map[staking.Address]bool{},
+			registry.Runtime{
+				ID:   rtID1,
+				Kind: registry.KindCompute,
+				Executor: registry.ExecutorParameters{
+					GroupSize:       2,
+					GroupBackupSize: 0,
+				},
+				Constraints: map[scheduler.CommitteeKind]map[scheduler.Role]registry.SchedulingConstraints{
+					scheduler.KindComputeExecutor: {
+						scheduler.RoleWorker: {
+							TEEMemory: &registry.TEEMemoryConstraint{Amount: 2 << 30},
+						},
+					},
+				},
+				Deployments: []*registry.VersionInfo{
+					{},
+				},
+			},
+			false,
+		},
+		{
+			"executor: nodes with enough spare TEE memory are eligible",
+			scheduler.KindComputeExecutor,
+			[]*node.Node{
+				{
+					ID:       nodeID1,
+					EntityID: entityID1,
+					Runtimes: []*node.Runtime{
+						{ID: rtID1}, // Matching runtime ID.
+					},
+					Roles:    node.RoleComputeWorker,
+					Capacity: &node.CapacityInfo{TEEMemory: 4 << 30}, // Already assigned 2 GiB.
+				},
+				{
+					ID:       nodeID3,
+					EntityID: entityID1,
+					Runtimes: []*node.Runtime{
+						{ID: rtID1}, // Matching runtime ID.
+					},
+					Roles: node.RoleComputeWorker,
+				},
+			},
+			map[signature.PublicKey]*registry.NodeStatus{},
+			map[staking.Address]bool{},
+			registry.Runtime{
+				ID:   rtID1,
+				Kind: registry.KindCompute,
+				Executor: registry.ExecutorParameters{
+					GroupSize:       2,
+					GroupBackupSize: 0,
+				},
+				Constraints: map[scheduler.CommitteeKind]map[scheduler.Role]registry.SchedulingConstraints{
+					scheduler.KindComputeExecutor: {
+						scheduler.RoleWorker: {
+							TEEMemory: &registry.TEEMemoryConstraint{Amount: 2 << 30},
+						},
+					},
+				},
+				Deployments: []*registry.VersionInfo{
+					{},
+				},
+			},
+			true,
+		},
 	} {
 		var nodes []*nodeWithStatus
 		for _, node := range tc.nodes {
@@ -790,6 +954,14 @@ func TestElectCommittee(t *testing.T) {
 			nodes = append(nodes, &nodeWithStatus{node, status})
 		}
 
+		// Pretend that all nodes were already elected for another runtime requiring
+		// 2 GiB of TEE memory, this only affects nodes that advertise their capacity.
+		assignments := newNodeAssignments()
+		for _, node := range tc.nodes {
+			assignments.runtimes[node.ID] = 1
+			assignments.teeMemory[node.ID] = 2 << 30
+		}
+
 		err := app.electCommittee(
 			ctx,
 			schedulerParameters,
@@ -801,6 +973,7 @@ func TestElectCommittee(t *testing.T) {
 			tc.validatorEntities,
 			&tc.rt, //nolint:gosec
 			nodes,
+			assignments,
 			tc.kind,
 		)
 		require.NoError(err, "committee election should not fail")
@@ -5,9 +5,12 @@ import (
 	"crypto"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"math/rand"
 	"sort"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/drbg"
@@ -157,6 +160,7 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 	validatorEntities map[staking.Address]bool,
 	rt *registry.Runtime,
 	nodeList []*nodeWithStatus,
+	assignments *nodeAssignments,
 	kind scheduler.CommitteeKind,
 ) error {
 	// Only generic compute runtimes need to elect all the committees.
@@ -232,6 +236,15 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 	// Decode per-role constraints.
 	cs := rt.Constraints[kind]
 
+	// Determine the TEE memory required to host the runtime. Nodes elected
+	// to multiple roles only host the runtime once.
+	var teeMemory uint64
+	for _, role := range committeeRoles {
+		if tm := cs[role].TEEMemory; tm != nil && tm.Amount > teeMemory {
+			teeMemory = tm.Amount
+		}
+	}
+
 	// Perform pre-election eligiblity filtering.
 	nodeLists := make(map[scheduler.Role][]*node.Node)
 	for _, n := range nodeList {
@@ -246,6 +259,20 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 		if !isSuitableFn(ctx, n, rt, epoch, registryParameters) {
 			continue
 		}
+		// Skip nodes which were already elected for as many runtimes as
+		// they can host.
+		if registryParameters.EnableNodeCapacity && n.node.Capacity.IsSaturated(assignments.runtimes[n.node.ID]) {
+			ctx.Logger().Debug("skipping saturated node",
+				"kind", kind,
+				"runtime_id", rt.ID,
+				"id", n.node.ID,
+				"assigned", assignments.runtimes[n.node.ID],
+			)
+			if !ctx.IsSimulation() {
+				capacityExclusions.With(prometheus.Labels{"runtime": rt.ID.String()}).Inc()
+			}
+			continue
+		}
 
 		// If the election uses VRFs, make sure that the node bothered to submit
 		// a VRF proof for this election.
@@ -285,6 +312,25 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 				}
 			}
 
+			// TEE memory constraint.
+			if tm := cs[role].TEEMemory; tm != nil && registryParameters.EnableNodeCapacity {
+				if !n.node.Capacity.HasTEEMemory(assignments.teeMemory[n.node.ID], tm.Amount) {
+					// Not eligible if the node does not have enough spare TEE memory.
+					ctx.Logger().Debug("skipping node without enough TEE memory",
+						"kind", kind,
+						"role", role,
+						"runtime_id", rt.ID,
+						"id", n.node.ID,
+						"assigned", assignments.teeMemory[n.node.ID],
+						"required", tm.Amount,
+					)
+					if !ctx.IsSimulation() {
+						capacityExclusions.With(prometheus.Labels{"runtime": rt.ID.String()}).Inc()
+					}
+					continue
+				}
+			}
+
 			nodeLists[role] = append(nodeLists[role], n.node)
 			eligible = true
 		}
@@ -521,6 +567,22 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 	if err = schedulerState.NewMutableState(ctx.State()).PutCommittee(ctx, committee); err != nil {
 		return fmt.Errorf("cometbft/scheduler: failed to save committee: %w", err)
 	}
+
+	// Account for the runtime assignment of each elected node, counting nodes
+	// elected to multiple roles only once.
+	assigned := make(map[signature.PublicKey]bool)
+	for _, member := range members {
+		if assigned[member.PublicKey] {
+			continue
+		}
+		assigned[member.PublicKey] = true
+		assignments.runtimes[member.PublicKey]++
+		if assignments.teeMemory[member.PublicKey] > math.MaxUint64-teeMemory {
+			assignments.teeMemory[member.PublicKey] = math.MaxUint64
+		} else {
+			assignments.teeMemory[member.PublicKey] += teeMemory
+		}
+	}
 	return nil
 }
 
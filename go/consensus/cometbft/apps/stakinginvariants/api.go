@@ -0,0 +1,11 @@
+package stakinginvariants
+
+const (
+	// AppID is the unique application identifier.
+	// This application is optional and doesn't alter the consensus state,
+	// so no need to reserve a low sequential identifier.
+	AppID uint8 = 0x97
+
+	// AppName is the ABCI application name.
+	AppName string = "998_stakinginvariants"
+)
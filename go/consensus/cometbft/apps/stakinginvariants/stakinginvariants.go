@@ -0,0 +1,194 @@
+// Package stakinginvariants implements a non-normative application that
+// verifies staking invariants at each epoch transition.
+package stakinginvariants
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+)
+
+var (
+	logger = logging.GetLogger("stakinginvariants")
+
+	invariantViolations = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "oasis_consensus_staking_invariant_violations",
+			Help: "Number of epochs at which staking invariants were violated.",
+		},
+	)
+
+	metricsOnce sync.Once
+
+	_ api.Application = (*stakingInvariantsApplication)(nil)
+)
+
+// stakingInvariantsApplication is a non-normative mux app that verifies
+// staking invariants at each epoch transition. It should not alter the
+// CometBFT application state.
+type stakingInvariantsApplication struct {
+	state api.ApplicationState
+	halt  bool
+}
+
+func (app *stakingInvariantsApplication) Name() string {
+	return AppName
+}
+
+func (app *stakingInvariantsApplication) ID() uint8 {
+	return AppID
+}
+
+func (app *stakingInvariantsApplication) Methods() []transaction.MethodName {
+	return nil
+}
+
+func (app *stakingInvariantsApplication) Blessed() bool {
+	return false
+}
+
+func (app *stakingInvariantsApplication) Dependencies() []string {
+	return []string{stakingState.AppName}
+}
+
+func (app *stakingInvariantsApplication) QueryFactory() interface{} {
+	return nil
+}
+
+func (app *stakingInvariantsApplication) OnRegister(state api.ApplicationState, _ api.MessageDispatcher) {
+	app.state = state
+}
+
+func (app *stakingInvariantsApplication) OnCleanup() {
+}
+
+func (app *stakingInvariantsApplication) ExecuteMessage(*api.Context, interface{}, interface{}) (interface{}, error) {
+	return nil, fmt.Errorf("stakinginvariants: unexpected message")
+}
+
+func (app *stakingInvariantsApplication) ExecuteTx(*api.Context, *transaction.Transaction) error {
+	return fmt.Errorf("stakinginvariants: unexpected transaction")
+}
+
+func (app *stakingInvariantsApplication) InitChain(*api.Context, types.RequestInitChain, *genesis.Document) error {
+	return nil
+}
+
+func (app *stakingInvariantsApplication) BeginBlock(*api.Context) error {
+	return nil
+}
+
+func (app *stakingInvariantsApplication) EndBlock(ctx *api.Context) (types.ResponseEndBlock, error) {
+	changed, epoch := app.state.EpochChanged(ctx)
+	if !changed {
+		return types.ResponseEndBlock{}, nil
+	}
+
+	err := checkInvariants(ctx)
+	if err == nil {
+		return types.ResponseEndBlock{}, nil
+	}
+
+	invariantViolations.Inc()
+	logger.Error("staking invariants violated",
+		"err", err,
+		"height", ctx.BlockHeight(),
+		"epoch", epoch,
+	)
+	if app.halt {
+		return types.ResponseEndBlock{}, fmt.Errorf("cometbft/stakinginvariants: %w", err)
+	}
+	return types.ResponseEndBlock{}, nil
+}
+
+// checkInvariants verifies that no staking quantity is negative and that
+// all balances, escrows and debonding escrows together with the common pool,
+// last block fees and governance deposits add up to the total supply.
+func checkInvariants(ctx *api.Context) error {
+	st := stakingState.NewMutableState(ctx.State())
+
+	totalSupply, err := st.TotalSupply(ctx)
+	if err != nil {
+		return fmt.Errorf("TotalSupply: %w", err)
+	}
+	if !totalSupply.IsValid() {
+		return fmt.Errorf("total supply %v is invalid", totalSupply)
+	}
+
+	var total quantity.Quantity
+	addresses, err := st.Addresses(ctx)
+	if err != nil {
+		return fmt.Errorf("Addresses: %w", err)
+	}
+	for _, addr := range addresses {
+		acct, aerr := st.Account(ctx, addr)
+		if aerr != nil {
+			return fmt.Errorf("Account %s: %w", addr, aerr)
+		}
+		for _, q := range []struct {
+			name string
+			q    *quantity.Quantity
+		}{
+			{"general balance", &acct.General.Balance},
+			{"escrow active balance", &acct.Escrow.Active.Balance},
+			{"escrow active total shares", &acct.Escrow.Active.TotalShares},
+			{"escrow debonding balance", &acct.Escrow.Debonding.Balance},
+			{"escrow debonding total shares", &acct.Escrow.Debonding.TotalShares},
+		} {
+			if !q.q.IsValid() {
+				return fmt.Errorf("%s of account %s is invalid", q.name, addr)
+			}
+		}
+
+		_ = total.Add(&acct.General.Balance)
+		_ = total.Add(&acct.Escrow.Active.Balance)
+		_ = total.Add(&acct.Escrow.Debonding.Balance)
+	}
+
+	for _, q := range []struct {
+		name string
+		fn   func() (*quantity.Quantity, error)
+	}{
+		{"common pool", func() (*quantity.Quantity, error) { return st.CommonPool(ctx) }},
+		{"last block fees", func() (*quantity.Quantity, error) { return st.LastBlockFees(ctx) }},
+		{"governance deposits", func() (*quantity.Quantity, error) { return st.GovernanceDeposits(ctx) }},
+	} {
+		v, qerr := q.fn()
+		if qerr != nil {
+			return fmt.Errorf("%s: %w", q.name, qerr)
+		}
+		if !v.IsValid() {
+			return fmt.Errorf("%s %v is invalid", q.name, v)
+		}
+		_ = total.Add(v)
+	}
+
+	if total.Cmp(totalSupply) != 0 {
+		return fmt.Errorf("sum of all balances (%s) does not match total supply (%s)", &total, totalSupply)
+	}
+
+	return nil
+}
+
+// New constructs a new staking invariants application instance.
+//
+// If halt is true, an invariant violation halts the node, otherwise it is
+// only logged and reported via metrics.
+func New(halt bool) api.Application {
+	metricsOnce.Do(func() {
+		prometheus.MustRegister(invariantViolations)
+	})
+
+	return &stakingInvariantsApplication{
+		halt: halt,
+	}
+}
@@ -0,0 +1,39 @@
+package stakinginvariants
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestCheckInvariants(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	st := stakingState.NewMutableState(ctx.State())
+
+	addr := staking.NewAddress(memorySigner.NewTestSigner("staking invariants test").Public())
+	var acct staking.Account
+	require.NoError(acct.General.Balance.FromUint64(100))
+	require.NoError(acct.Escrow.Active.Balance.FromUint64(200))
+	require.NoError(acct.Escrow.Debonding.Balance.FromUint64(300))
+	require.NoError(st.SetAccount(ctx, addr, &acct), "SetAccount")
+	require.NoError(st.SetCommonPool(ctx, quantity.NewFromUint64(1000)), "SetCommonPool")
+	require.NoError(st.SetLastBlockFees(ctx, quantity.NewFromUint64(10)), "SetLastBlockFees")
+	require.NoError(st.SetGovernanceDeposits(ctx, quantity.NewFromUint64(20)), "SetGovernanceDeposits")
+
+	require.NoError(st.SetTotalSupply(ctx, quantity.NewFromUint64(1630)), "SetTotalSupply")
+	require.NoError(checkInvariants(ctx), "invariants should hold")
+
+	require.NoError(st.SetTotalSupply(ctx, quantity.NewFromUint64(1631)), "SetTotalSupply")
+	require.Error(checkInvariants(ctx), "total supply mismatch should be detected")
+}
@@ -3,6 +3,7 @@ package cometbft
 import (
 	"context"
 
+	"github.com/oasisprotocol/oasis-core/go/beacon/hooks"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
@@ -38,7 +39,8 @@ func NewLightClient(
 	dataDir string,
 	genesis *genesisAPI.Document,
 	consensus consensusAPI.Backend,
+	epochHooks *hooks.Dispatcher,
 	p2p rpc.P2P,
 ) (lightAPI.ClientService, error) {
-	return light.New(ctx, dataDir, genesis, consensus, p2p)
+	return light.New(ctx, dataDir, genesis, consensus, epochHooks, p2p)
 }
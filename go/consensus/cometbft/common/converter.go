@@ -23,7 +23,11 @@ func ConsensusAddressesToCometBFT(addrs []string) ([]string, error) {
 		// string comparison to check ID equality.
 		// See: p2p/transport.go:MultiplexTransport.upgrade()
 		id := strings.ToLower(crypto.PublicKeyToCometBFT(&addr.ID).Address().String())
-		tmAddr := fmt.Sprintf("%s@%s:%d", id, addr.Address.IP, addr.Address.Port)
+		host := addr.Address.IP.String()
+		if addr.Address.IsHostname() {
+			host = addr.Address.Host
+		}
+		tmAddr := fmt.Sprintf("%s@%s:%d", id, host, addr.Address.Port)
 		tmAddrs = append(tmAddrs, tmAddr)
 	}
 	return tmAddrs, nil
@@ -51,6 +51,12 @@ type Config struct {
 	// Supplementary sanity checks configuration.
 	SupplementarySanity SupplementarySanityConfig `yaml:"supplementary_sanity,omitempty"`
 
+	// Staking invariant checks configuration.
+	StakingInvariants StakingInvariantsConfig `yaml:"staking_invariants,omitempty"`
+
+	// Election consistency checks configuration.
+	ElectionChecks ElectionChecksConfig `yaml:"election_checks,omitempty"`
+
 	// Enable CometBFT debug logs (very verbose).
 	LogDebug bool `yaml:"log_debug,omitempty"`
 
@@ -134,6 +140,32 @@ type SupplementarySanityConfig struct {
 	Interval uint64 `yaml:"interval"`
 }
 
+const (
+	// CheckActionHalt halts the node when a consensus check fails.
+	CheckActionHalt = "halt"
+	// CheckActionAlert only logs and reports consensus check failures via
+	// metrics.
+	CheckActionAlert = "alert"
+)
+
+// StakingInvariantsConfig is the staking invariant checks configuration structure.
+type StakingInvariantsConfig struct {
+	// Enable staking invariant checks at each epoch transition.
+	Enabled bool `yaml:"enabled"`
+	// Action to take on invariant violation (halt, alert).
+	Action string `yaml:"action"`
+}
+
+// ElectionChecksConfig is the election consistency checks configuration
+// structure.
+type ElectionChecksConfig struct {
+	// Enable recomputing elections at each epoch transition and comparing
+	// them with the consensus state (slows down epoch transitions).
+	Enabled bool `yaml:"enabled"`
+	// Action to take on election drift (halt, alert).
+	Action string `yaml:"action"`
+}
+
 // DebugConfig is the debug configuration structure.
 type DebugConfig struct {
 	// Allow non-routable addresses in P2P address book.
@@ -180,6 +212,22 @@ func (c *Config) Validate() error {
 	if c.SupplementarySanity.Enabled && c.SupplementarySanity.Interval < 1 {
 		return fmt.Errorf("supplementary_sanity.interval must be >= 1")
 	}
+
+	if c.StakingInvariants.Enabled {
+		switch c.StakingInvariants.Action {
+		case CheckActionHalt, CheckActionAlert:
+		default:
+			return fmt.Errorf("unknown staking_invariants.action: %s", c.StakingInvariants.Action)
+		}
+	}
+
+	if c.ElectionChecks.Enabled {
+		switch c.ElectionChecks.Action {
+		case CheckActionHalt, CheckActionAlert:
+		default:
+			return fmt.Errorf("unknown election_checks.action: %s", c.ElectionChecks.Action)
+		}
+	}
 	return nil
 }
 
@@ -228,6 +276,14 @@ func DefaultConfig() Config {
 			Enabled:  false,
 			Interval: 10,
 		},
+		StakingInvariants: StakingInvariantsConfig{
+			Enabled: false,
+			Action:  CheckActionAlert,
+		},
+		ElectionChecks: ElectionChecksConfig{
+			Enabled: false,
+			Action:  CheckActionAlert,
+		},
 		LogDebug: false,
 		Debug: DebugConfig{
 			P2PAddrBookLenient:              false,
@@ -226,7 +226,8 @@ func checkVotesOnlyDifferByTimestamp(lastSignBytes, newSignBytes []byte) (time.T
 //go:linkname checkProposalsOnlyDifferByTimestamp github.com/cometbft/cometbft/privval.checkProposalsOnlyDifferByTimestamp
 func checkProposalsOnlyDifferByTimestamp(lastSignBytes, newSignBytes []byte) (time.Time, bool)
 
-const privValFileName = "oasis_priv_validator.json"
+// PrivValFileName is the name of the file holding the consensus validator's last sign state.
+const PrivValFileName = "oasis_priv_validator.json"
 
 const (
 	// stepNone      int8 = 0
@@ -348,7 +349,7 @@ func (pv *privVal) save() error {
 // LoadOrGeneratePrivVal loads or generates a CometBFT PrivValidator for an
 // Oasis node signature signer.
 func LoadOrGeneratePrivVal(baseDir string, signer signature.Signer) (cmttypes.PrivValidator, error) {
-	fn := filepath.Join(baseDir, privValFileName)
+	fn := filepath.Join(baseDir, PrivValFileName)
 
 	pv := &privVal{
 		filePath: fn,
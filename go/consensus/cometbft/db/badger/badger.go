@@ -73,6 +73,7 @@ func New(fn string, noSuffix bool) (dbm.DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("cometbft/db/badger: failed to open database: %w", err)
 	}
+	cmnBadger.RegisterForSnapshots(db, false)
 
 	gc := cmnBadger.NewGCWorker(logger, db)
 	gc.Start()
@@ -24,6 +24,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction/results"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	tmcommon "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/common"
@@ -138,6 +139,11 @@ func (srv *archiveService) EstimateGas(context.Context, *consensusAPI.EstimateGa
 	return 0, consensusAPI.ErrUnsupported
 }
 
+// Implements consensusAPI.Backend.
+func (srv *archiveService) SimulateTx(context.Context, *transaction.SignedTransaction) (*results.Result, error) {
+	return nil, consensusAPI.ErrUnsupported
+}
+
 // Implements consensusAPI.Backend.
 func (srv *archiveService) GetSignerNonce(context.Context, *consensusAPI.GetSignerNonceRequest) (uint64, error) {
 	return 0, consensusAPI.ErrUnsupported
@@ -8,6 +8,7 @@ import (
 	"sync/atomic"
 
 	dbm "github.com/cometbft/cometbft-db"
+	cmtabcitypes "github.com/cometbft/cometbft/abci/types"
 	cmtmerkle "github.com/cometbft/cometbft/crypto/merkle"
 	cmtcore "github.com/cometbft/cometbft/rpc/core"
 	cmtcoretypes "github.com/cometbft/cometbft/rpc/core/types"
@@ -33,9 +34,12 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci"
 	coreState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	schedulerApp "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/stakinginvariants"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/supplementarysanity"
 	tmbeacon "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/beacon"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/common"
+	cmtConfig "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/config"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/crypto"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/db"
 	tmgovernance "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/governance"
@@ -245,7 +249,11 @@ func (n *commonNode) initialize() error {
 	n.svcMgr.RegisterCleanupOnly(n.staking, "staking backend")
 
 	var scScheduler tmscheduler.ServiceClient
-	if scScheduler, err = tmscheduler.New(n.parentNode); err != nil {
+	electionChecks := config.GlobalConfig.Consensus.ElectionChecks
+	if scScheduler, err = tmscheduler.New(n.parentNode, schedulerApp.Config{
+		CheckElections: electionChecks.Enabled,
+		HaltOnDrift:    electionChecks.Action == cmtConfig.CheckActionHalt,
+	}); err != nil {
 		n.Logger.Error("scheduler: failed to initialize scheduler backend",
 			"err", err,
 		)
@@ -296,6 +304,14 @@ func (n *commonNode) initialize() error {
 		}
 	}
 
+	// Enable staking invariant checks when enabled.
+	if cfg := config.GlobalConfig.Consensus.StakingInvariants; cfg.Enabled {
+		sia := stakinginvariants.New(cfg.Action == cmtConfig.CheckActionHalt)
+		if err = n.RegisterApplication(sia); err != nil {
+			return fmt.Errorf("failed to register staking invariants app: %w", err)
+		}
+	}
+
 	atomic.StoreUint32(&n.state, stateInitialized)
 
 	return nil
@@ -496,6 +512,22 @@ func (n *commonNode) EstimateGas(_ context.Context, req *consensusAPI.EstimateGa
 	return n.mux.EstimateGas(req.Signer, req.Transaction)
 }
 
+// Implements consensusAPI.Backend.
+func (n *commonNode) SimulateTx(_ context.Context, tx *transaction.SignedTransaction) (*results.Result, error) {
+	if tx == nil {
+		return nil, consensusAPI.ErrInvalidArgument
+	}
+
+	rawTx := cbor.Marshal(tx)
+	rs, err := n.mux.SimulateTx(rawTx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Events are reported as if the transaction was included in the next block.
+	return resultFromCometBFT(rawTx, n.mux.State().BlockHeight()+1, rs)
+}
+
 // Implements consensusAPI.Backend.
 func (n *commonNode) MinGasPrice(ctx context.Context) (*quantity.Quantity, error) {
 	cs, err := coreState.NewImmutableState(ctx, n.mux.State(), consensusAPI.HeightLatest)
@@ -693,71 +725,65 @@ func (n *commonNode) GetTransactionsWithResults(ctx context.Context, height int6
 		return nil, err
 	}
 	for txIdx, rs := range res.TxsResults {
-		// Transaction result.
-		result := &results.Result{
-			Error: results.Error{
-				Module:  rs.GetCodespace(),
-				Code:    rs.GetCode(),
-				Message: rs.GetLog(),
-			},
-			GasUsed: uint64(rs.GetGasUsed()),
-		}
-
-		// Transaction staking events.
-		stakingEvents, err := tmstaking.EventsFromCometBFT(
-			txsWithResults.Transactions[txIdx],
-			blk.Height,
-			rs.Events,
-		)
+		result, err := resultFromCometBFT(txsWithResults.Transactions[txIdx], blk.Height, rs)
 		if err != nil {
 			return nil, err
 		}
-		for _, e := range stakingEvents {
-			result.Events = append(result.Events, &results.Event{Staking: e})
-		}
+		txsWithResults.Results = append(txsWithResults.Results, result)
+	}
+	return &txsWithResults, nil
+}
 
-		// Transaction registry events.
-		registryEvents, _, err := tmregistry.EventsFromCometBFT(
-			txsWithResults.Transactions[txIdx],
-			blk.Height,
-			rs.Events,
-		)
-		if err != nil {
-			return nil, err
-		}
-		for _, e := range registryEvents {
-			result.Events = append(result.Events, &results.Event{Registry: e})
-		}
+// resultFromCometBFT converts a CometBFT transaction execution result into a consensus
+// transaction result.
+func resultFromCometBFT(tx []byte, height int64, rs *cmtabcitypes.ResponseDeliverTx) (*results.Result, error) {
+	// Transaction result.
+	result := &results.Result{
+		Error: results.Error{
+			Module:  rs.GetCodespace(),
+			Code:    rs.GetCode(),
+			Message: rs.GetLog(),
+		},
+		GasUsed: uint64(rs.GetGasUsed()),
+	}
 
-		// Transaction roothash events.
-		roothashEvents, err := tmroothash.EventsFromCometBFT(
-			txsWithResults.Transactions[txIdx],
-			blk.Height,
-			rs.Events,
-		)
-		if err != nil {
-			return nil, err
-		}
-		for _, e := range roothashEvents {
-			result.Events = append(result.Events, &results.Event{RootHash: e})
-		}
+	// Transaction staking events.
+	stakingEvents, err := tmstaking.EventsFromCometBFT(tx, height, rs.Events)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range stakingEvents {
+		result.Events = append(result.Events, &results.Event{Staking: e})
+	}
 
-		// Transaction governance events.
-		governanceEvents, err := tmgovernance.EventsFromCometBFT(
-			txsWithResults.Transactions[txIdx],
-			blk.Height,
-			rs.Events,
-		)
-		if err != nil {
-			return nil, err
-		}
-		for _, e := range governanceEvents {
-			result.Events = append(result.Events, &results.Event{Governance: e})
-		}
+	// Transaction registry events.
+	registryEvents, _, err := tmregistry.EventsFromCometBFT(tx, height, rs.Events)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range registryEvents {
+		result.Events = append(result.Events, &results.Event{Registry: e})
+	}
 
-		txsWithResults.Results = append(txsWithResults.Results, result)
+	// Transaction roothash events.
+	roothashEvents, err := tmroothash.EventsFromCometBFT(tx, height, rs.Events)
+	if err != nil {
+		return nil, err
 	}
-	return &txsWithResults, nil
+	for _, e := range roothashEvents {
+		result.Events = append(result.Events, &results.Event{RootHash: e})
+	}
+
+	// Transaction governance events.
+	governanceEvents, err := tmgovernance.EventsFromCometBFT(tx, height, rs.Events)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range governanceEvents {
+		result.Events = append(result.Events, &results.Event{Governance: e})
+	}
+
+	return result, nil
 }
 
 // Implements consensusAPI.Backend.
@@ -960,6 +986,11 @@ func (n *commonNode) SubmissionManager() consensusAPI.SubmissionManager {
 	return &consensusAPI.NoOpSubmissionManager{}
 }
 
+// Implements consensusAPI.Backend.
+func (n *commonNode) FenceCommits(ctx context.Context) (func(), error) {
+	return n.mux.FenceCommits(ctx)
+}
+
 // Implements consensusAPI.Backend.
 func (n *commonNode) RegisterP2PService(p2pAPI.Service) error {
 	return consensusAPI.ErrUnsupported
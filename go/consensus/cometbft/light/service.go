@@ -16,6 +16,8 @@ import (
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	cmttypes "github.com/cometbft/cometbft/types"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/beacon/hooks"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/config"
@@ -47,9 +49,10 @@ type client struct {
 
 	logger *logging.Logger
 
-	genesis   *genesisAPI.Document
-	consensus consensus.Backend
-	p2p       rpc.P2P
+	genesis    *genesisAPI.Document
+	consensus  consensus.Backend
+	epochHooks *hooks.Dispatcher
+	p2p        rpc.P2P
 
 	store cmtlightstore.Store
 
@@ -154,7 +157,7 @@ func (c *client) worker() {
 	tmChainID := cmtAPI.CometBFTChainID(chainCtx)
 
 	// Loads the local block at the provided height and adds it to the trust store.
-	trustLocalBlock := func(ctx context.Context, height int64) error {
+	trustLocalBlock := func(ctx context.Context, height int64) (err error) {
 		var lb *consensus.LightBlock
 		if lb, err = c.consensus.GetLightBlock(ctx, height); err != nil {
 			return fmt.Errorf("failed to obtain block %d from consensus: %w", height, err)
@@ -236,25 +239,23 @@ func (c *client) worker() {
 	c.lc = &lightClient{tmc: tmc}
 	c.initOnce.Do(func() { close(c.initCh) })
 
-	// Watch epochs and insert new trusted blocks on every epoch transition.
-	ch, sub, err := c.consensus.Beacon().WatchEpochs(c.ctx)
-	if err != nil {
-		c.logger.Error("failed to watch epochs", "err", err)
-		return
-	}
-	defer sub.Close()
-	for {
+	// Insert new trusted blocks on every epoch transition.
+	err = c.epochHooks.Register("consensus/light/trust", hooks.OrderLightClient, func(ctx context.Context, _ beacon.EpochTime) error {
 		select {
 		case <-c.stopCh:
-			return
-		case <-c.ctx.Done():
-			return
-		case <-ch:
-			if err = trustLocalBlock(c.ctx, consensus.HeightLatest); err != nil {
-				c.logger.Error("failed to store light block into trust store", "err", err)
-				continue
-			}
+			return nil
+		default:
 		}
+		return trustLocalBlock(ctx, consensus.HeightLatest)
+	})
+	if err != nil {
+		c.logger.Error("failed to register epoch hook", "err", err)
+		return
+	}
+
+	select {
+	case <-c.stopCh:
+	case <-c.ctx.Done():
 	}
 }
 
@@ -380,7 +381,14 @@ func (c *client) GetVerifiedParameters(ctx context.Context, height int64) (*cmtp
 // New creates a new CometBFT light client service backed by the local full node.
 //
 // This light client is initialized with a trusted blocks obtained from the local consensus backend.
-func New(ctx context.Context, dataDir string, genesis *genesisAPI.Document, c consensus.Backend, p2p rpc.P2P) (api.ClientService, error) {
+func New(
+	ctx context.Context,
+	dataDir string,
+	genesis *genesisAPI.Document,
+	c consensus.Backend,
+	epochHooks *hooks.Dispatcher,
+	p2p rpc.P2P,
+) (api.ClientService, error) {
 	tdb, err := db.New(filepath.Join(dataDir, dbName), false)
 	if err != nil {
 		return nil, err
@@ -388,15 +396,16 @@ func New(ctx context.Context, dataDir string, genesis *genesisAPI.Document, c co
 	store := cmtlightdb.New(dbm.NewPrefixDB(tdb, []byte{}), "")
 
 	return &client{
-		ctx:       ctx,
-		enabled:   c.SupportedFeatures().Has(consensus.FeatureFullNode),
-		logger:    logging.GetLogger("consensus/cometbft/light"),
-		genesis:   genesis,
-		consensus: c,
-		p2p:       p2p,
-		store:     store,
-		initCh:    make(chan struct{}),
-		stopCh:    make(chan struct{}),
-		quitCh:    make(chan struct{}),
+		ctx:        ctx,
+		enabled:    c.SupportedFeatures().Has(consensus.FeatureFullNode),
+		logger:     logging.GetLogger("consensus/cometbft/light"),
+		genesis:    genesis,
+		consensus:  c,
+		epochHooks: epochHooks,
+		p2p:        p2p,
+		store:      store,
+		initCh:     make(chan struct{}),
+		stopCh:     make(chan struct{}),
+		quitCh:     make(chan struct{}),
 	}, nil
 }
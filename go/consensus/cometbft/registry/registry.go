@@ -13,6 +13,7 @@ import (
 	"github.com/eapache/channels"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
@@ -38,11 +39,12 @@ type serviceClient struct {
 	backend tmapi.Backend
 	querier *app.QueryFactory
 
-	entityNotifier   *pubsub.Broker
-	nodeNotifier     *pubsub.Broker
-	nodeListNotifier *pubsub.Broker
-	runtimeNotifier  *pubsub.Broker
-	eventNotifier    *pubsub.Broker
+	entityNotifier         *pubsub.Broker
+	nodeNotifier           *pubsub.Broker
+	nodeListNotifier       *pubsub.Broker
+	nodeExpirationNotifier *pubsub.Broker
+	runtimeNotifier        *pubsub.Broker
+	eventNotifier          *pubsub.Broker
 }
 
 // NodeListEpochInternalEvent is the per-epoch node list event.
@@ -132,6 +134,14 @@ func (sc *serviceClient) WatchNodeList(context.Context) (<-chan *api.NodeList, p
 	return typedCh, sub, nil
 }
 
+func (sc *serviceClient) WatchNodeExpirations(context.Context) (<-chan *api.NodeExpirations, pubsub.ClosableSubscription, error) {
+	typedCh := make(chan *api.NodeExpirations)
+	sub := sc.nodeExpirationNotifier.Subscribe()
+	sub.Unwrap(typedCh)
+
+	return typedCh, sub, nil
+}
+
 func (sc *serviceClient) GetRuntime(ctx context.Context, query *api.GetRuntimeQuery) (*api.Runtime, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
@@ -237,6 +247,14 @@ func (sc *serviceClient) ConsensusParameters(ctx context.Context, height int64)
 	return q.ConsensusParameters(ctx)
 }
 
+func (sc *serviceClient) GetEntityWhitelist(ctx context.Context, height int64) ([]signature.PublicKey, error) {
+	q, err := sc.querier.QueryAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return q.EntityWhitelist(ctx)
+}
+
 // Implements api.ServiceClient.
 func (sc *serviceClient) ServiceDescriptor() tmapi.ServiceDescriptor {
 	return tmapi.NewStaticServiceDescriptor(api.ModuleName, app.EventType, []cmtpubsub.Query{app.QueryApp})
@@ -260,6 +278,16 @@ func (sc *serviceClient) DeliverEvent(ctx context.Context, height int64, tx cmtt
 			continue
 		}
 		sc.nodeListNotifier.Broadcast(nl)
+
+		ne, err := sc.getNodeExpirations(ctx, height, nl)
+		if err != nil {
+			sc.logger.Error("worker: failed to get node expirations",
+				"height", ev.Height,
+				"err", err,
+			)
+			continue
+		}
+		sc.nodeExpirationNotifier.Broadcast(ne)
 	}
 
 	// Notify subscribers of events.
@@ -354,6 +382,14 @@ func EventsFromCometBFT(
 					continue
 				}
 				events = append(events, &api.Event{Height: height, TxHash: txHash, NodeUnfrozenEvent: &e})
+			case eventsAPI.IsAttributeKind(key, &api.EntityWhitelistEvent{}):
+				// Entity whitelist event.
+				var e api.EntityWhitelistEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("registry: corrupt EntityWhitelist event: %w", err))
+					continue
+				}
+				events = append(events, &api.Event{Height: height, TxHash: txHash, EntityWhitelistEvent: &e})
 			}
 		}
 	}
@@ -379,6 +415,18 @@ func (sc *serviceClient) getNodeList(ctx context.Context, height int64) (*api.No
 	}, nil
 }
 
+func (sc *serviceClient) getNodeExpirations(ctx context.Context, height int64, nl *api.NodeList) (*api.NodeExpirations, error) {
+	epoch, err := sc.backend.Beacon().GetEpoch(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("registry: failed to query epoch: %w", err)
+	}
+
+	return &api.NodeExpirations{
+		Epoch: epoch,
+		Nodes: api.ExpiringNodes(nl.Nodes, epoch),
+	}, nil
+}
+
 // New constructs a new CometBFT backed registry Backend instance.
 func New(ctx context.Context, backend tmapi.Backend) (ServiceClient, error) {
 	// Initialize and register the CometBFT service component.
@@ -407,6 +455,25 @@ func New(ctx context.Context, backend tmapi.Backend) (ServiceClient, error) {
 
 		wr <- nodeList
 	})
+	sc.nodeExpirationNotifier = pubsub.NewBrokerEx(func(ch channels.Channel) {
+		wr := ch.In()
+		nodeList, err := sc.getNodeList(ctx, consensus.HeightLatest)
+		if err != nil {
+			sc.logger.Error("node expiration notifier: unable to get a list of nodes",
+				"err", err,
+			)
+			return
+		}
+		expirations, err := sc.getNodeExpirations(ctx, consensus.HeightLatest, nodeList)
+		if err != nil {
+			sc.logger.Error("node expiration notifier: unable to get node expirations",
+				"err", err,
+			)
+			return
+		}
+
+		wr <- expirations
+	})
 	sc.runtimeNotifier = pubsub.NewBrokerEx(func(ch channels.Channel) {
 		wr := ch.In()
 		runtimes, err := sc.GetRuntimes(ctx, &api.GetRuntimesQuery{Height: consensus.HeightLatest, IncludeSuspended: true})
@@ -24,6 +24,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
+	"github.com/oasisprotocol/oasis-core/go/runtime/history"
 	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
 )
 
@@ -70,6 +71,7 @@ type serviceClient struct {
 	allBlockNotifier *pubsub.Broker
 	runtimeNotifiers map[common.Namespace]*runtimeBrokers
 	genesisBlocks    map[common.Namespace]*block.Block
+	blockHistories   map[common.Namespace]api.BlockHistory
 
 	queryCh        chan cmtpubsub.Query
 	cmdCh          chan interface{}
@@ -195,6 +197,18 @@ func (sc *serviceClient) WatchBlocks(_ context.Context, id common.Namespace) (<-
 	return ch, sub, nil
 }
 
+// Implements api.Backend.
+func (sc *serviceClient) WatchBlocksFrom(ctx context.Context, request *api.WatchBlocksFromRequest) (<-chan *api.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	sc.RLock()
+	bh := sc.blockHistories[request.RuntimeID]
+	sc.RUnlock()
+	if bh == nil {
+		return nil, nil, api.ErrNoBlockHistory
+	}
+
+	return history.WatchBlocksSince(ctx, bh, request.Round, sc.logger)
+}
+
 func (sc *serviceClient) WatchAllBlocks() (<-chan *block.Block, *pubsub.Subscription) {
 	sub := sc.allBlockNotifier.Subscribe()
 	ch := make(chan *block.Block)
@@ -236,15 +250,20 @@ func (sc *serviceClient) WatchExecutorCommitments(_ context.Context, id common.N
 }
 
 // Implements api.Backend.
-func (sc *serviceClient) TrackRuntime(ctx context.Context, history api.BlockHistory) error {
-	sc.pruneHandler.trackRuntime(history)
-	return sc.trackRuntime(ctx, history.RuntimeID(), history)
+func (sc *serviceClient) TrackRuntime(ctx context.Context, bh api.BlockHistory) error {
+	sc.pruneHandler.trackRuntime(bh)
+
+	sc.Lock()
+	sc.blockHistories[bh.RuntimeID()] = bh
+	sc.Unlock()
+
+	return sc.trackRuntime(ctx, bh.RuntimeID(), bh)
 }
 
-func (sc *serviceClient) trackRuntime(ctx context.Context, id common.Namespace, history api.BlockHistory) error {
+func (sc *serviceClient) trackRuntime(ctx context.Context, id common.Namespace, bh api.BlockHistory) error {
 	cmd := &cmdTrackRuntime{
 		runtimeID:    id,
-		blockHistory: history,
+		blockHistory: bh,
 	}
 
 	select {
@@ -903,6 +922,7 @@ func New(
 		allBlockNotifier: pubsub.NewBroker(false),
 		runtimeNotifiers: make(map[common.Namespace]*runtimeBrokers),
 		genesisBlocks:    make(map[common.Namespace]*block.Block),
+		blockHistories:   make(map[common.Namespace]api.BlockHistory),
 		queryCh:          make(chan cmtpubsub.Query, runtimeRegistry.MaxRuntimeCount),
 		cmdCh:            make(chan interface{}, runtimeRegistry.MaxRuntimeCount),
 		trackedRuntime:   make(map[common.Namespace]*trackedRuntime),
@@ -0,0 +1,76 @@
+package roothash
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/history"
+)
+
+const recvTimeout = 5 * time.Second
+
+func TestWatchBlocksFrom(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("roothash watch blocks from test ns"), 0)
+	sc := &serviceClient{
+		blockHistories: make(map[common.Namespace]api.BlockHistory),
+		logger:         logging.GetLogger("cometbft/roothash/test"),
+	}
+
+	// Runtimes without a tracked block history should be rejected.
+	_, _, err := sc.WatchBlocksFrom(ctx, &api.WatchBlocksFromRequest{RuntimeID: runtimeID})
+	require.ErrorIs(err, api.ErrNoBlockHistory, "WatchBlocksFrom should fail without block history")
+
+	h, err := history.New(runtimeID, t.TempDir(), history.NewNonePrunerFactory(), false)
+	require.NoError(err, "history.New")
+	defer h.Close()
+	sc.blockHistories[runtimeID] = h
+
+	commit := func(round uint64, notify bool) {
+		blk := block.NewGenesisBlock(runtimeID, 0)
+		blk.Header.Round = round
+		err = h.Commit(&api.AnnotatedBlock{Height: int64(round), Block: blk}, notify)
+		require.NoError(err, "Commit")
+	}
+	expectRounds := func(ch <-chan *api.AnnotatedBlock, rounds ...uint64) {
+		for _, round := range rounds {
+			select {
+			case blk := <-ch:
+				require.EqualValues(round, blk.Block.Header.Round)
+			case <-time.After(recvTimeout):
+				t.Fatalf("failed to receive block %d", round)
+			}
+		}
+	}
+
+	for round := uint64(0); round < 3; round++ {
+		commit(round, false)
+	}
+
+	ch, sub, err := sc.WatchBlocksFrom(ctx, &api.WatchBlocksFromRequest{RuntimeID: runtimeID, Round: 1})
+	require.NoError(err, "WatchBlocksFrom")
+	defer sub.Close()
+
+	// Historic blocks should be replayed first.
+	expectRounds(ch, 1, 2)
+
+	// Live blocks should follow without gaps or duplicates.
+	commit(3, true)
+	commit(4, false)
+	commit(5, true)
+	expectRounds(ch, 3, 4, 5)
+	select {
+	case blk := <-ch:
+		t.Fatalf("unexpected block %d", blk.Block.Header.Round)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -142,9 +142,9 @@ func (sc *serviceClient) DeliverEvent(ctx context.Context, height int64, _ cmtty
 }
 
 // New constructs a new CometBFT-based scheduler Backend instance.
-func New(backend tmapi.Backend) (ServiceClient, error) {
+func New(backend tmapi.Backend, cfg app.Config) (ServiceClient, error) {
 	// Initialze and register the CometBFT service component.
-	a := app.New()
+	a := app.New(cfg)
 	if err := backend.RegisterApplication(a); err != nil {
 		return nil, err
 	}
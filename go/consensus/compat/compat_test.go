@@ -0,0 +1,172 @@
+package compat
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+var (
+	testEntityID = signature.NewPublicKey("1111111111111111111111111111111111111111111111111111111111111111")
+	testNodeID   = signature.NewPublicKey("2222222222222222222222222222222222222222222222222222222222222222")
+	testOtherID  = signature.NewPublicKey("3333333333333333333333333333333333333333333333333333333333333333")
+
+	testRuntimeID = common.NewTestNamespaceFromSeed([]byte("compat runtime"), 0)
+)
+
+// fixture is a serialized consensus object fixture.
+type fixture struct {
+	// name is the name of the fixture file.
+	name string
+	// object returns the object that was used to generate the fixture, it must match the one
+	// in testdata/gen.
+	object func() interface{}
+}
+
+var fixtures = []fixture{
+	{
+		name: "block_header.bin",
+		object: func() interface{} {
+			return &block.Header{
+				Version:        1,
+				Namespace:      testRuntimeID,
+				Round:          42,
+				Timestamp:      1700000000,
+				HeaderType:     block.Normal,
+				PreviousHash:   hash.NewFromBytes([]byte("previous")),
+				IORoot:         hash.NewFromBytes([]byte("io")),
+				StateRoot:      hash.NewFromBytes([]byte("state")),
+				MessagesHash:   hash.NewFromBytes([]byte("messages")),
+				InMessagesHash: hash.NewFromBytes([]byte("in messages")),
+			}
+		},
+	},
+	{
+		name: "entity_descriptor.bin",
+		object: func() interface{} {
+			return &entity.Entity{
+				Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+				ID:        testEntityID,
+				Nodes:     []signature.PublicKey{testNodeID},
+			}
+		},
+	},
+	{
+		name: "node_descriptor.bin",
+		object: func() interface{} {
+			addr := node.Address{IP: net.ParseIP("192.0.2.1"), Port: 26656}
+			return &node.Node{
+				Versioned:  cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+				ID:         testNodeID,
+				EntityID:   testEntityID,
+				Expiration: 32,
+				TLS:        node.TLSInfo{PubKey: testOtherID},
+				P2P: node.P2PInfo{
+					ID:        testOtherID,
+					Addresses: []node.Address{addr},
+				},
+				Consensus: node.ConsensusInfo{
+					ID:        testOtherID,
+					Addresses: []node.ConsensusAddress{{ID: testOtherID, Address: addr}},
+				},
+				VRF: node.VRFInfo{ID: testOtherID},
+				Runtimes: []*node.Runtime{
+					{
+						ID:      testRuntimeID,
+						Version: version.Version{Major: 1, Minor: 2, Patch: 3},
+					},
+				},
+				Roles:           node.RoleComputeWorker | node.RoleValidator,
+				SoftwareVersion: node.SoftwareVersion("1.2.3"),
+			}
+		},
+	},
+	{
+		name: "runtime_descriptor.bin",
+		object: func() interface{} {
+			return &registry.Runtime{
+				Versioned:   cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+				ID:          testRuntimeID,
+				EntityID:    testEntityID,
+				Kind:        registry.KindCompute,
+				TEEHardware: node.TEEHardwareInvalid,
+				Executor: registry.ExecutorParameters{
+					GroupSize:            3,
+					RoundTimeout:         10,
+					MaxMessages:          32,
+					MinLiveRoundsPercent: 90,
+				},
+				TxnScheduler: registry.TxnSchedulerParameters{
+					MaxBatchSize:      100,
+					MaxBatchSizeBytes: 1024,
+				},
+				Storage: registry.StorageParameters{
+					CheckpointInterval:  100,
+					CheckpointNumKept:   2,
+					CheckpointChunkSize: 1024,
+				},
+				AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+					AnyNode: &registry.AnyNodeRuntimeAdmissionPolicy{},
+				},
+				GovernanceModel: registry.GovernanceEntity,
+				Deployments: []*registry.VersionInfo{
+					{Version: version.Version{Major: 1}},
+				},
+			}
+		},
+	},
+	{
+		name: "transfer_transaction.bin",
+		object: func() interface{} {
+			return transaction.NewTransaction(
+				7,
+				&transaction.Fee{Amount: *quantity.NewFromUint64(100), Gas: 1000},
+				staking.MethodTransfer,
+				&staking.Transfer{
+					To:     staking.NewAddress(testOtherID),
+					Amount: *quantity.NewFromUint64(1_000_000),
+				},
+			)
+		},
+	},
+}
+
+func TestSerializationCompatibility(t *testing.T) {
+	for _, f := range fixtures {
+		t.Run(f.name, func(t *testing.T) {
+			require := require.New(t)
+			path := filepath.Join("testdata", f.name)
+
+			raw, err := os.ReadFile(path)
+			require.NoError(err, "ReadFile")
+
+			// Objects serialized by the previous release must be decodable by the current code.
+			obj := f.object()
+			decoded := reflect.New(reflect.TypeOf(obj).Elem()).Interface()
+			err = cbor.Unmarshal(raw, decoded)
+			require.NoError(err, "decoding fixture produced by the previous release should succeed")
+			require.EqualValues(obj, decoded, "decoded fixture should match the expected object")
+
+			// Objects serialized by the current code must be decodable by the previous release.
+			require.Equal(raw, cbor.Marshal(decoded), "re-encoded fixture should match the previous release")
+			require.Equal(raw, cbor.Marshal(obj), "encoded object should match the previous release")
+		})
+	}
+}
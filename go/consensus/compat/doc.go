@@ -0,0 +1,23 @@
+// Package compat contains the cross-version serialization compatibility tests
+// of consensus objects.
+//
+// The testdata directory contains fixtures of serialized consensus objects as
+// produced by the previous release. The tests make sure that the current code
+// is able to decode them and that it serializes the decoded objects back into
+// exactly the same bytes so that the previous release is able to decode
+// objects produced by the current code.
+//
+// The fixtures must never be generated by the current code, as that would make
+// the tests pass trivially. Instead, they are generated by the testdata/gen
+// command built against the previous release. After a release, regenerate them
+// from the root of the repository by running:
+//
+//	git worktree add /tmp/oasis-core-prev <previous-release-tag>
+//	cp -r go/consensus/compat/testdata/gen /tmp/oasis-core-prev/go/consensus/compat/testdata/
+//	(cd /tmp/oasis-core-prev/go && go run ./consensus/compat/testdata/gen -out "$OLDPWD/go/consensus/compat/testdata")
+//	git worktree remove --force /tmp/oasis-core-prev
+//
+// The objects in testdata/gen must match the ones in the tests. The current
+// fixtures were generated at commit 7f3a7d7, the revision the current
+// development cycle is based on.
+package compat
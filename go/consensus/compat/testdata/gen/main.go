@@ -0,0 +1,134 @@
+// Command gen generates the serialization fixtures used by the compat tests.
+//
+// It must be built against the code of the previous release, see the compat package
+// documentation for details. The objects must match the ones in compat_test.go.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+var outDir = flag.String("out", ".", "directory to write the fixtures to")
+
+var (
+	testEntityID = signature.NewPublicKey("1111111111111111111111111111111111111111111111111111111111111111")
+	testNodeID   = signature.NewPublicKey("2222222222222222222222222222222222222222222222222222222222222222")
+	testOtherID  = signature.NewPublicKey("3333333333333333333333333333333333333333333333333333333333333333")
+
+	testRuntimeID = common.NewTestNamespaceFromSeed([]byte("compat runtime"), 0)
+)
+
+func fixtures() map[string]interface{} {
+	addr := node.Address{IP: net.ParseIP("192.0.2.1"), Port: 26656}
+
+	return map[string]interface{}{
+		"block_header.bin": &block.Header{
+			Version:        1,
+			Namespace:      testRuntimeID,
+			Round:          42,
+			Timestamp:      1700000000,
+			HeaderType:     block.Normal,
+			PreviousHash:   hash.NewFromBytes([]byte("previous")),
+			IORoot:         hash.NewFromBytes([]byte("io")),
+			StateRoot:      hash.NewFromBytes([]byte("state")),
+			MessagesHash:   hash.NewFromBytes([]byte("messages")),
+			InMessagesHash: hash.NewFromBytes([]byte("in messages")),
+		},
+		"entity_descriptor.bin": &entity.Entity{
+			Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+			ID:        testEntityID,
+			Nodes:     []signature.PublicKey{testNodeID},
+		},
+		"node_descriptor.bin": &node.Node{
+			Versioned:  cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+			ID:         testNodeID,
+			EntityID:   testEntityID,
+			Expiration: 32,
+			TLS:        node.TLSInfo{PubKey: testOtherID},
+			P2P: node.P2PInfo{
+				ID:        testOtherID,
+				Addresses: []node.Address{addr},
+			},
+			Consensus: node.ConsensusInfo{
+				ID:        testOtherID,
+				Addresses: []node.ConsensusAddress{{ID: testOtherID, Address: addr}},
+			},
+			VRF: node.VRFInfo{ID: testOtherID},
+			Runtimes: []*node.Runtime{
+				{
+					ID:      testRuntimeID,
+					Version: version.Version{Major: 1, Minor: 2, Patch: 3},
+				},
+			},
+			Roles:           node.RoleComputeWorker | node.RoleValidator,
+			SoftwareVersion: node.SoftwareVersion("1.2.3"),
+		},
+		"runtime_descriptor.bin": &registry.Runtime{
+			Versioned:   cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+			ID:          testRuntimeID,
+			EntityID:    testEntityID,
+			Kind:        registry.KindCompute,
+			TEEHardware: node.TEEHardwareInvalid,
+			Executor: registry.ExecutorParameters{
+				GroupSize:            3,
+				RoundTimeout:         10,
+				MaxMessages:          32,
+				MinLiveRoundsPercent: 90,
+			},
+			TxnScheduler: registry.TxnSchedulerParameters{
+				MaxBatchSize:      100,
+				MaxBatchSizeBytes: 1024,
+			},
+			Storage: registry.StorageParameters{
+				CheckpointInterval:  100,
+				CheckpointNumKept:   2,
+				CheckpointChunkSize: 1024,
+			},
+			AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+				AnyNode: &registry.AnyNodeRuntimeAdmissionPolicy{},
+			},
+			GovernanceModel: registry.GovernanceEntity,
+			Deployments: []*registry.VersionInfo{
+				{Version: version.Version{Major: 1}},
+			},
+		},
+		"transfer_transaction.bin": transaction.NewTransaction(
+			7,
+			&transaction.Fee{Amount: *quantity.NewFromUint64(100), Gas: 1000},
+			staking.MethodTransfer,
+			&staking.Transfer{
+				To:     staking.NewAddress(testOtherID),
+				Amount: *quantity.NewFromUint64(1_000_000),
+			},
+		),
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	for name, obj := range fixtures() {
+		path := filepath.Join(*outDir, name)
+		if err := os.WriteFile(path, cbor.Marshal(obj), 0o644); err != nil { // nolint: gosec
+			fmt.Fprintf(os.Stderr, "failed to write fixture %s: %s\n", path, err)
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,343 @@
+// Package eventbus implements an in-process consensus event bus.
+//
+// Instead of each node-internal subsystem independently watching the various
+// consensus backends, the event bus watches all of them and republishes their
+// events as a single typed stream. Subscribers can use filters to only
+// receive the events they are interested in.
+package eventbus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cenkalti/backoff/v4"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	cmnBackoff "github.com/oasisprotocol/oasis-core/go/common/backoff"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/service"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+var _ service.BackgroundService = (*Bus)(nil)
+
+// Kind is the kind of an event.
+type Kind uint8
+
+const (
+	// KindInvalid is an invalid event kind.
+	KindInvalid Kind = iota
+	// KindBlock is the kind of consensus block events.
+	KindBlock
+	// KindEpoch is the kind of epoch transition events.
+	KindEpoch
+	// KindElection is the kind of committee election events.
+	KindElection
+	// KindRegistry is the kind of registry events.
+	KindRegistry
+	// KindStaking is the kind of staking events.
+	KindStaking
+	// KindGovernance is the kind of governance events.
+	KindGovernance
+)
+
+// String returns a string representation of the event kind.
+func (k Kind) String() string {
+	switch k {
+	case KindBlock:
+		return "block"
+	case KindEpoch:
+		return "epoch"
+	case KindElection:
+		return "election"
+	case KindRegistry:
+		return "registry"
+	case KindStaking:
+		return "staking"
+	case KindGovernance:
+		return "governance"
+	default:
+		return fmt.Sprintf("[unknown kind: %d]", k)
+	}
+}
+
+// Event is a consensus event published on the event bus.
+//
+// Exactly one of the fields is set.
+type Event struct {
+	// Block is a finalized consensus block.
+	Block *consensus.Block
+	// Epoch is the new epoch after an epoch transition.
+	Epoch *beacon.EpochTime
+	// Election is a newly elected committee.
+	Election *scheduler.Committee
+	// Registry is a registry event.
+	Registry *registry.Event
+	// Staking is a staking event.
+	Staking *staking.Event
+	// Governance is a governance event.
+	Governance *governance.Event
+}
+
+// Kind returns the kind of the event.
+func (e *Event) Kind() Kind {
+	switch {
+	case e.Block != nil:
+		return KindBlock
+	case e.Epoch != nil:
+		return KindEpoch
+	case e.Election != nil:
+		return KindElection
+	case e.Registry != nil:
+		return KindRegistry
+	case e.Staking != nil:
+		return KindStaking
+	case e.Governance != nil:
+		return KindGovernance
+	default:
+		return KindInvalid
+	}
+}
+
+// Filter is a subscription filter which returns true iff the event should be delivered to the
+// subscriber.
+type Filter func(*Event) bool
+
+// KindFilter returns a filter which only accepts events of the given kinds.
+func KindFilter(kinds ...Kind) Filter {
+	accepted := make(map[Kind]bool, len(kinds))
+	for _, k := range kinds {
+		accepted[k] = true
+	}
+	return func(ev *Event) bool {
+		return accepted[ev.Kind()]
+	}
+}
+
+// Bus is the consensus event bus.
+type Bus struct {
+	consensus consensus.Backend
+
+	notifier *pubsub.Broker
+
+	ctx       context.Context
+	cancelCtx context.CancelFunc
+	quitCh    chan struct{}
+
+	logger *logging.Logger
+}
+
+// Subscribe subscribes to events accepted by the given filter. A nil filter accepts all events.
+//
+// Closing the returned subscription releases all associated resources.
+func (b *Bus) Subscribe(filter Filter) (<-chan *Event, pubsub.ClosableSubscription) {
+	ctx, csub := pubsub.NewContextSubscription(b.ctx)
+	sub := b.notifier.Subscribe()
+	subCh := make(chan *Event)
+	sub.Unwrap(subCh)
+
+	ch := make(chan *Event)
+	go func() {
+		defer close(ch)
+		defer sub.Close()
+
+		for {
+			var ev *Event
+			select {
+			case <-ctx.Done():
+				return
+			case ev = <-subCh:
+			}
+
+			if filter != nil && !filter(ev) {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- ev:
+			}
+		}
+	}()
+
+	return ch, csub
+}
+
+func (b *Bus) publish(ev *Event) {
+	b.notifier.Broadcast(ev)
+}
+
+// Name returns the service name.
+func (b *Bus) Name() string {
+	return "consensus event bus"
+}
+
+// Start starts the service.
+func (b *Bus) Start() error {
+	go b.worker()
+	return nil
+}
+
+// Stop halts the service.
+func (b *Bus) Stop() {
+	b.cancelCtx()
+}
+
+// Quit returns a channel that will be closed when the service terminates.
+func (b *Bus) Quit() <-chan struct{} {
+	return b.quitCh
+}
+
+// Cleanup performs the service specific post-termination cleanup.
+func (b *Bus) Cleanup() {
+}
+
+func (b *Bus) worker() {
+	defer close(b.quitCh)
+
+	// Wait for consensus to be synced so that subscribers are not flooded
+	// with historic events while catching up.
+	select {
+	case <-b.ctx.Done():
+		return
+	case <-b.consensus.Synced():
+	}
+
+	for {
+		// Failing to watch the consensus backends must not terminate the
+		// bus, as that would bring down the whole node.
+		var w *watchers
+		watch := func() error {
+			var err error
+			w, err = b.watch()
+			if err != nil {
+				b.logger.Error("failed to watch consensus events, retrying",
+					"err", err,
+				)
+			}
+			return err
+		}
+		if err := backoff.Retry(watch, backoff.WithContext(cmnBackoff.NewExponentialBackOff(), b.ctx)); err != nil {
+			return
+		}
+
+		b.forward(w)
+		w.Close()
+
+		if b.ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// watchers are the subscriptions to all of the consensus backends.
+type watchers struct {
+	blkCh       <-chan *consensus.Block
+	epochCh     <-chan beacon.EpochTime
+	committeeCh <-chan *scheduler.Committee
+	regCh       <-chan *registry.Event
+	stakingCh   <-chan *staking.Event
+	govCh       <-chan *governance.Event
+
+	subs []pubsub.ClosableSubscription
+}
+
+// Close closes all of the subscriptions.
+func (w *watchers) Close() {
+	for _, sub := range w.subs {
+		sub.Close()
+	}
+}
+
+// watch subscribes to all of the consensus backends.
+func (b *Bus) watch() (*watchers, error) {
+	var (
+		w   watchers
+		sub pubsub.ClosableSubscription
+		err error
+	)
+	defer func() {
+		if err != nil {
+			w.Close()
+		}
+	}()
+
+	if w.blkCh, sub, err = b.consensus.WatchBlocks(b.ctx); err != nil {
+		return nil, fmt.Errorf("failed to watch blocks: %w", err)
+	}
+	w.subs = append(w.subs, sub)
+
+	if w.epochCh, sub, err = b.consensus.Beacon().WatchEpochs(b.ctx); err != nil {
+		return nil, fmt.Errorf("failed to watch epochs: %w", err)
+	}
+	w.subs = append(w.subs, sub)
+
+	if w.committeeCh, sub, err = b.consensus.Scheduler().WatchCommittees(b.ctx); err != nil {
+		return nil, fmt.Errorf("failed to watch committees: %w", err)
+	}
+	w.subs = append(w.subs, sub)
+
+	if w.regCh, sub, err = b.consensus.Registry().WatchEvents(b.ctx); err != nil {
+		return nil, fmt.Errorf("failed to watch registry events: %w", err)
+	}
+	w.subs = append(w.subs, sub)
+
+	if w.stakingCh, sub, err = b.consensus.Staking().WatchEvents(b.ctx); err != nil {
+		return nil, fmt.Errorf("failed to watch staking events: %w", err)
+	}
+	w.subs = append(w.subs, sub)
+
+	if w.govCh, sub, err = b.consensus.Governance().WatchEvents(b.ctx); err != nil {
+		return nil, fmt.Errorf("failed to watch governance events: %w", err)
+	}
+	w.subs = append(w.subs, sub)
+
+	return &w, nil
+}
+
+// forward publishes events from the given watchers until the bus is stopped
+// or one of the watchers terminates.
+func (b *Bus) forward(w *watchers) {
+	for {
+		var (
+			ev Event
+			ok bool
+		)
+		select {
+		case <-b.ctx.Done():
+			return
+		case ev.Block, ok = <-w.blkCh:
+		case epoch, eok := <-w.epochCh:
+			ev.Epoch, ok = &epoch, eok
+		case ev.Election, ok = <-w.committeeCh:
+		case ev.Registry, ok = <-w.regCh:
+		case ev.Staking, ok = <-w.stakingCh:
+		case ev.Governance, ok = <-w.govCh:
+		}
+		if !ok {
+			b.logger.Warn("consensus watcher terminated, resubscribing")
+			return
+		}
+		b.publish(&ev)
+	}
+}
+
+// New creates a new consensus event bus.
+func New(ctx context.Context, consensus consensus.Backend) *Bus {
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &Bus{
+		consensus: consensus,
+		notifier:  pubsub.NewBroker(false),
+		ctx:       ctx,
+		cancelCtx: cancel,
+		quitCh:    make(chan struct{}),
+		logger:    logging.GetLogger("consensus/eventbus"),
+	}
+}
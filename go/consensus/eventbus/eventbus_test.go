@@ -0,0 +1,205 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestSubscribe(t *testing.T) {
+	require := require.New(t)
+
+	bus := New(context.Background(), nil)
+	defer bus.Stop()
+
+	allCh, allSub := bus.Subscribe(nil)
+	defer allSub.Close()
+	epochCh, epochSub := bus.Subscribe(KindFilter(KindEpoch))
+	defer epochSub.Close()
+
+	epoch := beacon.EpochTime(42)
+	events := []*Event{
+		{Block: &consensus.Block{Height: 1}},
+		{Registry: &registry.Event{Height: 1}},
+		{Epoch: &epoch},
+		{Block: &consensus.Block{Height: 2}},
+	}
+	for _, ev := range events {
+		bus.publish(ev)
+	}
+
+	recv := func(ch <-chan *Event) *Event {
+		select {
+		case ev := <-ch:
+			return ev
+		case <-time.After(time.Second):
+			t.Fatalf("failed to receive event")
+			return nil
+		}
+	}
+
+	for _, ev := range events {
+		require.Equal(ev, recv(allCh), "unfiltered subscription should receive all events")
+	}
+	ev := recv(epochCh)
+	require.Equal(KindEpoch, ev.Kind())
+	require.EqualValues(42, *ev.Epoch)
+
+	// Closing the subscription should terminate the channel.
+	epochSub.Close()
+	select {
+	case _, ok := <-epochCh:
+		require.False(ok, "channel should be closed")
+	case <-time.After(time.Second):
+		t.Fatalf("channel should be closed")
+	}
+}
+
+func TestKind(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(KindInvalid, (&Event{}).Kind())
+	require.Equal(KindBlock, (&Event{Block: &consensus.Block{}}).Kind())
+	require.Equal("registry", KindRegistry.String())
+}
+
+func newTestSubscription() pubsub.ClosableSubscription {
+	return pubsub.NewBroker(false).Subscribe()
+}
+
+type testBeacon struct {
+	beacon.Backend
+
+	epochCh chan beacon.EpochTime
+}
+
+func (b *testBeacon) WatchEpochs(context.Context) (<-chan beacon.EpochTime, pubsub.ClosableSubscription, error) {
+	return b.epochCh, newTestSubscription(), nil
+}
+
+type testScheduler struct {
+	scheduler.Backend
+}
+
+func (s *testScheduler) WatchCommittees(context.Context) (<-chan *scheduler.Committee, pubsub.ClosableSubscription, error) {
+	return make(chan *scheduler.Committee), newTestSubscription(), nil
+}
+
+type testRegistry struct {
+	registry.Backend
+}
+
+func (r *testRegistry) WatchEvents(context.Context) (<-chan *registry.Event, pubsub.ClosableSubscription, error) {
+	return make(chan *registry.Event), newTestSubscription(), nil
+}
+
+type testStaking struct {
+	staking.Backend
+}
+
+func (s *testStaking) WatchEvents(context.Context) (<-chan *staking.Event, pubsub.ClosableSubscription, error) {
+	return make(chan *staking.Event), newTestSubscription(), nil
+}
+
+type testGovernance struct {
+	governance.Backend
+}
+
+func (g *testGovernance) WatchEvents(context.Context) (<-chan *governance.Event, pubsub.ClosableSubscription, error) {
+	return make(chan *governance.Event), newTestSubscription(), nil
+}
+
+type testConsensus struct {
+	consensus.Backend
+
+	failures int
+	watches  int
+	blkCh    chan *consensus.Block
+	beacon   *testBeacon
+}
+
+func (c *testConsensus) Synced() <-chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}
+
+func (c *testConsensus) WatchBlocks(context.Context) (<-chan *consensus.Block, pubsub.ClosableSubscription, error) {
+	c.watches++
+	if c.watches <= c.failures {
+		return nil, nil, fmt.Errorf("not available")
+	}
+	return c.blkCh, newTestSubscription(), nil
+}
+
+func (c *testConsensus) Beacon() beacon.Backend {
+	return c.beacon
+}
+
+func (c *testConsensus) Scheduler() scheduler.Backend {
+	return &testScheduler{}
+}
+
+func (c *testConsensus) Registry() registry.Backend {
+	return &testRegistry{}
+}
+
+func (c *testConsensus) Staking() staking.Backend {
+	return &testStaking{}
+}
+
+func (c *testConsensus) Governance() governance.Backend {
+	return &testGovernance{}
+}
+
+func TestWorkerRetry(t *testing.T) {
+	require := require.New(t)
+
+	c := &testConsensus{
+		failures: 2,
+		blkCh:    make(chan *consensus.Block),
+		beacon:   &testBeacon{epochCh: make(chan beacon.EpochTime)},
+	}
+	bus := New(context.Background(), c)
+	ch, sub := bus.Subscribe(KindFilter(KindBlock))
+	defer sub.Close()
+
+	require.NoError(bus.Start())
+	defer bus.Stop()
+
+	send := func(blkCh chan *consensus.Block, height int64) {
+		select {
+		case blkCh <- &consensus.Block{Height: height}:
+		case <-bus.Quit():
+			t.Fatalf("event bus should not terminate when watching fails")
+		case <-time.After(10 * time.Second):
+			t.Fatalf("event bus should retry watching")
+		}
+		select {
+		case ev := <-ch:
+			require.EqualValues(height, ev.Block.Height)
+		case <-time.After(time.Second):
+			t.Fatalf("failed to receive event")
+		}
+	}
+
+	send(c.blkCh, 1)
+	require.Equal(3, c.watches, "event bus should retry watching until it succeeds")
+
+	// A terminated watcher should be resubscribed.
+	blkCh := c.blkCh
+	c.blkCh = make(chan *consensus.Block)
+	close(blkCh)
+	send(c.blkCh, 2)
+}
@@ -126,7 +126,32 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 	})
 	require.NoError(err, "EstimateGas")
 
+	simSigner := memorySigner.NewTestSigner("simulate tx signer")
+	simTx, err := transaction.Sign(simSigner, transaction.NewTransaction(0, nil, staking.MethodTransfer, &staking.Transfer{}))
+	require.NoError(err, "transaction.Sign")
+	simResult, err := backend.SimulateTx(ctx, simTx)
+	require.NoError(err, "SimulateTx")
+	require.NotNil(simResult, "SimulateTx should return a result")
+	require.True(simResult.IsSuccess(), "SimulateTx should succeed")
+	require.NotZero(simResult.GasUsed, "SimulateTx should report gas used")
+
+	_, err = backend.SimulateTx(ctx, nil)
+	require.ErrorIs(err, consensus.ErrInvalidArgument, "SimulateTx with nil transaction should fail")
+
+	badSimTx := *simTx
+	badSimTx.Signature.Signature[0] ^= 0xff
+	simResult, err = backend.SimulateTx(ctx, &badSimTx)
+	require.NoError(err, "SimulateTx")
+	require.False(simResult.IsSuccess(), "SimulateTx with an invalid signature should fail")
+
 	nonce, err := backend.GetSignerNonce(ctx, &consensus.GetSignerNonceRequest{
+		AccountAddress: staking.NewAddress(simSigner.Public()),
+		Height:         consensus.HeightLatest,
+	})
+	require.NoError(err, "GetSignerNonce")
+	require.Equal(uint64(0), nonce, "SimulateTx should not persist any state changes")
+
+	nonce, err = backend.GetSignerNonce(ctx, &consensus.GetSignerNonceRequest{
 		AccountAddress: staking.NewAddress(
 			signature.NewPublicKey("badfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
 		),
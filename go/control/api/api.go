@@ -7,10 +7,12 @@ import (
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/backup"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
@@ -18,6 +20,7 @@ import (
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	block "github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+	runtimeConfig "github.com/oasisprotocol/oasis-core/go/runtime/config"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 	commonWorker "github.com/oasisprotocol/oasis-core/go/worker/common/api"
@@ -68,6 +71,62 @@ type NodeController interface {
 	// If the bundle upgrades an existing ROFL component, the latter will
 	// be upgraded to the new version.
 	AddBundle(ctx context.Context, path string) error
+
+	// WatchRuntimeLogs returns the captured output of the given hosted runtime, one line at
+	// a time, starting with the oldest retained line.
+	//
+	// If following is requested, new output is streamed as it is produced.
+	WatchRuntimeLogs(ctx context.Context, req *RuntimeLogsRequest) (<-chan string, pubsub.ClosableSubscription, error)
+
+	// GetProfile captures a profile of the running node process.
+	//
+	// If persisting is requested, the profile is written to the node's data directory and only
+	// its path is returned. Otherwise the profile is returned in the response.
+	GetProfile(ctx context.Context, req *ProfileRequest) (*ProfileResponse, error)
+
+	// CreateSnapshot writes a backup archive of the node state to the given path.
+	//
+	// While the databases are being copied, consensus commits are fenced so that the archive
+	// contains a consistent view of the node state. The consensus layer does not make progress
+	// during that time.
+	CreateSnapshot(ctx context.Context, req *SnapshotRequest) (*backup.Manifest, error)
+}
+
+// RuntimeLogsRequest is a request for the captured output of a hosted runtime.
+type RuntimeLogsRequest struct {
+	// RuntimeID is the runtime identifier.
+	RuntimeID common.Namespace `json:"runtime_id"`
+
+	// Follow specifies whether new output should be streamed as it is produced.
+	Follow bool `json:"follow,omitempty"`
+}
+
+// ProfileRequest is a request to capture a profile of the node process.
+type ProfileRequest struct {
+	// Kind is the kind of the profile (cpu, heap, goroutine).
+	Kind string `json:"kind"`
+
+	// Duration is the duration of CPU profiling. If not specified, a default is used.
+	Duration time.Duration `json:"duration,omitempty"`
+
+	// Persist specifies whether the profile should be written to the node's data directory
+	// instead of being returned.
+	Persist bool `json:"persist,omitempty"`
+}
+
+// ProfileResponse is the response to a profile request.
+type ProfileResponse struct {
+	// Path is the path of the persisted profile, if persisting was requested.
+	Path string `json:"path,omitempty"`
+
+	// Data is the profile in pprof format, if persisting was not requested.
+	Data []byte `json:"data,omitempty"`
+}
+
+// SnapshotRequest is a request to create a backup archive of the node state.
+type SnapshotRequest struct {
+	// Path is the absolute path of the backup archive to create. It must not exist.
+	Path string `json:"path"`
 }
 
 // Status is the current status overview.
@@ -158,6 +217,14 @@ type RegistrationStatus struct {
 
 	// NodeStatus is the registry live status of the node.
 	NodeStatus *registry.NodeStatus `json:"node_status,omitempty"`
+
+	// Deferred is true if the registration is currently deferred as the local consensus node is
+	// lagging behind its peers.
+	Deferred bool `json:"deferred,omitempty"`
+
+	// ConsensusLag is the number of blocks the local consensus node was lagging behind its peers
+	// when the registration was last deferred.
+	ConsensusLag uint64 `json:"consensus_lag,omitempty"`
 }
 
 // RuntimeStatus is the per-runtime status overview.
@@ -197,6 +264,22 @@ type RuntimeStatus struct {
 
 	// Components contains statuses of the runtime components.
 	Components []ComponentStatus `json:"components,omitempty"`
+
+	// Config is the parsed local configuration of the runtime, if the runtime is configured.
+	Config *RuntimeConfigStatus `json:"config,omitempty"`
+}
+
+// RuntimeConfigStatus is the parsed local configuration of a runtime.
+type RuntimeConfigStatus struct {
+	// Components are the configured runtime components.
+	Components []runtimeConfig.ComponentConfig `json:"components,omitempty"`
+
+	// Registries are the configured base URLs used to fetch runtime bundle metadata.
+	Registries []string `json:"registries,omitempty"`
+
+	// LocalConfigKeys are the sorted top-level keys of the runtime local configuration. Values
+	// are omitted as they are opaque to the node and may contain sensitive data.
+	LocalConfigKeys []string `json:"local_config_keys,omitempty"`
 }
 
 // ComponentStatus is the runtime component status overview.
@@ -5,7 +5,9 @@ import (
 
 	"google.golang.org/grpc"
 
+	"github.com/oasisprotocol/oasis-core/go/common/backup"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	upgradeApi "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
@@ -14,7 +16,7 @@ var (
 	serviceName = cmnGrpc.NewServiceName("NodeController")
 
 	// methodRequestShutdown is the RequestShutdown method.
-	methodRequestShutdown = serviceName.NewMethod("RequestShutdown", false)
+	methodRequestShutdown = serviceName.NewMethod("RequestShutdown", false).WithMutating()
 	// methodWaitSync is the WaitSync method.
 	methodWaitSync = serviceName.NewMethod("WaitSync", nil)
 	// methodIsSynced is the IsSynced method.
@@ -24,13 +26,19 @@ var (
 	// methodIsReady is the IsReady method.
 	methodIsReady = serviceName.NewMethod("IsReady", nil)
 	// methodUpgradeBinary is the UpgradeBinary method.
-	methodUpgradeBinary = serviceName.NewMethod("UpgradeBinary", upgradeApi.Descriptor{})
+	methodUpgradeBinary = serviceName.NewMethod("UpgradeBinary", upgradeApi.Descriptor{}).WithMutating()
 	// methodCancelUpgrade is the CancelUpgrade method.
-	methodCancelUpgrade = serviceName.NewMethod("CancelUpgrade", nil)
+	methodCancelUpgrade = serviceName.NewMethod("CancelUpgrade", nil).WithMutating()
 	// methodGetStatus is the GetStatus method.
 	methodGetStatus = serviceName.NewMethod("GetStatus", nil)
 	// methodAddBundle is the AddBundle method.
-	methodAddBundle = serviceName.NewMethod("AddBundle", nil)
+	methodAddBundle = serviceName.NewMethod("AddBundle", nil).WithMutating()
+	// methodGetProfile is the GetProfile method.
+	methodGetProfile = serviceName.NewMethod("GetProfile", ProfileRequest{}).WithMutating()
+	// methodCreateSnapshot is the CreateSnapshot method.
+	methodCreateSnapshot = serviceName.NewMethod("CreateSnapshot", SnapshotRequest{}).WithMutating()
+	// methodWatchRuntimeLogs is the WatchRuntimeLogs method.
+	methodWatchRuntimeLogs = serviceName.NewMethod("WatchRuntimeLogs", RuntimeLogsRequest{})
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -73,8 +81,22 @@ var (
 				MethodName: methodAddBundle.ShortName(),
 				Handler:    handlerAddBundle,
 			},
+			{
+				MethodName: methodGetProfile.ShortName(),
+				Handler:    handlerGetProfile,
+			},
+			{
+				MethodName: methodCreateSnapshot.ShortName(),
+				Handler:    handlerCreateSnapshot,
+			},
+		},
+		Streams: []grpc.StreamDesc{
+			{
+				StreamName:    methodWatchRuntimeLogs.ShortName(),
+				Handler:       handlerWatchRuntimeLogs,
+				ServerStreams: true,
+			},
 		},
-		Streams: []grpc.StreamDesc{},
 	}
 )
 
@@ -265,6 +287,81 @@ func handlerAddBundle(
 	return interceptor(ctx, &path, info, handler)
 }
 
+func handlerGetProfile(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req ProfileRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).GetProfile(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetProfile.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetProfile(ctx, req.(*ProfileRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
+func handlerCreateSnapshot(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req SnapshotRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).CreateSnapshot(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodCreateSnapshot.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).CreateSnapshot(ctx, req.(*SnapshotRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
+func handlerWatchRuntimeLogs(srv interface{}, stream grpc.ServerStream) error {
+	var req RuntimeLogsRequest
+	if err := stream.RecvMsg(&req); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(NodeController).WatchRuntimeLogs(ctx, &req)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case line, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(line); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // RegisterService registers a new node controller service with the given gRPC server.
 func RegisterService(server *grpc.Server, service NodeController) {
 	server.RegisterService(&serviceDesc, service)
@@ -333,3 +430,54 @@ func (c *NodeControllerClient) AddBundle(ctx context.Context, path string) error
 	}
 	return nil
 }
+
+func (c *NodeControllerClient) GetProfile(ctx context.Context, req *ProfileRequest) (*ProfileResponse, error) {
+	var rsp ProfileResponse
+	if err := c.conn.Invoke(ctx, methodGetProfile.FullName(), req, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *NodeControllerClient) CreateSnapshot(ctx context.Context, req *SnapshotRequest) (*backup.Manifest, error) {
+	var rsp backup.Manifest
+	if err := c.conn.Invoke(ctx, methodCreateSnapshot.FullName(), req, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *NodeControllerClient) WatchRuntimeLogs(ctx context.Context, req *RuntimeLogsRequest) (<-chan string, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[0], methodWatchRuntimeLogs.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(req); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+
+		for {
+			var line string
+			if serr := stream.RecvMsg(&line); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
@@ -14,7 +14,7 @@ var (
 	debugServiceName = cmnGrpc.NewServiceName("DebugController")
 
 	// methodSetEpoch is the SetEpoch method.
-	methodSetEpoch = debugServiceName.NewMethod("SetEpoch", beacon.EpochTime(0))
+	methodSetEpoch = debugServiceName.NewMethod("SetEpoch", beacon.EpochTime(0)).WithMutating()
 	// methodWaitNodesRegistered is the WaitNodesRegistered method.
 	methodWaitNodesRegistered = debugServiceName.NewMethod("WaitNodesRegistered", int(0))
 
@@ -7,6 +7,10 @@ import "fmt"
 type Config struct {
 	// File is the path to the genesis document file.
 	File string `yaml:"file"`
+
+	// ChainContext is the expected chain domain separation context (genesis document hash).
+	// If set, the node refuses to start with a genesis document that does not match.
+	ChainContext string `yaml:"chain_context,omitempty"`
 }
 
 // Validate validates the configuration settings.
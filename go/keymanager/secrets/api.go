@@ -150,6 +150,11 @@ type Status struct {
 	RSK *signature.PublicKey `json:"rsk,omitempty"`
 }
 
+// IsActive returns true iff the key manager is initialized and has at least one active node.
+func (s *Status) IsActive() bool {
+	return s.IsInitialized && len(s.Nodes) > 0
+}
+
 // NextGeneration returns the generation of the next master secret.
 func (s *Status) NextGeneration() uint64 {
 	if len(s.Checksum) == 0 {
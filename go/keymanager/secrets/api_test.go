@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
 )
 
@@ -36,6 +37,15 @@ func TestStatus(t *testing.T) {
 	// Uninitialized key manager.
 	var s Status
 	require.Equal(uint64(0), s.NextGeneration())
+	require.False(s.IsActive())
+
+	// Initialized key manager without active nodes.
+	s.IsInitialized = true
+	require.False(s.IsActive())
+
+	// Initialized key manager with active nodes.
+	s.Nodes = []signature.PublicKey{memorySigner.NewTestSigner("node").Public()}
+	require.True(s.IsActive())
 
 	// Key manager with one master secret generation.
 	s.Checksum = []byte{1, 2, 3}
@@ -27,6 +27,9 @@ import (
 const (
 	CfgConfigFile = "config"
 
+	// CfgNetwork is the command line flag for selecting a well-known network preset.
+	CfgNetwork = "network"
+
 	// CfgDebugAllowTestKeys is the command line flag to enable the debug test
 	// keys.
 	CfgDebugAllowTestKeys = "debug.allow_test_keys"
@@ -47,6 +50,7 @@ const (
 
 var (
 	cfgFile string
+	network string
 
 	rootLog = logging.GetLogger("oasis-node")
 
@@ -142,6 +146,7 @@ func init() {
 	_ = viper.BindPFlags(debugFlags)
 
 	RootFlags.StringVar(&cfgFile, CfgConfigFile, "", "config file")
+	RootFlags.StringVar(&network, CfgNetwork, "", fmt.Sprintf("network preset providing configuration defaults (%s)", strings.Join(config.NetworkNames(), ", ")))
 	_ = viper.BindPFlags(RootFlags)
 
 	RootFlags.AddFlagSet(debugFlags)
@@ -150,11 +155,11 @@ func init() {
 
 // InitConfig initializes the global configuration.
 func InitConfig() {
-	if cfgFile != "" {
+	if cfgFile != "" || network != "" {
 		// Read the config file if one is provided, otherwise
 		// it is assumed that the combination of default values,
-		// command line flags and env vars is sufficient.
-		if err := config.InitConfig(cfgFile); err != nil {
+		// network defaults, command line flags and env vars is sufficient.
+		if err := config.InitConfig(network, cfgFile); err != nil {
 			EarlyLogAndExit(err)
 		}
 	}
@@ -3,6 +3,8 @@
 package flags
 
 import (
+	"fmt"
+
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
@@ -29,6 +31,13 @@ const (
 	// yes.
 	CfgAssumeYes      = "assume_yes"
 	cfgAssumeYesShort = "y"
+
+	cfgFormat = "format"
+
+	// FormatText is the human-readable text output format.
+	FormatText = "text"
+	// FormatJSON is the canonical JSON output format.
+	FormatJSON = "json"
 )
 
 var (
@@ -50,6 +59,9 @@ var (
 
 	// AssumeYesFlag has the assume yes flag.
 	AssumeYesFlag = flag.NewFlagSet("", flag.ContinueOnError)
+
+	// FormatFlags has the output format flag.
+	FormatFlags = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
 // Verbose returns true iff the verbose flag is set.
@@ -92,6 +104,17 @@ func AssumeYes() bool {
 	return viper.GetBool(CfgAssumeYes)
 }
 
+// Format returns the set output format.
+func Format() (string, error) {
+	format := viper.GetString(cfgFormat)
+	switch format {
+	case FormatText, FormatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported output format: '%s'", format)
+	}
+}
+
 func init() {
 	VerboseFlags.BoolP(cfgVerbose, "v", false, "verbose output")
 
@@ -109,6 +132,8 @@ func init() {
 
 	AssumeYesFlag.BoolP(CfgAssumeYes, cfgAssumeYesShort, false, "automatically assume yes for all questions")
 
+	FormatFlags.String(cfgFormat, FormatText, "output format (text, json)")
+
 	for _, v := range []*flag.FlagSet{
 		VerboseFlags,
 		ForceFlags,
@@ -117,6 +142,7 @@ func init() {
 		DebugDontBlameOasisFlag,
 		DryRunFlag,
 		AssumeYesFlag,
+		FormatFlags,
 	} {
 		_ = viper.BindPFlags(v)
 	}
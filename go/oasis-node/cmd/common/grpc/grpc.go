@@ -16,6 +16,7 @@ import (
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 )
 
@@ -49,13 +50,14 @@ var (
 // This internally takes a snapshot of the current global tracer, so
 // make sure you initialize the global tracer before calling this.
 func NewServerTCP(cert *tls.Certificate, installWrapper bool) (*cmnGrpc.Server, error) {
-	config := &cmnGrpc.ServerConfig{
+	svrCfg := &cmnGrpc.ServerConfig{
 		Name:           "internal",
 		Port:           uint16(viper.GetInt(CfgServerPort)),
 		Identity:       identity.WithTLSCertificate(cert),
 		InstallWrapper: installWrapper,
+		Config:         &config.GlobalConfig.GRPC,
 	}
-	return cmnGrpc.NewServer(config)
+	return cmnGrpc.NewServer(svrCfg)
 }
 
 // NewServerLocal constructs a new gRPC server service listening on
@@ -64,13 +66,14 @@ func NewServerTCP(cert *tls.Certificate, installWrapper bool) (*cmnGrpc.Server,
 // This internally takes a snapshot of the current global tracer, so
 // make sure you initialize the global tracer before calling this.
 func NewServerLocal(installWrapper bool) (*cmnGrpc.Server, error) {
-	config := &cmnGrpc.ServerConfig{
+	svrCfg := &cmnGrpc.ServerConfig{
 		Name:           "internal",
 		Path:           common.InternalSocketPath(),
 		InstallWrapper: installWrapper,
+		Config:         &config.GlobalConfig.GRPC,
 	}
 
-	return cmnGrpc.NewServer(config)
+	return cmnGrpc.NewServer(svrCfg)
 }
 
 func NewClient(cmd *cobra.Command) (*grpc.ClientConn, error) {
@@ -93,6 +96,7 @@ func NewClient(cmd *cobra.Command) (*grpc.ClientConn, error) {
 		creds = credentials.NewTLS(&tls.Config{})
 	}
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	opts = append(opts, cmnGrpc.ClientLimitOptions(&config.GlobalConfig.GRPC)...)
 	if viper.GetBool(CfgWait) {
 		opts = append(opts, grpc.WithDefaultCallOptions(grpc.WaitForReady(true)))
 	}
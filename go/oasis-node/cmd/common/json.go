@@ -1,6 +1,7 @@
 package common
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 )
@@ -13,3 +14,29 @@ func PrettyJSONMarshal(v interface{}) ([]byte, error) {
 	}
 	return formatted, nil
 }
+
+// CanonicalJSONMarshal returns canonical JSON encoding of v.
+//
+// The canonical encoding has all object keys sorted and contains no insignificant whitespace so
+// that the output is stable and can be reliably parsed or compared by scripts.
+func CanonicalJSONMarshal(v interface{}) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal to JSON: %w", err)
+	}
+
+	// Round-trip through a generic value as maps are always encoded with sorted keys. Numbers
+	// are kept verbatim so that large integers do not lose precision.
+	var generic interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err = dec.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	canonical, err := json.Marshal(generic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal to canonical JSON: %w", err)
+	}
+	return canonical, nil
+}
@@ -0,0 +1,30 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalJSONMarshal(t *testing.T) {
+	require := require.New(t)
+
+	type inner struct {
+		Zeta  uint64 `json:"zeta"`
+		Alpha string `json:"alpha"`
+	}
+	type outer struct {
+		Inner  inner            `json:"inner"`
+		Values map[string]int64 `json:"values"`
+		Big    uint64           `json:"big"`
+	}
+
+	v := outer{
+		Inner:  inner{Zeta: 1, Alpha: "a"},
+		Values: map[string]int64{"b": 2, "a": -1},
+		Big:    18446744073709551615,
+	}
+	raw, err := CanonicalJSONMarshal(v)
+	require.NoError(err, "CanonicalJSONMarshal")
+	require.Equal(`{"big":18446744073709551615,"inner":{"alpha":"a","zeta":1},"values":{"a":-1,"b":2}}`, string(raw))
+}
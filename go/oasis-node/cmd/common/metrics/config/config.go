@@ -3,6 +3,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 )
 
@@ -10,19 +11,36 @@ import (
 type Config struct {
 	// Metrics mode (none, pull, push).
 	Mode string `yaml:"mode"`
-	// Metrics pull address.
+	// Metrics backend (prometheus, statsd, otlp).
+	//
+	// The statsd and otlp backends only support push mode.
+	Backend string `yaml:"backend"`
+	// Metrics pull or push address.
+	//
+	// For the statsd backend this is the UDP address of the StatsD server and for the otlp
+	// backend this is the URL of the OTLP/HTTP metrics endpoint.
 	Address string `yaml:"address"`
 
-	// Metrics push job name (debug-only).
+	// Metrics push job name (debug-only, prometheus backend).
 	JobName string `yaml:"job_name,omitempty"`
-	// Metrics push instance labels (debug-only).
+	// Metrics push instance labels (debug-only for the prometheus backend).
 	Labels map[string]string `yaml:"labels,omitempty"`
-	// Metrics push interval (debug-only).
+	// Metrics push interval (debug-only for the prometheus backend).
 	Interval time.Duration `yaml:"interval,omitempty"`
 }
 
 // Validate validates the configuration settings.
 func (c *Config) Validate() error {
+	switch c.Backend {
+	case "prometheus":
+	case "statsd", "otlp":
+		if c.Mode == "pull" {
+			return fmt.Errorf("%s backend does not support pull mode", c.Backend)
+		}
+	default:
+		return fmt.Errorf("unknown metrics backend: %s", c.Backend)
+	}
+
 	switch c.Mode {
 	case "none":
 	case "pull":
@@ -33,15 +51,27 @@ func (c *Config) Validate() error {
 		if len(c.Address) == 0 {
 			return fmt.Errorf("missing address in push mode")
 		}
-		if len(c.JobName) == 0 {
-			return fmt.Errorf("missing job_name in push mode")
-		}
-		if len(c.Labels) == 0 {
-			return fmt.Errorf("missing labels in push mode")
-		}
 		if c.Interval == 0 {
 			return fmt.Errorf("missing interval in push mode")
 		}
+
+		switch c.Backend {
+		case "prometheus":
+			if len(c.JobName) == 0 {
+				return fmt.Errorf("missing job_name in push mode")
+			}
+			if len(c.Labels) == 0 {
+				return fmt.Errorf("missing labels in push mode")
+			}
+		case "otlp":
+			u, err := url.Parse(c.Address)
+			if err != nil {
+				return fmt.Errorf("malformed otlp address: %w", err)
+			}
+			if u.Scheme != "http" && u.Scheme != "https" {
+				return fmt.Errorf("otlp address must be an http or https URL")
+			}
+		}
 	default:
 		return fmt.Errorf("unknown metrics mode: %s", c.Mode)
 	}
@@ -53,6 +83,7 @@ func (c *Config) Validate() error {
 func DefaultConfig() Config {
 	return Config{
 		Mode:     "none",
+		Backend:  "prometheus",
 		Address:  "127.0.0.1:3000",
 		JobName:  "",
 		Labels:   map[string]string{},
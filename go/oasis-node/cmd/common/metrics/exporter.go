@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/oasisprotocol/oasis-core/go/common/service"
+)
+
+const (
+	MetricsBackendPrometheus = "prometheus"
+	MetricsBackendStatsD     = "statsd"
+	MetricsBackendOTLP       = "otlp"
+)
+
+// Exporter exports gathered metrics to a metrics backend.
+type Exporter interface {
+	// Export exports the given metric families.
+	Export(ctx context.Context, mfs []*dto.MetricFamily) error
+}
+
+// exportService periodically gathers all registered metrics and exports them using an exporter.
+type exportService struct {
+	service.BaseBackgroundService
+
+	gatherer prometheus.Gatherer
+	exporter Exporter
+	interval time.Duration
+
+	rsvc *resourceService
+
+	stopCh chan struct{}
+	quitCh chan struct{}
+}
+
+func (s *exportService) Start() error {
+	if err := s.rsvc.Start(); err != nil {
+		return err
+	}
+
+	go s.worker()
+	return nil
+}
+
+func (s *exportService) Stop() {
+	close(s.stopCh)
+}
+
+func (s *exportService) Quit() <-chan struct{} {
+	return s.quitCh
+}
+
+func (s *exportService) Cleanup() {
+	s.rsvc.Cleanup()
+}
+
+func (s *exportService) worker() {
+	defer func() {
+		s.rsvc.Stop()
+		<-s.rsvc.Quit()
+		close(s.quitCh)
+	}()
+
+	t := time.NewTicker(s.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-t.C:
+		}
+
+		mfs, err := s.gatherer.Gather()
+		if err != nil {
+			// Gathering can partially fail, export whatever has been gathered.
+			s.Logger.Warn("Gather: failed",
+				"err", err,
+			)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.interval)
+		err = s.exporter.Export(ctx, mfs)
+		cancel()
+		if err != nil {
+			s.Logger.Warn("Export: failed",
+				"err", err,
+			)
+		}
+	}
+}
+
+func newExportService(backend string, exporter Exporter, interval time.Duration) *exportService {
+	svc := &exportService{
+		BaseBackgroundService: *service.NewBaseBackgroundService("metrics"),
+		gatherer:              prometheus.DefaultGatherer,
+		exporter:              exporter,
+		interval:              interval,
+		rsvc:                  newResourceService(interval),
+		stopCh:                make(chan struct{}),
+		quitCh:                make(chan struct{}),
+	}
+
+	svc.Logger.Debug("initializing metrics export service",
+		"mode", MetricsModePush,
+		"backend", backend,
+		"interval", interval,
+	)
+
+	return svc
+}
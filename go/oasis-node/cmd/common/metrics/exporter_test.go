@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistry() (*prometheus.Registry, prometheus.Counter) {
+	reg := prometheus.NewRegistry()
+
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "test_counter",
+		Help:        "Test counter.",
+		ConstLabels: prometheus.Labels{"kind": "a"},
+	})
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "test_gauge",
+		Help: "Test gauge.",
+	})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "test_histogram",
+		Help:    "Test histogram.",
+		Buckets: []float64{1, 5},
+	})
+	reg.MustRegister(counter, gauge, histogram)
+
+	counter.Add(3)
+	gauge.Set(1.5)
+	histogram.Observe(0.5)
+	histogram.Observe(2)
+	histogram.Observe(10)
+
+	return reg, counter
+}
+
+func TestStatsdExporter(t *testing.T) {
+	require := require.New(t)
+
+	reg, counter := newTestRegistry()
+	e := newStatsdExporter("127.0.0.1:8125", map[string]string{"instance": "node-1"})
+
+	mfs, err := reg.Gather()
+	require.NoError(err, "Gather")
+	require.Equal([]string{
+		"test_counter:3|c|#instance:node-1,kind:a",
+		"test_gauge:1.5|g|#instance:node-1",
+		"test_histogram_sum:12.5|c|#instance:node-1",
+		"test_histogram_count:3|c|#instance:node-1",
+	}, e.lines(mfs))
+
+	// Counters should be exported as deltas.
+	counter.Add(2)
+	mfs, err = reg.Gather()
+	require.NoError(err, "Gather")
+	require.Equal([]string{
+		"test_counter:2|c|#instance:node-1,kind:a",
+		"test_gauge:1.5|g|#instance:node-1",
+		"test_histogram_sum:0|c|#instance:node-1",
+		"test_histogram_count:0|c|#instance:node-1",
+	}, e.lines(mfs))
+}
+
+func TestOtlpExporter(t *testing.T) {
+	require := require.New(t)
+
+	reg, _ := newTestRegistry()
+	e := newOtlpExporter("http://127.0.0.1:4318/v1/metrics", map[string]string{"instance": "node-1"})
+
+	mfs, err := reg.Gather()
+	require.NoError(err, "Gather")
+	req := e.request(mfs, time.Unix(10, 0))
+
+	require.Len(req.ResourceMetrics, 1)
+	require.Contains(req.ResourceMetrics[0].Resource.Attributes, otlpKeyValue{
+		Key:   "instance",
+		Value: otlpAnyString{StringValue: "node-1"},
+	})
+	require.Len(req.ResourceMetrics[0].ScopeMetrics, 1)
+	metrics := req.ResourceMetrics[0].ScopeMetrics[0].Metrics
+	require.Len(metrics, 3)
+
+	counter := metrics[0]
+	require.Equal("test_counter", counter.Name)
+	require.NotNil(counter.Sum)
+	require.True(counter.Sum.IsMonotonic)
+	require.Len(counter.Sum.DataPoints, 1)
+	require.EqualValues(3, counter.Sum.DataPoints[0].AsDouble)
+	require.Equal("10000000000", counter.Sum.DataPoints[0].TimeUnixNano)
+
+	gauge := metrics[1]
+	require.Equal("test_gauge", gauge.Name)
+	require.NotNil(gauge.Gauge)
+	require.EqualValues(1.5, gauge.Gauge.DataPoints[0].AsDouble)
+
+	histogram := metrics[2]
+	require.Equal("test_histogram", histogram.Name)
+	require.NotNil(histogram.Histogram)
+	dp := histogram.Histogram.DataPoints[0]
+	require.Equal("3", dp.Count)
+	require.EqualValues(12.5, dp.Sum)
+	require.Equal([]float64{1, 5}, dp.ExplicitBounds)
+	require.Equal([]string{"1", "1", "1"}, dp.BucketCounts, "bucket counts should not be cumulative")
+
+	_, err = json.Marshal(req)
+	require.NoError(err, "request should be JSON serializable")
+}
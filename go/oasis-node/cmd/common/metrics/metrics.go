@@ -1,4 +1,7 @@
-// Package metrics implements a prometheus metrics service.
+// Package metrics implements a metrics service.
+//
+// Metrics are collected using prometheus and can either be scraped by or pushed to prometheus or
+// periodically exported to a StatsD server or an OTLP endpoint.
 package metrics
 
 import (
@@ -252,16 +255,37 @@ func newPushService() (service.BackgroundService, error) {
 // New constructs a new metrics service.
 func New(ctx context.Context) (service.BackgroundService, error) {
 	mode := strings.ToLower(config.GlobalConfig.Metrics.Mode)
-	switch mode {
-	case MetricsModeNone:
+	if mode == MetricsModeNone {
 		return newStubService()
-	case MetricsModePull:
-		return newPullService(ctx)
-	default:
-		if mode == MetricsModePush && flags.DebugDontBlameOasis() {
-			return newPushService()
+	}
+
+	backend := strings.ToLower(config.GlobalConfig.Metrics.Backend)
+	switch backend {
+	case MetricsBackendPrometheus, "":
+		switch mode {
+		case MetricsModePull:
+			return newPullService(ctx)
+		default:
+			if mode == MetricsModePush && flags.DebugDontBlameOasis() {
+				return newPushService()
+			}
+			return nil, fmt.Errorf("metrics: unsupported mode: '%v'", mode)
 		}
-		return nil, fmt.Errorf("metrics: unsupported mode: '%v'", mode)
+	case MetricsBackendStatsD, MetricsBackendOTLP:
+		if mode != MetricsModePush {
+			return nil, fmt.Errorf("metrics: unsupported mode for %s backend: '%v'", backend, mode)
+		}
+
+		var exporter Exporter
+		switch backend {
+		case MetricsBackendStatsD:
+			exporter = newStatsdExporter(config.GlobalConfig.Metrics.Address, config.GlobalConfig.Metrics.Labels)
+		case MetricsBackendOTLP:
+			exporter = newOtlpExporter(config.GlobalConfig.Metrics.Address, config.GlobalConfig.Metrics.Labels)
+		}
+		return newExportService(backend, exporter, config.GlobalConfig.Metrics.Interval), nil
+	default:
+		return nil, fmt.Errorf("metrics: unsupported backend: '%v'", backend)
 	}
 }
 
@@ -0,0 +1,313 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+)
+
+// otlpAggregationTemporalityCumulative is the OTLP cumulative aggregation temporality.
+const otlpAggregationTemporalityCumulative = 2
+
+// otlpExporter exports metrics to an OTLP/HTTP metrics endpoint using the JSON encoding.
+//
+// Counters are exported as cumulative monotonic sums, gauges and untyped metrics as gauges,
+// histograms as cumulative explicit bucket histograms and summaries as summaries.
+type otlpExporter struct {
+	url    string
+	labels map[string]string
+	client *http.Client
+
+	startTime time.Time
+}
+
+type otlpRequest struct {
+	ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     otlpResource       `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   otlpScope    `json:"scope"`
+	Metrics []otlpMetric `json:"metrics"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string        `json:"key"`
+	Value otlpAnyString `json:"value"`
+}
+
+type otlpAnyString struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpMetric struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Gauge       *otlpGauge     `json:"gauge,omitempty"`
+	Sum         *otlpSum       `json:"sum,omitempty"`
+	Histogram   *otlpHistogram `json:"histogram,omitempty"`
+	Summary     *otlpSummary   `json:"summary,omitempty"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpNumberDataPoint `json:"dataPoints"`
+}
+
+type otlpSum struct {
+	DataPoints             []otlpNumberDataPoint `json:"dataPoints"`
+	AggregationTemporality int                   `json:"aggregationTemporality"`
+	IsMonotonic            bool                  `json:"isMonotonic"`
+}
+
+type otlpNumberDataPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string         `json:"timeUnixNano"`
+	AsDouble          float64        `json:"asDouble"`
+}
+
+type otlpHistogram struct {
+	DataPoints             []otlpHistogramDataPoint `json:"dataPoints"`
+	AggregationTemporality int                      `json:"aggregationTemporality"`
+}
+
+type otlpHistogramDataPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	TimeUnixNano      string         `json:"timeUnixNano"`
+	Count             string         `json:"count"`
+	Sum               float64        `json:"sum"`
+	BucketCounts      []string       `json:"bucketCounts"`
+	ExplicitBounds    []float64      `json:"explicitBounds"`
+}
+
+type otlpSummary struct {
+	DataPoints []otlpSummaryDataPoint `json:"dataPoints"`
+}
+
+type otlpSummaryDataPoint struct {
+	Attributes        []otlpKeyValue      `json:"attributes,omitempty"`
+	StartTimeUnixNano string              `json:"startTimeUnixNano"`
+	TimeUnixNano      string              `json:"timeUnixNano"`
+	Count             string              `json:"count"`
+	Sum               float64             `json:"sum"`
+	QuantileValues    []otlpQuantileValue `json:"quantileValues"`
+}
+
+type otlpQuantileValue struct {
+	Quantile float64 `json:"quantile"`
+	Value    float64 `json:"value"`
+}
+
+func (e *otlpExporter) Export(ctx context.Context, mfs []*dto.MetricFamily) error {
+	body, err := json.Marshal(e.request(mfs, time.Now()))
+	if err != nil {
+		return fmt.Errorf("metrics/otlp: failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("metrics/otlp: failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("metrics/otlp: failed to send request: %w", err)
+	}
+	defer rsp.Body.Close()
+	_, _ = io.Copy(io.Discard, rsp.Body)
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return fmt.Errorf("metrics/otlp: unexpected response status: %s", rsp.Status)
+	}
+	return nil
+}
+
+// request converts the given metric families to an OTLP export request.
+func (e *otlpExporter) request(mfs []*dto.MetricFamily, now time.Time) *otlpRequest {
+	startTime := formatUnixNano(e.startTime)
+	timestamp := formatUnixNano(now)
+
+	metrics := make([]otlpMetric, 0, len(mfs))
+	for _, mf := range mfs {
+		metric := otlpMetric{
+			Name:        mf.GetName(),
+			Description: mf.GetHelp(),
+		}
+
+		switch mf.GetType() {
+		case dto.MetricType_COUNTER:
+			metric.Sum = &otlpSum{
+				AggregationTemporality: otlpAggregationTemporalityCumulative,
+				IsMonotonic:            true,
+			}
+			for _, m := range mf.GetMetric() {
+				metric.Sum.DataPoints = appendOtlpNumberDataPoint(metric.Sum.DataPoints, otlpNumberDataPoint{
+					Attributes:        otlpAttributes(m.GetLabel()),
+					StartTimeUnixNano: startTime,
+					TimeUnixNano:      timestamp,
+					AsDouble:          m.GetCounter().GetValue(),
+				})
+			}
+		case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+			metric.Gauge = &otlpGauge{}
+			for _, m := range mf.GetMetric() {
+				value := m.GetGauge().GetValue()
+				if mf.GetType() == dto.MetricType_UNTYPED {
+					value = m.GetUntyped().GetValue()
+				}
+				metric.Gauge.DataPoints = appendOtlpNumberDataPoint(metric.Gauge.DataPoints, otlpNumberDataPoint{
+					Attributes:   otlpAttributes(m.GetLabel()),
+					TimeUnixNano: timestamp,
+					AsDouble:     value,
+				})
+			}
+		case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+			metric.Histogram = &otlpHistogram{
+				AggregationTemporality: otlpAggregationTemporalityCumulative,
+			}
+			for _, m := range mf.GetMetric() {
+				h := m.GetHistogram()
+				dp := otlpHistogramDataPoint{
+					Attributes:        otlpAttributes(m.GetLabel()),
+					StartTimeUnixNano: startTime,
+					TimeUnixNano:      timestamp,
+					Count:             strconv.FormatUint(h.GetSampleCount(), 10),
+					Sum:               h.GetSampleSum(),
+					BucketCounts:      []string{},
+					ExplicitBounds:    []float64{},
+				}
+				// Prometheus buckets are cumulative while OTLP bucket counts are not.
+				var prev uint64
+				for _, b := range h.GetBucket() {
+					if math.IsInf(b.GetUpperBound(), 1) {
+						continue
+					}
+					dp.ExplicitBounds = append(dp.ExplicitBounds, b.GetUpperBound())
+					dp.BucketCounts = append(dp.BucketCounts, strconv.FormatUint(b.GetCumulativeCount()-prev, 10))
+					prev = b.GetCumulativeCount()
+				}
+				dp.BucketCounts = append(dp.BucketCounts, strconv.FormatUint(h.GetSampleCount()-prev, 10))
+				metric.Histogram.DataPoints = append(metric.Histogram.DataPoints, dp)
+			}
+		case dto.MetricType_SUMMARY:
+			metric.Summary = &otlpSummary{}
+			for _, m := range mf.GetMetric() {
+				s := m.GetSummary()
+				dp := otlpSummaryDataPoint{
+					Attributes:        otlpAttributes(m.GetLabel()),
+					StartTimeUnixNano: startTime,
+					TimeUnixNano:      timestamp,
+					Count:             strconv.FormatUint(s.GetSampleCount(), 10),
+					Sum:               s.GetSampleSum(),
+					QuantileValues:    []otlpQuantileValue{},
+				}
+				for _, q := range s.GetQuantile() {
+					if math.IsNaN(q.GetValue()) {
+						continue
+					}
+					dp.QuantileValues = append(dp.QuantileValues, otlpQuantileValue{
+						Quantile: q.GetQuantile(),
+						Value:    q.GetValue(),
+					})
+				}
+				metric.Summary.DataPoints = append(metric.Summary.DataPoints, dp)
+			}
+		default:
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+
+	resourceAttrs := []otlpKeyValue{
+		{Key: "service.name", Value: otlpAnyString{StringValue: "oasis-node"}},
+		{Key: "service.version", Value: otlpAnyString{StringValue: version.SoftwareVersion}},
+	}
+	labels := make([]otlpKeyValue, 0, len(e.labels))
+	for k, v := range e.labels {
+		labels = append(labels, otlpKeyValue{Key: k, Value: otlpAnyString{StringValue: v}})
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].Key < labels[j].Key
+	})
+	resourceAttrs = append(resourceAttrs, labels...)
+
+	return &otlpRequest{
+		ResourceMetrics: []otlpResourceMetrics{
+			{
+				Resource: otlpResource{
+					Attributes: resourceAttrs,
+				},
+				ScopeMetrics: []otlpScopeMetrics{
+					{
+						Scope: otlpScope{
+							Name:    "github.com/oasisprotocol/oasis-core/go",
+							Version: version.SoftwareVersion,
+						},
+						Metrics: metrics,
+					},
+				},
+			},
+		},
+	}
+}
+
+func appendOtlpNumberDataPoint(dps []otlpNumberDataPoint, dp otlpNumberDataPoint) []otlpNumberDataPoint {
+	// JSON cannot represent non-finite values.
+	if math.IsNaN(dp.AsDouble) || math.IsInf(dp.AsDouble, 0) {
+		return dps
+	}
+	return append(dps, dp)
+}
+
+func otlpAttributes(lps []*dto.LabelPair) []otlpKeyValue {
+	if len(lps) == 0 {
+		return nil
+	}
+	attrs := make([]otlpKeyValue, 0, len(lps))
+	for _, lp := range lps {
+		attrs = append(attrs, otlpKeyValue{
+			Key:   lp.GetName(),
+			Value: otlpAnyString{StringValue: lp.GetValue()},
+		})
+	}
+	return attrs
+}
+
+func formatUnixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func newOtlpExporter(url string, labels map[string]string) *otlpExporter {
+	return &otlpExporter{
+		url:       url,
+		labels:    labels,
+		client:    &http.Client{},
+		startTime: time.Now(),
+	}
+}
@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// statsdMaxPacketSize is the maximum size of a StatsD packet that is safe to send over UDP
+// without fragmentation on typical networks.
+const statsdMaxPacketSize = 1432
+
+// statsdTagReplacer replaces characters that have a special meaning in StatsD lines.
+var statsdTagReplacer = strings.NewReplacer(",", "_", "|", "_", "\n", "_")
+
+// statsdExporter exports metrics to a StatsD server over UDP.
+//
+// Labels are exported as tags using the DogStatsD tag extension. Counters are exported as the
+// difference since the previous export, gauges as their current value. Histograms and summaries
+// are exported as their sum and count counters, with summary quantiles exported as gauges.
+type statsdExporter struct {
+	addr   string
+	labels map[string]string
+
+	conn net.Conn
+
+	// counters are the counter values at the time of the previous export.
+	counters map[string]float64
+}
+
+func (e *statsdExporter) Export(_ context.Context, mfs []*dto.MetricFamily) error {
+	if e.conn == nil {
+		conn, err := net.Dial("udp", e.addr)
+		if err != nil {
+			return fmt.Errorf("metrics/statsd: failed to dial: %w", err)
+		}
+		e.conn = conn
+	}
+
+	var packet []byte
+	for _, line := range e.lines(mfs) {
+		if len(packet) > 0 && len(packet)+len(line)+1 > statsdMaxPacketSize {
+			if _, err := e.conn.Write(packet); err != nil {
+				return fmt.Errorf("metrics/statsd: failed to write: %w", err)
+			}
+			packet = packet[:0]
+		}
+		if len(packet) > 0 {
+			packet = append(packet, '\n')
+		}
+		packet = append(packet, line...)
+	}
+	if len(packet) > 0 {
+		if _, err := e.conn.Write(packet); err != nil {
+			return fmt.Errorf("metrics/statsd: failed to write: %w", err)
+		}
+	}
+	return nil
+}
+
+// lines converts the given metric families to StatsD lines.
+func (e *statsdExporter) lines(mfs []*dto.MetricFamily) []string {
+	var lines []string
+	for _, mf := range mfs {
+		name := mf.GetName()
+		for _, m := range mf.GetMetric() {
+			tags := e.tags(m.GetLabel())
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				lines = e.appendCounter(lines, name, tags, m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				lines = appendStatsdLine(lines, name, m.GetGauge().GetValue(), "g", tags)
+			case dto.MetricType_UNTYPED:
+				lines = appendStatsdLine(lines, name, m.GetUntyped().GetValue(), "g", tags)
+			case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+				h := m.GetHistogram()
+				lines = e.appendCounter(lines, name+"_sum", tags, h.GetSampleSum())
+				lines = e.appendCounter(lines, name+"_count", tags, float64(h.GetSampleCount()))
+			case dto.MetricType_SUMMARY:
+				s := m.GetSummary()
+				lines = e.appendCounter(lines, name+"_sum", tags, s.GetSampleSum())
+				lines = e.appendCounter(lines, name+"_count", tags, float64(s.GetSampleCount()))
+				for _, q := range s.GetQuantile() {
+					qTags := append(append([]string{}, tags...), "quantile:"+formatStatsdValue(q.GetQuantile()))
+					lines = appendStatsdLine(lines, name, q.GetValue(), "g", qTags)
+				}
+			}
+		}
+	}
+	return lines
+}
+
+func (e *statsdExporter) appendCounter(lines []string, name string, tags []string, value float64) []string {
+	key := name + "|" + strings.Join(tags, ",")
+	delta := value - e.counters[key]
+	if delta < 0 {
+		// Counter has been reset.
+		delta = value
+	}
+	e.counters[key] = value
+	return appendStatsdLine(lines, name, delta, "c", tags)
+}
+
+// tags returns the sorted StatsD tags for the given metric labels and the configured labels.
+func (e *statsdExporter) tags(lps []*dto.LabelPair) []string {
+	tags := make([]string, 0, len(lps)+len(e.labels))
+	for k, v := range e.labels {
+		tags = append(tags, k+":"+statsdTagReplacer.Replace(v))
+	}
+	for _, lp := range lps {
+		tags = append(tags, lp.GetName()+":"+statsdTagReplacer.Replace(lp.GetValue()))
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+func appendStatsdLine(lines []string, name string, value float64, kind string, tags []string) []string {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return lines
+	}
+	line := name + ":" + formatStatsdValue(value) + "|" + kind
+	if len(tags) > 0 {
+		line += "|#" + strings.Join(tags, ",")
+	}
+	return append(lines, line)
+}
+
+func formatStatsdValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func newStatsdExporter(addr string, labels map[string]string) *statsdExporter {
+	return &statsdExporter{
+		addr:     addr,
+		labels:   labels,
+		counters: make(map[string]float64),
+	}
+}
@@ -2,7 +2,9 @@
 package pprof
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/http/pprof"
@@ -16,6 +18,21 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/config"
 )
 
+const (
+	// ProfilesDirName is the name of the directory holding captured profiles.
+	ProfilesDirName = "profiles"
+
+	// ProfileCPU is the name of the CPU profile.
+	ProfileCPU = "cpu"
+	// ProfileHeap is the name of the heap profile.
+	ProfileHeap = "heap"
+	// ProfileGoroutine is the name of the goroutine profile.
+	ProfileGoroutine = "goroutine"
+
+	// DefaultCPUProfileDuration is the default duration of CPU profiling.
+	DefaultCPUProfileDuration = 30 * time.Second
+)
+
 type pprofService struct {
 	service.BaseBackgroundService
 
@@ -47,6 +64,60 @@ func DumpHeapToFile(name string) error {
 	return nil
 }
 
+// WriteProfile writes the named profile of the current process to the given writer.
+//
+// CPU profiles are collected for the given duration (or until the context is canceled), other
+// profiles are snapshots taken at the time of the call.
+func WriteProfile(ctx context.Context, w io.Writer, name string, duration time.Duration) error {
+	switch name {
+	case ProfileCPU:
+		if duration <= 0 {
+			duration = DefaultCPUProfileDuration
+		}
+		if err := runtimePprof.StartCPUProfile(w); err != nil {
+			return fmt.Errorf("failed to start CPU profile: %w", err)
+		}
+		defer runtimePprof.StopCPUProfile()
+
+		select {
+		case <-time.After(duration):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	case ProfileHeap, ProfileGoroutine:
+		if name == ProfileHeap {
+			runtime.GC()
+		}
+		if err := runtimePprof.Lookup(name).WriteTo(w, 0); err != nil {
+			return fmt.Errorf("failed to write %s profile: %w", name, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported profile: %s", name)
+	}
+}
+
+// DumpProfileToDir writes the named profile of the current process to a file with unique suffix
+// in the given directory and returns the path of the file.
+func DumpProfileToDir(ctx context.Context, dir string, name string, duration time.Duration) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create profile directory: %w", err)
+	}
+	f, err := os.CreateTemp(dir, name+".*.pb")
+	if err != nil {
+		return "", fmt.Errorf("failed to create file for profiler output: %w", err)
+	}
+	defer f.Close()
+
+	if err = WriteProfile(ctx, f, name, duration); err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
 func (p *pprofService) Start() error {
 	if p.address == "" {
 		return nil
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	commandSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/command"
 	compositeSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/composite"
 	fileSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
@@ -40,6 +41,10 @@ const (
 	cfgSignerPluginName   = "signer.plugin.name"
 	cfgSignerPluginPath   = "signer.plugin.path"
 	cfgSignerPluginConfig = "signer.plugin.config"
+
+	cfgSignerCommandPath    = "signer.command.path"
+	cfgSignerCommandArgs    = "signer.command.args"
+	cfgSignerCommandTimeout = "signer.command.timeout"
 )
 
 var (
@@ -124,6 +129,13 @@ func doNewFactory(signerBackend, signerDir string, roles ...signature.SignerRole
 			Config: viper.GetString(cfgSignerPluginConfig),
 		}
 		return pluginSigner.NewFactory(config, roles...)
+	case commandSigner.SignerName:
+		config := &commandSigner.FactoryConfig{
+			Path:    viper.GetString(cfgSignerCommandPath),
+			Args:    viper.GetStringSlice(cfgSignerCommandArgs),
+			Timeout: viper.GetDuration(cfgSignerCommandTimeout),
+		}
+		return commandSigner.NewFactory(config, roles...)
 	default:
 		return nil, fmt.Errorf("unsupported signer backend: %s", signerBackend)
 	}
@@ -179,7 +191,7 @@ func doNewComposite(signerDir string, roles ...signature.SignerRole) (signature.
 }
 
 func init() {
-	Flags.StringP(CfgSigner, "s", "file", "signer backend [file, plugin, remote, composite]")
+	Flags.StringP(CfgSigner, "s", "file", "signer backend [file, plugin, remote, command, composite]")
 	Flags.String(cfgSignerRemoteAddress, "", "remote signer server address")
 	Flags.String(cfgSignerRemoteClientCert, "", "remote signer client certificate path")
 	Flags.String(cfgSignerRemoteClientKey, "", "remote signer client certificate key path")
@@ -188,6 +200,9 @@ func init() {
 	Flags.String(cfgSignerPluginName, "", "plugin signer backend name")
 	Flags.String(cfgSignerPluginPath, "", "plugin signer binary path")
 	Flags.String(cfgSignerPluginConfig, "", "plugin signer configuration")
+	Flags.String(cfgSignerCommandPath, "", "command signer executable path")
+	Flags.StringSlice(cfgSignerCommandArgs, nil, "command signer executable arguments (the role name is appended)")
+	Flags.Duration(cfgSignerCommandTimeout, commandSigner.DefaultTimeout, "command signer execution timeout")
 
 	_ = viper.BindPFlags(Flags)
 
@@ -0,0 +1,93 @@
+// Package config implements the config sub-commands.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/config"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+)
+
+var (
+	configCmd = &cobra.Command{
+		Use:   "config",
+		Short: "node configuration utilities",
+	}
+
+	dumpEffectiveCmd = &cobra.Command{
+		Use:   "dump-effective",
+		Args:  cobra.NoArgs,
+		Short: "print the resolved configuration with the source of each setting",
+		Long: `Print the fully resolved node configuration given the --config and
+--network flags. Each setting is annotated with its source, which is one of
+default, network (the network preset) or file (the config file).`,
+		RunE: doDumpEffective,
+	}
+
+	diffCmd = &cobra.Command{
+		Use:   "diff <config-a> <config-b>",
+		Args:  cobra.ExactArgs(2),
+		Short: "print the settings that differ between two config files",
+		Long: `Print the settings that differ between the configurations resolved from
+the two given config files. The --network flag is applied to both.`,
+		RunE: doDiff,
+	}
+)
+
+func doDumpEffective(cmd *cobra.Command, _ []string) error {
+	cmd.SilenceUsage = true
+
+	settings, err := config.EffectiveConfig(
+		viper.GetString(cmdCommon.CfgNetwork),
+		viper.GetString(cmdCommon.CfgConfigFile),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range settings {
+		fmt.Fprintf(os.Stdout, "%s = %s  # %s\n", s.Key, s.Value, s.Source)
+	}
+	return nil
+}
+
+func doDiff(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	network := viper.GetString(cmdCommon.CfgNetwork)
+	a, err := config.LoadConfig(network, args[0])
+	if a == nil {
+		return err
+	}
+	b, err := config.LoadConfig(network, args[1])
+	if b == nil {
+		return err
+	}
+
+	diffs, err := config.Diff(a, b)
+	if err != nil {
+		return err
+	}
+
+	formatValue := func(v *string) string {
+		if v == nil {
+			return "<unset>"
+		}
+		return *v
+	}
+	for _, d := range diffs {
+		fmt.Fprintf(os.Stdout, "%s\n  - %s\n  + %s\n", d.Key, formatValue(d.A), formatValue(d.B))
+	}
+	return nil
+}
+
+// Register registers the config sub-command and all of its children.
+func Register(parentCmd *cobra.Command) {
+	configCmd.AddCommand(dumpEffectiveCmd)
+	configCmd.AddCommand(diffCmd)
+	parentCmd.AddCommand(configCmd)
+}
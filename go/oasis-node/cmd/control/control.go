@@ -6,15 +6,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/backup"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	genesisFile "github.com/oasisprotocol/oasis-core/go/genesis/file"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/pprof"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/registration"
 )
@@ -22,6 +29,13 @@ import (
 var (
 	shutdownWait = false
 
+	runtimeLogsRuntime string
+	runtimeLogsFollow  bool
+
+	profileDuration time.Duration
+	profileOutput   string
+	profilePersist  bool
+
 	controlCmd = &cobra.Command{
 		Use:   "control",
 		Short: "node control interface utilities",
@@ -83,6 +97,35 @@ var (
 		Run:   doAddBundle,
 	}
 
+	controlProfileCmd = &cobra.Command{
+		Use:   "profile <cpu|heap|goroutine>",
+		Short: "capture a profile of the running node",
+		Args:  cobra.ExactArgs(1),
+		Run:   doProfile,
+	}
+
+	controlSnapshotCmd = &cobra.Command{
+		Use:   "snapshot <path>",
+		Short: "create a backup archive of the node state",
+		Long: "Create a backup archive of the node state. The archive does not contain the node's " +
+			"keys or the consensus validator's last sign state, which must be backed up separately.",
+		Args: cobra.ExactArgs(1),
+		Run:  doSnapshot,
+	}
+
+	controlRestoreCmd = &cobra.Command{
+		Use:   "restore <path>",
+		Short: "restore node state from a backup archive (the node must not be running)",
+		Args:  cobra.ExactArgs(1),
+		Run:   doRestore,
+	}
+
+	controlRuntimeLogsCmd = &cobra.Command{
+		Use:   "runtime-logs",
+		Short: "show captured output of a hosted runtime",
+		Run:   doRuntimeLogs,
+	}
+
 	logger = logging.GetLogger("cmd/control")
 )
 
@@ -320,12 +363,183 @@ func doAddBundle(cmd *cobra.Command, args []string) {
 	}
 }
 
+func doProfile(cmd *cobra.Command, args []string) {
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	rsp, err := client.GetProfile(context.Background(), &control.ProfileRequest{
+		Kind:     args[0],
+		Duration: profileDuration,
+		Persist:  profilePersist,
+	})
+	if err != nil {
+		logger.Error("failed to capture profile",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	if profilePersist {
+		fmt.Printf("Profile written to node data directory: %s\n", rsp.Path)
+		return
+	}
+
+	output := profileOutput
+	if output == "" {
+		output = args[0] + ".pb"
+	}
+	if err = os.WriteFile(output, rsp.Data, 0o600); err != nil {
+		logger.Error("failed to write profile",
+			"err", err,
+			"output", output,
+		)
+		os.Exit(1)
+	}
+	fmt.Printf("Profile written to: %s\n", output)
+}
+
+func doSnapshot(cmd *cobra.Command, args []string) {
+	path, err := filepath.Abs(args[0])
+	if err != nil {
+		logger.Error("failed to resolve snapshot path",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	manifest, err := client.CreateSnapshot(context.Background(), &control.SnapshotRequest{
+		Path: path,
+	})
+	if err != nil {
+		logger.Error("failed to create snapshot",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	prettyManifest, err := cmdCommon.PrettyJSONMarshal(manifest)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of snapshot manifest",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettyManifest))
+}
+
+func doRestore(_ *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	dataDir := cmdCommon.DataDir()
+	if dataDir == "" {
+		logger.Error("data directory not configured")
+		os.Exit(1)
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		logger.Error("failed to open backup archive",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	manifest, err := backup.Restore(f, dataDir, validateManifest)
+	if err != nil {
+		logger.Error("failed to restore backup archive",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Restored node state at consensus height %d into: %s\n", manifest.Consensus.Height, dataDir)
+	fmt.Println("Node keys are not part of the backup, copy them into the data directory before starting the node.")
+}
+
+// validateManifest makes sure that the backup manifest matches the configured network.
+func validateManifest(manifest *backup.Manifest) error {
+	if expected := config.GlobalConfig.Genesis.ChainContext; expected != "" && expected != manifest.ChainContext {
+		return fmt.Errorf("backup is for a different chain context (expected: %s got: %s)",
+			expected,
+			manifest.ChainContext,
+		)
+	}
+	if config.GlobalConfig.Genesis.File == "" {
+		return nil
+	}
+
+	provider, err := genesisFile.DefaultFileProvider()
+	if err != nil {
+		return fmt.Errorf("failed to load genesis document: %w", err)
+	}
+	genesisDoc, err := provider.GetGenesisDocument()
+	if err != nil {
+		return fmt.Errorf("failed to load genesis document: %w", err)
+	}
+	if genesisDoc.ChainContext() != manifest.ChainContext {
+		return fmt.Errorf("backup does not match the configured genesis document (expected: %s got: %s)",
+			genesisDoc.ChainContext(),
+			manifest.ChainContext,
+		)
+	}
+	if manifest.Consensus.GenesisHeight != genesisDoc.Height {
+		return fmt.Errorf("backup has an unexpected genesis height (expected: %d got: %d)",
+			genesisDoc.Height,
+			manifest.Consensus.GenesisHeight,
+		)
+	}
+	return nil
+}
+
+func doRuntimeLogs(cmd *cobra.Command, _ []string) {
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(runtimeLogsRuntime); err != nil {
+		logger.Error("malformed runtime identifier",
+			"err", err,
+			"runtime_id", runtimeLogsRuntime,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	ch, sub, err := client.WatchRuntimeLogs(context.Background(), &control.RuntimeLogsRequest{
+		RuntimeID: runtimeID,
+		Follow:    runtimeLogsFollow,
+	})
+	if err != nil {
+		logger.Error("failed to fetch runtime logs",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	defer sub.Close()
+
+	for line := range ch {
+		fmt.Println(line)
+	}
+}
+
 // Register registers the client sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	controlCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
 
 	controlShutdownCmd.Flags().BoolVarP(&shutdownWait, "wait", "w", false, "wait for the node to finish shutdown")
 
+	controlProfileCmd.Flags().DurationVar(&profileDuration, "duration", pprof.DefaultCPUProfileDuration, "duration of CPU profiling")
+	controlProfileCmd.Flags().StringVarP(&profileOutput, "output", "o", "", "output file (defaults to <kind>.pb)")
+	controlProfileCmd.Flags().BoolVar(&profilePersist, "persist", false, "write the profile to the node's data directory instead")
+
+	controlRuntimeLogsCmd.Flags().StringVar(&runtimeLogsRuntime, "runtime", "", "runtime identifier (hex)")
+	controlRuntimeLogsCmd.Flags().BoolVarP(&runtimeLogsFollow, "follow", "f", false, "stream new output as it is produced")
+
 	controlCmd.AddCommand(controlIsSyncedCmd)
 	controlCmd.AddCommand(controlWaitSyncCmd)
 	controlCmd.AddCommand(controlShutdownCmd)
@@ -335,5 +549,9 @@ func Register(parentCmd *cobra.Command) {
 	controlCmd.AddCommand(controlStatusCmd)
 	controlCmd.AddCommand(controlRuntimeStatsCmd)
 	controlCmd.AddCommand(controlAddBundleCmd)
+	controlCmd.AddCommand(controlProfileCmd)
+	controlCmd.AddCommand(controlRuntimeLogsCmd)
+	controlCmd.AddCommand(controlSnapshotCmd)
+	controlCmd.AddCommand(controlRestoreCmd)
 	parentCmd.AddCommand(controlCmd)
 }
@@ -7,7 +7,11 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/beacon"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/byzantine"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/control"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/diffepochs"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/dumpdb"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/dumpobject"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/querystate"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/registry"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/storage"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/txsource"
 )
@@ -25,7 +29,11 @@ func Register(parentCmd *cobra.Command) {
 	txsource.Register(debugCmd)
 	control.Register(debugCmd)
 	dumpdb.Register(debugCmd)
+	dumpobject.Register(debugCmd)
 	beacon.Register(debugCmd)
+	registry.Register(debugCmd)
+	diffepochs.Register(debugCmd)
+	querystate.Register(debugCmd)
 
 	parentCmd.AddCommand(debugCmd)
 }
@@ -0,0 +1,315 @@
+// Package diffepochs implements the diff-epochs sub-command.
+package diffepochs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+const (
+	cfgFrom = "from"
+	cfgTo   = "to"
+)
+
+var (
+	diffEpochsCmd = &cobra.Command{
+		Use:   "diff-epochs",
+		Short: "show registry and scheduler changes between two epochs",
+		Long: "Compare the registered nodes and runtimes, the validator set and the elected " +
+			"runtime committees at the start of two epochs and print what changed.",
+		Run: doDiffEpochs,
+	}
+
+	diffEpochsFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	logger = logging.GetLogger("cmd/debug/diffepochs")
+)
+
+// epochSnapshot is a snapshot of the registry and scheduler state at the start of an epoch.
+type epochSnapshot struct {
+	nodes      map[signature.PublicKey]*node.Node
+	runtimes   map[common.Namespace]*registry.Runtime
+	suspended  map[common.Namespace]bool
+	validators map[signature.PublicKey]*scheduler.Validator
+	committees map[common.Namespace]map[scheduler.CommitteeKind]map[signature.PublicKey]scheduler.Role
+}
+
+type clients struct {
+	beacon    beacon.Backend
+	registry  registry.Backend
+	scheduler scheduler.Backend
+}
+
+func fetchSnapshot(ctx context.Context, c *clients, epoch beacon.EpochTime) (*epochSnapshot, error) {
+	height, err := c.beacon.GetEpochBlock(ctx, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query height of epoch %d: %w", epoch, err)
+	}
+
+	nodes, err := c.registry.GetNodes(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query nodes: %w", err)
+	}
+	runtimes, err := c.registry.GetRuntimes(ctx, &registry.GetRuntimesQuery{Height: height, IncludeSuspended: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query runtimes: %w", err)
+	}
+	active, err := c.registry.GetRuntimes(ctx, &registry.GetRuntimesQuery{Height: height})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query active runtimes: %w", err)
+	}
+	validators, err := c.scheduler.GetValidators(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query validators: %w", err)
+	}
+
+	snap := &epochSnapshot{
+		nodes:      make(map[signature.PublicKey]*node.Node),
+		runtimes:   make(map[common.Namespace]*registry.Runtime),
+		suspended:  make(map[common.Namespace]bool),
+		validators: make(map[signature.PublicKey]*scheduler.Validator),
+		committees: make(map[common.Namespace]map[scheduler.CommitteeKind]map[signature.PublicKey]scheduler.Role),
+	}
+	for _, n := range nodes {
+		snap.nodes[n.ID] = n
+	}
+	for _, rt := range runtimes {
+		snap.runtimes[rt.ID] = rt
+		snap.suspended[rt.ID] = true
+	}
+	for _, rt := range active {
+		delete(snap.suspended, rt.ID)
+	}
+	for _, v := range validators {
+		snap.validators[v.ID] = v
+	}
+	for id := range snap.runtimes {
+		committees, cerr := c.scheduler.GetCommittees(ctx, &scheduler.GetCommitteesRequest{Height: height, RuntimeID: id})
+		if cerr != nil {
+			return nil, fmt.Errorf("failed to query committees of runtime %s: %w", id, cerr)
+		}
+		byKind := make(map[scheduler.CommitteeKind]map[signature.PublicKey]scheduler.Role)
+		for _, committee := range committees {
+			members := make(map[signature.PublicKey]scheduler.Role)
+			for _, m := range committee.Members {
+				members[m.PublicKey] = m.Role
+			}
+			byKind[committee.Kind] = members
+		}
+		snap.committees[id] = byKind
+	}
+
+	return snap, nil
+}
+
+// diffSnapshots returns a human readable list of changes between the given snapshots.
+func diffSnapshots(from, to *epochSnapshot) []string {
+	var changes []string
+
+	// Nodes.
+	nodeIDs := make(map[signature.PublicKey]bool)
+	for id := range from.nodes {
+		nodeIDs[id] = true
+	}
+	for id := range to.nodes {
+		nodeIDs[id] = true
+	}
+	for _, id := range sortedPublicKeys(nodeIDs) {
+		prev, next := from.nodes[id], to.nodes[id]
+		switch {
+		case prev == nil:
+			changes = append(changes, fmt.Sprintf("node %s joined (entity: %s, roles: %s)", id, next.EntityID, next.Roles))
+		case next == nil:
+			changes = append(changes, fmt.Sprintf("node %s left (entity: %s, roles: %s)", id, prev.EntityID, prev.Roles))
+		case prev.Roles != next.Roles:
+			changes = append(changes, fmt.Sprintf("node %s roles changed: %s -> %s", id, prev.Roles, next.Roles))
+		}
+	}
+
+	// Runtimes.
+	runtimeIDs := make(map[common.Namespace]bool)
+	for id := range from.runtimes {
+		runtimeIDs[id] = true
+	}
+	for id := range to.runtimes {
+		runtimeIDs[id] = true
+	}
+	sortedRuntimeIDs := sortedNamespaces(runtimeIDs)
+	for _, id := range sortedRuntimeIDs {
+		prev, next := from.runtimes[id], to.runtimes[id]
+		switch {
+		case prev == nil:
+			changes = append(changes, fmt.Sprintf("runtime %s registered", id))
+		case next == nil:
+			changes = append(changes, fmt.Sprintf("runtime %s removed", id))
+		default:
+			if !bytes.Equal(cbor.Marshal(prev), cbor.Marshal(next)) {
+				changes = append(changes, fmt.Sprintf("runtime %s descriptor updated", id))
+			}
+			switch {
+			case !from.suspended[id] && to.suspended[id]:
+				changes = append(changes, fmt.Sprintf("runtime %s suspended", id))
+			case from.suspended[id] && !to.suspended[id]:
+				changes = append(changes, fmt.Sprintf("runtime %s resumed", id))
+			}
+		}
+	}
+
+	// Validators.
+	validatorIDs := make(map[signature.PublicKey]bool)
+	for id := range from.validators {
+		validatorIDs[id] = true
+	}
+	for id := range to.validators {
+		validatorIDs[id] = true
+	}
+	for _, id := range sortedPublicKeys(validatorIDs) {
+		prev, next := from.validators[id], to.validators[id]
+		switch {
+		case prev == nil:
+			changes = append(changes, fmt.Sprintf("validator %s joined (voting power: %d)", id, next.VotingPower))
+		case next == nil:
+			changes = append(changes, fmt.Sprintf("validator %s left (voting power: %d)", id, prev.VotingPower))
+		case prev.VotingPower != next.VotingPower:
+			changes = append(changes, fmt.Sprintf("validator %s voting power changed: %d -> %d", id, prev.VotingPower, next.VotingPower))
+		}
+	}
+
+	// Committees.
+	for _, id := range sortedRuntimeIDs {
+		for kind := scheduler.KindComputeExecutor; kind < scheduler.MaxCommitteeKind; kind++ {
+			prev, next := from.committees[id][kind], to.committees[id][kind]
+
+			memberIDs := make(map[signature.PublicKey]bool)
+			for nodeID := range prev {
+				memberIDs[nodeID] = true
+			}
+			for nodeID := range next {
+				memberIDs[nodeID] = true
+			}
+			for _, nodeID := range sortedPublicKeys(memberIDs) {
+				prevRole, wasMember := prev[nodeID]
+				nextRole, isMember := next[nodeID]
+				switch {
+				case !wasMember:
+					changes = append(changes, fmt.Sprintf("runtime %s %s committee: node %s joined as %s", id, kind, nodeID, nextRole))
+				case !isMember:
+					changes = append(changes, fmt.Sprintf("runtime %s %s committee: node %s left (was %s)", id, kind, nodeID, prevRole))
+				case prevRole != nextRole:
+					changes = append(changes, fmt.Sprintf("runtime %s %s committee: node %s role changed: %s -> %s", id, kind, nodeID, prevRole, nextRole))
+				}
+			}
+		}
+	}
+
+	return changes
+}
+
+func sortedPublicKeys(set map[signature.PublicKey]bool) []signature.PublicKey {
+	keys := make([]signature.PublicKey, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i][:], keys[j][:]) < 0
+	})
+	return keys
+}
+
+func sortedNamespaces(set map[common.Namespace]bool) []common.Namespace {
+	ids := make([]common.Namespace, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(ids[i][:], ids[j][:]) < 0
+	})
+	return ids
+}
+
+func doConnect(cmd *cobra.Command) (*grpc.ClientConn, *clients) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	conn, err := cmdGrpc.NewClient(cmd)
+	if err != nil {
+		logger.Error("failed to establish connection with node",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	return conn, &clients{
+		beacon:    beacon.NewClient(conn),
+		registry:  registry.NewClient(conn),
+		scheduler: scheduler.NewClient(conn),
+	}
+}
+
+func doDiffEpochs(cmd *cobra.Command, _ []string) {
+	conn, c := doConnect(cmd)
+	defer conn.Close()
+
+	ctx := context.Background()
+	fromEpoch := beacon.EpochTime(viper.GetUint64(cfgFrom))
+	toEpoch := beacon.EpochTime(viper.GetUint64(cfgTo))
+
+	from, err := fetchSnapshot(ctx, c, fromEpoch)
+	if err != nil {
+		logger.Error("failed to fetch state",
+			"err", err,
+			"epoch", fromEpoch,
+		)
+		os.Exit(1)
+	}
+	to, err := fetchSnapshot(ctx, c, toEpoch)
+	if err != nil {
+		logger.Error("failed to fetch state",
+			"err", err,
+			"epoch", toEpoch,
+		)
+		os.Exit(1)
+	}
+
+	changes := diffSnapshots(from, to)
+	if len(changes) == 0 {
+		fmt.Printf("no changes between epochs %d and %d\n", fromEpoch, toEpoch)
+		return
+	}
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+}
+
+// Register registers the diff-epochs sub-command.
+func Register(parentCmd *cobra.Command) {
+	diffEpochsCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+	diffEpochsCmd.Flags().AddFlagSet(diffEpochsFlags)
+	parentCmd.AddCommand(diffEpochsCmd)
+}
+
+func init() {
+	diffEpochsFlags.Uint64(cfgFrom, 0, "epoch to compare from")
+	diffEpochsFlags.Uint64(cfgTo, 0, "epoch to compare to")
+	_ = viper.BindPFlags(diffEpochsFlags)
+}
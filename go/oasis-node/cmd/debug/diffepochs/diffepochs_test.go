@@ -0,0 +1,70 @@
+package diffepochs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+func newSnapshot() *epochSnapshot {
+	return &epochSnapshot{
+		nodes:      make(map[signature.PublicKey]*node.Node),
+		runtimes:   make(map[common.Namespace]*registry.Runtime),
+		suspended:  make(map[common.Namespace]bool),
+		validators: make(map[signature.PublicKey]*scheduler.Validator),
+		committees: make(map[common.Namespace]map[scheduler.CommitteeKind]map[signature.PublicKey]scheduler.Role),
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	require := require.New(t)
+
+	nodeA := signature.NewPublicKey("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	nodeB := signature.NewPublicKey("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	nodeC := signature.NewPublicKey("cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc")
+	rtID := common.NewTestNamespaceFromSeed([]byte("diff epochs test"), 0)
+
+	from, to := newSnapshot(), newSnapshot()
+	require.Empty(diffSnapshots(from, to))
+
+	from.nodes[nodeA] = &node.Node{ID: nodeA, Roles: node.RoleComputeWorker}
+	from.nodes[nodeB] = &node.Node{ID: nodeB, Roles: node.RoleComputeWorker}
+	to.nodes[nodeB] = &node.Node{ID: nodeB, Roles: node.RoleComputeWorker | node.RoleObserver}
+	to.nodes[nodeC] = &node.Node{ID: nodeC, Roles: node.RoleValidator}
+
+	from.runtimes[rtID] = &registry.Runtime{ID: rtID}
+	to.runtimes[rtID] = &registry.Runtime{ID: rtID}
+	to.suspended[rtID] = true
+
+	to.validators[nodeC] = &scheduler.Validator{ID: nodeC, VotingPower: 10}
+
+	from.committees[rtID] = map[scheduler.CommitteeKind]map[signature.PublicKey]scheduler.Role{
+		scheduler.KindComputeExecutor: {
+			nodeA: scheduler.RoleWorker,
+			nodeB: scheduler.RoleBackupWorker,
+		},
+	}
+	to.committees[rtID] = map[scheduler.CommitteeKind]map[signature.PublicKey]scheduler.Role{
+		scheduler.KindComputeExecutor: {
+			nodeB: scheduler.RoleWorker,
+		},
+	}
+
+	changes := diffSnapshots(from, to)
+	require.Len(changes, 7)
+	require.Contains(changes[0], "node "+nodeA.String()+" left")
+	require.Contains(changes[1], "node "+nodeB.String()+" roles changed")
+	require.Contains(changes[2], "node "+nodeC.String()+" joined")
+	require.Equal("runtime "+rtID.String()+" suspended", changes[3])
+	require.Equal("validator "+nodeC.String()+" joined (voting power: 10)", changes[4])
+	require.Contains(changes[5], "node "+nodeA.String()+" left (was worker)")
+	require.Contains(changes[6], "node "+nodeB.String()+" role changed: backup-worker -> worker")
+
+	require.Empty(diffSnapshots(to, to))
+}
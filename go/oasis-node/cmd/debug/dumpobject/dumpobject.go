@@ -0,0 +1,158 @@
+// Package dumpobject implements the dump-object sub-command.
+package dumpobject
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
+)
+
+const (
+	cfgType     = "type"
+	cfgEncoding = "encoding"
+
+	encodingAuto   = "auto"
+	encodingHex    = "hex"
+	encodingBase64 = "base64"
+)
+
+var (
+	dumpObjectCmd = &cobra.Command{
+		Use:   "dump-object [<data>]",
+		Short: "decode a CBOR-encoded on-chain object into JSON",
+		Long: "Decode a hex or base64 encoded CBOR object into JSON using the Go type " +
+			"definitions. If no data is given, it is read from standard input.",
+		Args: cobra.MaximumNArgs(1),
+		Run:  doDumpObject,
+	}
+
+	dumpObjectFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	logger = logging.GetLogger("cmd/debug/dumpobject")
+)
+
+// objectTypes maps object type names to constructors of the corresponding Go types.
+var objectTypes = map[string]func() interface{}{
+	"header":     func() interface{} { return new(block.Header) },
+	"node":       func() interface{} { return new(node.Node) },
+	"runtime":    func() interface{} { return new(registry.Runtime) },
+	"commitment": func() interface{} { return new(commitment.ExecutorCommitment) },
+}
+
+func objectTypeNames() []string {
+	names := make([]string, 0, len(objectTypes))
+	for name := range objectTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// decodeData decodes the textual representation of raw CBOR data.
+func decodeData(data, encoding string) ([]byte, error) {
+	data = strings.TrimSpace(data)
+	switch encoding {
+	case encodingHex:
+		return hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	case encodingBase64:
+		return base64.StdEncoding.DecodeString(data)
+	case encodingAuto:
+		if raw, err := hex.DecodeString(strings.TrimPrefix(data, "0x")); err == nil {
+			return raw, nil
+		}
+		raw, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, fmt.Errorf("data is neither valid hex nor base64")
+		}
+		return raw, nil
+	default:
+		return nil, fmt.Errorf("unknown encoding: %s", encoding)
+	}
+}
+
+// decodeObject decodes raw CBOR data into an object of the given type.
+func decodeObject(typ string, raw []byte) (interface{}, error) {
+	newObj, ok := objectTypes[typ]
+	if !ok {
+		return nil, fmt.Errorf("unknown object type: %s (supported: %s)", typ, strings.Join(objectTypeNames(), ", "))
+	}
+	obj := newObj()
+	if err := cbor.Unmarshal(raw, obj); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", typ, err)
+	}
+	return obj, nil
+}
+
+func doDumpObject(_ *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	var data string
+	switch len(args) {
+	case 0:
+		rawData, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			logger.Error("failed to read data from standard input",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		data = string(rawData)
+	default:
+		data = args[0]
+	}
+
+	raw, err := decodeData(data, viper.GetString(cfgEncoding))
+	if err != nil {
+		logger.Error("failed to decode data",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	obj, err := decodeObject(viper.GetString(cfgType), raw)
+	if err != nil {
+		logger.Error("failed to decode object",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	prettyJSON, err := cmdCommon.PrettyJSONMarshal(obj)
+	if err != nil {
+		logger.Error("failed to marshal object",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettyJSON))
+}
+
+// Register registers the dump-object sub-command.
+func Register(parentCmd *cobra.Command) {
+	dumpObjectCmd.Flags().AddFlagSet(dumpObjectFlags)
+	parentCmd.AddCommand(dumpObjectCmd)
+}
+
+func init() {
+	dumpObjectFlags.String(cfgType, "", fmt.Sprintf("object type (%s)", strings.Join(objectTypeNames(), ", ")))
+	dumpObjectFlags.String(cfgEncoding, encodingAuto, "data encoding (auto, hex, base64)")
+	_ = viper.BindPFlags(dumpObjectFlags)
+}
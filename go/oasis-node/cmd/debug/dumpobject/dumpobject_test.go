@@ -0,0 +1,49 @@
+package dumpobject
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+)
+
+func TestDecodeObject(t *testing.T) {
+	require := require.New(t)
+
+	hdr := block.Header{
+		Round:      42,
+		HeaderType: block.Normal,
+	}
+	raw := cbor.Marshal(&hdr)
+
+	for _, tc := range []struct {
+		data     string
+		encoding string
+	}{
+		{hex.EncodeToString(raw), encodingHex},
+		{"0x" + hex.EncodeToString(raw), encodingAuto},
+		{base64.StdEncoding.EncodeToString(raw), encodingBase64},
+		{base64.StdEncoding.EncodeToString(raw), encodingAuto},
+	} {
+		decoded, err := decodeData(tc.data, tc.encoding)
+		require.NoError(err, "decodeData(%s)", tc.encoding)
+		require.Equal(raw, decoded)
+	}
+
+	obj, err := decodeObject("header", raw)
+	require.NoError(err, "decodeObject")
+	require.Equal(&hdr, obj)
+
+	_, err = decodeObject("node", raw)
+	require.Error(err, "decoding into the wrong type should fail")
+
+	_, err = decodeObject("unknown", raw)
+	require.Error(err, "unknown object types should be rejected")
+
+	_, err = decodeData("not valid!", encodingAuto)
+	require.Error(err, "invalid data should be rejected")
+}
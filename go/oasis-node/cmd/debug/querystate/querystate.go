@@ -0,0 +1,306 @@
+// Package querystate implements the query-state sub-command.
+package querystate
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci"
+	cmtAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	cmtCommon "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/common"
+	genesisFile "github.com/oasisprotocol/oasis-core/go/genesis/file"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+)
+
+const (
+	cfgHeight     = "query_state.height"
+	cfgPrefix     = "query_state.prefix"
+	cfgLimit      = "query_state.limit"
+	cfgReadOnlyDB = "query_state.read_only_db"
+)
+
+var (
+	queryStateCmd = &cobra.Command{
+		Use:   "query-state",
+		Short: "query raw ABCI application state at a retained height",
+		Long: "Iterate over the raw key/value pairs of the local ABCI application state at the " +
+			"given height, starting with the given hex-encoded key prefix, and print them as JSON. " +
+			"Values of known state keys are decoded into their Go types, other CBOR values are " +
+			"decoded generically. The node must not be running.",
+		Run: doQueryState,
+	}
+
+	queryStateFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	logger = logging.GetLogger("cmd/debug/querystate")
+)
+
+// knownTypes maps ABCI state key prefixes to constructors of the Go types of their values.
+var knownTypes = map[byte]struct {
+	name string
+	new  func() interface{}
+}{
+	// Registry.
+	0x10: {"registry/signed_entity", func() interface{} { return new(entity.SignedEntity) }},
+	0x11: {"registry/signed_node", func() interface{} { return new(node.MultiSignedNode) }},
+	0x13: {"registry/runtime", func() interface{} { return new(registry.Runtime) }},
+	0x15: {"registry/node_status", func() interface{} { return new(registry.NodeStatus) }},
+	0x16: {"registry/parameters", func() interface{} { return new(registry.ConsensusParameters) }},
+	0x18: {"registry/suspended_runtime", func() interface{} { return new(registry.Runtime) }},
+	// Root hash.
+	0x20: {"roothash/runtime_state", func() interface{} { return new(roothash.RuntimeState) }},
+	0x21: {"roothash/parameters", func() interface{} { return new(roothash.ConsensusParameters) }},
+	// Staking.
+	0x50: {"staking/account", func() interface{} { return new(staking.Account) }},
+	0x51: {"staking/total_supply", func() interface{} { return new(quantity.Quantity) }},
+	0x52: {"staking/common_pool", func() interface{} { return new(quantity.Quantity) }},
+	0x53: {"staking/delegation", func() interface{} { return new(staking.Delegation) }},
+	0x54: {"staking/debonding_delegation", func() interface{} { return new(staking.DebondingDelegation) }},
+	0x56: {"staking/parameters", func() interface{} { return new(staking.ConsensusParameters) }},
+	0x57: {"staking/last_block_fees", func() interface{} { return new(quantity.Quantity) }},
+	0x59: {"staking/governance_deposits", func() interface{} { return new(quantity.Quantity) }},
+}
+
+// stateEntry is a key/value pair of the ABCI application state.
+type stateEntry struct {
+	// Key is the hex-encoded key.
+	Key string `json:"key"`
+	// Type is the name of the known value type, if any.
+	Type string `json:"type,omitempty"`
+	// Value is the decoded value, if the value could be decoded.
+	Value interface{} `json:"value,omitempty"`
+	// Raw is the hex-encoded raw value, if the value could not be decoded.
+	Raw string `json:"raw,omitempty"`
+}
+
+// decodeEntry decodes the given key/value pair, using the Go type of the value if the key
+// prefix is known, falling back to generic CBOR decoding and finally to the raw value.
+func decodeEntry(key, value []byte) *stateEntry {
+	entry := &stateEntry{
+		Key: hex.EncodeToString(key),
+	}
+	if len(value) == 0 {
+		return entry
+	}
+
+	if len(key) > 0 {
+		if typ, ok := knownTypes[key[0]]; ok {
+			obj := typ.new()
+			if err := cbor.Unmarshal(value, obj); err == nil {
+				entry.Type = typ.name
+				entry.Value = obj
+				return entry
+			}
+		}
+	}
+
+	var generic interface{}
+	if err := cbor.Unmarshal(value, &generic); err == nil {
+		entry.Value = jsonValue(generic)
+		return entry
+	}
+
+	entry.Raw = hex.EncodeToString(value)
+	return entry
+}
+
+// jsonValue converts a generically decoded CBOR value into a value that can be marshalled into
+// JSON, as CBOR maps can have non-string keys.
+func jsonValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			var key string
+			switch k := k.(type) {
+			case string:
+				key = k
+			case []byte:
+				key = hex.EncodeToString(k)
+			default:
+				key = fmt.Sprintf("%v", k)
+			}
+			m[key] = jsonValue(val)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, 0, len(v))
+		for _, val := range v {
+			s = append(s, jsonValue(val))
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+// queryTree returns up to limit entries with keys starting with the given prefix.
+func queryTree(ctx context.Context, tree mkvs.ImmutableKeyValueTree, prefix []byte, limit uint64) ([]*stateEntry, error) {
+	it := tree.NewIterator(ctx)
+	defer it.Close()
+
+	entries := []*stateEntry{}
+	for it.Seek(prefix); it.Valid(); it.Next() {
+		if !bytes.HasPrefix(it.Key(), prefix) {
+			break
+		}
+		if limit > 0 && uint64(len(entries)) >= limit {
+			break
+		}
+		entries = append(entries, decodeEntry(it.Key(), it.Value()))
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func doQueryState(*cobra.Command, []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	dataDir := cmdCommon.DataDir()
+	if dataDir == "" {
+		logger.Error("data directory must be set")
+		os.Exit(1)
+	}
+
+	prefix, err := hex.DecodeString(strings.TrimPrefix(viper.GetString(cfgPrefix), "0x"))
+	if err != nil {
+		logger.Error("malformed key prefix",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	fp, err := genesisFile.NewFileProvider(flags.GenesisFile())
+	if err != nil {
+		logger.Error("failed to load genesis document",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	doc, err := fp.GetGenesisDocument()
+	if err != nil {
+		logger.Error("failed to get genesis document",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	ldb, _, stateRoot, err := abci.InitStateStorage(
+		&abci.ApplicationConfig{
+			DataDir:             filepath.Join(dataDir, cmtCommon.StateDir),
+			StorageBackend:      config.GlobalConfig.Storage.Backend,
+			MemoryOnlyStorage:   false,
+			ReadOnlyStorage:     viper.GetBool(cfgReadOnlyDB),
+			DisableCheckpointer: true,
+			ChainContext:        doc.ChainContext(),
+		},
+	)
+	if err != nil {
+		logger.Error("failed to initialize ABCI storage backend",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	defer ldb.Cleanup()
+
+	qs := &queryState{
+		ldb:    ldb,
+		height: int64(stateRoot.Version),
+	}
+	height := viper.GetInt64(cfgHeight)
+	is, err := cmtAPI.NewImmutableState(ctx, qs, height)
+	if err != nil {
+		logger.Error("failed to get state at the requested height (it may have been pruned)",
+			"err", err,
+			"height", height,
+			"latest_height", qs.height,
+		)
+		os.Exit(1)
+	}
+
+	entries, err := queryTree(ctx, is, prefix, viper.GetUint64(cfgLimit))
+	if err != nil {
+		logger.Error("failed to query state",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	prettyJSON, err := cmdCommon.PrettyJSONMarshal(entries)
+	if err != nil {
+		logger.Error("failed to marshal state entries",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettyJSON))
+}
+
+// queryState is the application query state backed by local ABCI state storage.
+type queryState struct {
+	ldb    storage.LocalBackend
+	height int64
+}
+
+func (qs *queryState) Storage() storage.LocalBackend {
+	return qs.ldb
+}
+
+func (qs *queryState) Checkpointer() checkpoint.Checkpointer {
+	return nil
+}
+
+func (qs *queryState) BlockHeight() int64 {
+	return qs.height
+}
+
+func (qs *queryState) GetEpoch(context.Context, int64) (beacon.EpochTime, error) {
+	return beacon.EpochTime(0), fmt.Errorf("querystate/queryState: GetEpoch not supported")
+}
+
+func (qs *queryState) LastRetainedVersion() (int64, error) {
+	return 0, fmt.Errorf("querystate/queryState: LastRetainedVersion not supported")
+}
+
+// Register registers the query-state sub-command.
+func Register(parentCmd *cobra.Command) {
+	queryStateCmd.Flags().AddFlagSet(flags.GenesisFileFlags)
+	queryStateCmd.Flags().AddFlagSet(queryStateFlags)
+	parentCmd.AddCommand(queryStateCmd)
+}
+
+func init() {
+	queryStateFlags.Int64(cfgHeight, 0, "ABCI state height to query (0 = most recent)")
+	queryStateFlags.String(cfgPrefix, "", "hex-encoded key prefix (empty = all keys)")
+	queryStateFlags.Uint64(cfgLimit, 100, "maximum number of entries to print (0 = unlimited)")
+	queryStateFlags.Bool(cfgReadOnlyDB, false, "read-only DB access")
+	_ = viper.BindPFlags(queryStateFlags)
+}
@@ -0,0 +1,72 @@
+package querystate
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+func TestQueryTree(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	tree := mkvs.New(nil, nil, node.RootTypeState)
+	defer tree.Close()
+
+	var account staking.Account
+	err := account.General.Balance.FromUint64(42)
+	require.NoError(err, "FromUint64")
+	totalSupply := quantity.NewFromUint64(1000)
+
+	entries := map[string][]byte{
+		"5001": cbor.Marshal(&account),
+		"5002": cbor.Marshal(&account),
+		"51":   cbor.Marshal(totalSupply),
+		"f001": cbor.Marshal(map[uint64]string{1: "one"}),
+		"f002": {0xff, 0xff},
+	}
+	for key, value := range entries {
+		rawKey, herr := hex.DecodeString(key)
+		require.NoError(herr, "DecodeString")
+		err = tree.Insert(ctx, rawKey, value)
+		require.NoError(err, "Insert")
+	}
+
+	// Known types should be decoded into their Go types.
+	result, err := queryTree(ctx, tree, []byte{0x50}, 0)
+	require.NoError(err, "queryTree")
+	require.Len(result, 2)
+	require.Equal("5001", result[0].Key)
+	require.Equal("staking/account", result[0].Type)
+	require.Equal(&account, result[0].Value)
+
+	// The limit should be respected.
+	result, err = queryTree(ctx, tree, []byte{0x50}, 1)
+	require.NoError(err, "queryTree")
+	require.Len(result, 1)
+
+	// Unknown CBOR values should be decoded generically and non-CBOR values returned raw.
+	result, err = queryTree(ctx, tree, []byte{0xf0}, 0)
+	require.NoError(err, "queryTree")
+	require.Len(result, 2)
+	require.Empty(result[0].Type)
+	require.Equal(map[string]interface{}{"1": "one"}, result[0].Value)
+	require.Equal("ffff", result[1].Raw)
+	_, err = cmdCommon.PrettyJSONMarshal(result)
+	require.NoError(err, "PrettyJSONMarshal")
+
+	// All entries should be returned for an empty prefix.
+	result, err = queryTree(ctx, tree, nil, 0)
+	require.NoError(err, "queryTree")
+	require.Len(result, len(entries))
+	require.Equal("staking/total_supply", result[2].Type)
+}
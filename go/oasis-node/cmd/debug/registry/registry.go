@@ -0,0 +1,150 @@
+// Package registry implements the registry debug sub-commands.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+var (
+	registryCmd = &cobra.Command{
+		Use:   "registry",
+		Short: "debug the registry",
+	}
+
+	registryVerifyCmd = &cobra.Command{
+		Use:   "verify",
+		Short: "verify registry state consistency",
+		Long: "Walk the registry state of a live node or, if a genesis file is given, " +
+			"of a genesis dump and report any inconsistencies found.",
+		Run: doVerify,
+	}
+
+	logger = logging.GetLogger("cmd/debug/registry")
+)
+
+// registryState is the registry state together with the context needed
+// to verify it.
+type registryState struct {
+	state             *registry.Genesis
+	epoch             beacon.EpochTime
+	debondingInterval beacon.EpochTime
+}
+
+func loadFromGenesis(filename string) (*registryState, error) {
+	// Avoid the file genesis provider as it refuses to load documents
+	// which fail the sanity checks.
+	raw, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read genesis file: %w", err)
+	}
+	var doc genesis.Document
+	if err = json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("malformed genesis file: %w", err)
+	}
+
+	return &registryState{
+		state:             &doc.Registry,
+		epoch:             doc.Beacon.Base,
+		debondingInterval: doc.Staking.Parameters.DebondingInterval,
+	}, nil
+}
+
+func loadFromNode(cmd *cobra.Command) (*registryState, error) {
+	conn, err := cmdGrpc.NewClient(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to establish connection with node: %w", err)
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+
+	// Resolve the height first so that all queries observe the same state.
+	blk, err := consensus.NewClient(conn).GetBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latest block: %w", err)
+	}
+	height := blk.Height
+
+	state, err := registry.NewClient(conn).StateToGenesis(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query registry state: %w", err)
+	}
+	epoch, err := beacon.NewClient(conn).GetEpoch(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query epoch: %w", err)
+	}
+	params, err := staking.NewClient(conn).ConsensusParameters(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query staking parameters: %w", err)
+	}
+
+	return &registryState{
+		state:             state,
+		epoch:             epoch,
+		debondingInterval: params.DebondingInterval,
+	}, nil
+}
+
+func doVerify(cmd *cobra.Command, _ []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	var (
+		rs  *registryState
+		err error
+	)
+	if cmd.Flags().Changed(flags.CfgGenesisFile) {
+		rs, err = loadFromGenesis(flags.GenesisFile())
+	} else {
+		rs, err = loadFromNode(cmd)
+	}
+	if err != nil {
+		logger.Error("failed to load registry state",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	issues := Verify(rs.state, rs.epoch, rs.debondingInterval)
+
+	prettyJSON, err := cmdCommon.PrettyJSONMarshal(issues)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of inconsistencies",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettyJSON))
+
+	if len(issues) > 0 {
+		logger.Error("registry state is inconsistent",
+			"num_issues", len(issues),
+		)
+		os.Exit(1)
+	}
+}
+
+// Register registers the registry sub-command and all of it's children.
+func Register(parentCmd *cobra.Command) {
+	registryVerifyCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+	registryVerifyCmd.Flags().AddFlagSet(flags.GenesisFileFlags)
+
+	registryCmd.AddCommand(registryVerifyCmd)
+	parentCmd.AddCommand(registryCmd)
+}
@@ -0,0 +1,111 @@
+package registry
+
+import (
+	"fmt"
+	"math"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+const (
+	// KindInvalidSignature is the inconsistency kind for node descriptors
+	// with invalid signatures.
+	KindInvalidSignature = "invalid_signature"
+	// KindMalformedDescriptor is the inconsistency kind for node descriptors
+	// that cannot be decoded.
+	KindMalformedDescriptor = "malformed_descriptor"
+	// KindUnknownRuntime is the inconsistency kind for nodes referencing
+	// runtimes which are not registered.
+	KindUnknownRuntime = "unknown_runtime"
+	// KindExpiredNode is the inconsistency kind for expired nodes which
+	// should have already been removed from the registry.
+	KindExpiredNode = "expired_node"
+	// KindUnroutableAddress is the inconsistency kind for nodes advertising
+	// unroutable addresses.
+	KindUnroutableAddress = "unroutable_address"
+	// KindDuplicateConsensusID is the inconsistency kind for nodes sharing
+	// the same consensus identifier.
+	KindDuplicateConsensusID = "duplicate_consensus_id"
+)
+
+// Inconsistency is a single inconsistency found in the registry state.
+type Inconsistency struct {
+	// NodeID is the identifier of the offending node.
+	NodeID signature.PublicKey `json:"node_id"`
+	// Kind is the kind of the inconsistency.
+	Kind string `json:"kind"`
+	// Details is a human readable description of the inconsistency.
+	Details string `json:"details"`
+}
+
+// Verify walks the given registry state and returns all inconsistencies.
+//
+// Expired nodes are kept in the registry for the debonding interval so they
+// can still be slashed, only nodes which are past that are reported.
+func Verify(state *registry.Genesis, epoch, debondingInterval beacon.EpochTime) []*Inconsistency {
+	var issues []*Inconsistency
+	report := func(id signature.PublicKey, kind string, format string, args ...interface{}) {
+		issues = append(issues, &Inconsistency{
+			NodeID:  id,
+			Kind:    kind,
+			Details: fmt.Sprintf(format, args...),
+		})
+	}
+
+	runtimes := make(map[common.Namespace]bool)
+	for _, rt := range state.Runtimes {
+		runtimes[rt.ID] = true
+	}
+	for _, rt := range state.SuspendedRuntimes {
+		runtimes[rt.ID] = true
+	}
+
+	allowUnroutable := state.Parameters.DebugAllowUnroutableAddresses
+	consensusIDs := make(map[signature.PublicKey]signature.PublicKey)
+	for _, sigNode := range state.Nodes {
+		var n node.Node
+		if err := sigNode.Open(registry.RegisterGenesisNodeSignatureContext, &n); err != nil {
+			// Still decode the descriptor so that other checks can be performed.
+			if err = cbor.Unmarshal(sigNode.Blob, &n); err != nil {
+				report(signature.PublicKey{}, KindMalformedDescriptor, "failed to decode node descriptor: %s", err)
+				continue
+			}
+			report(n.ID, KindInvalidSignature, "invalid node descriptor signature")
+		}
+
+		for _, rt := range n.Runtimes {
+			if !runtimes[rt.ID] {
+				report(n.ID, KindUnknownRuntime, "runtime %s is not registered", rt.ID)
+			}
+		}
+
+		if n.IsExpired(uint64(epoch)) && math.MaxUint64-n.Expiration >= uint64(debondingInterval) &&
+			beacon.EpochTime(n.Expiration)+debondingInterval < epoch {
+			report(n.ID, KindExpiredNode, "node expired at epoch %d (current epoch: %d)", n.Expiration, epoch)
+		}
+
+		for _, addr := range n.P2P.Addresses {
+			if err := registry.VerifyAddress(addr, allowUnroutable); err != nil {
+				report(n.ID, KindUnroutableAddress, "p2p address %s: %s", addr, err)
+			}
+		}
+		for _, addr := range n.Consensus.Addresses {
+			if err := registry.VerifyAddress(addr.Address, allowUnroutable); err != nil {
+				report(n.ID, KindUnroutableAddress, "consensus address %s: %s", addr.Address, err)
+			}
+		}
+
+		if other, ok := consensusIDs[n.Consensus.ID]; ok {
+			report(n.ID, KindDuplicateConsensusID, "consensus ID %s also used by node %s", n.Consensus.ID, other)
+			continue
+		}
+		consensusIDs[n.Consensus.ID] = n.ID
+	}
+
+	return issues
+}
@@ -0,0 +1,98 @@
+package registry
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+func TestVerify(t *testing.T) {
+	require := require.New(t)
+
+	var rtID, unknownRtID common.Namespace
+	require.NoError(rtID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"))
+	require.NoError(unknownRtID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001"))
+
+	routable := node.Address{IP: net.ParseIP("8.8.8.8"), Port: 26656}
+	unroutable := node.Address{IP: net.ParseIP("127.0.0.1"), Port: 26656}
+
+	signNode := func(name string, fn func(n *node.Node)) (*node.MultiSignedNode, signature.PublicKey) {
+		signer := memorySigner.NewTestSigner(name)
+		n := &node.Node{
+			Versioned:  cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+			ID:         signer.Public(),
+			Expiration: 10,
+			P2P: node.P2PInfo{
+				Addresses: []node.Address{routable},
+			},
+			Consensus: node.ConsensusInfo{
+				ID: memorySigner.NewTestSigner(name + " consensus").Public(),
+			},
+			Runtimes: []*node.Runtime{{ID: rtID}},
+		}
+		if fn != nil {
+			fn(n)
+		}
+		sigNode, err := node.MultiSignNode([]signature.Signer{signer}, registry.RegisterNodeSignatureContext, n)
+		require.NoError(err, "MultiSignNode")
+		return sigNode, n.ID
+	}
+
+	state := &registry.Genesis{
+		Runtimes: []*registry.Runtime{{ID: rtID}},
+	}
+
+	// A consistent state should not report anything.
+	okNode, _ := signNode("verify ok", nil)
+	state.Nodes = []*node.MultiSignedNode{okNode}
+	require.Empty(Verify(state, 5, 1))
+
+	unknownRtNode, unknownRtNodeID := signNode("verify unknown runtime", func(n *node.Node) {
+		n.Runtimes = append(n.Runtimes, &node.Runtime{ID: unknownRtID})
+	})
+	expiredNode, expiredNodeID := signNode("verify expired", func(n *node.Node) {
+		n.Expiration = 2
+	})
+	unroutableNode, unroutableNodeID := signNode("verify unroutable", func(n *node.Node) {
+		n.P2P.Addresses = []node.Address{unroutable}
+	})
+	duplicateNode, duplicateNodeID := signNode("verify duplicate", func(n *node.Node) {
+		n.Consensus.ID = memorySigner.NewTestSigner("verify ok consensus").Public()
+	})
+	state.Nodes = append(state.Nodes, unknownRtNode, expiredNode, unroutableNode, duplicateNode)
+
+	issues := Verify(state, beacon.EpochTime(5), 1)
+	require.Len(issues, 4)
+	require.Equal(unknownRtNodeID, issues[0].NodeID)
+	require.Equal(KindUnknownRuntime, issues[0].Kind)
+	require.Equal(expiredNodeID, issues[1].NodeID)
+	require.Equal(KindExpiredNode, issues[1].Kind)
+	require.Equal(unroutableNodeID, issues[2].NodeID)
+	require.Equal(KindUnroutableAddress, issues[2].Kind)
+	require.Equal(duplicateNodeID, issues[3].NodeID)
+	require.Equal(KindDuplicateConsensusID, issues[3].Kind)
+
+	// Expired nodes within the debonding interval are expected.
+	issues = Verify(state, beacon.EpochTime(5), 10)
+	require.Len(issues, 3)
+
+	// Unroutable addresses are fine when allowed.
+	state.Parameters.DebugAllowUnroutableAddresses = true
+	issues = Verify(state, beacon.EpochTime(5), 10)
+	require.Len(issues, 2)
+
+	// Suspended runtimes are still registered.
+	state.SuspendedRuntimes = []*registry.Runtime{{ID: unknownRtID}}
+	issues = Verify(state, beacon.EpochTime(5), 10)
+	require.Len(issues, 1)
+	require.Equal(KindDuplicateConsensusID, issues[0].Kind)
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	cmdControl "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/control"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	storageClient "github.com/oasisprotocol/oasis-core/go/storage/client"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+)
+
+const cfgCheckpointsNodes = "storage.checkpoints.nodes"
+
+var (
+	storageCheckpointsCmd = &cobra.Command{
+		Use:   "checkpoints runtime-id (hex)",
+		Short: "list checkpoints served by the public storage endpoints of registered storage nodes",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+				return err
+			}
+			if err := ValidateRuntimeIDStr(args[0]); err != nil {
+				return fmt.Errorf("malformed runtime id '%v': %w", args[0], err)
+			}
+			return nil
+		},
+		Run: doCheckpoints,
+	}
+
+	storageCheckpointsFlags = flag.NewFlagSet("", flag.ContinueOnError)
+)
+
+func doCheckpoints(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	var id common.Namespace
+	if err := id.UnmarshalHex(args[0]); err != nil {
+		logger.Error("failed to decode runtime id",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	var endpoints []storageClient.Endpoint
+	for _, raw := range viper.GetStringSlice(cfgCheckpointsNodes) {
+		var ep storageClient.Endpoint
+		if err := ep.UnmarshalText([]byte(raw)); err != nil {
+			logger.Error("failed to parse storage node endpoint",
+				"err", err,
+				"endpoint", raw,
+			)
+			os.Exit(1)
+		}
+		endpoints = append(endpoints, ep)
+	}
+	if len(endpoints) == 0 {
+		logger.Error("no storage nodes configured")
+		os.Exit(1)
+	}
+
+	// Node descriptors used to verify the storage nodes are looked up via the connected node.
+	conn, _ := cmdControl.DoConnect(cmd)
+	defer conn.Close()
+
+	client, err := storageClient.DialCommittee(ctx, registry.NewClient(conn), endpoints, storageClient.FlagOptions()...)
+	if err != nil {
+		logger.Error("failed to connect to storage nodes",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	defer client.Close()
+
+	cps, err := client.GetCheckpoints(ctx, &checkpoint.GetCheckpointsRequest{
+		Version:   1,
+		Namespace: id,
+	})
+	if err != nil {
+		logger.Error("failed to get checkpoints",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	for _, cp := range cps {
+		fmt.Printf("%s chunks=%d\n", cp.Root, len(cp.Chunks))
+	}
+}
+
+func init() {
+	storageCheckpointsFlags.StringSlice(cfgCheckpointsNodes, nil, "public storage endpoints of storage nodes (<node-id>@<address>)")
+	_ = viper.BindPFlags(storageCheckpointsFlags)
+}
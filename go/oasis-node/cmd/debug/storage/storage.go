@@ -17,6 +17,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
 	storageAPI "github.com/oasisprotocol/oasis-core/go/storage/api"
+	storageClient "github.com/oasisprotocol/oasis-core/go/storage/client"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 	"github.com/oasisprotocol/oasis-core/go/worker/storage"
 	storageWorkerAPI "github.com/oasisprotocol/oasis-core/go/worker/storage/api"
@@ -224,8 +225,13 @@ func Register(parentCmd *cobra.Command) {
 
 	storageBenchmarkCmd.Flags().AddFlagSet(storageBenchmarkFlags)
 
+	storageCheckpointsCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+	storageCheckpointsCmd.Flags().AddFlagSet(storageCheckpointsFlags)
+	storageCheckpointsCmd.Flags().AddFlagSet(storageClient.Flags)
+
 	storageCmd.AddCommand(storageCheckRootsCmd)
 	storageCmd.AddCommand(storageExportCmd)
 	storageCmd.AddCommand(storageBenchmarkCmd)
+	storageCmd.AddCommand(storageCheckpointsCmd)
 	parentCmd.AddCommand(storageCmd)
 }
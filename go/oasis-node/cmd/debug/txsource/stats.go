@@ -0,0 +1,197 @@
+package txsource
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+)
+
+// mixEntry is a workload together with its share of the configured submission rate.
+type mixEntry struct {
+	name   string
+	weight uint64
+}
+
+// parseMix parses workload mix entries of the form `<name>=<weight>`. The weight may be omitted
+// in which case it defaults to 1.
+func parseMix(entries []string) ([]mixEntry, error) {
+	var mix []mixEntry
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		name, rawWeight, hasWeight := strings.Cut(e, "=")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("malformed mix entry '%s'", e)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate mix entry for workload %s", name)
+		}
+		seen[name] = true
+
+		weight := uint64(1)
+		if hasWeight {
+			var err error
+			if weight, err = strconv.ParseUint(strings.TrimSpace(rawWeight), 10, 64); err != nil {
+				return nil, fmt.Errorf("malformed weight of workload %s: %w", name, err)
+			}
+			if weight == 0 {
+				return nil, fmt.Errorf("weight of workload %s must be positive", name)
+			}
+		}
+		mix = append(mix, mixEntry{name: name, weight: weight})
+	}
+	return mix, nil
+}
+
+// rateLimiter spaces out operations so that at most the configured number of operations is
+// started per second. A nil rate limiter does not limit anything.
+type rateLimiter struct {
+	sync.Mutex
+
+	interval time.Duration
+	next     time.Time
+}
+
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	at := l.next
+	l.next = l.next.Add(l.interval)
+	l.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func newRateLimiter(rate float64) *rateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / rate),
+	}
+}
+
+// submissionStats are the transaction submission statistics of a workload.
+type submissionStats struct {
+	sync.Mutex
+
+	submitted  uint64
+	accepted   uint64
+	failed     uint64
+	latencySum time.Duration
+	latencyMin time.Duration
+	latencyMax time.Duration
+}
+
+func (s *submissionStats) record(latency time.Duration, err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.submitted++
+	if err != nil {
+		s.failed++
+		return
+	}
+	s.accepted++
+	s.latencySum += latency
+	if s.accepted == 1 || latency < s.latencyMin {
+		s.latencyMin = latency
+	}
+	if latency > s.latencyMax {
+		s.latencyMax = latency
+	}
+}
+
+// report logs the statistics collected since the workload was started.
+func (s *submissionStats) report(logger *logging.Logger, name string, elapsed time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+
+	var avg time.Duration
+	if s.accepted > 0 {
+		avg = s.latencySum / time.Duration(s.accepted)
+	}
+	var tps float64
+	if elapsed > 0 {
+		tps = float64(s.accepted) / elapsed.Seconds()
+	}
+
+	logger.Info("workload submission statistics",
+		"name", name,
+		"elapsed", elapsed,
+		"submitted", s.submitted,
+		"accepted", s.accepted,
+		"failed", s.failed,
+		"accepted_per_sec", tps,
+		"latency_min", s.latencyMin,
+		"latency_avg", avg,
+		"latency_max", s.latencyMax,
+	)
+}
+
+// instrumentedSubmissionManager is a submission manager that limits the rate at which
+// transactions are submitted and records the acceptance latency and failures.
+type instrumentedSubmissionManager struct {
+	consensus.SubmissionManager
+
+	limiter *rateLimiter
+	stats   *submissionStats
+}
+
+// Implements consensus.SubmissionManager.
+func (m *instrumentedSubmissionManager) SignAndSubmitTx(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) error {
+	if err := m.limiter.wait(ctx); err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err := m.SubmissionManager.SignAndSubmitTx(ctx, signer, tx)
+	m.stats.record(time.Since(start), err)
+	return err
+}
+
+// Implements consensus.SubmissionManager.
+func (m *instrumentedSubmissionManager) SignAndSubmitTxWithProof(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) (*transaction.SignedTransaction, *transaction.Proof, error) {
+	if err := m.limiter.wait(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	start := time.Now()
+	sigTx, proof, err := m.SubmissionManager.SignAndSubmitTxWithProof(ctx, signer, tx)
+	m.stats.record(time.Since(start), err)
+	return sigTx, proof, err
+}
+
+func newInstrumentedSubmissionManager(sm consensus.SubmissionManager, rate float64) *instrumentedSubmissionManager {
+	return &instrumentedSubmissionManager{
+		SubmissionManager: sm,
+		limiter:           newRateLimiter(rate),
+		stats:             &submissionStats{},
+	}
+}
@@ -0,0 +1,70 @@
+package txsource
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMix(t *testing.T) {
+	require := require.New(t)
+
+	mix, err := parseMix([]string{"transfer=3", " registration = 1 ", "queries"})
+	require.NoError(err, "parseMix")
+	require.Equal([]mixEntry{
+		{name: "transfer", weight: 3},
+		{name: "registration", weight: 1},
+		{name: "queries", weight: 1},
+	}, mix)
+
+	for _, entries := range [][]string{
+		{"=1"},
+		{"transfer=0"},
+		{"transfer=-1"},
+		{"transfer=x"},
+		{"transfer", "transfer=2"},
+	} {
+		_, err = parseMix(entries)
+		require.Error(err, "parseMix(%v) should fail", entries)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	require := require.New(t)
+
+	var unlimited *rateLimiter
+	require.Nil(newRateLimiter(0), "zero rate should not be limited")
+	require.NoError(unlimited.wait(context.Background()))
+
+	l := newRateLimiter(100)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		require.NoError(l.wait(context.Background()))
+	}
+	require.GreaterOrEqual(time.Since(start), 40*time.Millisecond, "operations should be spaced out")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	l = newRateLimiter(0.001)
+	require.NoError(l.wait(ctx), "first operation should not wait")
+	require.ErrorIs(l.wait(ctx), context.Canceled)
+}
+
+func TestSubmissionStats(t *testing.T) {
+	require := require.New(t)
+
+	var s submissionStats
+	s.record(2*time.Second, nil)
+	s.record(time.Second, nil)
+	s.record(5*time.Second, fmt.Errorf("failed"))
+
+	require.EqualValues(3, s.submitted)
+	require.EqualValues(2, s.accepted)
+	require.EqualValues(1, s.failed)
+	require.Equal(time.Second, s.latencyMin)
+	require.Equal(2*time.Second, s.latencyMax)
+	require.Equal(3*time.Second, s.latencySum)
+}
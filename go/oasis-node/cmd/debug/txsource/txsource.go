@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"math/rand"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
@@ -34,6 +36,9 @@ const (
 	CfgTimeLimit       = "time_limit"
 	CfgGasPrice        = "gas_price"
 	CfgValidatorEntity = "validator_entity"
+	CfgMix             = "mix"
+	CfgRate            = "rate"
+	CfgReportInterval  = "report_interval"
 )
 
 var (
@@ -77,21 +82,20 @@ func doRun(cmd *cobra.Command, _ []string) error {
 	logger.Debug("setting chain context", "chain_context", genesisDoc.ChainContext())
 	genesisDoc.SetChainContext()
 
-	// Resolve the workload.
-	name := viper.GetString(CfgWorkload)
-	w, ok := workload.ByName[name]
-	if !ok {
-		return fmt.Errorf("workload %s not found", name)
+	// Resolve the workloads.
+	mix := []mixEntry{{name: viper.GetString(CfgWorkload), weight: 1}}
+	if entries := viper.GetStringSlice(CfgMix); len(entries) > 0 {
+		if mix, err = parseMix(entries); err != nil {
+			return fmt.Errorf("invalid workload mix: %w", err)
+		}
 	}
-
-	// Set up the deterministic random source.
-	hash := crypto.SHA512
-	seed := []byte(viper.GetString(CfgSeed))
-	src, err := drbg.New(hash, seed, nil, []byte(fmt.Sprintf("txsource workload generator v1, workload %s", name)))
-	if err != nil {
-		return fmt.Errorf("drbg.New: %w", err)
+	var totalWeight uint64
+	for _, e := range mix {
+		if _, ok := workload.ByName[e.name]; !ok {
+			return fmt.Errorf("workload %s not found", e.name)
+		}
+		totalWeight += e.weight
 	}
-	rng := rand.New(mathrand.New(src))
 
 	// Set up the gRPC client.
 	logger.Debug("dialing node", "addr", viper.GetString(cmdGrpc.CfgAddress))
@@ -117,21 +121,6 @@ func doRun(cmd *cobra.Command, _ []string) error {
 	}
 	logger.Debug("node synced")
 
-	// Generate and fund the account that will be used for funding accounts
-	// during the workload.
-	// NOTE: we don't use Test Entity account directly in the workloads
-	// as using the same account in all runs would lead to a lot of
-	// contention and nonce mismatches.
-	fundingAccount, err := memorySigner.NewFactory().Generate(signature.SignerEntity, rng)
-	if err != nil {
-		return fmt.Errorf("memory signer factory generate funding account %w", err)
-	}
-	if w.NeedsFunds() {
-		if err = workload.FundAccountFromTestEntity(ctx, cnsc, sm, fundingAccount); err != nil {
-			return fmt.Errorf("test entity account funding failure: %w", err)
-		}
-	}
-
 	// Load the validator entity paths if provided, some workloads need to make
 	// transactions as the validator entity.
 	var validatorEntities []signature.Signer
@@ -149,13 +138,102 @@ func doRun(cmd *cobra.Command, _ []string) error {
 		validatorEntities = append(validatorEntities, validatorEntity)
 	}
 
-	logger.Debug("entering workload", "name", name)
-	if err = w.Run(ctx, rng, conn, cnsc, sm, fundingAccount, validatorEntities); err != nil {
-		logger.Error("workload error", "err", err)
-		return fmt.Errorf("workload %s: %w", name, err)
+	// Run all workloads concurrently, each with its share of the submission rate.
+	rate := viper.GetFloat64(CfgRate)
+	runs := make([]*workloadRun, 0, len(mix))
+	for _, e := range mix {
+		run := &workloadRun{
+			name:     e.name,
+			workload: workload.ByName[e.name],
+			sm:       newInstrumentedSubmissionManager(sm, rate*float64(e.weight)/float64(totalWeight)),
+		}
+		if err = run.init(ctx, cnsc); err != nil {
+			return err
+		}
+		runs = append(runs, run)
+	}
+
+	start := time.Now()
+	stopReporting := make(chan struct{})
+	defer close(stopReporting)
+	if interval := viper.GetDuration(CfgReportInterval); interval > 0 {
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					for _, run := range runs {
+						run.sm.stats.report(logger, run.name, time.Since(start))
+					}
+				case <-stopReporting:
+					return
+				}
+			}
+		}()
+	}
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(runs))
+	for _, run := range runs {
+		wg.Add(1)
+		go func(run *workloadRun) {
+			defer wg.Done()
+
+			logger.Debug("entering workload", "name", run.name)
+			if werr := run.workload.Run(ctx, run.rng, conn, cnsc, run.sm, run.fundingAccount, validatorEntities); werr != nil {
+				logger.Error("workload error", "name", run.name, "err", werr)
+				errCh <- fmt.Errorf("workload %s: %w", run.name, werr)
+				return
+			}
+			logger.Debug("workload returned", "name", run.name)
+		}(run)
+	}
+	wg.Wait()
+	close(errCh)
+
+	for _, run := range runs {
+		run.sm.stats.report(logger, run.name, time.Since(start))
+	}
+
+	return <-errCh
+}
+
+// workloadRun is a workload scheduled as part of the workload mix.
+type workloadRun struct {
+	name     string
+	workload workload.Workload
+	sm       *instrumentedSubmissionManager
+
+	rng            *rand.Rand
+	fundingAccount signature.Signer
+}
+
+func (r *workloadRun) init(ctx context.Context, cnsc consensus.ClientBackend) error {
+	// Set up the deterministic random source.
+	hash := crypto.SHA512
+	seed := []byte(viper.GetString(CfgSeed))
+	src, err := drbg.New(hash, seed, nil, []byte(fmt.Sprintf("txsource workload generator v1, workload %s", r.name)))
+	if err != nil {
+		return fmt.Errorf("drbg.New: %w", err)
 	}
-	logger.Debug("workload returned", "name", name)
+	r.rng = rand.New(mathrand.New(src))
 
+	// Generate and fund the account that will be used for funding accounts
+	// during the workload.
+	// NOTE: we don't use Test Entity account directly in the workloads
+	// as using the same account in all runs would lead to a lot of
+	// contention and nonce mismatches.
+	r.fundingAccount, err = memorySigner.NewFactory().Generate(signature.SignerEntity, r.rng)
+	if err != nil {
+		return fmt.Errorf("memory signer factory generate funding account %w", err)
+	}
+	if r.workload.NeedsFunds() {
+		// Funding is not part of the workload, so bypass the instrumentation.
+		if err = workload.FundAccountFromTestEntity(ctx, cnsc, r.sm.SubmissionManager, r.fundingAccount); err != nil {
+			return fmt.Errorf("test entity account funding failure: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -171,6 +249,9 @@ func init() {
 	fs.Duration(CfgTimeLimit, 0, "Exit successfully after this long, or 0 to run forever")
 	fs.Uint64(CfgGasPrice, 0, "Gas price to use for consensus transactions")
 	fs.StringSlice(CfgValidatorEntity, nil, "Paths to validator entities")
+	fs.StringSlice(CfgMix, nil, "Workloads to run concurrently as <name>=<weight>, overrides --workload")
+	fs.Float64(CfgRate, 0, "Maximum number of consensus transactions submitted per second, split among workloads by weight, or 0 for no limit")
+	fs.Duration(CfgReportInterval, time.Minute, "Interval at which submission statistics are logged, or 0 to only log them on exit")
 	_ = viper.BindPFlags(fs)
 	txsourceCmd.Flags().AddFlagSet(fs)
 
@@ -91,7 +91,11 @@ func (bw *BaseWorkload) Consensus() consensus.ClientBackend {
 func (bw *BaseWorkload) GasPrice() uint64 {
 	// NOTE: This cannot fail as workloads use static price discovery.
 	gasPrice, _ := bw.sm.PriceDiscovery().GasPrice()
-	return gasPrice.ToBigInt().Uint64()
+	price, err := gasPrice.ToUint64()
+	if err != nil {
+		panic(fmt.Errorf("gas price overflow: %w", err))
+	}
+	return price
 }
 
 // FundSignAndSubmitTx funds the caller to cover transaction fees, signs the transaction and submits
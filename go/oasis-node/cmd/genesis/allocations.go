@@ -0,0 +1,326 @@
+package genesis
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/diff"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	cfgStakeAllocationsFile        = "stake_allocations.file"
+	cfgStakeAllocationsTotalSupply = "stake_allocations.total_supply"
+	cfgStakeAllocationsTemplate    = "stake_allocations.template"
+	cfgStakeAllocationsDiff        = "stake_allocations.diff"
+	cfgStakeAllocationsOutput      = "stake_allocations.output"
+)
+
+// stakeAllocationsHeader is the expected header of the stake allocations CSV file.
+var stakeAllocationsHeader = []string{"address", "general", "escrow_account", "escrow"}
+
+var (
+	stakeAllocationsCmd = &cobra.Command{
+		Use:   "stake-allocations",
+		Short: "build the staking genesis state from a CSV file of stake allocations",
+		Long: `Build the staking genesis state from a CSV file of stake allocations.
+
+The CSV file must start with the header 'address,general,escrow_account,escrow'
+and contain one allocation per line. The general amount is credited to the
+general balance of the account, the escrow amount is delegated by the account to
+the escrow account (or to itself if the escrow account is empty). All amounts
+are in base units and multiple allocations for the same account are summed up.`,
+		Run: doStakeAllocations,
+	}
+
+	stakeAllocationsFlags = flag.NewFlagSet("", flag.ContinueOnError)
+)
+
+// stakeAllocation is a single stake allocation.
+type stakeAllocation struct {
+	line int
+
+	address       staking.Address
+	general       quantity.Quantity
+	escrowAccount staking.Address
+	escrow        quantity.Quantity
+}
+
+// parseStakeAllocations parses stake allocations from a CSV file.
+func parseStakeAllocations(r io.Reader) ([]*stakeAllocation, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = len(stakeAllocationsHeader)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	for i, name := range stakeAllocationsHeader {
+		if strings.TrimSpace(header[i]) != name {
+			return nil, fmt.Errorf("malformed header: expected '%s'", strings.Join(stakeAllocationsHeader, ","))
+		}
+	}
+
+	var allocs []*stakeAllocation
+	for {
+		record, rerr := cr.Read()
+		if errors.Is(rerr, io.EOF) {
+			break
+		}
+		if rerr != nil {
+			return nil, rerr
+		}
+		line, _ := cr.FieldPos(0)
+
+		alloc, perr := parseStakeAllocation(record)
+		if perr != nil {
+			return nil, fmt.Errorf("line %d: %w", line, perr)
+		}
+		alloc.line = line
+		allocs = append(allocs, alloc)
+	}
+	return allocs, nil
+}
+
+func parseStakeAllocation(record []string) (*stakeAllocation, error) {
+	var alloc stakeAllocation
+	if err := alloc.address.UnmarshalText([]byte(strings.TrimSpace(record[0]))); err != nil {
+		return nil, fmt.Errorf("malformed address: %w", err)
+	}
+	if err := parseAmount(&alloc.general, record[1]); err != nil {
+		return nil, fmt.Errorf("malformed general amount: %w", err)
+	}
+	switch escrowAccount := strings.TrimSpace(record[2]); escrowAccount {
+	case "":
+		alloc.escrowAccount = alloc.address
+	default:
+		if err := alloc.escrowAccount.UnmarshalText([]byte(escrowAccount)); err != nil {
+			return nil, fmt.Errorf("malformed escrow account address: %w", err)
+		}
+	}
+	if err := parseAmount(&alloc.escrow, record[3]); err != nil {
+		return nil, fmt.Errorf("malformed escrow amount: %w", err)
+	}
+	if alloc.general.IsZero() && alloc.escrow.IsZero() {
+		return nil, fmt.Errorf("allocation of account %s is empty", alloc.address)
+	}
+	return &alloc, nil
+}
+
+func parseAmount(q *quantity.Quantity, raw string) error {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	return q.UnmarshalText([]byte(raw))
+}
+
+// buildStakingGenesis builds the staking genesis state from the given template and stake
+// allocations. The ledger and the delegations of the template are replaced and the sum of all
+// allocated stake together with the template's common pool, last block fees and governance
+// deposits must equal the declared total supply.
+func buildStakingGenesis(template *staking.Genesis, allocs []*stakeAllocation, totalSupply *quantity.Quantity) (*staking.Genesis, error) {
+	st := *template
+	st.TotalSupply = *totalSupply.Clone()
+	st.Ledger = make(map[staking.Address]*staking.Account)
+	st.Delegations = make(map[staking.Address]map[staking.Address]*staking.Delegation)
+	st.DebondingDelegations = nil
+
+	getAccount := func(addr staking.Address) *staking.Account {
+		acct, ok := st.Ledger[addr]
+		if !ok {
+			acct = &staking.Account{}
+			st.Ledger[addr] = acct
+		}
+		return acct
+	}
+
+	total := quantity.NewQuantity()
+	for _, q := range []*quantity.Quantity{&st.CommonPool, &st.LastBlockFees, &st.GovernanceDeposits} {
+		if err := total.Add(q); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, alloc := range allocs {
+		if err := total.Add(&alloc.general); err != nil {
+			return nil, err
+		}
+		if err := total.Add(&alloc.escrow); err != nil {
+			return nil, err
+		}
+
+		if !alloc.general.IsZero() {
+			if err := getAccount(alloc.address).General.Balance.Add(&alloc.general); err != nil {
+				return nil, err
+			}
+		}
+		if alloc.escrow.IsZero() {
+			continue
+		}
+
+		escrow := getAccount(alloc.escrowAccount)
+		delegations, ok := st.Delegations[alloc.escrowAccount]
+		if !ok {
+			delegations = make(map[staking.Address]*staking.Delegation)
+			st.Delegations[alloc.escrowAccount] = delegations
+		}
+		dg, ok := delegations[alloc.address]
+		if !ok {
+			dg = &staking.Delegation{}
+			delegations[alloc.address] = dg
+		}
+		// All escrow pools are built from scratch, so shares are always issued 1:1.
+		if _, err := escrow.Escrow.Active.Deposit(&dg.Shares, alloc.escrow.Clone(), &alloc.escrow); err != nil {
+			return nil, fmt.Errorf("line %d: failed to deposit escrow: %w", alloc.line, err)
+		}
+	}
+
+	if total.Cmp(totalSupply) != 0 {
+		return nil, fmt.Errorf("allocated stake (%s) does not match the declared total supply (%s)", total, totalSupply)
+	}
+	if len(st.Delegations) == 0 {
+		st.Delegations = nil
+	}
+
+	return &st, nil
+}
+
+func doStakeAllocations(cmd *cobra.Command, _ []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	st, existing, err := loadStakeAllocations()
+	if err != nil {
+		logger.Error("failed to build staking genesis state",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	raw, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		logger.Error("failed to marshal staking genesis state",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	if existing != nil {
+		existingRaw, merr := json.MarshalIndent(existing, "", "  ")
+		if merr != nil {
+			logger.Error("failed to marshal existing staking genesis state",
+				"err", merr,
+			)
+			os.Exit(1)
+		}
+		if string(existingRaw) == string(raw) {
+			fmt.Println("staking genesis state matches the existing genesis document")
+			return
+		}
+		d, derr := diff.UnifiedDiffString(string(existingRaw), string(raw), "Existing", "Allocations")
+		if derr != nil {
+			logger.Error("failed to compute staking genesis state diff",
+				"err", derr,
+			)
+			os.Exit(1)
+		}
+		fmt.Println(d)
+		os.Exit(1)
+	}
+
+	w, shouldClose, err := cmdCommon.GetOutputWriter(cmd, cfgStakeAllocationsOutput)
+	if err != nil {
+		logger.Error("failed to get writer for staking genesis state",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	if shouldClose {
+		defer w.Close()
+	}
+	if _, err = w.Write(append(raw, '\n')); err != nil {
+		logger.Error("failed to write staking genesis state",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+// loadStakeAllocations builds the staking genesis state from the configured stake allocations
+// and, in diff mode, also returns the staking state of the existing genesis document.
+func loadStakeAllocations() (*staking.Genesis, *staking.Genesis, error) {
+	var totalSupply quantity.Quantity
+	if err := totalSupply.UnmarshalText([]byte(viper.GetString(cfgStakeAllocationsTotalSupply))); err != nil {
+		return nil, nil, fmt.Errorf("malformed total supply: %w", err)
+	}
+
+	f, err := os.Open(viper.GetString(cfgStakeAllocationsFile))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open stake allocations file: %w", err)
+	}
+	defer f.Close()
+	allocs, err := parseStakeAllocations(f)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse stake allocations: %w", err)
+	}
+
+	var existing *staking.Genesis
+	if path := viper.GetString(cfgStakeAllocationsDiff); path != "" {
+		var doc genesis.Document
+		if err = loadJSON(path, &doc); err != nil {
+			return nil, nil, fmt.Errorf("failed to load existing genesis document: %w", err)
+		}
+		existing = &doc.Staking
+	}
+
+	// The template defaults to the existing genesis document in diff mode so that only the
+	// allocations are compared.
+	var template staking.Genesis
+	switch path := viper.GetString(cfgStakeAllocationsTemplate); {
+	case path != "":
+		if err = loadJSON(path, &template); err != nil {
+			return nil, nil, fmt.Errorf("failed to load staking genesis template: %w", err)
+		}
+	case existing != nil:
+		template = *existing
+	}
+
+	st, err := buildStakingGenesis(&template, allocs, &totalSupply)
+	if err != nil {
+		return nil, nil, err
+	}
+	return st, existing, nil
+}
+
+func loadJSON(path string, v interface{}) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+func init() {
+	stakeAllocationsFlags.String(cfgStakeAllocationsFile, "", "path to the stake allocations CSV file")
+	stakeAllocationsFlags.String(cfgStakeAllocationsTotalSupply, "", "declared total supply in base units")
+	stakeAllocationsFlags.String(cfgStakeAllocationsTemplate, "", "path to the staking genesis file with parameters to use")
+	stakeAllocationsFlags.String(cfgStakeAllocationsDiff, "", "path to an existing genesis file to diff the staking state against")
+	stakeAllocationsFlags.String(cfgStakeAllocationsOutput, "", "path to the output staking genesis file (default: stdout)")
+	_ = viper.BindPFlags(stakeAllocationsFlags)
+}
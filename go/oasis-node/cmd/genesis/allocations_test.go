@@ -0,0 +1,61 @@
+package genesis
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestStakeAllocations(t *testing.T) {
+	require := require.New(t)
+
+	alice := staking.NewAddress(signature.NewPublicKey("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"))
+	bob := staking.NewAddress(signature.NewPublicKey("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"))
+
+	csv := fmt.Sprintf(`address,general,escrow_account,escrow
+# Comments are ignored.
+%[1]s,100,,50
+%[2]s,10,%[1]s,20
+%[2]s,5,,
+`, alice, bob)
+	allocs, err := parseStakeAllocations(strings.NewReader(csv))
+	require.NoError(err, "parseStakeAllocations")
+	require.Len(allocs, 3)
+
+	template := staking.Genesis{
+		TokenSymbol: "TEST",
+		CommonPool:  *quantity.NewFromUint64(15),
+	}
+	st, err := buildStakingGenesis(&template, allocs, quantity.NewFromUint64(200))
+	require.NoError(err, "buildStakingGenesis")
+	require.Equal("TEST", st.TokenSymbol)
+	require.EqualValues(*quantity.NewFromUint64(200), st.TotalSupply)
+	require.EqualValues(*quantity.NewFromUint64(100), st.Ledger[alice].General.Balance)
+	require.EqualValues(*quantity.NewFromUint64(70), st.Ledger[alice].Escrow.Active.Balance)
+	require.EqualValues(*quantity.NewFromUint64(70), st.Ledger[alice].Escrow.Active.TotalShares)
+	require.EqualValues(*quantity.NewFromUint64(15), st.Ledger[bob].General.Balance)
+	require.True(st.Ledger[bob].Escrow.Active.Balance.IsZero())
+	require.EqualValues(*quantity.NewFromUint64(50), st.Delegations[alice][alice].Shares)
+	require.EqualValues(*quantity.NewFromUint64(20), st.Delegations[alice][bob].Shares)
+	require.Nil(template.Ledger, "template should not be modified")
+
+	_, err = buildStakingGenesis(&template, allocs, quantity.NewFromUint64(201))
+	require.Error(err, "allocations not matching the total supply should be rejected")
+
+	for _, invalid := range []string{
+		"address,general,escrow\n",
+		fmt.Sprintf("address,general,escrow_account,escrow\n%s,0,,0\n", alice),
+		fmt.Sprintf("address,general,escrow_account,escrow\n%s,-1,,\n", alice),
+		"address,general,escrow_account,escrow\ninvalid,1,,\n",
+		fmt.Sprintf("address,general,escrow_account,escrow\n%s,1,invalid,1\n", alice),
+	} {
+		_, err = parseStakeAllocations(strings.NewReader(invalid))
+		require.Error(err, "parseStakeAllocations(%q) should fail", invalid)
+	}
+}
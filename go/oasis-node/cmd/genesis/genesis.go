@@ -65,6 +65,13 @@ const (
 	CfgRegistryTEEFeaturesSGXSignedAttestations       = "registry.tee_features.sgx.signed_attestations"
 	CfgRegistryTEEFeaturesSGXDefaultMaxAttestationAge = "registry.tee_features.sgx.default_max_attestation_age"
 	CfgRegistryTEEFeaturesFreshnessProofs             = "registry.tee_features.freshness_proofs"
+	CfgRegistrySuspendRuntimesWithoutKeyManager       = "registry.suspend_runtimes_without_km"
+	CfgRegistryEnableHostnameAddresses                = "registry.enable_hostname_addresses"
+	CfgRegistryEnableNodeCapacity                     = "registry.enable_node_capacity"
+	CfgRegistryEnableNodeBuildInfo                    = "registry.enable_node_build_info"
+	CfgRegistryEntityAdmissionKey                     = "registry.entity_admission_key"
+	CfgRegistryEntityWhitelist                        = "registry.entity_whitelist"
+	CfgRegistryRequireDerivedRuntimeIDs               = "registry.require_derived_runtime_ids"
 
 	// Scheduler config flags.
 	cfgSchedulerMinValidators          = "scheduler.min_validators"
@@ -348,12 +355,17 @@ func doInitGenesis(*cobra.Command, []string) {
 func AppendRegistryState(doc *genesis.Document, entities, runtimes, nodes []string, l *logging.Logger) error {
 	regSt := registry.Genesis{
 		Parameters: registry.ConsensusParameters{
-			DebugAllowUnroutableAddresses: viper.GetBool(CfgRegistryDebugAllowUnroutableAddresses),
-			DebugAllowTestRuntimes:        viper.GetBool(CfgRegistryDebugAllowTestRuntimes),
-			GasCosts:                      registry.DefaultGasCosts, // TODO: Make these configurable.
-			MaxNodeExpiration:             viper.GetUint64(CfgRegistryMaxNodeExpiration),
-			DisableRuntimeRegistration:    viper.GetBool(CfgRegistryDisableRuntimeRegistration),
-			EnableRuntimeGovernanceModels: make(map[registry.RuntimeGovernanceModel]bool),
+			DebugAllowUnroutableAddresses:    viper.GetBool(CfgRegistryDebugAllowUnroutableAddresses),
+			DebugAllowTestRuntimes:           viper.GetBool(CfgRegistryDebugAllowTestRuntimes),
+			GasCosts:                         registry.DefaultGasCosts, // TODO: Make these configurable.
+			MaxNodeExpiration:                viper.GetUint64(CfgRegistryMaxNodeExpiration),
+			DisableRuntimeRegistration:       viper.GetBool(CfgRegistryDisableRuntimeRegistration),
+			EnableRuntimeGovernanceModels:    make(map[registry.RuntimeGovernanceModel]bool),
+			SuspendRuntimesWithoutKeyManager: viper.GetBool(CfgRegistrySuspendRuntimesWithoutKeyManager),
+			EnableHostnameAddresses:          viper.GetBool(CfgRegistryEnableHostnameAddresses),
+			EnableNodeCapacity:               viper.GetBool(CfgRegistryEnableNodeCapacity),
+			EnableNodeBuildInfo:              viper.GetBool(CfgRegistryEnableNodeBuildInfo),
+			RequireDerivedRuntimeIDs:         viper.GetBool(CfgRegistryRequireDerivedRuntimeIDs),
 		},
 		Entities: make([]*entity.SignedEntity, 0, len(entities)),
 		Runtimes: make([]*registry.Runtime, 0, len(runtimes)),
@@ -382,6 +394,20 @@ func AppendRegistryState(doc *genesis.Document, entities, runtimes, nodes []stri
 		regSt.Parameters.TEEFeatures.SGX.DefaultMaxAttestationAge = viper.GetUint64(CfgRegistryTEEFeaturesSGXDefaultMaxAttestationAge)
 	}
 
+	if keyStr := viper.GetString(CfgRegistryEntityAdmissionKey); keyStr != "" {
+		var key signature.PublicKey
+		if err := key.UnmarshalText([]byte(keyStr)); err != nil {
+			return fmt.Errorf("genesis: malformed entity admission key: %w", err)
+		}
+		regSt.Parameters.EntityAdmissionKey = &key
+	}
+
+	entityWhitelist, wlErr := parsePublicKeyStringSlice(CfgRegistryEntityWhitelist)
+	if wlErr != nil {
+		return fmt.Errorf("genesis: malformed entity whitelist: %w", wlErr)
+	}
+	regSt.EntityWhitelist = entityWhitelist
+
 	for _, gmStr := range viper.GetStringSlice(CfgRegistryEnableRuntimeGovernanceModels) {
 		var gm registry.RuntimeGovernanceModel
 		if err := gm.UnmarshalText([]byte(strings.ToLower(gmStr))); err != nil {
@@ -774,12 +800,14 @@ func Register(parentCmd *cobra.Command) {
 
 	migrateGenesisCmd.PersistentFlags().AddFlagSet(flags.GenesisFileFlags)
 	migrateGenesisCmd.PersistentFlags().AddFlagSet(migrateGenesisFlags)
+	stakeAllocationsCmd.Flags().AddFlagSet(stakeAllocationsFlags)
 
 	for _, v := range []*cobra.Command{
 		initGenesisCmd,
 		dumpGenesisCmd,
 		checkGenesisCmd,
 		migrateGenesisCmd,
+		stakeAllocationsCmd,
 	} {
 		genesisCmd.AddCommand(v)
 	}
@@ -813,6 +841,13 @@ func init() {
 	initGenesisFlags.Bool(CfgRegistryTEEFeaturesSGXSignedAttestations, true, "enable SGX RAK-signed attestations")
 	initGenesisFlags.Uint64(CfgRegistryTEEFeaturesSGXDefaultMaxAttestationAge, 1200, "default max attestation age (SGX RAK-signed attestations must be enabled") // ~2 hours at 6 sec per block.
 	initGenesisFlags.Bool(CfgRegistryTEEFeaturesFreshnessProofs, true, "enable freshness proofs")
+	initGenesisFlags.Bool(CfgRegistrySuspendRuntimesWithoutKeyManager, false, "suspend compute runtimes while their key manager is not available")
+	initGenesisFlags.Bool(CfgRegistryEnableHostnameAddresses, false, "allow node descriptors to contain hostname addresses")
+	initGenesisFlags.Bool(CfgRegistryEnableNodeCapacity, false, "allow node descriptors to contain capacity hints")
+	initGenesisFlags.Bool(CfgRegistryEnableNodeBuildInfo, false, "allow node descriptors to contain build metadata")
+	initGenesisFlags.String(CfgRegistryEntityAdmissionKey, "", "public key allowed to manage the entity whitelist (enables the whitelist)")
+	initGenesisFlags.StringSlice(CfgRegistryEntityWhitelist, nil, "public keys of entities allowed to register nodes and runtimes")
+	initGenesisFlags.Bool(CfgRegistryRequireDerivedRuntimeIDs, false, "require identifiers of new runtimes to be derived from the owning entity")
 	_ = initGenesisFlags.MarkHidden(CfgRegistryDebugAllowUnroutableAddresses)
 	_ = initGenesisFlags.MarkHidden(CfgRegistryDebugAllowTestRuntimes)
 
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/oasisprotocol/oasis-core/go/common/backup"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/service"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	"github.com/oasisprotocol/oasis-core/go/genesis/api"
 	genesisFile "github.com/oasisprotocol/oasis-core/go/genesis/file"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
@@ -61,6 +63,14 @@ func initGenesis(logger *logging.Logger) (api.Provider, error) {
 		logger.Error(err.Error())
 		return nil, err
 	}
+	if expected := config.GlobalConfig.Genesis.ChainContext; expected != "" && expected != genesisDoc.ChainContext() {
+		err = fmt.Errorf("failed to initialize the genesis provider: unexpected chain context (expected: %s got: %s)",
+			expected,
+			genesisDoc.ChainContext(),
+		)
+		logger.Error(err.Error())
+		return nil, err
+	}
 	genesisDoc.SetChainContext()
 
 	return genesis, nil
@@ -74,6 +84,13 @@ func configureDataDir(logger *logging.Logger) (string, error) {
 		logger.Error(err.Error())
 		return "", err
 	}
+	// Refuse to start from a partially restored data directory.
+	if err := backup.CheckFence(dataDir); err != nil {
+		logger.Error("failed to check data directory",
+			"err", err,
+		)
+		return "", err
+	}
 	return dataDir, nil
 }
 
@@ -8,16 +8,19 @@ import (
 	"sync"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/beacon/hooks"
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crash"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/notify"
 	"github.com/oasisprotocol/oasis-core/go/common/persistent"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft"
+	"github.com/oasisprotocol/oasis-core/go/consensus/eventbus"
 	consensusLightP2P "github.com/oasisprotocol/oasis-core/go/consensus/p2p/light"
 	controlAPI "github.com/oasisprotocol/oasis-core/go/control/api"
 	genesisAPI "github.com/oasisprotocol/oasis-core/go/genesis/api"
@@ -68,6 +71,8 @@ type Node struct {
 
 	Consensus   consensusAPI.Backend
 	LightClient consensusAPI.LightService
+	EpochHooks  *hooks.Dispatcher
+	EventBus    *eventbus.Bus
 
 	dataDir      string
 	chainContext string
@@ -91,6 +96,8 @@ type Node struct {
 	BeaconWorker       *workerBeacon.Worker
 	readyCh            chan struct{}
 
+	notifier *notify.Notifier
+
 	logger *logging.Logger
 }
 
@@ -205,6 +212,22 @@ func (n *Node) startRuntimeServices() error {
 		return err
 	}
 
+	// Start operator notifications.
+	if err = n.startNotifications(); err != nil {
+		n.logger.Error("failed to start notifications",
+			"err", err,
+		)
+		return err
+	}
+
+	// Start telemetry reporting.
+	if err = n.startTelemetry(); err != nil {
+		n.logger.Error("failed to start telemetry",
+			"err", err,
+		)
+		return err
+	}
+
 	n.logger.Debug("runtime services started")
 
 	return nil
@@ -225,7 +248,7 @@ func (n *Node) initRuntimeWorkers() error {
 	}
 
 	// Initialize the node's runtime registry.
-	n.RuntimeRegistry, err = runtimeRegistry.New(n.svcMgr.Ctx, n.dataDir, n.Consensus)
+	n.RuntimeRegistry, err = runtimeRegistry.New(n.svcMgr.Ctx, n.dataDir, n.Consensus, n.EpochHooks)
 	if err != nil {
 		return err
 	}
@@ -257,9 +280,11 @@ func (n *Node) initRuntimeWorkers() error {
 	// Initialize the registration worker.
 	n.RegistrationWorker, err = workerRegistration.New(
 		n.Consensus.Beacon(),
+		n.EpochHooks,
 		n.Consensus.Registry(),
 		n.Identity,
 		n.Consensus,
+		n.LightClient,
 		n.P2P,
 		&workerCommonCfg,
 		n.commonStore,
@@ -293,6 +318,7 @@ func (n *Node) initRuntimeWorkers() error {
 		n.grpcInternal,
 		n.CommonWorker,
 		n.RegistrationWorker,
+		n.commonStore,
 	)
 	if err != nil {
 		return err
@@ -557,6 +583,14 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 	node.svcMgr.Register(node.Consensus)
 	consensusAPI.RegisterService(node.grpcInternal.Server(), node.Consensus)
 
+	// Initialize epoch transition hooks.
+	node.EpochHooks = hooks.New(node.svcMgr.Ctx, node.Consensus)
+	node.svcMgr.Register(node.EpochHooks)
+
+	// Initialize the consensus event bus.
+	node.EventBus = eventbus.New(node.svcMgr.Ctx, node.Consensus)
+	node.svcMgr.Register(node.EventBus)
+
 	// Initialize P2P network. Since libp2p host starts listening immediately when created, make
 	// sure that we don't start it if it is not needed.
 	if !isArchive {
@@ -583,7 +617,7 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 	}
 
 	// Initialize CometBFT light client.
-	node.LightClient, err = cometbft.NewLightClient(node.svcMgr.Ctx, node.dataDir, genesisDoc, node.Consensus, node.P2P)
+	node.LightClient, err = cometbft.NewLightClient(node.svcMgr.Ctx, node.dataDir, genesisDoc, node.Consensus, node.EpochHooks, node.P2P)
 	if err != nil {
 		logger.Error("failed to initialize cometbft light client service",
 			"err", err,
@@ -630,6 +664,22 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 		return nil, err
 	}
 
+	// Start the epoch transition hooks service.
+	if err = node.EpochHooks.Start(); err != nil {
+		logger.Error("failed to start epoch transition hooks service",
+			"err", err,
+		)
+		return nil, err
+	}
+
+	// Start the consensus event bus service.
+	if err = node.EventBus.Start(); err != nil {
+		logger.Error("failed to start consensus event bus service",
+			"err", err,
+		)
+		return nil, err
+	}
+
 	// Start the consensus light client service.
 	if err = node.LightClient.Start(); err != nil {
 		logger.Error("failed to start consensus light client service",
@@ -1,18 +1,32 @@
 package node
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/backup"
+	cmnBadger "github.com/oasisprotocol/oasis-core/go/common/badger"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	cmtCommon "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/common"
+	cmtCrypto "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/crypto"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/pprof"
 	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/history"
+	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 	keymanagerWorker "github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
@@ -21,6 +35,16 @@ import (
 // Assert that the node implements NodeController interface.
 var _ control.NodeController = (*Node)(nil)
 
+// snapshotExcludeGlobs are the data directory paths that are never included in node state
+// snapshots.
+//
+// Key material must not leave the node, and restoring an older copy of the validator's last
+// sign state would allow the node to double sign.
+var snapshotExcludeGlobs = []string{
+	"*.pem",
+	filepath.Join(cmtCommon.StateDir, cmtCrypto.PrivValFileName),
+}
+
 // RequestShutdown implements control.NodeController.
 func (n *Node) RequestShutdown(ctx context.Context, wait bool) error {
 	ch, err := n.requestShutdown()
@@ -177,6 +201,207 @@ func (n *Node) AddBundle(_ context.Context, path string) error {
 	return n.RuntimeRegistry.GetBundleManager().Add(path)
 }
 
+// WatchRuntimeLogs implements control.NodeController.
+func (n *Node) WatchRuntimeLogs(ctx context.Context, req *control.RuntimeLogsRequest) (<-chan string, pubsub.ClosableSubscription, error) {
+	rt, err := n.RuntimeRegistry.GetRuntime(req.RuntimeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lines, updates, updatesSub, err := rt.Logs().Watch()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !req.Follow {
+		updatesSub.Close()
+		updates = nil
+	}
+
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+		defer updatesSub.Close()
+
+		for _, line := range lines {
+			select {
+			case ch <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if updates == nil {
+			return
+		}
+
+		for {
+			select {
+			case line, ok := <-updates:
+				if !ok {
+					return
+				}
+
+				select {
+				case ch <- line:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
+// GetProfile implements control.NodeController.
+func (n *Node) GetProfile(ctx context.Context, req *control.ProfileRequest) (*control.ProfileResponse, error) {
+	return captureProfile(ctx, n.dataDir, req)
+}
+
+func captureProfile(ctx context.Context, dataDir string, req *control.ProfileRequest) (*control.ProfileResponse, error) {
+	if req.Persist {
+		path, err := pprof.DumpProfileToDir(ctx, filepath.Join(dataDir, pprof.ProfilesDirName), req.Kind, req.Duration)
+		if err != nil {
+			return nil, err
+		}
+		return &control.ProfileResponse{Path: path}, nil
+	}
+
+	var buf bytes.Buffer
+	if err := pprof.WriteProfile(ctx, &buf, req.Kind, req.Duration); err != nil {
+		return nil, err
+	}
+	return &control.ProfileResponse{Data: buf.Bytes()}, nil
+}
+
+// CreateSnapshot implements control.NodeController.
+func (n *Node) CreateSnapshot(ctx context.Context, req *control.SnapshotRequest) (*backup.Manifest, error) {
+	if !filepath.IsAbs(req.Path) {
+		return nil, fmt.Errorf("snapshot path must be absolute: %s", req.Path)
+	}
+
+	f, err := os.OpenFile(req.Path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot archive: %w", err)
+	}
+	defer f.Close()
+
+	manifest, err := n.createSnapshot(ctx, f, req.Path)
+	if err != nil {
+		_ = os.Remove(req.Path)
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func (n *Node) createSnapshot(ctx context.Context, f *os.File, path string) (*backup.Manifest, error) {
+	// Stage database copies next to the archive so they don't end up in the data directory.
+	snapshotDir, err := os.MkdirTemp(filepath.Dir(path), ".snapshot-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	defer os.RemoveAll(snapshotDir)
+
+	n.logger.Info("creating node state snapshot",
+		"path", path,
+	)
+
+	manifest, err := n.snapshotDatabases(ctx, snapshotDir)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude := []string{path, snapshotDir}
+	for _, glob := range snapshotExcludeGlobs {
+		var matches []string
+		if matches, err = filepath.Glob(filepath.Join(n.dataDir, glob)); err != nil {
+			return nil, fmt.Errorf("invalid snapshot exclude pattern: %w", err)
+		}
+		exclude = append(exclude, matches...)
+	}
+	if err = backup.Create(f, n.dataDir, snapshotDir, manifest, exclude); err != nil {
+		return nil, fmt.Errorf("failed to write snapshot archive: %w", err)
+	}
+	if err = f.Sync(); err != nil {
+		return nil, fmt.Errorf("failed to sync snapshot archive: %w", err)
+	}
+
+	n.logger.Info("node state snapshot created",
+		"path", path,
+		"height", manifest.Consensus.Height,
+	)
+
+	return manifest, nil
+}
+
+// snapshotDatabases copies all node databases into the given directory while consensus commits
+// are fenced, and returns the manifest describing the copied state.
+//
+// Runtime state keeps changing while consensus commits are fenced as runtime workers may still
+// be processing already committed blocks. The runtime rounds are therefore taken from the copied
+// history databases and not from the live ones.
+func (n *Node) snapshotDatabases(ctx context.Context, snapshotDir string) (*backup.Manifest, error) {
+	release, err := n.Consensus.FenceCommits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fence consensus commits: %w", err)
+	}
+	defer release()
+
+	status, err := n.Consensus.GetStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consensus status: %w", err)
+	}
+	if status.LatestHeight < status.GenesisHeight {
+		return nil, consensus.ErrNoCommittedBlocks
+	}
+
+	manifest := &backup.Manifest{
+		Version:      backup.ManifestVersion,
+		CreatedAt:    time.Now().UTC(),
+		ChainContext: status.ChainContext,
+		Consensus: backup.ConsensusState{
+			GenesisHeight: status.GenesisHeight,
+			Height:        status.LatestHeight,
+			StateRoot:     status.LatestStateRoot.Hash,
+		},
+	}
+
+	manifest.Databases, err = cmnBadger.SnapshotDatabases(ctx, n.dataDir, snapshotDir)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(manifest.Databases)
+
+	if n.RuntimeRegistry != nil {
+		for _, rt := range n.RuntimeRegistry.Runtimes() {
+			rtDir := runtimeRegistry.GetRuntimeStateDir(snapshotDir, rt.ID())
+			if _, err = os.Stat(filepath.Join(rtDir, history.DbFilename)); errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			var blk *block.Block
+			blk, err = history.ReadLastBlock(rtDir)
+			switch {
+			case err == nil:
+			case errors.Is(err, roothash.ErrNotFound):
+				continue
+			default:
+				return nil, fmt.Errorf("failed to get latest block for runtime %s: %w", rt.ID(), err)
+			}
+
+			manifest.Runtimes = append(manifest.Runtimes, backup.RuntimeState{
+				ID:        rt.ID(),
+				Round:     blk.Header.Round,
+				StateRoot: blk.Header.StateRoot,
+			})
+		}
+	}
+
+	return manifest, nil
+}
+
 func (n *Node) getIdentityStatus() control.IdentityStatus {
 	return control.IdentityStatus{
 		Node:      n.Identity.NodeSigner.Public(),
@@ -355,6 +580,18 @@ func (n *Node) getRuntimeStatus(ctx context.Context) (map[common.Namespace]contr
 			})
 		}
 
+		// Fetch the parsed local runtime configuration.
+		if rtCfg, ok := config.GlobalConfig.Runtime.GetRuntime(rt.ID()); ok {
+			status.Config = &control.RuntimeConfigStatus{
+				Components: rtCfg.Components,
+				Registries: rtCfg.Registries,
+			}
+			for key := range rtCfg.Config {
+				status.Config.LocalConfigKeys = append(status.Config.LocalConfigKeys, key)
+			}
+			sort.Strings(status.Config.LocalConfigKeys)
+		}
+
 		// Store the runtime status.
 		runtimes[rt.ID()] = status
 	}
@@ -0,0 +1,280 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/notify"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/eventbus"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+)
+
+// startNotifications starts the operator notification subsystem, if configured.
+func (n *Node) startNotifications() error {
+	notifier, err := notify.New(&config.GlobalConfig.Notify, n.Identity.NodeSigner.Public())
+	if err != nil {
+		return err
+	}
+	if notifier == nil {
+		return nil
+	}
+	n.svcMgr.Register(notifier)
+	if err = notifier.Start(); err != nil {
+		return err
+	}
+	n.notifier = notifier
+
+	go n.notificationWorker(n.svcMgr.Ctx)
+
+	return nil
+}
+
+func (n *Node) notificationWorker(ctx context.Context) {
+	// Wait for the node to be synced so that we don't notify about stale conditions.
+	if err := n.WaitSync(ctx); err != nil {
+		return
+	}
+
+	go n.watchCommitteesForNotify(ctx)
+	for _, rt := range n.RuntimeRegistry.Runtimes() {
+		go n.watchRoundsForNotify(ctx, rt.ID())
+	}
+
+	ticker := time.NewTicker(config.GlobalConfig.Notify.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := n.checkRegistrationForNotify(ctx); err != nil {
+			n.logger.Warn("failed to check registration status for notifications",
+				"err", err,
+			)
+		}
+		if err := n.checkStorageForNotify(ctx); err != nil {
+			n.logger.Warn("failed to check storage status for notifications",
+				"err", err,
+			)
+		}
+	}
+}
+
+func (n *Node) watchCommitteesForNotify(ctx context.Context) {
+	ch, sub := n.EventBus.Subscribe(eventbus.KindFilter(eventbus.KindElection))
+	defer sub.Close()
+
+	nodeID := n.Identity.NodeSigner.Public()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-ch:
+			if !ok {
+				return
+			}
+			c := ev.Election
+			for _, member := range c.Members {
+				if !member.PublicKey.Equal(nodeID) {
+					continue
+				}
+				n.notifier.Notify(notify.EventCommitteeElected, &c.RuntimeID,
+					fmt.Sprintf("elected to %s committee as %s for epoch %d", c.Kind, member.Role, c.ValidFor),
+					map[string]interface{}{
+						"kind":  c.Kind.String(),
+						"role":  member.Role.String(),
+						"epoch": c.ValidFor,
+					},
+				)
+			}
+		}
+	}
+}
+
+func (n *Node) watchRoundsForNotify(ctx context.Context, runtimeID common.Namespace) {
+	ch, sub, err := n.Consensus.RootHash().WatchBlocks(ctx, runtimeID)
+	if err != nil {
+		n.logger.Error("failed to watch runtime blocks for notifications",
+			"err", err,
+			"runtime_id", runtimeID,
+		)
+		return
+	}
+	defer sub.Close()
+
+	// The latest block is pushed immediately, skip it as it is not a new round.
+	var initialized bool
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case blk, ok := <-ch:
+			if !ok {
+				return
+			}
+			if !initialized {
+				initialized = true
+				continue
+			}
+			if blk.Block.Header.HeaderType != block.RoundFailed {
+				continue
+			}
+			round := blk.Block.Header.Round
+			n.notifier.Notify(notify.EventRoundFailed, &runtimeID,
+				fmt.Sprintf("runtime round %d failed", round),
+				map[string]interface{}{
+					"round":  round,
+					"height": blk.Height,
+				},
+			)
+		}
+	}
+}
+
+func (n *Node) checkRegistrationForNotify(ctx context.Context) error {
+	if n.RegistrationWorker == nil || n.RegistrationWorker.WillNeverRegister() {
+		return nil
+	}
+
+	epoch, err := n.Consensus.Beacon().GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to query epoch: %w", err)
+	}
+	nd, err := n.Consensus.Registry().GetNode(ctx, &registry.IDQuery{
+		Height: consensus.HeightLatest,
+		ID:     n.Identity.NodeSigner.Public(),
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, registry.ErrNoSuchNode):
+		// Not registered (yet).
+		return nil
+	default:
+		return fmt.Errorf("failed to query node descriptor: %w", err)
+	}
+
+	// A registered node renews its registration every epoch, so a registration that expires at
+	// the end of the current epoch has failed to be renewed.
+	if nd.Expiration <= uint64(epoch) {
+		n.notifier.Notify(notify.EventRegistrationExpiring, nil,
+			fmt.Sprintf("node registration expires at epoch %d", nd.Expiration),
+			map[string]interface{}{
+				"epoch":      epoch,
+				"expiration": nd.Expiration,
+			},
+		)
+	}
+
+	return n.checkAttestationsForNotify(ctx, nd, epoch)
+}
+
+func (n *Node) checkAttestationsForNotify(ctx context.Context, nd *node.Node, epoch beacon.EpochTime) error {
+	blk, err := n.Consensus.GetBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to query latest block: %w", err)
+	}
+	height := uint64(blk.Height)
+
+	params, err := n.Consensus.Registry().ConsensusParameters(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to query registry parameters: %w", err)
+	}
+
+	for _, nrt := range nd.Runtimes {
+		tee := nrt.Capabilities.TEE
+		if tee == nil || tee.Hardware != node.TEEHardwareIntelSGX {
+			continue
+		}
+		var sa node.SGXAttestation
+		if err = cbor.Unmarshal(tee.Attestation, &sa); err != nil || sa.V == 0 {
+			// Legacy attestations are not height-bound.
+			continue
+		}
+
+		var rt *registry.Runtime
+		rt, err = n.Consensus.Registry().GetRuntime(ctx, &registry.GetRuntimeQuery{
+			Height: consensus.HeightLatest,
+			ID:     nrt.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to query runtime descriptor: %w", err)
+		}
+		vi := rt.DeploymentForVersion(nrt.Version)
+		if vi == nil {
+			vi = rt.ActiveDeployment(epoch)
+		}
+		if vi == nil {
+			continue
+		}
+		var sc node.SGXConstraints
+		if err = cbor.Unmarshal(vi.TEE, &sc); err != nil {
+			continue
+		}
+		if params.TEEFeatures != nil {
+			params.TEEFeatures.SGX.ApplyDefaultConstraints(&sc)
+		}
+		if sc.MaxAttestationAge == 0 {
+			continue
+		}
+
+		expiry := sa.Height + sc.MaxAttestationAge
+		if height+config.GlobalConfig.Notify.AttestationExpiryBlocks < expiry {
+			continue
+		}
+		n.notifier.Notify(notify.EventAttestationExpiring, &nrt.ID,
+			fmt.Sprintf("runtime attestation expires at height %d", expiry),
+			map[string]interface{}{
+				"height":             height,
+				"attestation_height": sa.Height,
+				"expiry_height":      expiry,
+			},
+		)
+	}
+	return nil
+}
+
+func (n *Node) checkStorageForNotify(ctx context.Context) error {
+	for _, rt := range n.RuntimeRegistry.Runtimes() {
+		storageNode := n.StorageWorker.GetRuntime(rt.ID())
+		if storageNode == nil {
+			continue
+		}
+		lastSynced, _, _ := storageNode.GetLastSynced()
+
+		blk, err := n.Consensus.RootHash().GetLatestBlock(ctx, &roothash.RuntimeRequest{
+			RuntimeID: rt.ID(),
+			Height:    consensus.HeightLatest,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to query latest runtime block: %w", err)
+		}
+		latest := blk.Header.Round
+
+		// Storage that has not synced anything yet reports an undefined round.
+		if lastSynced > latest || latest-lastSynced <= config.GlobalConfig.Notify.StorageLagRounds {
+			continue
+		}
+		runtimeID := rt.ID()
+		n.notifier.Notify(notify.EventStorageLagging, &runtimeID,
+			fmt.Sprintf("storage is %d rounds behind", latest-lastSynced),
+			map[string]interface{}{
+				"last_synced_round": lastSynced,
+				"latest_round":      latest,
+			},
+		)
+	}
+	return nil
+}
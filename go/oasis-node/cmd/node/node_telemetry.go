@@ -0,0 +1,57 @@
+package node
+
+import (
+	"context"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/telemetry"
+	"github.com/oasisprotocol/oasis-core/go/config"
+)
+
+// startTelemetry starts the telemetry reporter, if enabled.
+func (n *Node) startTelemetry() error {
+	reporter := telemetry.New(&config.GlobalConfig.Telemetry, n.Identity.NodeSigner, n.telemetryReport)
+	if reporter == nil {
+		return nil
+	}
+	n.svcMgr.Register(reporter)
+
+	n.logger.Info("telemetry reporting enabled",
+		"endpoint", config.GlobalConfig.Telemetry.Endpoint,
+	)
+
+	return reporter.Start()
+}
+
+// telemetryReport collects the minimal node health data sent to the telemetry collector.
+func (n *Node) telemetryReport(ctx context.Context) (*telemetry.Report, error) {
+	status, err := n.GetStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rpt := &telemetry.Report{
+		Time:            time.Now().Unix(),
+		ChainContext:    n.chainContext,
+		SoftwareVersion: status.SoftwareVersion,
+		Mode:            string(status.Mode),
+	}
+	if status.Consensus != nil {
+		rpt.ConsensusHeight = status.Consensus.LatestHeight
+	}
+	if status.Registration != nil && status.Registration.Descriptor != nil {
+		rpt.Roles = status.Registration.Descriptor.Roles
+	}
+	for id, rt := range status.Runtimes {
+		rtRpt := telemetry.RuntimeReport{
+			ID:          id,
+			LatestRound: rt.LatestRound,
+		}
+		if rt.Storage != nil && rt.Storage.LastFinalizedRound < rt.LatestRound {
+			rtRpt.RoundLag = rt.LatestRound - rt.Storage.LastFinalizedRound
+		}
+		rpt.Runtimes = append(rpt.Runtimes, rtRpt)
+	}
+
+	return rpt, nil
+}
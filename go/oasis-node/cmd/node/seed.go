@@ -25,6 +25,7 @@ type SeedNode struct {
 
 	stopOnce sync.Once
 
+	dataDir     string
 	commonStore *persistent.CommonStore
 
 	svcMgr       *background.ServiceManager
@@ -98,13 +99,13 @@ func NewSeedNode() (node *SeedNode, err error) {
 	}
 
 	// Configure a directory for the node to work in.
-	dataDir, err := configureDataDir(node.logger)
+	node.dataDir, err = configureDataDir(node.logger)
 	if err != nil {
 		return nil, err
 	}
 
 	// Open the common node store.
-	node.commonStore, err = persistent.NewCommonStore(dataDir)
+	node.commonStore, err = persistent.NewCommonStore(node.dataDir)
 	if err != nil {
 		logger.Error("failed to open common node store",
 			"err", err,
@@ -113,7 +114,7 @@ func NewSeedNode() (node *SeedNode, err error) {
 	}
 
 	// Generate or load the node's identity.
-	node.identity, err = loadOrGenerateIdentity(dataDir, node.logger)
+	node.identity, err = loadOrGenerateIdentity(node.dataDir, node.logger)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +133,7 @@ func NewSeedNode() (node *SeedNode, err error) {
 	controlApi.RegisterService(node.grpcInternal.Server(), node)
 
 	// Initialize and start the CometBFT seed.
-	node.cometbftSeed, err = cmtSeed.New(dataDir, node.identity, node.genesis)
+	node.cometbftSeed, err = cmtSeed.New(node.dataDir, node.identity, node.genesis)
 	if err != nil {
 		return nil, err
 	}
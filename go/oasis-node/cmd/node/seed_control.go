@@ -3,6 +3,8 @@ package node
 import (
 	"context"
 
+	"github.com/oasisprotocol/oasis-core/go/common/backup"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
@@ -86,3 +88,18 @@ func (n *SeedNode) GetStatus(context.Context) (*control.Status, error) {
 func (n *SeedNode) AddBundle(context.Context, string) error {
 	return control.ErrNotImplemented
 }
+
+// GetProfile implements control.NodeController.
+func (n *SeedNode) GetProfile(ctx context.Context, req *control.ProfileRequest) (*control.ProfileResponse, error) {
+	return captureProfile(ctx, n.dataDir, req)
+}
+
+// WatchRuntimeLogs implements control.NodeController.
+func (n *SeedNode) WatchRuntimeLogs(context.Context, *control.RuntimeLogsRequest) (<-chan string, pubsub.ClosableSubscription, error) {
+	return nil, nil, control.ErrNotImplemented
+}
+
+// CreateSnapshot implements control.NodeController.
+func (n *SeedNode) CreateSnapshot(context.Context, *control.SnapshotRequest) (*backup.Manifest, error) {
+	return nil, control.ErrNotImplemented
+}
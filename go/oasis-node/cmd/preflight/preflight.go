@@ -0,0 +1,293 @@
+// Package preflight implements the preflight sub-command.
+package preflight
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	genesisFile "github.com/oasisprotocol/oasis-core/go/genesis/file"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	runtimeConfig "github.com/oasisprotocol/oasis-core/go/runtime/config"
+)
+
+// socketDialTimeout is the timeout when checking whether the internal socket is in use.
+const socketDialTimeout = 1 * time.Second
+
+// sgxDevices are the device paths of the different Intel SGX driver versions.
+var sgxDevices = []string{"/dev/sgx_enclave", "/dev/sgx/enclave", "/dev/sgx", "/dev/isgx"}
+
+var preflightCmd = &cobra.Command{
+	Use:   "preflight",
+	Short: "check the environment before starting the node",
+	Long: "Check the environment for problems that would prevent the node from starting " +
+		"with the current configuration and print a report. Exits with a non-zero status " +
+		"in case any blockers were found.",
+	Run: doPreflight,
+}
+
+// Status is the status of a preflight check.
+type Status string
+
+const (
+	// StatusOK means that the check passed.
+	StatusOK Status = "ok"
+	// StatusWarning means that the check found a problem that does not prevent the node from
+	// starting.
+	StatusWarning Status = "warning"
+	// StatusBlocker means that the check found a problem that prevents the node from starting.
+	StatusBlocker Status = "blocker"
+	// StatusSkipped means that the check does not apply to the current configuration.
+	StatusSkipped Status = "skipped"
+)
+
+// CheckResult is the result of a single preflight check.
+type CheckResult struct {
+	// Name is the name of the check.
+	Name string `json:"name"`
+	// Status is the check status.
+	Status Status `json:"status"`
+	// Message is a human readable description of the check outcome.
+	Message string `json:"message,omitempty"`
+}
+
+// Report is the preflight report.
+type Report struct {
+	// Checks are the results of all performed checks.
+	Checks []*CheckResult `json:"checks"`
+	// Blockers is the number of checks which found a blocker.
+	Blockers int `json:"blockers"`
+}
+
+func okResult(name, format string, a ...interface{}) *CheckResult {
+	return &CheckResult{Name: name, Status: StatusOK, Message: fmt.Sprintf(format, a...)}
+}
+
+func warningResult(name, format string, a ...interface{}) *CheckResult {
+	return &CheckResult{Name: name, Status: StatusWarning, Message: fmt.Sprintf(format, a...)}
+}
+
+func blockerResult(name, format string, a ...interface{}) *CheckResult {
+	return &CheckResult{Name: name, Status: StatusBlocker, Message: fmt.Sprintf(format, a...)}
+}
+
+func skippedResult(name, format string, a ...interface{}) *CheckResult {
+	return &CheckResult{Name: name, Status: StatusSkipped, Message: fmt.Sprintf(format, a...)}
+}
+
+func checkDataDir(dataDir string) *CheckResult {
+	const name = "datadir"
+
+	if dataDir == "" {
+		return blockerResult(name, "data directory is not configured")
+	}
+
+	if _, err := os.Lstat(dataDir); err != nil {
+		if !os.IsNotExist(err) {
+			return blockerResult(name, "failed to stat data directory: %s", err)
+		}
+		return warningResult(name, "data directory %s does not exist and will be created", dataDir)
+	}
+
+	// Mkdir only validates the type, permissions and owner of existing directories.
+	if err := common.Mkdir(dataDir); err != nil {
+		return blockerResult(name, "%s", err)
+	}
+
+	f, err := os.CreateTemp(dataDir, ".preflight-*")
+	if err != nil {
+		return blockerResult(name, "data directory %s is not writable: %s", dataDir, err)
+	}
+	f.Close()
+	_ = os.Remove(f.Name())
+
+	return okResult(name, "data directory %s is usable", dataDir)
+}
+
+func checkNotRunning(socketPath string) *CheckResult {
+	const name = "not_running"
+
+	if _, err := os.Stat(socketPath); err != nil {
+		return okResult(name, "internal socket %s does not exist", socketPath)
+	}
+
+	conn, err := net.DialTimeout("unix", socketPath, socketDialTimeout)
+	if err != nil {
+		return okResult(name, "internal socket %s is stale", socketPath)
+	}
+	conn.Close()
+
+	return blockerResult(name, "a node is already running using internal socket %s", socketPath)
+}
+
+func checkPort(name, address string) *CheckResult {
+	ln, err := net.Listen("tcp", address)
+	if err != nil {
+		return blockerResult(name, "address %s is not bindable: %s", address, err)
+	}
+	ln.Close()
+
+	return okResult(name, "address %s is bindable", address)
+}
+
+func checkPorts(cfg *config.Config) []*CheckResult {
+	var results []*CheckResult
+
+	switch {
+	case cfg.Mode == config.ModeStatelessClient:
+		results = append(results, skippedResult("consensus_port", "stateless clients do not run consensus P2P"))
+	case cfg.Consensus.ListenAddress == "":
+		results = append(results, skippedResult("consensus_port", "consensus listen address is not configured"))
+	default:
+		address := strings.TrimPrefix(cfg.Consensus.ListenAddress, "tcp://")
+		results = append(results, checkPort("consensus_port", address))
+	}
+
+	if cfg.P2P.Port != 0 {
+		results = append(results, checkPort("p2p_port", fmt.Sprintf(":%d", cfg.P2P.Port)))
+	}
+
+	if cfg.Storage.PublicGRPC.Enabled {
+		results = append(results, checkPort("storage_public_grpc_port", fmt.Sprintf(":%d", cfg.Storage.PublicGRPC.Port)))
+	}
+
+	return results
+}
+
+func hasSGXDevice() (string, bool) {
+	for _, dev := range sgxDevices {
+		fi, err := os.Stat(dev)
+		if err != nil {
+			continue
+		}
+		if fi.Mode()&os.ModeDevice != 0 {
+			return dev, true
+		}
+	}
+	return "", false
+}
+
+func checkSGX(cfg *runtimeConfig.Config) *CheckResult {
+	const name = "sgx"
+
+	if len(cfg.Runtimes) == 0 && len(cfg.Paths) == 0 {
+		return skippedResult(name, "no runtimes are configured")
+	}
+
+	dev, ok := hasSGXDevice()
+	switch cfg.Environment {
+	case runtimeConfig.RuntimeEnvironmentSGX:
+		if !ok {
+			return blockerResult(name, "runtime environment is sgx, but no SGX device was found")
+		}
+	case runtimeConfig.RuntimeEnvironmentAuto, "":
+		if !ok {
+			return warningResult(name, "no SGX device was found, SGX runtimes will not be able to run")
+		}
+	default:
+		return skippedResult(name, "runtime environment %s does not use SGX", cfg.Environment)
+	}
+
+	if cfg.SGXLoader == "" {
+		return warningResult(name, "SGX device %s found, but the SGX loader is not configured", dev)
+	}
+	if _, err := os.Stat(cfg.SGXLoader); err != nil {
+		return blockerResult(name, "SGX loader %s is not accessible: %s", cfg.SGXLoader, err)
+	}
+
+	return okResult(name, "SGX device %s found", dev)
+}
+
+func checkGenesis(cfg *config.Config) *CheckResult {
+	const name = "genesis"
+
+	if cfg.Genesis.File == "" {
+		return blockerResult(name, "genesis file is not configured")
+	}
+
+	provider, err := genesisFile.DefaultFileProvider()
+	if err != nil {
+		return blockerResult(name, "failed to load genesis document: %s", err)
+	}
+	genesisDoc, err := provider.GetGenesisDocument()
+	if err != nil {
+		return blockerResult(name, "failed to load genesis document: %s", err)
+	}
+
+	chainContext := genesisDoc.ChainContext()
+	if expected := cfg.Genesis.ChainContext; expected != "" && expected != chainContext {
+		return blockerResult(name, "genesis document does not match the expected chain context (expected: %s got: %s)",
+			expected,
+			chainContext,
+		)
+	}
+
+	return okResult(name, "genesis document for chain context %s", chainContext)
+}
+
+// runChecks performs all preflight checks for the given configuration.
+func runChecks(cfg *config.Config, dataDir string) *Report {
+	var report Report
+
+	report.Checks = append(report.Checks, checkDataDir(dataDir))
+	if dataDir != "" {
+		socketPath := cfg.Common.InternalSocketPath
+		if socketPath == "" {
+			socketPath = filepath.Join(dataDir, cmdCommon.InternalSocketName)
+		}
+		report.Checks = append(report.Checks, checkNotRunning(socketPath))
+	}
+	report.Checks = append(report.Checks, checkRlimit())
+	report.Checks = append(report.Checks, checkPorts(cfg)...)
+	report.Checks = append(report.Checks, checkSGX(&cfg.Runtime))
+	report.Checks = append(report.Checks, checkGenesis(cfg))
+
+	for _, check := range report.Checks {
+		if check.Status == StatusBlocker {
+			report.Blockers++
+		}
+	}
+
+	return &report
+}
+
+func doPreflight(*cobra.Command, []string) {
+	format, err := cmdFlags.Format()
+	if err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	report := runChecks(&config.GlobalConfig, cmdCommon.DataDir())
+
+	switch format {
+	case cmdFlags.FormatJSON:
+		raw, merr := cmdCommon.CanonicalJSONMarshal(report)
+		if merr != nil {
+			cmdCommon.EarlyLogAndExit(merr)
+		}
+		fmt.Println(string(raw))
+	default:
+		for _, check := range report.Checks {
+			fmt.Printf("[%-7s] %s: %s\n", check.Status, check.Name, check.Message)
+		}
+		fmt.Printf("%d blocker(s) found\n", report.Blockers)
+	}
+
+	if report.Blockers > 0 {
+		os.Exit(1)
+	}
+}
+
+// Register registers the preflight sub-command.
+func Register(parentCmd *cobra.Command) {
+	preflightCmd.Flags().AddFlagSet(cmdFlags.FormatFlags)
+	parentCmd.AddCommand(preflightCmd)
+}
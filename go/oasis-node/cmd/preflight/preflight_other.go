@@ -0,0 +1,32 @@
+//go:build !windows
+// +build !windows
+
+package preflight
+
+import (
+	"syscall"
+
+	"github.com/oasisprotocol/oasis-core/go/config"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+)
+
+func checkRlimit() *CheckResult {
+	const name = "rlimit_nofile"
+
+	var rlim syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rlim); err != nil {
+		return blockerResult(name, "failed to query RLIMIT_NOFILE: %s", err)
+	}
+
+	current := rlim.Cur
+	if desired := config.GlobalConfig.Common.Debug.Rlimit; cmdFlags.DebugDontBlameOasis() && desired > 0 {
+		// The node will attempt to raise the limit on its own.
+		current = desired
+	}
+	if current < cmdCommon.RequiredRlimit {
+		return blockerResult(name, "too low RLIMIT_NOFILE, current: %d required: %d", current, cmdCommon.RequiredRlimit)
+	}
+
+	return okResult(name, "RLIMIT_NOFILE is %d", current)
+}
@@ -0,0 +1,57 @@
+package preflight
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDataDir(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(StatusBlocker, checkDataDir("").Status, "unset data directory")
+
+	dataDir := filepath.Join(t.TempDir(), "node")
+	require.Equal(StatusWarning, checkDataDir(dataDir).Status, "missing data directory")
+
+	err := os.Mkdir(dataDir, 0o755)
+	require.NoError(err, "Mkdir")
+	require.Equal(StatusBlocker, checkDataDir(dataDir).Status, "data directory with invalid permissions")
+
+	err = os.Chmod(dataDir, 0o700)
+	require.NoError(err, "Chmod")
+	require.Equal(StatusOK, checkDataDir(dataDir).Status, "valid data directory")
+
+	entries, err := os.ReadDir(dataDir)
+	require.NoError(err, "ReadDir")
+	require.Empty(entries, "check should not leave files behind")
+}
+
+func TestCheckPort(t *testing.T) {
+	require := require.New(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err, "Listen")
+	address := ln.Addr().String()
+
+	require.Equal(StatusBlocker, checkPort("test", address).Status, "port in use")
+
+	ln.Close()
+	require.Equal(StatusOK, checkPort("test", address).Status, "free port")
+}
+
+func TestCheckNotRunning(t *testing.T) {
+	require := require.New(t)
+
+	socketPath := filepath.Join(t.TempDir(), "internal.sock")
+	require.Equal(StatusOK, checkNotRunning(socketPath).Status, "missing socket")
+
+	ln, err := net.Listen("unix", socketPath)
+	require.NoError(err, "Listen")
+	defer ln.Close()
+
+	require.Equal(StatusBlocker, checkNotRunning(socketPath).Status, "socket in use")
+}
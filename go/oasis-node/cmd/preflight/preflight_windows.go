@@ -0,0 +1,8 @@
+//go:build windows
+// +build windows
+
+package preflight
+
+func checkRlimit() *CheckResult {
+	return skippedResult("rlimit_nofile", "not supported on this platform")
+}
@@ -336,6 +336,14 @@ func doList(cmd *cobra.Command, _ []string) {
 		cmdCommon.EarlyLogAndExit(err)
 	}
 
+	format, err := cmdFlags.Format()
+	if err != nil {
+		logger.Error("invalid output format",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
 	conn, client := doConnect(cmd)
 	defer conn.Close()
 
@@ -347,6 +355,18 @@ func doList(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if format == cmdFlags.FormatJSON {
+		var entitiesJSON []byte
+		if entitiesJSON, err = cmdCommon.CanonicalJSONMarshal(entities); err != nil {
+			logger.Error("failed to get canonical JSON of entities",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		fmt.Println(string(entitiesJSON))
+		return
+	}
+
 	for _, ent := range entities {
 		var entString string
 		switch cmdFlags.Verbose() {
@@ -423,6 +443,7 @@ func Register(parentCmd *cobra.Command) {
 	deregisterCmd.Flags().AddFlagSet(registerOrDeregisterFlags)
 
 	listCmd.Flags().AddFlagSet(cmdFlags.VerboseFlags)
+	listCmd.Flags().AddFlagSet(cmdFlags.FormatFlags)
 	listCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
 
 	parentCmd.AddCommand(entityCmd)
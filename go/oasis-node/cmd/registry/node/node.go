@@ -280,6 +280,14 @@ func doList(cmd *cobra.Command, _ []string) {
 		cmdCommon.EarlyLogAndExit(err)
 	}
 
+	format, err := cmdFlags.Format()
+	if err != nil {
+		logger.Error("invalid output format",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
 	conn, client := doConnect(cmd)
 	defer conn.Close()
 
@@ -291,6 +299,18 @@ func doList(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if format == cmdFlags.FormatJSON {
+		var nodesJSON []byte
+		if nodesJSON, err = cmdCommon.CanonicalJSONMarshal(nodes); err != nil {
+			logger.Error("failed to get canonical JSON of nodes",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		fmt.Println(string(nodesJSON))
+		return
+	}
+
 	for _, node := range nodes {
 		var nodeString string
 		switch cmdFlags.Verbose() {
@@ -372,6 +392,7 @@ func Register(parentCmd *cobra.Command) {
 
 	listCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
 	listCmd.Flags().AddFlagSet(cmdFlags.VerboseFlags)
+	listCmd.Flags().AddFlagSet(cmdFlags.FormatFlags)
 
 	isRegisteredCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
 
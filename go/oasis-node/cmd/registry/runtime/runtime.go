@@ -119,6 +119,14 @@ func doList(cmd *cobra.Command, _ []string) {
 		cmdCommon.EarlyLogAndExit(err)
 	}
 
+	format, err := cmdFlags.Format()
+	if err != nil {
+		logger.Error("invalid output format",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
 	conn, client := doConnect(cmd)
 	defer conn.Close()
 
@@ -134,6 +142,18 @@ func doList(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if format == cmdFlags.FormatJSON {
+		var runtimesJSON []byte
+		if runtimesJSON, err = cmdCommon.CanonicalJSONMarshal(runtimes); err != nil {
+			logger.Error("failed to get canonical JSON of runtimes",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		fmt.Println(string(runtimesJSON))
+		return
+	}
+
 	for _, rt := range runtimes {
 		var rtString string
 		switch cmdFlags.Verbose() {
@@ -173,6 +193,7 @@ func Register(parentCmd *cobra.Command) {
 
 	listCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
 	listCmd.Flags().AddFlagSet(cmdFlags.VerboseFlags)
+	listCmd.Flags().AddFlagSet(cmdFlags.FormatFlags)
 	listCmd.Flags().AddFlagSet(runtimeListFlags)
 
 	registerCmd.Flags().AddFlagSet(registerFlags)
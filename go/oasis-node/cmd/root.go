@@ -8,6 +8,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/config"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/consensus"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/control"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug"
@@ -17,6 +18,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/identity"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/keymanager"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/node"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/preflight"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/registry"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/signer"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/stake"
@@ -72,6 +74,7 @@ func init() {
 
 	// Register all of the sub-commands.
 	for _, v := range []func(*cobra.Command){
+		config.Register,
 		control.Register,
 		debug.Register,
 		genesis.Register,
@@ -85,6 +88,7 @@ func init() {
 		storage.Register,
 		consensus.Register,
 		node.Register,
+		preflight.Register,
 	} {
 		v(rootCmd)
 	}
@@ -144,13 +144,32 @@ var (
 	}
 )
 
+// accountInfo is the account info including delegations from and to the account.
+type accountInfo struct {
+	Height                       int64                                          `json:"height"`
+	Address                      api.Address                                    `json:"address"`
+	Account                      *api.Account                                   `json:"account"`
+	OutgoingDelegations          map[api.Address]*api.DelegationInfo            `json:"outgoing_delegations"`
+	IncomingDelegations          map[api.Address]*api.Delegation                `json:"incoming_delegations"`
+	OutgoingDebondingDelegations map[api.Address][]*api.DebondingDelegationInfo `json:"outgoing_debonding_delegations"`
+	IncomingDebondingDelegations map[api.Address][]*api.DebondingDelegation     `json:"incoming_debonding_delegations"`
+}
+
 func doAccountInfo(cmd *cobra.Command, _ []string) {
 	if err := cmdCommon.Init(); err != nil {
 		cmdCommon.EarlyLogAndExit(err)
 	}
 
+	format, err := cmdFlags.Format()
+	if err != nil {
+		logger.Error("invalid output format",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
 	var addr api.Address
-	if err := addr.UnmarshalText([]byte(viper.GetString(CfgAccountAddr))); err != nil {
+	if err = addr.UnmarshalText([]byte(viper.GetString(CfgAccountAddr))); err != nil {
 		logger.Error("failed to parse account address",
 			"err", err,
 		)
@@ -166,10 +185,10 @@ func doAccountInfo(cmd *cobra.Command, _ []string) {
 
 	// If height is latest height, take height from latest block.
 	if height == consensus.HeightLatest {
-		blk, err := consensusClient.GetBlock(context.Background(), consensus.HeightLatest)
-		if err != nil {
+		blk, berr := consensusClient.GetBlock(context.Background(), consensus.HeightLatest)
+		if berr != nil {
 			logger.Error("failed to fetch latest block",
-				"err", err,
+				"err", berr,
 			)
 			os.Exit(1)
 		}
@@ -182,6 +201,28 @@ func doAccountInfo(cmd *cobra.Command, _ []string) {
 	incomingDelegations := getDelegationsTo(ctx, addr, height, client)
 	outgoingDebondingDelegationInfos := getDebondingDelegationInfosFor(ctx, addr, height, client)
 	incomingDebondingDelegations := getDebondingDelegationsTo(ctx, addr, height, client)
+
+	if format == cmdFlags.FormatJSON {
+		info := &accountInfo{
+			Height:                       height,
+			Address:                      addr,
+			Account:                      acct,
+			OutgoingDelegations:          outgoingDelegationInfos,
+			IncomingDelegations:          incomingDelegations,
+			OutgoingDebondingDelegations: outgoingDebondingDelegationInfos,
+			IncomingDebondingDelegations: incomingDebondingDelegations,
+		}
+		var infoJSON []byte
+		if infoJSON, err = cmdCommon.CanonicalJSONMarshal(info); err != nil {
+			logger.Error("failed to get canonical JSON of account info",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		fmt.Println(string(infoJSON))
+		return
+	}
+
 	symbol := getTokenSymbol(ctx, client)
 	exp := getTokenValueExponent(ctx, client)
 	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenSymbol, symbol)
@@ -540,6 +581,7 @@ func registerAccountCmd() {
 
 	accountInfoCmd.Flags().AddFlagSet(commonAccountFlags)
 	accountInfoCmd.Flags().AddFlagSet(accountInfoFlags)
+	accountInfoCmd.Flags().AddFlagSet(cmdFlags.FormatFlags)
 	accountNonceCmd.Flags().AddFlagSet(commonAccountFlags)
 	accountValidateAddressCmd.Flags().AddFlagSet(commonAccountFlags)
 	accountValidateAddressCmd.Flags().AddFlagSet(cmdFlags.VerboseFlags)
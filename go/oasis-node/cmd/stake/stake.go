@@ -15,6 +15,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
@@ -26,6 +27,17 @@ import (
 // CfgPublicKey configures the public key.
 const CfgPublicKey = "public_key"
 
+// thresholdKinds are the staking threshold kinds queried by the info command.
+var thresholdKinds = []api.ThresholdKind{
+	api.KindEntity,
+	api.KindNodeValidator,
+	api.KindNodeCompute,
+	api.KindNodeKeyManager,
+	api.KindRuntimeCompute,
+	api.KindRuntimeKeyManager,
+	api.KindKeyManagerChurp,
+}
+
 var (
 	stakeCmd = &cobra.Command{
 		Use:        "stake",
@@ -176,79 +188,61 @@ func getDebondingDelegationsTo(
 	return delegations
 }
 
-func doInfo(cmd *cobra.Command, _ []string) {
-	if err := cmdCommon.Init(); err != nil {
-		cmdCommon.EarlyLogAndExit(err)
-	}
+// stakingInfo is the common staking info.
+type stakingInfo struct {
+	TokenSymbol        string                                   `json:"token_symbol"`
+	TokenValueExponent uint8                                    `json:"token_value_exponent"`
+	TotalSupply        *quantity.Quantity                       `json:"total_supply"`
+	CommonPool         *quantity.Quantity                       `json:"common_pool"`
+	LastBlockFees      *quantity.Quantity                       `json:"last_block_fees"`
+	GovernanceDeposits *quantity.Quantity                       `json:"governance_deposits"`
+	Thresholds         map[api.ThresholdKind]*quantity.Quantity `json:"thresholds"`
+}
 
-	conn, client := doConnect(cmd)
-	defer conn.Close()
+func getStakingInfo(ctx context.Context, client api.Backend, height int64) *stakingInfo {
+	var (
+		info stakingInfo
+		err  error
+	)
+	info.TokenSymbol = getTokenSymbol(ctx, client)
+	info.TokenValueExponent = getTokenValueExponent(ctx, client)
 
-	height := consensus.HeightLatest
-
-	ctx := context.Background()
-	symbol := getTokenSymbol(ctx, client)
-	fmt.Printf("Token's ticker symbol: %s\n", symbol)
-	exp := getTokenValueExponent(ctx, client)
-	fmt.Printf("Token's value base-10 exponent: %d\n", exp)
-	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenSymbol, symbol)
-	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenValueExponent, exp)
-
-	totalSupply, err := client.TotalSupply(ctx, height)
+	info.TotalSupply, err = client.TotalSupply(ctx, height)
 	if err != nil {
 		logger.Error("failed to query total supply",
 			"err", err,
 		)
 		os.Exit(1)
 	}
-	fmt.Print("Total supply: ")
-	token.PrettyPrintAmount(ctx, *totalSupply, os.Stdout)
-	fmt.Println()
 
-	commonPool, err := client.CommonPool(ctx, height)
+	info.CommonPool, err = client.CommonPool(ctx, height)
 	if err != nil {
 		logger.Error("failed to query common pool",
 			"err", err,
 		)
 		os.Exit(1)
 	}
-	fmt.Print("Common pool: ")
-	token.PrettyPrintAmount(ctx, *commonPool, os.Stdout)
-	fmt.Println()
 
-	lastBlockFees, err := client.LastBlockFees(ctx, height)
+	info.LastBlockFees, err = client.LastBlockFees(ctx, height)
 	if err != nil {
 		logger.Error("failed to query last block fees",
 			"err", err,
 		)
 		os.Exit(1)
 	}
-	fmt.Print("Last block fees: ")
-	token.PrettyPrintAmount(ctx, *lastBlockFees, os.Stdout)
-	fmt.Println()
 
-	governanceDeposits, err := client.GovernanceDeposits(ctx, height)
+	info.GovernanceDeposits, err = client.GovernanceDeposits(ctx, height)
 	if err != nil {
 		logger.Error("failed to query governance deposits",
 			"err", err,
 		)
 		os.Exit(1)
 	}
-	fmt.Print("Governance deposits: ")
-	token.PrettyPrintAmount(ctx, *governanceDeposits, os.Stdout)
-	fmt.Println()
 
-	thresholdsToQuery := []api.ThresholdKind{
-		api.KindEntity,
-		api.KindNodeValidator,
-		api.KindNodeCompute,
-		api.KindNodeKeyManager,
-		api.KindRuntimeCompute,
-		api.KindRuntimeKeyManager,
-		api.KindKeyManagerChurp,
-	}
-	for _, kind := range thresholdsToQuery {
-		thres, err := client.Threshold(ctx, &api.ThresholdQuery{Kind: kind, Height: height})
+	info.Thresholds = make(map[api.ThresholdKind]*quantity.Quantity)
+	for _, kind := range thresholdKinds {
+		var thres *quantity.Quantity
+		thres, err = client.Threshold(ctx, &api.ThresholdQuery{Kind: kind, Height: height})
 		if err != nil {
 			if errors.Is(err, api.ErrInvalidThreshold) {
 				logger.Warn(fmt.Sprintf("invalid staking threshold kind: %s", kind))
@@ -259,6 +253,69 @@ func doInfo(cmd *cobra.Command, _ []string) {
 			)
 			os.Exit(1)
 		}
+		info.Thresholds[kind] = thres
+	}
+
+	return &info
+}
+
+func doInfo(cmd *cobra.Command, _ []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	format, err := cmdFlags.Format()
+	if err != nil {
+		logger.Error("invalid output format",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	ctx := context.Background()
+	info := getStakingInfo(ctx, client, consensus.HeightLatest)
+
+	if format == cmdFlags.FormatJSON {
+		var infoJSON []byte
+		if infoJSON, err = cmdCommon.CanonicalJSONMarshal(info); err != nil {
+			logger.Error("failed to get canonical JSON of staking info",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		fmt.Println(string(infoJSON))
+		return
+	}
+
+	fmt.Printf("Token's ticker symbol: %s\n", info.TokenSymbol)
+	fmt.Printf("Token's value base-10 exponent: %d\n", info.TokenValueExponent)
+	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenSymbol, info.TokenSymbol)
+	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenValueExponent, info.TokenValueExponent)
+
+	fmt.Print("Total supply: ")
+	token.PrettyPrintAmount(ctx, *info.TotalSupply, os.Stdout)
+	fmt.Println()
+
+	fmt.Print("Common pool: ")
+	token.PrettyPrintAmount(ctx, *info.CommonPool, os.Stdout)
+	fmt.Println()
+
+	fmt.Print("Last block fees: ")
+	token.PrettyPrintAmount(ctx, *info.LastBlockFees, os.Stdout)
+	fmt.Println()
+
+	fmt.Print("Governance deposits: ")
+	token.PrettyPrintAmount(ctx, *info.GovernanceDeposits, os.Stdout)
+	fmt.Println()
+
+	for _, kind := range thresholdKinds {
+		thres, ok := info.Thresholds[kind]
+		if !ok {
+			continue
+		}
 		fmt.Printf("Staking threshold (%s): ", kind)
 		token.PrettyPrintAmount(ctx, *thres, os.Stdout)
 		fmt.Println()
@@ -270,6 +327,14 @@ func doList(cmd *cobra.Command, _ []string) {
 		cmdCommon.EarlyLogAndExit(err)
 	}
 
+	format, err := cmdFlags.Format()
+	if err != nil {
+		logger.Error("invalid output format",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
 	conn, client := doConnect(cmd)
 	defer conn.Close()
 
@@ -285,6 +350,28 @@ func doList(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if format == cmdFlags.FormatJSON {
+		// Output a map of addresses to accounts in verbose mode and a list of addresses otherwise.
+		var out interface{} = addresses
+		if cmdFlags.Verbose() {
+			accounts := make(map[api.Address]*api.Account, len(addresses))
+			for _, addr := range addresses {
+				accounts[addr] = getAccount(ctx, addr, height, client)
+			}
+			out = accounts
+		}
+
+		var outJSON []byte
+		if outJSON, err = cmdCommon.CanonicalJSONMarshal(out); err != nil {
+			logger.Error("failed to get canonical JSON of accounts",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		fmt.Println(string(outJSON))
+		return
+	}
+
 	for _, addr := range addresses {
 		var acctString string
 		switch cmdFlags.Verbose() {
@@ -353,9 +440,11 @@ func Register(parentCmd *cobra.Command) {
 }
 
 func init() {
+	infoFlags.AddFlagSet(cmdFlags.FormatFlags)
 	infoFlags.AddFlagSet(cmdGrpc.ClientFlags)
 
 	listFlags.AddFlagSet(cmdFlags.VerboseFlags)
+	listFlags.AddFlagSet(cmdFlags.FormatFlags)
 	listFlags.AddFlagSet(cmdGrpc.ClientFlags)
 
 	pubkey2AddressFlags.String(CfgPublicKey, "", "Public key (Base64-encoded)")
@@ -39,6 +39,7 @@ type Compute struct { // nolint: maligned
 	sentryIndices []int
 
 	storageBackend          string
+	storageMemoryOnly       bool
 	disablePublicRPC        bool
 	checkpointSyncDisabled  bool
 	checkpointCheckInterval time.Duration
@@ -64,6 +65,7 @@ type ComputeCfg struct {
 	SentryIndices []int
 
 	StorageBackend          string
+	StorageMemoryOnly       bool
 	DisablePublicRPC        bool
 	CheckpointSyncDisabled  bool
 	CheckpointCheckInterval time.Duration
@@ -169,6 +171,7 @@ func (worker *Compute) ModifyConfig() error {
 	worker.Config.Runtime.AttestInterval = worker.net.cfg.RuntimeAttestInterval
 
 	worker.Config.Storage.Backend = worker.storageBackend
+	worker.Config.Storage.Debug.MemoryOnly = worker.storageMemoryOnly
 	worker.Config.Storage.PublicRPCEnabled = !worker.disablePublicRPC
 	worker.Config.Storage.CheckpointSyncDisabled = worker.checkpointSyncDisabled
 	worker.Config.Storage.Checkpointer.Enabled = true
@@ -233,6 +236,7 @@ func (net *Network) NewCompute(cfg *ComputeCfg) (*Compute, error) {
 	worker := &Compute{
 		Node:                    host,
 		storageBackend:          cfg.StorageBackend,
+		storageMemoryOnly:       cfg.StorageMemoryOnly,
 		sentryIndices:           cfg.SentryIndices,
 		disablePublicRPC:        cfg.DisablePublicRPC,
 		checkpointSyncDisabled:  cfg.CheckpointSyncDisabled,
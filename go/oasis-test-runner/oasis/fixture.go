@@ -382,8 +382,9 @@ type ComputeWorkerFixture struct {
 
 	EnableProfiling bool `json:"enable_profiling"`
 
-	StorageBackend   string `json:"storage_backend,omitempty"`
-	DisablePublicRPC bool   `json:"disable_public_rpc"`
+	StorageBackend    string `json:"storage_backend,omitempty"`
+	StorageMemoryOnly bool   `json:"storage_memory_only,omitempty"`
+	DisablePublicRPC  bool   `json:"disable_public_rpc"`
 
 	// Consensus contains configuration for the consensus backend.
 	Consensus ConsensusFixture `json:"consensus"`
@@ -429,6 +430,7 @@ func (f *ComputeWorkerFixture) Create(net *Network) (*Compute, error) {
 		},
 		RuntimeProvisioner:      f.RuntimeProvisioner,
 		StorageBackend:          f.StorageBackend,
+		StorageMemoryOnly:       f.StorageMemoryOnly,
 		SentryIndices:           f.Sentries,
 		CheckpointCheckInterval: f.CheckpointCheckInterval,
 		// The checkpoint syncing flag is intentionally flipped here.
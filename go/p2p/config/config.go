@@ -3,6 +3,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 )
 
@@ -10,10 +11,17 @@ import (
 type Config struct {
 	// Port to use for incoming P2P connections.
 	Port uint16 `yaml:"port"`
+	// DualStack enables listening for incoming P2P connections on IPv6 in
+	// addition to IPv4.
+	DualStack bool `yaml:"dual_stack,omitempty"`
 
 	// Seed node(s) of the form pubkey@IP:port.
 	Seeds []string `yaml:"seeds,omitempty"`
 
+	// Proxy is the URL of a SOCKS5 proxy (e.g., socks5://127.0.0.1:9050) through which all
+	// outgoing P2P connections, including connections to committee members, are dialed.
+	Proxy string `yaml:"proxy,omitempty"`
+
 	Discovery         DiscoveryConfig         `yaml:"discovery,omitempty"`
 	Registration      RegistrationConfig      `yaml:"registration,omitempty"`
 	Gossipsub         GossipsubConfig         `yaml:"gossipsub,omitempty"`
@@ -39,6 +47,9 @@ type BootstrapConfig struct {
 type RegistrationConfig struct {
 	// Address/port(s) to use for P2P connections when registering this node
 	// (if not set, all non-loopback local interfaces will be used).
+	//
+	// Hostnames are registered as-is and resolved by peers on each dial, which
+	// requires hostname addresses to be enabled in the registry.
 	Addresses []string `yaml:"addresses"`
 }
 
@@ -96,6 +107,19 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("gossipsub.validate_throttle must be >= 0")
 	}
 
+	if c.Proxy != "" {
+		u, err := url.Parse(c.Proxy)
+		if err != nil {
+			return fmt.Errorf("proxy: malformed URL: %w", err)
+		}
+		if u.Scheme != "socks5" && u.Scheme != "socks5h" {
+			return fmt.Errorf("proxy: unsupported scheme '%s'", u.Scheme)
+		}
+		if u.Host == "" {
+			return fmt.Errorf("proxy: missing host")
+		}
+	}
+
 	return nil
 }
 
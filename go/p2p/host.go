@@ -3,6 +3,7 @@ package p2p
 import (
 	"fmt"
 	"net"
+	"net/url"
 	"time"
 
 	"github.com/libp2p/go-libp2p"
@@ -13,7 +14,9 @@ import (
 	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
 	"github.com/libp2p/go-libp2p/p2p/net/conngater"
 	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
+	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
 	"github.com/multiformats/go-multiaddr"
+	"golang.org/x/net/proxy"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
@@ -26,9 +29,12 @@ import (
 type HostConfig struct {
 	Signer signature.Signer
 
-	UserAgent  string
-	ListenAddr multiaddr.Multiaddr
-	Port       uint16
+	UserAgent   string
+	ListenAddrs []multiaddr.Multiaddr
+	Port        uint16
+
+	// Proxy is the optional SOCKS5 proxy through which outgoing connections are dialed.
+	Proxy *url.URL
 
 	ConnManagerConfig
 	ConnGaterConfig
@@ -56,14 +62,24 @@ func NewHost(cfg *HostConfig) (host.Host, *conngater.BasicConnectionGater, error
 		return nil, nil, err
 	}
 
-	host, err := libp2p.New(
+	opts := []libp2p.Option{
 		libp2p.UserAgent(cfg.UserAgent),
-		libp2p.ListenAddrs(cfg.ListenAddr),
+		libp2p.ListenAddrs(cfg.ListenAddrs...),
 		libp2p.Identity(id),
 		libp2p.ResourceManager(rm),
 		libp2p.ConnectionManager(cm),
 		libp2p.ConnectionGater(cg),
-	)
+	}
+	if cfg.Proxy != nil {
+		dialer, perr := newProxyDialer(cfg.Proxy)
+		if perr != nil {
+			return nil, nil, perr
+		}
+		// Only the TCP transport can be proxied, so it replaces the default transports.
+		opts = append(opts, libp2p.Transport(tcp.NewTCPTransport, tcp.WithDialerForAddr(dialer)))
+	}
+
+	host, err := libp2p.New(opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -72,6 +88,22 @@ func NewHost(cfg *HostConfig) (host.Host, *conngater.BasicConnectionGater, error
 	return host, cg, nil
 }
 
+// newProxyDialer returns a dialer which dials all TCP connections through the given SOCKS5 proxy.
+func newProxyDialer(u *url.URL) (tcp.DialerForAddr, error) {
+	d, err := proxy.FromURL(u, proxy.Direct)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create proxy dialer: %w", err)
+	}
+	cd, ok := d.(proxy.ContextDialer)
+	if !ok {
+		return nil, fmt.Errorf("proxy dialer does not support contexts")
+	}
+
+	return func(multiaddr.Multiaddr) (tcp.ContextDialer, error) {
+		return cd, nil
+	}, nil
+}
+
 // NewHost constructs a new libp2p host.
 func (cfg *HostConfig) NewHost() (host.Host, *conngater.BasicConnectionGater, error) {
 	return NewHost(cfg)
@@ -83,26 +115,40 @@ func (cfg *HostConfig) Load() error {
 	port := config.GlobalConfig.P2P.Port
 
 	// Listen for connections on all interfaces.
-	listenAddr, err := multiaddr.NewMultiaddr(
-		fmt.Sprintf("/ip4/0.0.0.0/tcp/%d", port),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create multiaddress: %w", err)
+	listenAddrStrs := []string{fmt.Sprintf("/ip4/0.0.0.0/tcp/%d", port)}
+	if config.GlobalConfig.P2P.DualStack {
+		listenAddrStrs = append(listenAddrStrs, fmt.Sprintf("/ip6/::/tcp/%d", port))
+	}
+	listenAddrs := make([]multiaddr.Multiaddr, 0, len(listenAddrStrs))
+	for _, addrStr := range listenAddrStrs {
+		listenAddr, err := multiaddr.NewMultiaddr(addrStr)
+		if err != nil {
+			return fmt.Errorf("failed to create multiaddress: %w", err)
+		}
+		listenAddrs = append(listenAddrs, listenAddr)
 	}
 
 	var cmCfg ConnManagerConfig
-	if err = cmCfg.Load(); err != nil {
+	if err := cmCfg.Load(); err != nil {
 		return fmt.Errorf("failed to load connection manager config: %w", err)
 	}
 
 	var cgCfg ConnGaterConfig
-	if err = cgCfg.Load(); err != nil {
+	if err := cgCfg.Load(); err != nil {
 		return fmt.Errorf("failed to load connection gater config: %w", err)
 	}
 
+	if proxyURL := config.GlobalConfig.P2P.Proxy; proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("malformed proxy URL: %w", err)
+		}
+		cfg.Proxy = u
+	}
+
 	cfg.UserAgent = userAgent
 	cfg.Port = port
-	cfg.ListenAddr = listenAddr
+	cfg.ListenAddrs = listenAddrs
 	cfg.ConnManagerConfig = cmCfg
 	cfg.ConnGaterConfig = cgCfg
 
@@ -138,7 +184,7 @@ func (cfg *ConnManagerConfig) NewConnManager() (*connmgr.BasicConnMgr, error) {
 // Load loads connection manager configuration.
 func (cfg *ConnManagerConfig) Load() error {
 	persistentPeersMap := make(map[core.PeerID]struct{})
-	for _, pp := range config.GlobalConfig.P2P.ConnectionManager.PersistentPeers {
+	for _, pp := range persistentPeerAddresses() {
 		var addr node.ConsensusAddress
 		if err := addr.UnmarshalText([]byte(pp)); err != nil {
 			return fmt.Errorf("malformed address (expected pubkey@IP:port): %w", err)
@@ -165,6 +211,15 @@ func (cfg *ConnManagerConfig) Load() error {
 	return nil
 }
 
+// persistentPeerAddresses returns the addresses of all peers that the node should stay connected
+// to, including any upstream storage nodes that the node replicates from.
+func persistentPeerAddresses() []string {
+	var addrs []string
+	addrs = append(addrs, config.GlobalConfig.P2P.ConnectionManager.PersistentPeers...)
+	addrs = append(addrs, config.GlobalConfig.Storage.ReplicateFrom...)
+	return addrs
+}
+
 // ConnGaterConfig describes a set of settings for a connection gater.
 type ConnGaterConfig struct {
 	BlockedPeers []net.IP
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"sync"
 	"time"
 
@@ -173,16 +174,10 @@ func (p *p2p) Addresses() []node.Address {
 
 	var addresses []node.Address
 	for _, v := range addrs {
-		netAddr, err := manet.ToNetAddr(v)
+		nodeAddr, err := multiAddrToNodeAddr(v)
 		if err != nil {
 			panic(err)
 		}
-		tcpAddr := (netAddr).(*net.TCPAddr)
-		nodeAddr := node.Address{
-			IP:   tcpAddr.IP,
-			Port: int64(tcpAddr.Port),
-			Zone: tcpAddr.Zone,
-		}
 
 		if err := registryAPI.VerifyAddress(nodeAddr, allowUnroutable); err != nil {
 			continue
@@ -224,6 +219,40 @@ func (p *p2p) Peers(runtimeID common.Namespace) []string {
 	return peers
 }
 
+// multiAddrToNodeAddr converts a TCP multiaddress to a node address, preserving hostnames.
+func multiAddrToNodeAddr(addr multiaddr.Multiaddr) (node.Address, error) {
+	if host, err := addr.ValueForProtocol(multiaddr.P_DNS); err == nil {
+		rawPort, err := addr.ValueForProtocol(multiaddr.P_TCP)
+		if err != nil {
+			return node.Address{}, err
+		}
+		port, err := strconv.ParseUint(rawPort, 10, 16)
+		if err != nil {
+			return node.Address{}, err
+		}
+
+		var nodeAddr node.Address
+		if err = nodeAddr.FromHostname(host, uint16(port)); err != nil {
+			return node.Address{}, err
+		}
+		return nodeAddr, nil
+	}
+
+	netAddr, err := manet.ToNetAddr(addr)
+	if err != nil {
+		return node.Address{}, err
+	}
+	tcpAddr, ok := netAddr.(*net.TCPAddr)
+	if !ok {
+		return node.Address{}, fmt.Errorf("p2p: unsupported address: %s", addr)
+	}
+	return node.Address{
+		IP:   tcpAddr.IP,
+		Port: int64(tcpAddr.Port),
+		Zone: tcpAddr.Zone,
+	}, nil
+}
+
 func filterGloballyReachableAddresses(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
 	ret := make([]multiaddr.Multiaddr, 0, len(addrs))
 	for _, addr := range addrs {
@@ -465,7 +494,12 @@ func (cfg *Config) Load() error {
 	var addresses []multiaddr.Multiaddr
 	for _, addr := range rawAddresses {
 		var mAddr multiaddr.Multiaddr
-		mAddr, err = manet.FromNetAddr(addr.ToTCPAddr())
+		if addr.IsHostname() {
+			// Hostnames are resolved by libp2p on each dial.
+			mAddr, err = addr.MultiAddress()
+		} else {
+			mAddr, err = manet.FromNetAddr(addr.ToTCPAddr())
+		}
 		if err != nil {
 			return fmt.Errorf("failed to convert address to multiaddress: %w", err)
 		}
@@ -507,7 +541,7 @@ type GossipSubConfig struct {
 
 // Load loads gossipsub configuration.
 func (cfg *GossipSubConfig) Load() error {
-	persistentPeers, err := api.AddrInfosFromConsensusAddrs(config.GlobalConfig.P2P.ConnectionManager.PersistentPeers)
+	persistentPeers, err := api.AddrInfosFromConsensusAddrs(persistentPeerAddresses())
 	if err != nil {
 		return fmt.Errorf("failed to convert persistent peers' addresses: %w", err)
 	}
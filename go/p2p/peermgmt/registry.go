@@ -7,6 +7,7 @@ import (
 
 	"github.com/libp2p/go-libp2p/core"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
 	manet "github.com/multiformats/go-multiaddr/net"
 
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
@@ -275,7 +276,13 @@ func p2pInfoToAddrInfo(pi *node.P2PInfo) (*peer.AddrInfo, error) {
 		return nil, fmt.Errorf("failed to extract public key from node P2P ID: %w", err)
 	}
 	for _, nodeAddr := range pi.Addresses {
-		addr, err := manet.FromNetAddr(nodeAddr.ToTCPAddr())
+		var addr multiaddr.Multiaddr
+		if nodeAddr.IsHostname() {
+			// Hostnames are resolved by libp2p on each dial.
+			addr, err = nodeAddr.MultiAddress()
+		} else {
+			addr, err = manet.FromNetAddr(nodeAddr.ToTCPAddr())
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert address to libp2p format: %w", err)
 		}
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	cryptorand "crypto/rand"
 	"math/rand"
 	"sort"
@@ -10,10 +11,12 @@ import (
 	"github.com/libp2p/go-libp2p/core"
 	"github.com/libp2p/go-libp2p/core/event"
 	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/mathrand"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 )
 
@@ -24,6 +27,11 @@ const (
 	// newPeerScoreMultiplier is the score multiplier for new peers for which we don't yet have any
 	// historical measurements.
 	newPeerScoreMultiplier = 0.9
+
+	// statsPersistInterval is the interval between persisting peer statistics.
+	statsPersistInterval = 1 * time.Minute
+	// maxPersistedPeerStats is the maximum number of persisted peer statistics.
+	maxPersistedPeerStats = 1024
 )
 
 // Inverse alpha (1/alpha) values for computing the exponential moving average of latencies used for
@@ -104,12 +112,41 @@ type PeerRemoved struct {
 	BadPeer bool
 }
 
+// PeerManagerOption is a peer manager option setter.
+type PeerManagerOption func(mgr *peerManager)
+
+// WithStatsStore configures the peer manager to persist peer statistics under the given key of
+// the given store, so that peer scoring survives restarts.
+//
+// Statistics of peers are also retained while the peers are disconnected. Statistics are
+// persisted periodically and once more when the given context is canceled.
+func WithStatsStore(ctx context.Context, store *persistent.ServiceStore, key string) PeerManagerOption {
+	return func(mgr *peerManager) {
+		mgr.statsCtx = ctx
+		mgr.statsStore = store
+		mgr.statsKey = []byte(key)
+	}
+}
+
 type peerStats struct {
 	successes         int
 	failures          int
 	avgRequestLatency time.Duration
 }
 
+// persistedPeerStats are the persisted statistics of a single peer.
+type persistedPeerStats struct {
+	Successes         int           `json:"successes"`
+	Failures          int           `json:"failures"`
+	AvgRequestLatency time.Duration `json:"avg_request_latency"`
+}
+
+// persistedStats are the persisted peer manager statistics.
+type persistedStats struct {
+	AvgRequestLatency time.Duration                 `json:"avg_request_latency"`
+	Peers             map[string]persistedPeerStats `json:"peers"`
+}
+
 // getScore returns the peer score (lower is better).
 func (ps *peerStats) getScore(avgRequestLatency time.Duration) float64 {
 	if ps.successes+ps.failures > 0 {
@@ -143,6 +180,12 @@ type peerManager struct {
 
 	avgRequestLatency time.Duration
 
+	statsCtx   context.Context
+	statsStore *persistent.ServiceStore
+	statsKey   []byte
+	statsDirty bool
+	pastPeers  map[core.PeerID]*peerStats
+
 	logger *logging.Logger
 }
 
@@ -158,7 +201,13 @@ func (mgr *peerManager) AddPeer(peerID core.PeerID) {
 	if mgr.ignoredPeers[peerID] {
 		return
 	}
-	mgr.peers[peerID] = &peerStats{}
+	ps, ok := mgr.pastPeers[peerID]
+	if ok {
+		delete(mgr.pastPeers, peerID)
+	} else {
+		ps = &peerStats{}
+	}
+	mgr.peers[peerID] = ps
 
 	mgr.logger.Debug("added new peer",
 		"peer_id", peerID,
@@ -171,11 +220,15 @@ func (mgr *peerManager) RemovePeer(peerID core.PeerID) {
 	mgr.Lock()
 	defer mgr.Unlock()
 
-	if _, exists := mgr.peers[peerID]; !exists {
+	ps, exists := mgr.peers[peerID]
+	if !exists {
 		return
 	}
 
 	delete(mgr.peers, peerID)
+	if mgr.statsStore != nil && len(mgr.pastPeers) < maxPersistedPeerStats {
+		mgr.pastPeers[peerID] = ps
+	}
 
 	mgr.logger.Debug("removed peer",
 		"peer_id", peerID,
@@ -194,6 +247,7 @@ func (mgr *peerManager) RecordSuccess(peerID core.PeerID, latency time.Duration)
 	}
 	ps.successes++
 	ps.recordLatency(latency)
+	mgr.statsDirty = true
 
 	// Update global stats.
 	if mgr.avgRequestLatency == 0 {
@@ -217,6 +271,7 @@ func (mgr *peerManager) RecordFailure(peerID core.PeerID, latency time.Duration)
 	}
 	ps.failures++
 	ps.recordLatency(latency)
+	mgr.statsDirty = true
 }
 
 func (mgr *peerManager) RecordBadPeer(peerID core.PeerID) {
@@ -225,6 +280,8 @@ func (mgr *peerManager) RecordBadPeer(peerID core.PeerID) {
 
 	mgr.p2p.BlockPeer(peerID)
 	mgr.ignoredPeers[peerID] = true
+	delete(mgr.pastPeers, peerID)
+	mgr.statsDirty = true
 
 	if _, exists := mgr.peers[peerID]; !exists {
 		return
@@ -370,8 +427,104 @@ func (mgr *peerManager) peerProtocolWatcher() {
 	}
 }
 
+// restoreStats restores persisted peer statistics.
+func (mgr *peerManager) restoreStats() {
+	var stats persistedStats
+	if err := mgr.statsStore.GetCBOR(mgr.statsKey, &stats); err != nil {
+		if err != persistent.ErrNotFound {
+			mgr.logger.Error("failed to restore peer statistics",
+				"err", err,
+			)
+		}
+		return
+	}
+
+	mgr.avgRequestLatency = stats.AvgRequestLatency
+	for id, ps := range stats.Peers {
+		peerID, err := peer.Decode(id)
+		if err != nil {
+			continue
+		}
+		mgr.pastPeers[peerID] = &peerStats{
+			successes:         ps.Successes,
+			failures:          ps.Failures,
+			avgRequestLatency: ps.AvgRequestLatency,
+		}
+	}
+
+	mgr.logger.Debug("restored peer statistics",
+		"num_peers", len(mgr.pastPeers),
+	)
+}
+
+// persistStats persists peer statistics in case they changed since they were last persisted.
+func (mgr *peerManager) persistStats() {
+	mgr.Lock()
+	if !mgr.statsDirty {
+		mgr.Unlock()
+		return
+	}
+	mgr.statsDirty = false
+
+	// Keep statistics of peers with the most interactions.
+	peers := make([]core.PeerID, 0, len(mgr.peers)+len(mgr.pastPeers))
+	allStats := make(map[core.PeerID]*peerStats, len(mgr.peers)+len(mgr.pastPeers))
+	for _, m := range []map[core.PeerID]*peerStats{mgr.pastPeers, mgr.peers} {
+		for peerID, ps := range m {
+			if ps.successes+ps.failures == 0 {
+				continue
+			}
+			peers = append(peers, peerID)
+			allStats[peerID] = ps
+		}
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		pi, pj := allStats[peers[i]], allStats[peers[j]]
+		return pi.successes+pi.failures > pj.successes+pj.failures
+	})
+	if len(peers) > maxPersistedPeerStats {
+		peers = peers[:maxPersistedPeerStats]
+	}
+
+	stats := persistedStats{
+		AvgRequestLatency: mgr.avgRequestLatency,
+		Peers:             make(map[string]persistedPeerStats, len(peers)),
+	}
+	for _, peerID := range peers {
+		ps := allStats[peerID]
+		stats.Peers[peerID.String()] = persistedPeerStats{
+			Successes:         ps.successes,
+			Failures:          ps.failures,
+			AvgRequestLatency: ps.avgRequestLatency,
+		}
+	}
+	mgr.Unlock()
+
+	if err := mgr.statsStore.PutCBOR(mgr.statsKey, &stats); err != nil {
+		mgr.logger.Error("failed to persist peer statistics",
+			"err", err,
+		)
+	}
+}
+
+func (mgr *peerManager) statsPersister() {
+	ticker := time.NewTicker(statsPersistInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			mgr.persistStats()
+		case <-mgr.statsCtx.Done():
+			// Make sure statistics gathered since the last tick are not lost.
+			mgr.persistStats()
+			return
+		}
+	}
+}
+
 // NewPeerManager creates a new peer manager for the given protocol.
-func NewPeerManager(p2p P2P, protocolID protocol.ID) PeerManager {
+func NewPeerManager(p2p P2P, protocolID protocol.ID, opts ...PeerManagerOption) PeerManager {
 	if p2p.Host() == nil {
 		// No P2P service, use the no-op peer manager.
 		return &nopPeerManager{}
@@ -384,10 +537,18 @@ func NewPeerManager(p2p P2P, protocolID protocol.ID) PeerManager {
 		peerUpdatesNotifier: pubsub.NewBroker(false),
 		peers:               make(map[core.PeerID]*peerStats),
 		ignoredPeers:        make(map[core.PeerID]bool),
+		pastPeers:           make(map[core.PeerID]*peerStats),
 		logger: logging.GetLogger("p2p/rpc/peermgr").With(
 			"protocol_id", protocolID,
 		),
 	}
+	for _, opt := range opts {
+		opt(mgr)
+	}
+	if mgr.statsStore != nil {
+		mgr.restoreStats()
+		go mgr.statsPersister()
+	}
 	go mgr.peerProtocolWatcher()
 
 	return mgr
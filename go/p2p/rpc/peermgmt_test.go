@@ -1,16 +1,20 @@
 package rpc
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core"
+	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
 )
 
 type testP2P struct {
@@ -91,3 +95,87 @@ func TestWatchUpdates(t *testing.T) {
 	case <-time.After(100 * time.Millisecond):
 	}
 }
+
+func TestPersistStats(t *testing.T) {
+	require := require.New(t)
+
+	listenAddr, err := multiaddr.NewMultiaddr("/ip4/0.0.0.0/tcp/0")
+	require.NoError(err, "NewMultiaddr failed")
+	host, err := libp2p.New(
+		libp2p.ListenAddrs(listenAddr),
+	)
+	require.NoError(err, "libp2p.New failed")
+	defer host.Close()
+
+	cs, err := persistent.NewCommonStore(t.TempDir())
+	require.NoError(err, "NewCommonStore")
+	defer cs.Close()
+	store := cs.GetServiceStore("p2p/rpc/test")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, pk, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(err, "GenerateEd25519Key")
+	peerID, err := peer.IDFromPublicKey(pk)
+	require.NoError(err, "IDFromPublicKey")
+
+	mgr := NewPeerManager(&testP2P{host}, testProtocol, WithStatsStore(ctx, store, "test")).(*peerManager)
+	mgr.AddPeer(peerID)
+	mgr.RecordSuccess(peerID, 100*time.Millisecond)
+	mgr.RecordFailure(peerID, 300*time.Millisecond)
+	mgr.RemovePeer(peerID)
+	mgr.persistStats()
+
+	// Statistics should be restored and used once the peer is added again.
+	mgr = NewPeerManager(&testP2P{host}, testProtocol, WithStatsStore(ctx, store, "test")).(*peerManager)
+	require.Equal(100*time.Millisecond, mgr.avgRequestLatency)
+	mgr.AddPeer(peerID)
+	require.Equal(&peerStats{
+		successes:         1,
+		failures:          1,
+		avgRequestLatency: 120 * time.Millisecond,
+	}, mgr.peers[peerID])
+
+	// Statistics of other keys should be independent.
+	mgr = NewPeerManager(&testP2P{host}, testProtocol, WithStatsStore(ctx, store, "other")).(*peerManager)
+	require.Empty(mgr.pastPeers)
+}
+
+func TestPersistStatsOnStop(t *testing.T) {
+	require := require.New(t)
+
+	listenAddr, err := multiaddr.NewMultiaddr("/ip4/0.0.0.0/tcp/0")
+	require.NoError(err, "NewMultiaddr failed")
+	host, err := libp2p.New(
+		libp2p.ListenAddrs(listenAddr),
+	)
+	require.NoError(err, "libp2p.New failed")
+	defer host.Close()
+
+	cs, err := persistent.NewCommonStore(t.TempDir())
+	require.NoError(err, "NewCommonStore")
+	defer cs.Close()
+	store := cs.GetServiceStore("p2p/rpc/test")
+
+	_, pk, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(err, "GenerateEd25519Key")
+	peerID, err := peer.IDFromPublicKey(pk)
+	require.NoError(err, "IDFromPublicKey")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr := NewPeerManager(&testP2P{host}, testProtocol, WithStatsStore(ctx, store, "test"))
+	mgr.AddPeer(peerID)
+	mgr.RecordSuccess(peerID, 100*time.Millisecond)
+
+	// Statistics should be flushed once the context is canceled.
+	cancel()
+	require.Eventually(func() bool {
+		var stats persistedStats
+		if err := store.GetCBOR([]byte("test"), &stats); err != nil {
+			return false
+		}
+		_, ok := stats.Peers[peerID.String()]
+		return ok
+	}, 5*time.Second, 10*time.Millisecond, "statistics should be persisted on stop")
+}
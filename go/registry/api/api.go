@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
@@ -132,6 +133,11 @@ var (
 	MethodRegisterRuntime = transaction.NewMethodName(ModuleName, "RegisterRuntime", Runtime{})
 	// MethodProveFreshness is the method name for freshness proofs.
 	MethodProveFreshness = transaction.NewMethodName(ModuleName, "ProveFreshness", [32]byte{})
+	// MethodAddEntityToWhitelist is the method name for adding entities to the entity whitelist.
+	MethodAddEntityToWhitelist = transaction.NewMethodName(ModuleName, "AddEntityToWhitelist", AddEntityToWhitelist{})
+	// MethodRemoveEntityFromWhitelist is the method name for removing entities from the entity
+	// whitelist.
+	MethodRemoveEntityFromWhitelist = transaction.NewMethodName(ModuleName, "RemoveEntityFromWhitelist", RemoveEntityFromWhitelist{})
 
 	// Methods is the list of all methods supported by the registry backend.
 	Methods = []transaction.MethodName{
@@ -141,6 +147,8 @@ var (
 		MethodUnfreezeNode,
 		MethodRegisterRuntime,
 		MethodProveFreshness,
+		MethodAddEntityToWhitelist,
+		MethodRemoveEntityFromWhitelist,
 	}
 
 	// RuntimesRequiredRoles are the Node roles that require runtimes.
@@ -202,6 +210,13 @@ type Backend interface {
 	// order.
 	WatchNodeList(context.Context) (<-chan *NodeList, pubsub.ClosableSubscription, error)
 
+	// WatchNodeExpirations returns a channel that produces a stream of
+	// NodeExpirations, one per epoch, listing the nodes whose registrations
+	// will expire at the start of the next epoch unless they are renewed.
+	// Upon subscription, the expirations for the current epoch will be sent
+	// immediately.
+	WatchNodeExpirations(context.Context) (<-chan *NodeExpirations, pubsub.ClosableSubscription, error)
+
 	// GetRuntime gets a runtime by ID.
 	GetRuntime(context.Context, *GetRuntimeQuery) (*Runtime, error)
 
@@ -225,6 +240,10 @@ type Backend interface {
 	// ConsensusParameters returns the registry consensus parameters.
 	ConsensusParameters(ctx context.Context, height int64) (*ConsensusParameters, error)
 
+	// GetEntityWhitelist returns the identifiers of all whitelisted entities at the
+	// specified block height.
+	GetEntityWhitelist(ctx context.Context, height int64) ([]signature.PublicKey, error)
+
 	// Cleanup cleans up the registry backend.
 	Cleanup()
 }
@@ -265,6 +284,18 @@ type ConsensusAddressQuery struct {
 // DeregisterEntity is a request to deregister an entity.
 type DeregisterEntity struct{}
 
+// AddEntityToWhitelist is a request to add an entity to the entity whitelist.
+type AddEntityToWhitelist struct {
+	// EntityID is the identifier of the entity to whitelist.
+	EntityID signature.PublicKey `json:"entity_id"`
+}
+
+// RemoveEntityFromWhitelist is a request to remove an entity from the entity whitelist.
+type RemoveEntityFromWhitelist struct {
+	// EntityID is the identifier of the entity to remove from the whitelist.
+	EntityID signature.PublicKey `json:"entity_id"`
+}
+
 // NewRegisterEntityTx creates a new register entity transaction.
 func NewRegisterEntityTx(nonce uint64, fee *transaction.Fee, sigEnt *entity.SignedEntity) *transaction.Transaction {
 	return transaction.NewTransaction(nonce, fee, MethodRegisterEntity, sigEnt)
@@ -295,6 +326,16 @@ func NewProveFreshnessTx(nonce uint64, fee *transaction.Fee, blob [32]byte) *tra
 	return transaction.NewTransaction(nonce, fee, MethodProveFreshness, blob)
 }
 
+// NewAddEntityToWhitelistTx creates a new add entity to whitelist transaction.
+func NewAddEntityToWhitelistTx(nonce uint64, fee *transaction.Fee, add *AddEntityToWhitelist) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodAddEntityToWhitelist, add)
+}
+
+// NewRemoveEntityFromWhitelistTx creates a new remove entity from whitelist transaction.
+func NewRemoveEntityFromWhitelistTx(nonce uint64, fee *transaction.Fee, remove *RemoveEntityFromWhitelist) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodRemoveEntityFromWhitelist, remove)
+}
+
 // EntityEvent is the event that is returned via WatchEntities to signify
 // entity registration changes and updates.
 type EntityEvent struct {
@@ -351,6 +392,17 @@ func (e *NodeUnfrozenEvent) EventKind() string {
 	return "node_unfrozen"
 }
 
+// EntityWhitelistEvent signifies an entity whitelist change.
+type EntityWhitelistEvent struct {
+	EntityID      signature.PublicKey `json:"entity_id"`
+	IsWhitelisted bool                `json:"is_whitelisted"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *EntityWhitelistEvent) EventKind() string {
+	return "entity_whitelist"
+}
+
 var _ events.CustomTypedAttribute = (*NodeListEpochEvent)(nil)
 
 // NodeListEpochEvent is the per epoch node list event.
@@ -382,6 +434,7 @@ type Event struct {
 	EntityEvent           *EntityEvent           `json:"entity,omitempty"`
 	NodeEvent             *NodeEvent             `json:"node,omitempty"`
 	NodeUnfrozenEvent     *NodeUnfrozenEvent     `json:"node_unfrozen,omitempty"`
+	EntityWhitelistEvent  *EntityWhitelistEvent  `json:"entity_whitelist,omitempty"`
 }
 
 // NodeList is a per-epoch immutable node list.
@@ -389,6 +442,27 @@ type NodeList struct {
 	Nodes []*node.Node `json:"nodes"`
 }
 
+// NodeExpirations is a per-epoch list of nodes whose registrations will
+// expire at the start of the next epoch unless they are renewed.
+type NodeExpirations struct {
+	// Epoch is the epoch in which the node list was generated.
+	Epoch beacon.EpochTime `json:"epoch"`
+	// Nodes is the list of expiring nodes, sorted by node ID.
+	Nodes []*node.Node `json:"nodes"`
+}
+
+// ExpiringNodes returns the nodes that are not expired in the given epoch
+// but will be expired in the following epoch.
+func ExpiringNodes(nodes []*node.Node, epoch beacon.EpochTime) []*node.Node {
+	var expiring []*node.Node
+	for _, n := range nodes {
+		if !n.IsExpired(uint64(epoch)) && n.IsExpired(uint64(epoch+1)) {
+			expiring = append(expiring, n)
+		}
+	}
+	return expiring
+}
+
 // NodeLookup interface implements various ways for the verification
 // functions to look-up nodes in the registry's state.
 type NodeLookup interface {
@@ -527,6 +601,18 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 		)
 		return nil, nil, ErrInvalidArgument
 	}
+	if n.Capacity != nil && !params.EnableNodeCapacity {
+		logger.Error("RegisterNode: node capacity hints are not enabled",
+			"node", n,
+		)
+		return nil, nil, fmt.Errorf("%w: node capacity hints are not enabled", ErrInvalidArgument)
+	}
+	if n.Build != nil && !params.EnableNodeBuildInfo {
+		logger.Error("RegisterNode: node build metadata is not enabled",
+			"node", n,
+		)
+		return nil, nil, fmt.Errorf("%w: node build metadata is not enabled", ErrInvalidArgument)
+	}
 
 	// This should never happen, unless there's a bug in the caller.
 	if !entity.ID.Equal(n.EntityID) {
@@ -852,8 +938,50 @@ func VerifyNodeRuntimeEnclaveIDs(
 	return fmt.Errorf("%w: node running unknown runtime enclave version", ErrInvalidArgument)
 }
 
+// nonPublicHostnameSuffixes are the domains that are reserved for local or private use and
+// can never resolve to a globally routable address.
+var nonPublicHostnameSuffixes = []string{
+	"localhost",
+	"localdomain",
+	"local",
+	"internal",
+	"lan",
+	"home.arpa",
+	"invalid",
+	"test",
+}
+
+// isFullyQualifiedHostname returns true iff the given hostname is a fully qualified domain name
+// outside of the domains reserved for local or private use.
+func isFullyQualifiedHostname(host string) bool {
+	host = strings.ToLower(host)
+	if !strings.Contains(host, ".") {
+		return false
+	}
+	for _, suffix := range nonPublicHostnameSuffixes {
+		if host == suffix || strings.HasSuffix(host, "."+suffix) {
+			return false
+		}
+	}
+	return true
+}
+
 // VerifyAddress verifies a node address.
+//
+// Addresses referring to hostnames can only be resolved later, so unless unroutable addresses
+// are allowed, they are only required to be fully qualified names outside of the domains
+// reserved for local or private use.
 func VerifyAddress(addr node.Address, allowUnroutable bool) error {
+	if addr.IsHostname() {
+		if !node.IsValidHostname(addr.Host) {
+			return fmt.Errorf("%w: malformed hostname", ErrInvalidArgument)
+		}
+		if !allowUnroutable && !isFullyQualifiedHostname(addr.Host) {
+			return fmt.Errorf("%w: hostname not fully qualified", ErrInvalidArgument)
+		}
+		return nil
+	}
+
 	if !allowUnroutable {
 		// Use the runtime to reject clearly invalid addresses.
 		if !addr.IP.IsGlobalUnicast() {
@@ -869,6 +997,13 @@ func VerifyAddress(addr node.Address, allowUnroutable bool) error {
 }
 
 func verifyAddresses(params *ConsensusParameters, addressRequired bool, addresses interface{}) error {
+	verifyAddress := func(addr node.Address) error {
+		if addr.IsHostname() && !params.EnableHostnameAddresses {
+			return fmt.Errorf("%w: hostname addresses are not enabled", ErrInvalidArgument)
+		}
+		return VerifyAddress(addr, params.DebugAllowUnroutableAddresses)
+	}
+
 	switch addrs := addresses.(type) {
 	case []node.ConsensusAddress:
 		if len(addrs) == 0 && addressRequired {
@@ -878,7 +1013,7 @@ func verifyAddresses(params *ConsensusParameters, addressRequired bool, addresse
 			if !v.ID.IsValid() {
 				return fmt.Errorf("%w: consensus address ID invalid", ErrInvalidArgument)
 			}
-			if err := VerifyAddress(v.Address, params.DebugAllowUnroutableAddresses); err != nil {
+			if err := verifyAddress(v.Address); err != nil {
 				return err
 			}
 		}
@@ -887,7 +1022,7 @@ func verifyAddresses(params *ConsensusParameters, addressRequired bool, addresse
 			return fmt.Errorf("%w: missing node address", ErrInvalidArgument)
 		}
 		for _, v := range addrs {
-			if err := VerifyAddress(v, params.DebugAllowUnroutableAddresses); err != nil {
+			if err := verifyAddress(v); err != nil {
 				return err
 			}
 		}
@@ -1200,6 +1335,16 @@ func VerifyRegisterComputeRuntimeArgs(ctx context.Context, logger *logging.Logge
 
 // VerifyRuntimeNew verifies a new runtime.
 func VerifyRuntimeNew(logger *logging.Logger, rt *Runtime, now beacon.EpochTime, params *ConsensusParameters, isGenesis bool) error {
+	if !isGenesis {
+		// Runtimes existing at genesis may keep their legacy identifiers.
+		if err := VerifyRuntimeID(params, rt); err != nil {
+			logger.Error("RegisterRuntime: invalid runtime ID",
+				"runtime_id", rt.ID,
+				"entity_id", rt.EntityID,
+			)
+			return err
+		}
+	}
 	if !(isGenesis || params.DebugDeployImmediately) {
 		// Unless isGenesis or debug option set, forbid immediate deployment.
 		if rt.ActiveDeployment(now) != nil {
@@ -1212,6 +1357,18 @@ func VerifyRuntimeNew(logger *logging.Logger, rt *Runtime, now beacon.EpochTime,
 	return nil
 }
 
+// VerifyRuntimeID verifies that the runtime identifier has been derived from the owning entity in
+// case this is required by the consensus parameters.
+func VerifyRuntimeID(params *ConsensusParameters, rt *Runtime) error {
+	if !params.RequireDerivedRuntimeIDs {
+		return nil
+	}
+	if !rt.ID.IsDerivedFrom(rt.EntityID[:]) {
+		return fmt.Errorf("%w: runtime ID not derived from the owning entity", ErrInvalidArgument)
+	}
+	return nil
+}
+
 // VerifyRuntimeUpdate verifies changes while updating the runtime.
 func VerifyRuntimeUpdate(
 	logger *logging.Logger,
@@ -1375,6 +1532,9 @@ type Genesis struct {
 
 	// NodeStatuses is a set of node statuses.
 	NodeStatuses map[signature.PublicKey]*NodeStatus `json:"node_statuses,omitempty"`
+
+	// EntityWhitelist is the initial list of whitelisted entities.
+	EntityWhitelist []signature.PublicKey `json:"entity_whitelist,omitempty"`
 }
 
 // ConsensusParameters are the registry consensus parameters.
@@ -1419,6 +1579,28 @@ type ConsensusParameters struct {
 
 	// MaxRuntimeDeployments is the maximum number of runtime deployments.
 	MaxRuntimeDeployments uint8 `json:"max_runtime_deployments,omitempty"`
+
+	// SuspendRuntimesWithoutKeyManager is true iff compute runtimes should be suspended while
+	// their key manager is not initialized or has no active nodes.
+	SuspendRuntimesWithoutKeyManager bool `json:"suspend_runtimes_without_km,omitempty"`
+
+	// EnableHostnameAddresses is true iff node descriptors may contain addresses referring to
+	// hostnames instead of IP addresses.
+	EnableHostnameAddresses bool `json:"enable_hostname_addresses,omitempty"`
+
+	// EnableNodeCapacity is true iff node descriptors may contain capacity hints.
+	EnableNodeCapacity bool `json:"enable_node_capacity,omitempty"`
+
+	// EnableNodeBuildInfo is true iff node descriptors may contain build metadata.
+	EnableNodeBuildInfo bool `json:"enable_node_build_info,omitempty"`
+
+	// EntityAdmissionKey is the public key allowed to manage the entity whitelist. When set,
+	// only whitelisted entities may register nodes and runtimes.
+	EntityAdmissionKey *signature.PublicKey `json:"entity_admission_key,omitempty"`
+
+	// RequireDerivedRuntimeIDs is true iff identifiers of newly registered runtimes must be
+	// derived from the owning entity (see common.NewDerivedNamespace).
+	RequireDerivedRuntimeIDs bool `json:"require_derived_runtime_ids,omitempty"`
 }
 
 // ConsensusParameterChanges are allowed registry consensus parameter changes.
@@ -1443,6 +1625,30 @@ type ConsensusParameterChanges struct {
 
 	// MaxRuntimeDeployments is the new maximum number of runtime deployments.
 	MaxRuntimeDeployments *uint8 `json:"max_runtime_deployments,omitempty"`
+
+	// SuspendRuntimesWithoutKeyManager is the new suspend runtimes without key manager flag.
+	SuspendRuntimesWithoutKeyManager *bool `json:"suspend_runtimes_without_km,omitempty"`
+
+	// EnableHostnameAddresses is the new enable hostname addresses flag.
+	EnableHostnameAddresses *bool `json:"enable_hostname_addresses,omitempty"`
+
+	// EnableNodeCapacity is the new enable node capacity flag.
+	EnableNodeCapacity *bool `json:"enable_node_capacity,omitempty"`
+
+	// EnableNodeBuildInfo is the new enable node build info flag.
+	EnableNodeBuildInfo *bool `json:"enable_node_build_info,omitempty"`
+
+	// EntityAdmissionKey is the new entity admission key.
+	//
+	// When the entity whitelist is enabled this way, all entities with registered nodes or
+	// runtimes are added to the whitelist so that they can keep re-registering.
+	EntityAdmissionKey *signature.PublicKey `json:"entity_admission_key,omitempty"`
+
+	// DisableEntityWhitelist disables the entity whitelist by clearing the entity admission key.
+	DisableEntityWhitelist *bool `json:"disable_entity_whitelist,omitempty"`
+
+	// RequireDerivedRuntimeIDs is the new require derived runtime IDs flag.
+	RequireDerivedRuntimeIDs *bool `json:"require_derived_runtime_ids,omitempty"`
 }
 
 // Apply applies changes to the given consensus parameters.
@@ -1468,6 +1674,28 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.MaxRuntimeDeployments != nil {
 		params.MaxRuntimeDeployments = *c.MaxRuntimeDeployments
 	}
+	if c.SuspendRuntimesWithoutKeyManager != nil {
+		params.SuspendRuntimesWithoutKeyManager = *c.SuspendRuntimesWithoutKeyManager
+	}
+	if c.EnableHostnameAddresses != nil {
+		params.EnableHostnameAddresses = *c.EnableHostnameAddresses
+	}
+	if c.EnableNodeCapacity != nil {
+		params.EnableNodeCapacity = *c.EnableNodeCapacity
+	}
+	if c.EnableNodeBuildInfo != nil {
+		params.EnableNodeBuildInfo = *c.EnableNodeBuildInfo
+	}
+	if c.EntityAdmissionKey != nil {
+		key := *c.EntityAdmissionKey
+		params.EntityAdmissionKey = &key
+	}
+	if c.DisableEntityWhitelist != nil && *c.DisableEntityWhitelist {
+		params.EntityAdmissionKey = nil
+	}
+	if c.RequireDerivedRuntimeIDs != nil {
+		params.RequireDerivedRuntimeIDs = *c.RequireDerivedRuntimeIDs
+	}
 	return nil
 }
 
@@ -1487,6 +1715,8 @@ const (
 	GasOpRuntimeEpochMaintenance transaction.Op = "runtime_epoch_maintenance"
 	// GasOpProveFreshness is the gas operation identifier for freshness proofs.
 	GasOpProveFreshness transaction.Op = "prove_freshness"
+	// GasOpUpdateEntityWhitelist is the gas operation identifier for entity whitelist updates.
+	GasOpUpdateEntityWhitelist transaction.Op = "update_entity_whitelist"
 )
 
 // XXX: Define reasonable default gas costs.
@@ -1500,6 +1730,7 @@ var DefaultGasCosts = transaction.Costs{
 	GasOpRegisterRuntime:         1000,
 	GasOpRuntimeEpochMaintenance: 1000,
 	GasOpProveFreshness:          1000,
+	GasOpUpdateEntityWhitelist:   1000,
 }
 
 const (
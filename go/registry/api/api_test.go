@@ -77,6 +77,51 @@ func (rl *mockRuntimeLookup) Runtimes(context.Context) ([]*Runtime, error) {
 	panic("not implemented")
 }
 
+func TestVerifyAddress(t *testing.T) {
+	for _, tc := range []struct {
+		host            string
+		allowUnroutable bool
+		valid           bool
+	}{
+		{"node.example.com", false, true},
+		{"Node.Example.COM", false, true},
+		{"node-1.oasis.io", false, true},
+		{"localhost", false, false},
+		{"LOCALHOST", false, false},
+		{"node", false, false},
+		{"node.localhost", false, false},
+		{"node.local", false, false},
+		{"node.internal", false, false},
+		{"node.corp.internal", false, false},
+		{"node.lan", false, false},
+		{"node.home.arpa", false, false},
+		{"node.localdomain", false, false},
+		{"localhost", true, true},
+		{"node", true, true},
+		{"node.local", true, true},
+		{"node.internal", true, true},
+	} {
+		t.Run(fmt.Sprintf("%s/%t", tc.host, tc.allowUnroutable), func(t *testing.T) {
+			require := require.New(t)
+
+			var addr node.Address
+			err := addr.FromHostname(tc.host, 26656)
+			require.NoError(err, "FromHostname")
+
+			err = VerifyAddress(addr, tc.allowUnroutable)
+			if tc.valid {
+				require.NoError(err, "VerifyAddress")
+			} else {
+				require.ErrorIs(err, ErrInvalidArgument, "VerifyAddress")
+			}
+		})
+	}
+
+	// Malformed hostnames should always be rejected.
+	err := VerifyAddress(node.Address{Host: "-node.example.com", Port: 26656}, true)
+	require.ErrorIs(t, err, ErrInvalidArgument, "malformed hostname should be rejected")
+}
+
 func TestVerifyRegisterNodeArgs(t *testing.T) {
 	require := require.New(t)
 
@@ -352,3 +397,18 @@ func TestVerifyNodeUpdate(t *testing.T) {
 		require.Equal(t, tc.err, err, tc.msg)
 	}
 }
+
+func TestExpiringNodes(t *testing.T) {
+	require := require.New(t)
+
+	var nodes []*node.Node
+	for _, expiration := range []uint64{9, 10, 11} {
+		nodes = append(nodes, &node.Node{Expiration: expiration})
+	}
+
+	expiring := ExpiringNodes(nodes, beacon.EpochTime(10))
+	require.Len(expiring, 1, "only nodes expiring at the end of the epoch should be returned")
+	require.EqualValues(10, expiring[0].Expiration)
+
+	require.Empty(ExpiringNodes(nodes, beacon.EpochTime(12)), "already expired nodes should not be returned")
+}
@@ -5,16 +5,26 @@ import (
 
 	"google.golang.org/grpc"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
 )
 
 var (
 	// serviceName is the gRPC service name.
 	serviceName = cmnGrpc.NewServiceName("Registry")
 
+	// ServiceCapabilities are the capabilities of the registry gRPC service.
+	ServiceCapabilities = cmnGrpc.Capabilities{
+		Version: version.ConsensusProtocol,
+	}
+
+	// methodGetCapabilities is the GetCapabilities method.
+	methodGetCapabilities, descGetCapabilities = serviceName.NewCapabilitiesMethod(ServiceCapabilities)
+
 	// methodGetEntity is the GetEntity method.
 	methodGetEntity = serviceName.NewMethod("GetEntity", IDQuery{})
 	// methodGetEntities is the GetEntities method.
@@ -37,6 +47,8 @@ var (
 	methodGetEvents = serviceName.NewMethod("GetEvents", int64(0))
 	// methodConsensusParameters is the ConsensusParameters method.
 	methodConsensusParameters = serviceName.NewMethod("ConsensusParameters", int64(0))
+	// methodGetEntityWhitelist is the GetEntityWhitelist method.
+	methodGetEntityWhitelist = serviceName.NewMethod("GetEntityWhitelist", int64(0))
 
 	// methodWatchEntities is the WatchEntities method.
 	methodWatchEntities = serviceName.NewMethod("WatchEntities", nil)
@@ -48,12 +60,15 @@ var (
 	methodWatchRuntimes = serviceName.NewMethod("WatchRuntimes", nil)
 	// methodWatchEvents is the WatchEvents method.
 	methodWatchEvents = serviceName.NewMethod("WatchEvents", nil)
+	// methodWatchNodeExpirations is the WatchNodeExpirations method.
+	methodWatchNodeExpirations = serviceName.NewMethod("WatchNodeExpirations", nil)
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
 		ServiceName: string(serviceName),
 		HandlerType: (*Backend)(nil),
 		Methods: []grpc.MethodDesc{
+			descGetCapabilities,
 			{
 				MethodName: methodGetEntity.ShortName(),
 				Handler:    handlerGetEntity,
@@ -98,6 +113,10 @@ var (
 				MethodName: methodConsensusParameters.ShortName(),
 				Handler:    handlerConsensusParameters,
 			},
+			{
+				MethodName: methodGetEntityWhitelist.ShortName(),
+				Handler:    handlerGetEntityWhitelist,
+			},
 		},
 		Streams: []grpc.StreamDesc{
 			{
@@ -125,6 +144,11 @@ var (
 				Handler:       handlerWatchEvents,
 				ServerStreams: true,
 			},
+			{
+				StreamName:    methodWatchNodeExpirations.ShortName(),
+				Handler:       handlerWatchNodeExpirations,
+				ServerStreams: true,
+			},
 		},
 	}
 )
@@ -382,6 +406,29 @@ func handlerConsensusParameters(
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetEntityWhitelist(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetEntityWhitelist(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetEntityWhitelist.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetEntityWhitelist(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
 func handlerWatchEntities(srv interface{}, stream grpc.ServerStream) error {
 	if err := stream.RecvMsg(nil); err != nil {
 		return err
@@ -466,6 +513,34 @@ func handlerWatchNodeList(srv interface{}, stream grpc.ServerStream) error {
 	}
 }
 
+func handlerWatchNodeExpirations(srv interface{}, stream grpc.ServerStream) error {
+	if err := stream.RecvMsg(nil); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(Backend).WatchNodeExpirations(ctx)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(ev); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 func handlerWatchRuntimes(srv interface{}, stream grpc.ServerStream) error {
 	if err := stream.RecvMsg(nil); err != nil {
 		return err
@@ -691,6 +766,41 @@ func (c *Client) WatchNodeList(ctx context.Context) (<-chan *NodeList, pubsub.Cl
 	return ch, sub, nil
 }
 
+func (c *Client) WatchNodeExpirations(ctx context.Context) (<-chan *NodeExpirations, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[5], methodWatchNodeExpirations.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(nil); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *NodeExpirations)
+	go func() {
+		defer close(ch)
+
+		for {
+			var ev NodeExpirations
+			if serr := stream.RecvMsg(&ev); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
 func (c *Client) GetRuntime(ctx context.Context, query *GetRuntimeQuery) (*Runtime, error) {
 	var rsp Runtime
 	if err := c.conn.Invoke(ctx, methodGetRuntime.FullName(), query, &rsp); err != nil {
@@ -801,5 +911,22 @@ func (c *Client) ConsensusParameters(ctx context.Context, height int64) (*Consen
 	return &rsp, nil
 }
 
+func (c *Client) GetEntityWhitelist(ctx context.Context, height int64) ([]signature.PublicKey, error) {
+	var rsp []signature.PublicKey
+	if err := c.conn.Invoke(ctx, methodGetEntityWhitelist.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// GetCapabilities returns the capabilities of the remote registry service.
+func (c *Client) GetCapabilities(ctx context.Context) (*cmnGrpc.Capabilities, error) {
+	var rsp cmnGrpc.Capabilities
+	if err := c.conn.Invoke(ctx, methodGetCapabilities.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *Client) Cleanup() {
 }
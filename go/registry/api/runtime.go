@@ -214,6 +214,7 @@ type SchedulingConstraints struct {
 	ValidatorSet *ValidatorSetConstraint `json:"validator_set,omitempty"`
 	MaxNodes     *MaxNodesConstraint     `json:"max_nodes,omitempty"`
 	MinPoolSize  *MinPoolSizeConstraint  `json:"min_pool_size,omitempty"`
+	TEEMemory    *TEEMemoryConstraint    `json:"tee_memory,omitempty"`
 }
 
 // ValidatorSetConstraint specifies that the entity must have a node that is part of the validator
@@ -230,6 +231,13 @@ type MinPoolSizeConstraint struct {
 	Limit uint16 `json:"limit"`
 }
 
+// TEEMemoryConstraint specifies the amount of TEE memory (in bytes) a node needs to host the
+// runtime. Nodes advertising less spare TEE memory are not eligible, when node capacity hints are
+// enabled.
+type TEEMemoryConstraint struct {
+	Amount uint64 `json:"amount"`
+}
+
 // RuntimeStakingParameters are the stake-related parameters for a runtime.
 type RuntimeStakingParameters struct {
 	// Thresholds are the minimum stake thresholds for a runtime. These per-runtime thresholds are
@@ -463,6 +471,14 @@ func (r *Runtime) ValidateBasic(strictVersion bool) error {
 		return fmt.Errorf("bad staking parameters: %w", err)
 	}
 
+	for _, roleConstraints := range r.Constraints {
+		for _, cs := range roleConstraints {
+			if cs.TEEMemory != nil && r.TEEHardware == node.TEEHardwareInvalid {
+				return fmt.Errorf("TEE memory constraint specified for runtime without TEE")
+			}
+		}
+	}
+
 	if err := r.AdmissionPolicy.ValidateBasic(); err != nil {
 		return err
 	}
@@ -763,3 +763,47 @@ func TestDeployments(t *testing.T) {
 	})
 	require.Nil(ad)
 }
+
+func TestVerifyRuntimeDerivedID(t *testing.T) {
+	require := require.New(t)
+
+	logger := logging.GetLogger("runtime/tests")
+	entityID := signature.NewPublicKey("1234567890000000000000000000000000000000000000000000000000000000")
+	otherID := signature.NewPublicKey("abcdef0000000000000000000000000000000000000000000000000000000000")
+
+	derivedID, err := common.NewDerivedNamespace(entityID[:], 0, 0)
+	require.NoError(err, "NewDerivedNamespace")
+	otherDerivedID, err := common.NewDerivedNamespace(otherID[:], 0, 0)
+	require.NoError(err, "NewDerivedNamespace")
+	legacyID := common.NewTestNamespaceFromSeed([]byte("registry/api: legacy runtime"), 0)
+
+	for _, tc := range []struct {
+		name      string
+		id        common.Namespace
+		required  bool
+		isGenesis bool
+		valid     bool
+	}{
+		{"LegacyNotRequired", legacyID, false, false, true},
+		{"Derived", derivedID, true, false, true},
+		{"DerivedFromOtherEntity", otherDerivedID, true, false, false},
+		{"Legacy", legacyID, true, false, false},
+		{"LegacyAtGenesis", legacyID, true, true, true},
+	} {
+		params := &ConsensusParameters{
+			RequireDerivedRuntimeIDs: tc.required,
+		}
+		rt := &Runtime{
+			ID:       tc.id,
+			EntityID: entityID,
+		}
+
+		err = VerifyRuntimeNew(logger, rt, 0, params, tc.isGenesis)
+		switch tc.valid {
+		case true:
+			require.NoError(err, tc.name)
+		case false:
+			require.ErrorIs(err, ErrInvalidArgument, tc.name)
+		}
+	}
+}
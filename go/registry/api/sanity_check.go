@@ -25,6 +25,9 @@ func (p *ConsensusParameters) SanityCheck() error {
 			return fmt.Errorf("maximum node expiration not specified")
 		}
 	}
+	if p.EntityAdmissionKey != nil && !p.EntityAdmissionKey.IsValid() {
+		return fmt.Errorf("entity admission key is invalid")
+	}
 	return nil
 }
 
@@ -35,9 +38,19 @@ func (c *ConsensusParameterChanges) SanityCheck() error {
 		c.GasCosts == nil &&
 		c.MaxNodeExpiration == nil &&
 		c.EnableRuntimeGovernanceModels == nil &&
-		c.TEEFeatures == nil {
+		c.TEEFeatures == nil &&
+		c.SuspendRuntimesWithoutKeyManager == nil &&
+		c.EnableHostnameAddresses == nil &&
+		c.EnableNodeCapacity == nil &&
+		c.EnableNodeBuildInfo == nil &&
+		c.EntityAdmissionKey == nil &&
+		c.DisableEntityWhitelist == nil &&
+		c.RequireDerivedRuntimeIDs == nil {
 		return fmt.Errorf("consensus parameter changes should not be empty")
 	}
+	if c.EntityAdmissionKey != nil && c.DisableEntityWhitelist != nil && *c.DisableEntityWhitelist {
+		return fmt.Errorf("entity admission key cannot be set while disabling the entity whitelist")
+	}
 	return nil
 }
 
@@ -96,12 +109,17 @@ func (g *Genesis) SanityCheck(
 		}
 	}
 
-	// Add stake claims.
 	nodes, err := nodeLookup.Nodes(context.Background())
 	if err != nil {
 		return fmt.Errorf("registry: sanity check failed: could not obtain node list from nodeLookup: %w", err)
 	}
 
+	// Check entity whitelist.
+	if err = SanityCheckEntityWhitelist(&g.Parameters, g.EntityWhitelist, allRuntimes, nodes); err != nil {
+		return err
+	}
+
+	// Add stake claims.
 	// Skip suspended runtimes for computing stake claims.
 	runtimes, err := runtimesLookup.Runtimes(context.Background())
 	if err != nil {
@@ -126,6 +144,41 @@ func SanityCheckEntities(logger *logging.Logger, entities []*entity.SignedEntity
 	return seenEntities, nil
 }
 
+// SanityCheckEntityWhitelist examines the entity whitelist and, if the whitelist is enforced,
+// ensures that all runtimes and nodes are owned by whitelisted entities.
+func SanityCheckEntityWhitelist(
+	params *ConsensusParameters,
+	whitelist []signature.PublicKey,
+	runtimes []*Runtime,
+	nodes []*node.Node,
+) error {
+	whitelisted := make(map[signature.PublicKey]bool)
+	for _, id := range whitelist {
+		if !id.IsValid() {
+			return fmt.Errorf("registry: sanity check failed: invalid whitelisted entity: '%s'", id)
+		}
+		if whitelisted[id] {
+			return fmt.Errorf("registry: sanity check failed: duplicate whitelisted entity: '%s'", id)
+		}
+		whitelisted[id] = true
+	}
+
+	if params.EntityAdmissionKey == nil {
+		return nil
+	}
+	for _, rt := range runtimes {
+		if !whitelisted[rt.EntityID] {
+			return fmt.Errorf("registry: sanity check failed: runtime '%s' owned by non-whitelisted entity: '%s'", rt.ID, rt.EntityID)
+		}
+	}
+	for _, n := range nodes {
+		if !whitelisted[n.EntityID] {
+			return fmt.Errorf("registry: sanity check failed: node '%s' owned by non-whitelisted entity: '%s'", n.ID, n.EntityID)
+		}
+	}
+	return nil
+}
+
 // SanityCheckRuntimes examines the runtimes table.
 func SanityCheckRuntimes(
 	logger *logging.Logger,
@@ -92,6 +92,10 @@ var (
 	// value larger than the MaxInRuntimeMessages specified in consensus parameters.
 	ErrMaxInMessagesTooBig = errors.New(ModuleName, 13, "roothash: max incoming runtime messages is too big")
 
+	// ErrNoBlockHistory is the error returned when blocks are requested from a block history that
+	// is not available locally.
+	ErrNoBlockHistory = errors.New(ModuleName, 14, "roothash: block history not available")
+
 	// MethodExecutorCommit is the method name for executor commit submission.
 	MethodExecutorCommit = transaction.NewMethodName(ModuleName, "ExecutorCommit", ExecutorCommit{})
 
@@ -121,6 +125,9 @@ type Backend interface {
 	GetLatestBlock(ctx context.Context, request *RuntimeRequest) (*block.Block, error)
 
 	// GetRuntimeState returns the given runtime's state.
+	//
+	// This includes the executor commitment pool and committee of the round that is currently
+	// being processed, which can be used to monitor commitments as they are being collected.
 	GetRuntimeState(ctx context.Context, request *RuntimeRequest) (*RuntimeState, error)
 
 	// GetRoundRoots returns the stored state and I/O roots for the given runtime and round.
@@ -146,6 +153,17 @@ type Backend interface {
 	// confirmed.
 	WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *AnnotatedBlock, pubsub.ClosableSubscription, error)
 
+	// WatchBlocksFrom returns a channel that produces a stream of annotated blocks, starting with
+	// the given round.
+	//
+	// All blocks since the given round are first replayed from the locally tracked block history,
+	// after which new blocks are pushed into the stream as they are confirmed. Fails with
+	// ErrNoBlockHistory in case the runtime's block history is not tracked by this node.
+	//
+	// In case a block is pruned from the block history before it could be replayed, the stream
+	// is terminated and the error is reported by the subscription (see pubsub.SubscriptionErr).
+	WatchBlocksFrom(ctx context.Context, request *WatchBlocksFromRequest) (<-chan *AnnotatedBlock, pubsub.ClosableSubscription, error)
+
 	// WatchEvents returns a stream of protocol events.
 	WatchEvents(ctx context.Context, runtimeID common.Namespace) (<-chan *Event, pubsub.ClosableSubscription, error)
 
@@ -185,6 +203,12 @@ type RoundRootsRequest struct {
 	Round     uint64           `json:"round"`
 }
 
+// WatchBlocksFromRequest is a request to watch blocks starting with a specific round.
+type WatchBlocksFromRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Round     uint64           `json:"round"`
+}
+
 // InMessageQueueRequest is a request for queued incoming messages.
 type InMessageQueueRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
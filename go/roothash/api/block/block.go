@@ -44,6 +44,11 @@ func NewEmptyBlock(child *Block, timestamp uint64, htype HeaderType) *Block {
 	blk.Header.StateRoot = child.Header.StateRoot
 	blk.Header.MessagesHash.Empty()
 	blk.Header.InMessagesHash.Empty()
+	// State roots of other executor groups are unchanged as well.
+	for _, sr := range child.Header.ShardRoots {
+		sr.IORoot.Empty()
+		blk.Header.ShardRoots = append(blk.Header.ShardRoots, sr)
+	}
 
 	return &blk
 }
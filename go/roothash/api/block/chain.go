@@ -0,0 +1,44 @@
+package block
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidChain is the error returned when a sequence of headers does not form a valid chain.
+var ErrInvalidChain = errors.New("roothash: invalid header chain")
+
+// VerifyChain verifies that the given headers, ordered by round, form a contiguous chain.
+//
+// Each header must pass basic validity checks, belong to the same namespace as the first one,
+// have a round one greater than its predecessor and link to its predecessor via the previous
+// hash. Only the links between the given headers are verified, so the caller must separately
+// establish trust in one of them (usually the first or the last one, e.g., via consensus light
+// client state).
+func VerifyChain(headers []Header) error {
+	for i := range headers {
+		if err := headers[i].ValidateBasic(); err != nil {
+			return fmt.Errorf("%w: header %d: %w", ErrInvalidChain, i, err)
+		}
+	}
+	for i := 1; i < len(headers); i++ {
+		prev, cur := &headers[i-1], &headers[i]
+
+		if !cur.Namespace.Equal(&headers[0].Namespace) {
+			return fmt.Errorf("%w: header %d has namespace %s (expected: %s)",
+				ErrInvalidChain, i, cur.Namespace, headers[0].Namespace,
+			)
+		}
+		if cur.Round != prev.Round+1 {
+			return fmt.Errorf("%w: header %d has round %d (expected: %d)",
+				ErrInvalidChain, i, cur.Round, prev.Round+1,
+			)
+		}
+		if prevHash := prev.EncodedHash(); !cur.PreviousHash.Equal(&prevHash) {
+			return fmt.Errorf("%w: header %d does not link to header %d (previous hash: %s expected: %s)",
+				ErrInvalidChain, i, i-1, cur.PreviousHash, prevHash,
+			)
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package block
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
@@ -9,8 +10,25 @@ import (
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 )
 
-// ErrInvalidVersion is the error returned when a version is invalid.
-var ErrInvalidVersion = errors.New("roothash: invalid version")
+var (
+	// ErrInvalidVersion is the error returned when a version is invalid.
+	ErrInvalidVersion = errors.New("roothash: invalid version")
+
+	// ErrMalformedShardRoots is the error returned when the shard roots of a header are malformed.
+	ErrMalformedShardRoots = errors.New("roothash: malformed shard roots")
+)
+
+const (
+	// VersionSingleRoot is the header version where a block has a single I/O and state root.
+	VersionSingleRoot uint16 = 0
+
+	// VersionShardRoots is the header version where, in addition to the roots of the primary
+	// executor group, a block carries the roots of any other executor groups.
+	VersionShardRoots uint16 = 1
+
+	// LatestVersion is the latest supported header version.
+	LatestVersion = VersionShardRoots
+)
 
 // HeaderType is the type of header.
 type HeaderType uint8
@@ -96,6 +114,47 @@ type Header struct { // nolint: maligned
 
 	// InMessagesHash is the hash of processed incoming messages.
 	InMessagesHash hash.Hash `json:"in_msgs_hash"`
+
+	// ShardRoots are the roots of executor groups other than the primary one, ordered by group.
+	//
+	// Only present in headers with version VersionShardRoots or later. The IORoot and StateRoot
+	// fields always refer to the primary executor group.
+	ShardRoots []ShardRoots `json:"shard_roots,omitempty"`
+}
+
+// ShardRoots are the storage roots produced by a single executor group.
+//
+// Keep this in sync with /runtime/src/consensus/roothash/block.rs.
+type ShardRoots struct {
+	// Group is the executor group index. The primary executor group has index zero.
+	Group uint16 `json:"group"`
+
+	// IORoot is the I/O merkle root of the executor group.
+	IORoot hash.Hash `json:"io_root"`
+
+	// StateRoot is the state merkle root of the executor group.
+	StateRoot hash.Hash `json:"state_root"`
+}
+
+// ValidateBasic performs basic header validity checks.
+func (h *Header) ValidateBasic() error {
+	switch {
+	case h.Version > LatestVersion:
+		return fmt.Errorf("%w: %d", ErrInvalidVersion, h.Version)
+	case h.Version < VersionShardRoots:
+		if len(h.ShardRoots) > 0 {
+			return fmt.Errorf("%w: not supported in header version %d", ErrMalformedShardRoots, h.Version)
+		}
+	default:
+		var prevGroup uint16
+		for _, sr := range h.ShardRoots {
+			if sr.Group <= prevGroup {
+				return fmt.Errorf("%w: group %d out of order", ErrMalformedShardRoots, sr.Group)
+			}
+			prevGroup = sr.Group
+		}
+	}
+	return nil
 }
 
 // IsParentOf returns true iff the header is the parent of a child header.
@@ -126,6 +185,28 @@ func (h *Header) StorageRoots() []storage.Root {
 	}
 }
 
+// ShardStorageRoots returns the storage roots of executor groups other than the primary one.
+func (h *Header) ShardStorageRoots() []storage.Root {
+	roots := make([]storage.Root, 0, 2*len(h.ShardRoots))
+	for _, sr := range h.ShardRoots {
+		roots = append(roots,
+			storage.Root{
+				Namespace: h.Namespace,
+				Version:   h.Round,
+				Type:      storage.RootTypeIO,
+				Hash:      sr.IORoot,
+			},
+			storage.Root{
+				Namespace: h.Namespace,
+				Version:   h.Round,
+				Type:      storage.RootTypeState,
+				Hash:      sr.StateRoot,
+			},
+		)
+	}
+	return roots
+}
+
 // StorageRootIO returns the full IO storage root.
 func (h *Header) StorageRootIO() storage.Root {
 	return storage.Root{
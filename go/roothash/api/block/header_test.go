@@ -1,13 +1,17 @@
 package block
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"math/big"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
@@ -49,6 +53,44 @@ func TestConsistentHash(t *testing.T) {
 		InMessagesHash: emptyRoot,
 	}
 	require.EqualValues(t, populatedHeaderHash.String(), populated.EncodedHash().String())
+
+	var shardedHeaderHash hash.Hash
+	_ = shardedHeaderHash.UnmarshalHex("cea3c81eb664ff87d2b7a7aeb8452dec4341e8c90906ee4b04d412e1773e98b6")
+
+	sharded := populated
+	sharded.Version = VersionShardRoots
+	sharded.ShardRoots = []ShardRoots{
+		{Group: 1, IORoot: emptyRoot, StateRoot: emptyRoot},
+		{Group: 3, IORoot: emptyRoot, StateRoot: emptyHeaderHash},
+	}
+	require.EqualValues(t, shardedHeaderHash.String(), sharded.EncodedHash().String())
+}
+
+func TestHeaderValidateBasic(t *testing.T) {
+	require := require.New(t)
+
+	var root hash.Hash
+	root.Empty()
+
+	var hdr Header
+	require.NoError(hdr.ValidateBasic(), "header without shard roots should be valid")
+
+	hdr.ShardRoots = []ShardRoots{{Group: 1, IORoot: root, StateRoot: root}}
+	require.ErrorIs(hdr.ValidateBasic(), ErrMalformedShardRoots, "shard roots require a newer version")
+
+	hdr.Version = VersionShardRoots
+	require.NoError(hdr.ValidateBasic(), "header with shard roots should be valid")
+	require.Len(hdr.ShardStorageRoots(), 2, "ShardStorageRoots")
+
+	hdr.ShardRoots = append(hdr.ShardRoots, ShardRoots{Group: 1, IORoot: root, StateRoot: root})
+	require.ErrorIs(hdr.ValidateBasic(), ErrMalformedShardRoots, "duplicate groups should be rejected")
+
+	hdr.ShardRoots = []ShardRoots{{Group: 0, IORoot: root, StateRoot: root}}
+	require.ErrorIs(hdr.ValidateBasic(), ErrMalformedShardRoots, "primary group should be rejected")
+
+	hdr.ShardRoots = nil
+	hdr.Version = LatestVersion + 1
+	require.ErrorIs(hdr.ValidateBasic(), ErrInvalidVersion, "unsupported versions should be rejected")
 }
 
 func TestTimestamp(t *testing.T) {
@@ -117,3 +159,94 @@ func TestTimestamp(t *testing.T) {
 		}
 	}
 }
+
+func TestVerifyChain(t *testing.T) {
+	require := require.New(t)
+
+	ns := common.NewTestNamespaceFromSeed([]byte("verify chain test ns"), 0)
+	blk := NewGenesisBlock(ns, 0)
+	headers := []Header{blk.Header}
+	for i := 0; i < 3; i++ {
+		blk = NewEmptyBlock(blk, uint64(i+1), Normal)
+		headers = append(headers, blk.Header)
+	}
+
+	require.NoError(VerifyChain(nil), "empty chain should be valid")
+	require.NoError(VerifyChain(headers[:1]), "single header should be valid")
+	require.NoError(VerifyChain(headers), "chain should be valid")
+
+	// Broken links should be rejected.
+	broken := append([]Header{}, headers...)
+	broken[2].IORoot = hash.NewFromBytes([]byte("tampered"))
+	require.ErrorIs(VerifyChain(broken), ErrInvalidChain)
+
+	// Gaps should be rejected.
+	require.ErrorIs(VerifyChain([]Header{headers[0], headers[2]}), ErrInvalidChain)
+
+	// Other namespaces should be rejected.
+	other := append([]Header{}, headers...)
+	other[3].Namespace = common.NewTestNamespaceFromSeed([]byte("verify chain other ns"), 0)
+	require.ErrorIs(VerifyChain(other), ErrInvalidChain)
+}
+
+func TestHeaderVectors(t *testing.T) {
+	require := require.New(t)
+
+	// NOTE: The test vectors are generated by go/roothash/gen_vectors and are also used by the
+	// runtime to verify its header encoding.
+	raw, err := os.ReadFile("../../../../runtime/testdata/roothash_header_vectors.json")
+	require.NoError(err, "ReadFile")
+
+	var vectors []struct {
+		Kind    string    `json:"kind"`
+		Encoded string    `json:"encoded"`
+		Hash    hash.Hash `json:"hash"`
+	}
+	require.NoError(json.Unmarshal(raw, &vectors), "json.Unmarshal")
+	require.NotEmpty(vectors, "test vectors")
+
+	for _, v := range vectors {
+		encoded, err := hex.DecodeString(v.Encoded)
+		require.NoError(err, "hex.DecodeString (%s)", v.Kind)
+
+		var hdr Header
+		require.NoError(cbor.Unmarshal(encoded, &hdr), "cbor.Unmarshal (%s)", v.Kind)
+		require.NoError(hdr.ValidateBasic(), "ValidateBasic (%s)", v.Kind)
+		require.Equal(encoded, cbor.Marshal(&hdr), "encoding should round-trip (%s)", v.Kind)
+		require.Equal(v.Hash, hdr.EncodedHash(), "EncodedHash (%s)", v.Kind)
+	}
+}
+
+func FuzzHeader(f *testing.F) {
+	// Seed corpus.
+	var root hash.Hash
+	root.Empty()
+
+	var ns common.Namespace
+	_ = ns.UnmarshalBinary(root[:])
+
+	genesis := NewGenesisBlock(ns, 1_600_000_000)
+	f.Add(cbor.Marshal(&genesis.Header))
+
+	sharded := NewEmptyBlock(genesis, 1_600_000_001, RoundFailed)
+	sharded.Header.Version = VersionShardRoots
+	sharded.Header.ShardRoots = []ShardRoots{{Group: 1, IORoot: root, StateRoot: root}}
+	f.Add(cbor.Marshal(&sharded.Header))
+
+	// Fuzz.
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var hdr Header
+		if err := cbor.Unmarshal(data, &hdr); err != nil {
+			return
+		}
+		_ = hdr.ValidateBasic()
+
+		// Encoding a decoded header must be stable and agree with its hash.
+		encoded := cbor.Marshal(&hdr)
+		require.Equal(t, hash.NewFromBytes(encoded), hdr.EncodedHash(), "EncodedHash")
+
+		var dec Header
+		require.NoError(t, cbor.Unmarshal(encoded, &dec), "cbor.Unmarshal")
+		require.Equal(t, encoded, cbor.Marshal(&dec), "encoding should round-trip")
+	})
+}
@@ -2,12 +2,14 @@ package api
 
 import (
 	"context"
+	"io"
 
 	"google.golang.org/grpc"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
@@ -17,6 +19,14 @@ var (
 	// serviceName is the gRPC service name.
 	serviceName = cmnGrpc.NewServiceName("RootHash")
 
+	// ServiceCapabilities are the capabilities of the roothash gRPC service.
+	ServiceCapabilities = cmnGrpc.Capabilities{
+		Version: version.ConsensusProtocol,
+	}
+
+	// methodGetCapabilities is the GetCapabilities method.
+	methodGetCapabilities, descGetCapabilities = serviceName.NewCapabilitiesMethod(ServiceCapabilities)
+
 	// methodGetGenesisBlock is the GetGenesisBlock method.
 	methodGetGenesisBlock = serviceName.NewMethod("GetGenesisBlock", RuntimeRequest{})
 	// methodGetLatestBlock is the GetLatestBlock method.
@@ -42,6 +52,8 @@ var (
 
 	// methodWatchBlocks is the WatchBlocks method.
 	methodWatchBlocks = serviceName.NewMethod("WatchBlocks", common.Namespace{})
+	// methodWatchBlocksFrom is the WatchBlocksFrom method.
+	methodWatchBlocksFrom = serviceName.NewMethod("WatchBlocksFrom", WatchBlocksFromRequest{})
 	// methodWatchEvents is the WatchEvents method.
 	methodWatchEvents = serviceName.NewMethod("WatchEvents", common.Namespace{})
 	// methodWatchExecutorCommitments is the WatchExecutorCommitments method.
@@ -52,6 +64,7 @@ var (
 		ServiceName: string(serviceName),
 		HandlerType: (*Backend)(nil),
 		Methods: []grpc.MethodDesc{
+			descGetCapabilities,
 			{
 				MethodName: methodGetGenesisBlock.ShortName(),
 				Handler:    handlerGetGenesisBlock,
@@ -113,6 +126,11 @@ var (
 				Handler:       handlerWatchExecutorCommitments,
 				ServerStreams: true,
 			},
+			{
+				StreamName:    methodWatchBlocksFrom.ShortName(),
+				Handler:       handlerWatchBlocksFrom,
+				ServerStreams: true,
+			},
 		},
 	}
 )
@@ -399,6 +417,35 @@ func handlerWatchBlocks(srv interface{}, stream grpc.ServerStream) error {
 	}
 }
 
+func handlerWatchBlocksFrom(srv interface{}, stream grpc.ServerStream) error {
+	var rq WatchBlocksFromRequest
+	if err := stream.RecvMsg(&rq); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(Backend).WatchBlocksFrom(ctx, &rq)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case blk, ok := <-ch:
+			if !ok {
+				return pubsub.SubscriptionErr(sub)
+			}
+
+			if err := stream.SendMsg(blk); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 func handlerWatchEvents(srv interface{}, stream grpc.ServerStream) error {
 	var runtimeID common.Namespace
 	if err := stream.RecvMsg(&runtimeID); err != nil {
@@ -566,17 +613,34 @@ func (c *Client) GetEvents(ctx context.Context, height int64) ([]*Event, error)
 	return rsp, nil
 }
 
+// GetCapabilities returns the capabilities of the remote roothash service.
+func (c *Client) GetCapabilities(ctx context.Context) (*cmnGrpc.Capabilities, error) {
+	var rsp cmnGrpc.Capabilities
+	if err := c.conn.Invoke(ctx, methodGetCapabilities.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *Client) Cleanup() {
 }
 
 func (c *Client) WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *AnnotatedBlock, pubsub.ClosableSubscription, error) {
-	ctx, sub := pubsub.NewContextSubscription(ctx)
+	return c.watchBlocks(ctx, &serviceDesc.Streams[0], methodWatchBlocks, runtimeID)
+}
+
+func (c *Client) WatchBlocksFrom(ctx context.Context, request *WatchBlocksFromRequest) (<-chan *AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	return c.watchBlocks(ctx, &serviceDesc.Streams[3], methodWatchBlocksFrom, request)
+}
 
-	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[0], methodWatchBlocks.FullName())
+func (c *Client) watchBlocks(ctx context.Context, desc *grpc.StreamDesc, method *cmnGrpc.MethodDesc, request interface{}) (<-chan *AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextErrorSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, desc, method.FullName())
 	if err != nil {
 		return nil, nil, err
 	}
-	if err = stream.SendMsg(runtimeID); err != nil {
+	if err = stream.SendMsg(request); err != nil {
 		return nil, nil, err
 	}
 	if err = stream.CloseSend(); err != nil {
@@ -590,6 +654,9 @@ func (c *Client) WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<
 		for {
 			var blk AnnotatedBlock
 			if serr := stream.RecvMsg(&blk); serr != nil {
+				if serr != io.EOF && ctx.Err() == nil {
+					sub.SetErr(serr)
+				}
 				return
 			}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 )
@@ -72,6 +73,11 @@ type BlockHistory interface {
 	// Passing the special value `RoundLatest` will return the latest annotated block.
 	GetAnnotatedBlock(ctx context.Context, round uint64) (*AnnotatedBlock, error)
 
+	// GetBlockByHash returns the block with the given header hash.
+	//
+	// This method returns blocks that are both committed and synced to storage.
+	GetBlockByHash(ctx context.Context, hash hash.Hash) (*block.Block, error)
+
 	// GetEarliestBlock returns the earliest known block.
 	GetEarliestBlock(ctx context.Context) (*block.Block, error)
 }
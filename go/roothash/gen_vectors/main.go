@@ -0,0 +1,108 @@
+// gen_vectors generates test vectors for the runtime block header encoding.
+//
+// The generated vectors are used by the runtime to verify that its header encoding matches the
+// one used by the consensus layer. To update them, run:
+//
+//	go run ./roothash/gen_vectors > ../runtime/testdata/roothash_header_vectors.json
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+)
+
+// TestVector is a block header test vector.
+type TestVector struct {
+	// Kind is a human-readable description of the test vector.
+	Kind string `json:"kind"`
+	// Encoded is the hex-encoded canonical CBOR encoding of the header.
+	Encoded string `json:"encoded"`
+	// Hash is the hash of the header.
+	Hash hash.Hash `json:"hash"`
+}
+
+func makeTestVector(kind string, hdr *block.Header) TestVector {
+	if err := hdr.ValidateBasic(); err != nil {
+		panic(fmt.Sprintf("generated invalid header (%s): %v", kind, err))
+	}
+
+	return TestVector{
+		Kind:    kind,
+		Encoded: hex.EncodeToString(cbor.Marshal(hdr)),
+		Hash:    hdr.EncodedHash(),
+	}
+}
+
+func main() {
+	var ns common.Namespace
+	_ = ns.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001")
+
+	var emptyRoot hash.Hash
+	emptyRoot.Empty()
+
+	shardRoots := map[string][]block.ShardRoots{
+		"no shard roots": nil,
+		"one shard root": {
+			{Group: 1, IORoot: hash.NewFromBytes([]byte("io 1")), StateRoot: hash.NewFromBytes([]byte("state 1"))},
+		},
+		"multiple shard roots": {
+			{Group: 1, IORoot: emptyRoot, StateRoot: hash.NewFromBytes([]byte("state 1"))},
+			{Group: 2, IORoot: hash.NewFromBytes([]byte("io 2")), StateRoot: hash.NewFromBytes([]byte("state 2"))},
+			{Group: math.MaxUint16, IORoot: hash.NewFromBytes([]byte("io max")), StateRoot: emptyRoot},
+		},
+	}
+
+	vectors := []TestVector{
+		makeTestVector("empty header", &block.Header{}),
+		makeTestVector("genesis block", &block.NewGenesisBlock(ns, 1_600_000_000).Header),
+	}
+
+	for _, version := range []uint16{block.VersionSingleRoot, block.VersionShardRoots} {
+		for _, htype := range []block.HeaderType{
+			block.Normal,
+			block.RoundFailed,
+			block.EpochTransition,
+			block.Suspended,
+		} {
+			for _, round := range []uint64{0, 1, 1000, math.MaxUint64} {
+				for _, srKind := range []string{"no shard roots", "one shard root", "multiple shard roots"} {
+					if version < block.VersionShardRoots && shardRoots[srKind] != nil {
+						continue
+					}
+
+					hdr := block.Header{
+						Version:        version,
+						Namespace:      ns,
+						Round:          round,
+						Timestamp:      block.Timestamp(1_600_000_000 + round%1000),
+						HeaderType:     htype,
+						PreviousHash:   hash.NewFromBytes([]byte(fmt.Sprintf("previous %d", round))),
+						IORoot:         hash.NewFromBytes([]byte(fmt.Sprintf("io %d", round))),
+						StateRoot:      hash.NewFromBytes([]byte(fmt.Sprintf("state %d", round))),
+						MessagesHash:   emptyRoot,
+						InMessagesHash: emptyRoot,
+						ShardRoots:     shardRoots[srKind],
+					}
+					kind := fmt.Sprintf("version %d, header type %d, round %d, %s", version, htype, round, srKind)
+					vectors = append(vectors, makeTestVector(kind, &hdr))
+				}
+			}
+		}
+	}
+
+	// Generate output.
+	jsonOut, err := json.MarshalIndent(&vectors, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding test vectors: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s\n", jsonOut)
+}
@@ -485,6 +485,15 @@ func (s *runtimeState) testSuccessfulRound(t *testing.T, backend api.Backend, co
 	child, err := nextRuntimeBlock(ch, nil)
 	require.NoError(err, "nextRuntimeBlock")
 
+	// The commitment pool should be empty and ready to collect commitments.
+	rtState, err := backend.GetRuntimeState(ctx, &api.RuntimeRequest{
+		RuntimeID: s.rt.Runtime.ID,
+		Height:    child.Height,
+	})
+	require.NoError(err, "GetRuntimeState")
+	require.NotNil(rtState.CommitmentPool, "commitment pool should be available")
+	require.Empty(rtState.CommitmentPool.SchedulerCommitments, "commitment pool should be empty")
+
 	// Generate and submit all executor commitments.
 	blk, executorCommits, executorNodes := s.generateExecutorCommitments(t, consensus, child.Block, 0)
 	tx := api.NewExecutorCommitTx(0, nil, s.rt.Runtime.ID, executorCommits)
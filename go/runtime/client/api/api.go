@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
@@ -36,6 +37,9 @@ var (
 	ErrCheckTxFailed = errors.New(ModuleName, 5, "client: transaction check failed")
 	// ErrNoHostedRuntime is returned when the hosted runtime is not available locally.
 	ErrNoHostedRuntime = errors.New(ModuleName, 6, "client: no hosted runtime is available")
+	// ErrIndexingDisabled is returned when querying transactions by tag while the transaction
+	// tag index is disabled.
+	ErrIndexingDisabled = errors.New(ModuleName, 7, "client: transaction tag indexing is disabled")
 )
 
 // RuntimeClient is the runtime client interface.
@@ -64,6 +68,9 @@ type RuntimeClient interface {
 	// GetBlock fetches the given runtime block.
 	GetBlock(ctx context.Context, request *GetBlockRequest) (*block.Block, error)
 
+	// GetBlockByHash fetches the runtime block with the given header hash.
+	GetBlockByHash(ctx context.Context, request *GetBlockByHashRequest) (*block.Block, error)
+
 	// GetLastRetainedBlock returns the last retained block.
 	GetLastRetainedBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error)
 
@@ -81,12 +88,29 @@ type RuntimeClient interface {
 	// GetEvents returns all events emitted in a given block.
 	GetEvents(ctx context.Context, request *GetEventsRequest) ([]*Event, error)
 
+	// QueryTxn returns the location of the first transaction that emitted the given tag.
+	//
+	// Requires the transaction tag index to be enabled.
+	QueryTxn(ctx context.Context, request *QueryTxnRequest) (*TxnLocation, error)
+
+	// QueryTxns returns the locations of transactions that emitted the given tag, ordered by
+	// round.
+	//
+	// Requires the transaction tag index to be enabled.
+	QueryTxns(ctx context.Context, request *QueryTxnsRequest) ([]*TxnLocation, error)
+
 	// Query makes a runtime-specific query.
 	Query(ctx context.Context, request *QueryRequest) (*QueryResponse, error)
 
 	// WatchBlocks subscribes to blocks for a specific runtimes.
 	WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error)
 
+	// WatchBlocksSince subscribes to blocks for a specific runtime, starting with the given round.
+	//
+	// All retained blocks since the given round are replayed from block history before any new
+	// blocks are delivered.
+	WatchBlocksSince(ctx context.Context, request *WatchBlocksSinceRequest) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error)
+
 	// State returns a MKVS read syncer that can be used to read runtime state from a remote node
 	// and verify it against the trusted local root.
 	State() syncer.ReadSyncer
@@ -115,6 +139,25 @@ type SubmitTxMetaResponse struct {
 
 	// CheckTxError is the CheckTx error in case transaction failed the transaction check.
 	CheckTxError *protocol.Error `json:"check_tx_error,omitempty"`
+
+	// Latency is the latency decomposition of the transaction as observed by the client node.
+	Latency *TxLatency `json:"latency,omitempty"`
+}
+
+// TxLatency is the latency decomposition of a submitted transaction.
+//
+// Execution is timed by the timestamp of the runtime block that included the transaction, so
+// the execution and finalization latencies have a resolution of one second.
+type TxLatency struct {
+	// Scheduled is the time from submission until the transaction passed the transaction check
+	// and was queued for scheduling.
+	Scheduled time.Duration `json:"scheduled"`
+	// Executed is the time from scheduling until the runtime block that included the transaction
+	// was produced.
+	Executed time.Duration `json:"executed"`
+	// Finalized is the time from execution until the transaction result was available on the
+	// client node.
+	Finalized time.Duration `json:"finalized"`
 }
 
 // CheckTxRequest is a CheckTx request.
@@ -129,6 +172,18 @@ type GetBlockRequest struct {
 	Round     uint64           `json:"round"`
 }
 
+// GetBlockByHashRequest is a GetBlockByHash request.
+type GetBlockByHashRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Hash      hash.Hash        `json:"hash"`
+}
+
+// WatchBlocksSinceRequest is a WatchBlocksSince request.
+type WatchBlocksSinceRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Round     uint64           `json:"round"`
+}
+
 // GetTransactionsRequest is a GetTransactions request.
 type GetTransactionsRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
@@ -166,6 +221,30 @@ type PlainEvent struct {
 	Value []byte `json:"value"`
 }
 
+// QueryTxnRequest is a QueryTxn request.
+type QueryTxnRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Key       []byte           `json:"key"`
+	Value     []byte           `json:"value"`
+}
+
+// QueryTxnsRequest is a QueryTxns request.
+type QueryTxnsRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Key       []byte           `json:"key"`
+	Value     []byte           `json:"value"`
+	// Limit is the maximum number of returned transactions (0 = unlimited).
+	Limit uint64 `json:"limit,omitempty"`
+}
+
+// TxnLocation is the location of a transaction in the runtime block history.
+type TxnLocation struct {
+	// Round is the round of the block containing the transaction.
+	Round uint64 `json:"round"`
+	// TxHash is the transaction hash.
+	TxHash hash.Hash `json:"tx_hash"`
+}
+
 // QueryRequest is a Query request.
 type QueryRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
@@ -21,17 +21,19 @@ var (
 	serviceName = cmnGrpc.NewServiceName("RuntimeClient")
 
 	// methodSubmitTx is the SubmitTx method.
-	methodSubmitTx = serviceName.NewMethod("SubmitTx", SubmitTxRequest{})
+	methodSubmitTx = serviceName.NewMethod("SubmitTx", SubmitTxRequest{}).WithMutating()
 	// methodSubmitTxMeta is the SubmitTxMeta method.
-	methodSubmitTxMeta = serviceName.NewMethod("SubmitTxMeta", SubmitTxRequest{})
+	methodSubmitTxMeta = serviceName.NewMethod("SubmitTxMeta", SubmitTxRequest{}).WithMutating()
 	// methodSubmitTxNoWait is the SubmitTxNoWait method.
-	methodSubmitTxNoWait = serviceName.NewMethod("SubmitTxNoWait", SubmitTxRequest{})
+	methodSubmitTxNoWait = serviceName.NewMethod("SubmitTxNoWait", SubmitTxRequest{}).WithMutating()
 	// methodCheckTx is the CheckTx method.
 	methodCheckTx = serviceName.NewMethod("CheckTx", CheckTxRequest{})
 	// methodGetGenesisBlock is the GetGenesisBlock method.
 	methodGetGenesisBlock = serviceName.NewMethod("GetGenesisBlock", common.Namespace{})
 	// methodGetBlock is the GetBlock method.
 	methodGetBlock = serviceName.NewMethod("GetBlock", GetBlockRequest{})
+	// methodGetBlockByHash is the GetBlockByHash method.
+	methodGetBlockByHash = serviceName.NewMethod("GetBlockByHash", GetBlockByHashRequest{})
 	// methodGetLastRetainedBlock is the GetLastRetainedBlock method.
 	methodGetLastRetainedBlock = serviceName.NewMethod("GetLastRetainedBlock", common.Namespace{})
 	// methodGetTransactions is the GetTransactions method.
@@ -42,6 +44,10 @@ var (
 	methodGetUnconfirmedTransactions = serviceName.NewMethod("GetUnconfirmedTransactions", common.Namespace{})
 	// methodGetEvents is the GetEvents method.
 	methodGetEvents = serviceName.NewMethod("GetEvents", GetEventsRequest{})
+	// methodQueryTxn is the QueryTxn method.
+	methodQueryTxn = serviceName.NewMethod("QueryTxn", QueryTxnRequest{})
+	// methodQueryTxns is the QueryTxns method.
+	methodQueryTxns = serviceName.NewMethod("QueryTxns", QueryTxnsRequest{})
 	// methodQuery is the Query method.
 	methodQuery = serviceName.NewMethod("Query", QueryRequest{})
 	// methodStateSyncGet is the StateSyncGet method.
@@ -53,6 +59,8 @@ var (
 
 	// methodWatchBlocks is the WatchBlocks method.
 	methodWatchBlocks = serviceName.NewMethod("WatchBlocks", common.Namespace{})
+	// methodWatchBlocksSince is the WatchBlocksSince method.
+	methodWatchBlocksSince = serviceName.NewMethod("WatchBlocksSince", WatchBlocksSinceRequest{})
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -83,6 +91,10 @@ var (
 				MethodName: methodGetBlock.ShortName(),
 				Handler:    handlerGetBlock,
 			},
+			{
+				MethodName: methodGetBlockByHash.ShortName(),
+				Handler:    handlerGetBlockByHash,
+			},
 			{
 				MethodName: methodGetLastRetainedBlock.ShortName(),
 				Handler:    handlerGetLastRetainedBlock,
@@ -103,6 +115,14 @@ var (
 				MethodName: methodGetEvents.ShortName(),
 				Handler:    handlerGetEvents,
 			},
+			{
+				MethodName: methodQueryTxn.ShortName(),
+				Handler:    handlerQueryTxn,
+			},
+			{
+				MethodName: methodQueryTxns.ShortName(),
+				Handler:    handlerQueryTxns,
+			},
 			{
 				MethodName: methodQuery.ShortName(),
 				Handler:    handlerQuery,
@@ -126,6 +146,11 @@ var (
 				Handler:       handlerWatchBlocks,
 				ServerStreams: true,
 			},
+			{
+				StreamName:    methodWatchBlocksSince.ShortName(),
+				Handler:       handlerWatchBlocksSince,
+				ServerStreams: true,
+			},
 		},
 	}
 )
@@ -299,6 +324,31 @@ func handlerGetBlock(
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerGetBlockByHash(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq GetBlockByHashRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		rsp, err := srv.(RuntimeClient).GetBlockByHash(ctx, &rq)
+		return rsp, errorWrapNotFound(err)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetBlockByHash.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		rsp, err := srv.(RuntimeClient).GetBlockByHash(ctx, req.(*GetBlockByHashRequest))
+		return rsp, errorWrapNotFound(err)
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerGetLastRetainedBlock(
 	srv interface{},
 	ctx context.Context,
@@ -416,6 +466,56 @@ func handlerGetEvents(
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerQueryTxn(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq QueryTxnRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		rsp, err := srv.(RuntimeClient).QueryTxn(ctx, &rq)
+		return rsp, errorWrapNotFound(err)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodQueryTxn.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		rsp, err := srv.(RuntimeClient).QueryTxn(ctx, req.(*QueryTxnRequest))
+		return rsp, errorWrapNotFound(err)
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
+func handlerQueryTxns(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq QueryTxnsRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		rsp, err := srv.(RuntimeClient).QueryTxns(ctx, &rq)
+		return rsp, errorWrapNotFound(err)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodQueryTxns.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		rsp, err := srv.(RuntimeClient).QueryTxns(ctx, req.(*QueryTxnsRequest))
+		return rsp, errorWrapNotFound(err)
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerQuery( // nolint: revive
 	srv interface{},
 	ctx context.Context,
@@ -539,6 +639,35 @@ func handlerWatchBlocks(srv interface{}, stream grpc.ServerStream) error {
 	}
 }
 
+func handlerWatchBlocksSince(srv interface{}, stream grpc.ServerStream) error {
+	var rq WatchBlocksSinceRequest
+	if err := stream.RecvMsg(&rq); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(RuntimeClient).WatchBlocksSince(ctx, &rq)
+	if err != nil {
+		return errorWrapNotFound(err)
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case blk, ok := <-ch:
+			if !ok {
+				return errorWrapNotFound(pubsub.SubscriptionErr(sub))
+			}
+
+			if err := stream.SendMsg(blk); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // RegisterService registers a new runtime client service with the given gRPC server.
 func RegisterService(server *grpc.Server, service RuntimeClient) {
 	server.RegisterService(&serviceDesc, service)
@@ -596,6 +725,14 @@ func (c *Client) GetBlock(ctx context.Context, request *GetBlockRequest) (*block
 	return &rsp, nil
 }
 
+func (c *Client) GetBlockByHash(ctx context.Context, request *GetBlockByHashRequest) (*block.Block, error) {
+	var rsp block.Block
+	if err := c.conn.Invoke(ctx, methodGetBlockByHash.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *Client) GetLastRetainedBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error) {
 	var rsp block.Block
 	if err := c.conn.Invoke(ctx, methodGetLastRetainedBlock.FullName(), runtimeID, &rsp); err != nil {
@@ -636,6 +773,22 @@ func (c *Client) GetEvents(ctx context.Context, request *GetEventsRequest) ([]*E
 	return rsp, nil
 }
 
+func (c *Client) QueryTxn(ctx context.Context, request *QueryTxnRequest) (*TxnLocation, error) {
+	var rsp TxnLocation
+	if err := c.conn.Invoke(ctx, methodQueryTxn.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *Client) QueryTxns(ctx context.Context, request *QueryTxnsRequest) ([]*TxnLocation, error) {
+	var rsp []*TxnLocation
+	if err := c.conn.Invoke(ctx, methodQueryTxns.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *Client) Query(ctx context.Context, request *QueryRequest) (*QueryResponse, error) {
 	var rsp QueryResponse
 	if err := c.conn.Invoke(ctx, methodQuery.FullName(), request, &rsp); err != nil {
@@ -680,13 +833,21 @@ func (c *Client) State() syncer.ReadSyncer {
 }
 
 func (c *Client) WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	return c.watchBlocks(ctx, &serviceDesc.Streams[0], methodWatchBlocks, runtimeID)
+}
+
+func (c *Client) WatchBlocksSince(ctx context.Context, request *WatchBlocksSinceRequest) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	return c.watchBlocks(ctx, &serviceDesc.Streams[1], methodWatchBlocksSince, request)
+}
+
+func (c *Client) watchBlocks(ctx context.Context, desc *grpc.StreamDesc, method *cmnGrpc.MethodDesc, request interface{}) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
-	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[0], methodWatchBlocks.FullName())
+	stream, err := c.conn.NewStream(ctx, desc, method.FullName())
 	if err != nil {
 		return nil, nil, err
 	}
-	if err = stream.SendMsg(runtimeID); err != nil {
+	if err = stream.SendMsg(request); err != nil {
 		return nil, nil, err
 	}
 	if err = stream.CloseSend(); err != nil {
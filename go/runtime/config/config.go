@@ -109,6 +109,10 @@ type Config struct {
 	// History pruner configuration.
 	Prune PruneConfig `yaml:"prune,omitempty"`
 
+	// Index transaction tags emitted by runtimes so clients can look up transactions by tag
+	// (client nodes only).
+	IndexTags bool `yaml:"index_tags,omitempty"`
+
 	// RuntimeConfig maps runtime IDs to their respective local configurations.
 	// NOTE: This may go away in the future, use `RuntimeConfig.Config` instead.
 	RuntimeConfig map[string]map[string]interface{} `yaml:"config,omitempty"`
@@ -131,6 +135,12 @@ type Config struct {
 	// LoadBalancer is the load balancer configuration.
 	LoadBalancer LoadBalancerConfig `yaml:"load_balancer,omitempty"`
 
+	// KeyManagerCache is the key manager client metadata cache configuration.
+	KeyManagerCache KeyManagerCacheConfig `yaml:"key_manager_cache,omitempty"`
+
+	// Watchdog is the runtime worker event loop watchdog configuration.
+	Watchdog WatchdogConfig `yaml:"watchdog,omitempty"`
+
 	// Registries is the list of base URLs used to fetch runtime bundle metadata.
 	//
 	// The actual metadata URLs are constructed by appending the manifest hash
@@ -143,23 +153,40 @@ type Config struct {
 	// If not specified, a default value is used.
 	MaxBundleSize string `yaml:"max_bundle_size,omitempty"`
 
+	// MaxLogSize is the maximum amount of captured runtime output retained on disk
+	// for each runtime (e.g. 4MB). Output is stored in two segments and the older
+	// segment is discarded once the current one reaches half of the maximum size.
+	//
+	// If not specified, a default value of 4MB is used. If specified, it must be
+	// greater than zero.
+	MaxLogSize string `yaml:"max_log_size,omitempty"`
+
 	// DebugMockTEE enables mocking of the Trusted Execution Environment (TEE).
 	//
 	// This flag can only be used if the DebugDontBlameOasis flag is set.
 	DebugMockTEE bool `yaml:"debug_mock_tee,omitempty"`
 }
 
+// GetRuntime returns the configuration for the given runtime, if it exists.
+func (c *Config) GetRuntime(runtimeID common.Namespace) (*RuntimeConfig, bool) {
+	for i := range c.Runtimes {
+		if c.Runtimes[i].ID == runtimeID {
+			return &c.Runtimes[i], true
+		}
+	}
+	return nil, false
+}
+
 // GetComponent returns the configuration for the given component
 // of the specified runtime, if it exists.
 func (c *Config) GetComponent(runtimeID common.Namespace, compID component.ID) (ComponentConfig, bool) {
-	for _, rt := range c.Runtimes {
-		if rt.ID != runtimeID {
-			continue
-		}
-		for _, comp := range rt.Components {
-			if comp.ID == compID {
-				return comp, true
-			}
+	rt, ok := c.GetRuntime(runtimeID)
+	if !ok {
+		return ComponentConfig{}, false
+	}
+	for _, comp := range rt.Components {
+		if comp.ID == compID {
+			return comp, true
 		}
 	}
 
@@ -169,10 +196,8 @@ func (c *Config) GetComponent(runtimeID common.Namespace, compID component.ID) (
 // GetLocalConfig returns the local configuration for the given runtime,
 // if it exists.
 func (c *Config) GetLocalConfig(runtimeID common.Namespace) map[string]interface{} {
-	for _, rt := range c.Runtimes {
-		if rt.ID == runtimeID {
-			return rt.Config
-		}
+	if rt, ok := c.GetRuntime(runtimeID); ok {
+		return rt.Config
 	}
 
 	// Support legacy configuration where the runtime configuration is defined
@@ -201,9 +226,15 @@ type RuntimeConfig struct {
 
 // Validate validates the runtime configuration.
 func (c *RuntimeConfig) Validate() error {
+	seen := make(map[component.ID]bool, len(c.Components))
 	for _, comp := range c.Components {
+		if seen[comp.ID] {
+			return fmt.Errorf("component %s: duplicate component", comp.ID)
+		}
+		seen[comp.ID] = true
+
 		if err := comp.Validate(); err != nil {
-			return err
+			return fmt.Errorf("component %s: %w", comp.ID, err)
 		}
 	}
 	return nil
@@ -212,17 +243,17 @@ func (c *RuntimeConfig) Validate() error {
 // ComponentConfig is the component configuration.
 type ComponentConfig struct {
 	// ID is the component identifier.
-	ID component.ID `yaml:"id"`
+	ID component.ID `yaml:"id" json:"id"`
 
 	// TEE specifies the kind of Trusted Execution Environment (TEE)
 	// in which the component should run (none, sgx, tdx).
 	//
 	// If not provided, the TEE kind is selected automatically.
-	TEE TEESelectMode `yaml:"tee,omitempty"`
+	TEE TEESelectMode `yaml:"tee,omitempty" json:"tee,omitempty"`
 
 	// Disabled specifies whether the component is disabled. If a component is specified and not
 	// disabled, it is enabled.
-	Disabled bool `yaml:"disabled,omitempty"`
+	Disabled bool `yaml:"disabled,omitempty" json:"disabled,omitempty"`
 }
 
 // Validate validates the component configuration.
@@ -286,6 +317,22 @@ type LoadBalancerConfig struct {
 	NumInstances uint64 `yaml:"num_instances,omitempty"`
 }
 
+// KeyManagerCacheConfig is the key manager client metadata cache configuration.
+type KeyManagerCacheConfig struct {
+	// Size is the maximum number of cached key manager responses. Setting it
+	// to zero disables the cache, which is the default.
+	Size uint64 `yaml:"size"`
+	// TTL is the duration for which a cached response is considered valid.
+	TTL time.Duration `yaml:"ttl"`
+}
+
+// WatchdogConfig is the runtime worker event loop watchdog configuration.
+type WatchdogConfig struct {
+	// StallTimeout is the duration after which an event loop that is still processing the same
+	// event is considered stalled. Setting it to zero disables stall detection.
+	StallTimeout time.Duration `yaml:"stall_timeout"`
+}
+
 // Validate validates the configuration settings.
 func (c *Config) Validate() error {
 	switch c.Provisioner {
@@ -324,9 +371,23 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("cannot specify more than 128 instances for load balancing")
 	}
 
-	for _, rt := range c.Runtimes {
+	if c.KeyManagerCache.Size > 0 && c.KeyManagerCache.TTL < 1*time.Second {
+		return fmt.Errorf("key_manager_cache.ttl must be >= 1 second")
+	}
+
+	if c.Watchdog.StallTimeout != 0 && c.Watchdog.StallTimeout < 1*time.Second {
+		return fmt.Errorf("watchdog.stall_timeout must be >= 1 second")
+	}
+
+	seen := make(map[common.Namespace]bool, len(c.Runtimes))
+	for i, rt := range c.Runtimes {
+		if seen[rt.ID] {
+			return fmt.Errorf("runtimes[%d] (%s): duplicate runtime", i, rt.ID)
+		}
+		seen[rt.ID] = true
+
 		if err := rt.Validate(); err != nil {
-			return err
+			return fmt.Errorf("runtimes[%d] (%s): %w", i, rt.ID, err)
 		}
 	}
 
@@ -359,6 +420,13 @@ func DefaultConfig() Config {
 		LoadBalancer: LoadBalancerConfig{
 			NumInstances: 0,
 		},
+		KeyManagerCache: KeyManagerCacheConfig{
+			Size: 0,
+			TTL:  10 * time.Minute,
+		},
+		Watchdog: WatchdogConfig{
+			StallTimeout: 5 * time.Minute,
+		},
 		Registries: []string{oasisBundleRegistryURL},
 	}
 }
@@ -66,3 +66,37 @@ runtimes:
 	require.EqualValues(compCfg.ID.Name, "another")
 	require.True(compCfg.Disabled)
 }
+
+func TestRuntimeConfigValidate(t *testing.T) {
+	require := require.New(t)
+
+	yamlCfg := `
+runtimes:
+    - id: 8000000000000000000000000000000000000000000000000000000000000000
+      components:
+          - rofl.foo-test
+    - id: 8000000000000000000000000000000000000000000000000000000000000001
+      components:
+          - id: rofl.another
+            tee: invalid
+`
+	cfg := DefaultConfig()
+	err := yaml.Unmarshal([]byte(yamlCfg), &cfg)
+	require.NoError(err, "yaml.Unmarshal")
+
+	err = cfg.Validate()
+	require.ErrorContains(err, "runtimes[1] (8000000000000000000000000000000000000000000000000000000000000001): component rofl (another): unknown TEE select mode: invalid")
+
+	cfg.Runtimes[1].Components[0].TEE = TEESelectModeNone
+	require.NoError(cfg.Validate(), "Validate")
+
+	rt, ok := cfg.GetRuntime(cfg.Runtimes[1].ID)
+	require.True(ok)
+	require.Equal(&cfg.Runtimes[1], rt)
+
+	cfg.Runtimes[1].Components = append(cfg.Runtimes[1].Components, cfg.Runtimes[1].Components[0])
+	require.ErrorContains(cfg.Validate(), "duplicate component")
+
+	cfg.Runtimes[1] = cfg.Runtimes[0]
+	require.ErrorContains(cfg.Validate(), "runtimes[1] (8000000000000000000000000000000000000000000000000000000000000000): duplicate runtime")
+}
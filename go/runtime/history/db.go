@@ -2,6 +2,7 @@ package history
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/dgraph-io/badger/v4"
 	"github.com/dgraph-io/badger/v4/options"
@@ -9,12 +10,19 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnBadger "github.com/oasisprotocol/oasis-core/go/common/badger"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 )
 
-const dbVersion = 1
+const (
+	dbVersion = 2
+
+	// dbVersionNoHashIndex is the database schema version without the block hash index.
+	dbVersionNoHashIndex = 1
+)
 
 var (
 	// keyFormat is the namespace for the runtime history database key formats.
@@ -36,6 +44,11 @@ var (
 	// Deprecated: This key format is deprecated and will be removed in future versions
 	// after we clean up the remaining round results from the block history (#6098).
 	deprecatedRoundResultsKeyFmt = keyFormat.New(0x03, uint64(0)) //nolint:unused
+
+	// blockHashKeyFmt is the block hash index key format.
+	//
+	// Value is the CBOR-serialized round of the block.
+	blockHashKeyFmt = keyFormat.New(0x04, &hash.Hash{})
 )
 
 type dbMetadata struct {
@@ -70,6 +83,7 @@ func newDB(fn string, runtimeID common.Namespace) (*DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("runtime/history: failed to open database: %w", err)
 	}
+	cmnBadger.RegisterForSnapshots(db, false)
 
 	gc := cmnBadger.NewGCWorker(logger, db)
 	gc.Start()
@@ -80,7 +94,11 @@ func newDB(fn string, runtimeID common.Namespace) (*DB, error) {
 		gc:     gc,
 	}
 
-	// Ensure metadata is valid.
+	// Migrate and ensure metadata is valid.
+	if err = d.migrateHashIndex(); err != nil {
+		d.close()
+		return nil, err
+	}
 	if err = d.ensureMetadata(runtimeID); err != nil {
 		d.close()
 		return nil, err
@@ -139,6 +157,66 @@ func (d *DB) ensureMetadata(runtimeID common.Namespace) error {
 	})
 }
 
+// migrateHashIndex builds the block hash index for all blocks in a database without one.
+func (d *DB) migrateHashIndex() error {
+	meta, err := d.metadata()
+	switch err {
+	case nil:
+	case badger.ErrKeyNotFound:
+		return nil
+	default:
+		return err
+	}
+	if meta.Version != dbVersionNoHashIndex {
+		return nil
+	}
+
+	d.logger.Info("building block hash index")
+
+	// The index may be too big for a single transaction, so write it in batches. In case the
+	// migration is interrupted, it is restarted from scratch on next open.
+	wb := d.db.NewWriteBatch()
+	defer wb.Cancel()
+
+	err = d.db.View(func(tx *badger.Txn) error {
+		it := tx.NewIterator(badger.IteratorOptions{
+			PrefetchValues: true,
+			Prefix:         blockKeyFmt.Encode(),
+		})
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			var blk roothash.AnnotatedBlock
+			if verr := it.Item().Value(func(val []byte) error {
+				return cbor.UnmarshalTrusted(val, &blk)
+			}); verr != nil {
+				return verr
+			}
+			h := blk.Block.Header.EncodedHash()
+			if werr := wb.Set(blockHashKeyFmt.Encode(&h), cbor.Marshal(blk.Block.Header.Round)); werr != nil {
+				return werr
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("runtime/history: failed to build block hash index: %w", err)
+	}
+	if err = wb.Flush(); err != nil {
+		return fmt.Errorf("runtime/history: failed to build block hash index: %w", err)
+	}
+
+	return d.db.Update(func(tx *badger.Txn) error {
+		meta.Version = dbVersion
+		return tx.Set(metadataKeyFmt.Encode(), cbor.Marshal(meta))
+	})
+}
+
+func setBlockHash(tx *badger.Txn, blk *block.Block) error {
+	h := blk.Header.EncodedHash()
+	return tx.Set(blockHashKeyFmt.Encode(&h), cbor.Marshal(blk.Header.Round))
+}
+
 func (d *DB) metadata() (*dbMetadata, error) {
 	var meta *dbMetadata
 	err := d.db.View(func(tx *badger.Txn) error {
@@ -190,6 +268,9 @@ func (d *DB) commit(blks []*roothash.AnnotatedBlock) error {
 			if err := tx.Set(blockKeyFmt.Encode(blk.Block.Header.Round), cbor.Marshal(blk)); err != nil {
 				return err
 			}
+			if err := setBlockHash(tx, blk.Block); err != nil {
+				return err
+			}
 
 			meta.LastRound = blk.Block.Header.Round
 			if blk.Height > meta.LastConsensusHeight {
@@ -222,6 +303,28 @@ func (d *DB) getBlock(round uint64) (*roothash.AnnotatedBlock, error) {
 	return &blk, nil
 }
 
+func (d *DB) getRoundByHash(h hash.Hash) (uint64, error) {
+	var round uint64
+	txErr := d.db.View(func(tx *badger.Txn) error {
+		item, err := tx.Get(blockHashKeyFmt.Encode(&h))
+		switch err {
+		case nil:
+		case badger.ErrKeyNotFound:
+			return roothash.ErrNotFound
+		default:
+			return err
+		}
+
+		return item.Value(func(val []byte) error {
+			return cbor.UnmarshalTrusted(val, &round)
+		})
+	})
+	if txErr != nil {
+		return 0, txErr
+	}
+	return round, nil
+}
+
 func (d *DB) getEarliestBlock() (*roothash.AnnotatedBlock, error) {
 	var blk roothash.AnnotatedBlock
 	txErr := d.db.View(func(tx *badger.Txn) error {
@@ -243,6 +346,42 @@ func (d *DB) getEarliestBlock() (*roothash.AnnotatedBlock, error) {
 	return &blk, nil
 }
 
+// ReadLastBlock returns the last block stored in the history database located in the given
+// runtime state directory. The database must not be in use by a history keeper.
+func ReadLastBlock(dataDir string) (*block.Block, error) {
+	fn := filepath.Join(dataDir, DbFilename)
+	logger := logging.GetLogger("runtime/history").With("path", fn)
+
+	opts := badger.DefaultOptions(fn)
+	opts = opts.WithLogger(cmnBadger.NewLogAdapter(logger))
+	opts = opts.WithReadOnly(true)
+	opts = opts.WithCompression(options.None)
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("runtime/history: failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	d := &DB{
+		logger: logger,
+		db:     db,
+	}
+	meta, err := d.metadata()
+	if err != nil {
+		return nil, err
+	}
+	if meta.LastConsensusHeight == 0 {
+		// Nothing has been committed yet.
+		return nil, roothash.ErrNotFound
+	}
+	annBlk, err := d.getBlock(meta.LastRound)
+	if err != nil {
+		return nil, err
+	}
+	return annBlk.Block, nil
+}
+
 func (d *DB) close() {
 	d.gc.Stop()
 	d.db.Close()
@@ -11,11 +11,13 @@ import (
 	"github.com/eapache/channels"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
 )
 
 // DbFilename is the filename of the history database.
@@ -30,6 +32,18 @@ type Factory func(runtimeID common.Namespace, dataDir string) (History, error)
 type History interface {
 	roothash.BlockHistory
 
+	// IndexTags records the transaction tags emitted in the given round.
+	//
+	// Rounds must be indexed in increasing order, but may be skipped.
+	IndexTags(round uint64, tags transaction.Tags) error
+
+	// LastTagIndexedRound returns the last round with indexed transaction tags, if any.
+	LastTagIndexedRound() (uint64, bool, error)
+
+	// QueryTxns returns up to limit (0 = unlimited) transactions that emitted the given tag,
+	// ordered by round.
+	QueryTxns(key, value []byte, limit uint64) ([]*TaggedTxn, error)
+
 	// Pruner returns the history pruner.
 	Pruner() Pruner
 
@@ -230,6 +244,17 @@ func (h *runtimeHistory) GetAnnotatedBlock(ctx context.Context, round uint64) (*
 	return h.db.getBlock(resolvedRound)
 }
 
+func (h *runtimeHistory) GetBlockByHash(ctx context.Context, hash hash.Hash) (*block.Block, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	round, err := h.db.getRoundByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	return h.GetBlock(ctx, round)
+}
+
 func (h *runtimeHistory) GetEarliestBlock(ctx context.Context) (*block.Block, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
@@ -299,6 +324,7 @@ func New(runtimeID common.Namespace, dataDir string, prunerFactory PrunerFactory
 	if err != nil {
 		return nil, err
 	}
+	pruner.RegisterHandler(&tagPruneHandler{db: db})
 
 	ctx, cancelCtx := context.WithCancel(context.Background())
 
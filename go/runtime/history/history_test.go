@@ -7,13 +7,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dgraph-io/badger/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
 )
 
 const recvTimeout = 1 * time.Second
@@ -108,6 +112,13 @@ func TestHistory(t *testing.T) {
 	require.NoError(err, "GetAnnotatedBlock")
 	require.Equal(&blk, gotAnnBlk, "GetAnnotatedBlock should return the correct block")
 
+	gotBlk, err = history.GetBlockByHash(ctx, putBlk.Header.EncodedHash())
+	require.NoError(err, "GetBlockByHash")
+	require.Equal(&putBlk, gotBlk, "GetBlockByHash should return the correct block")
+
+	_, err = history.GetBlockByHash(ctx, hash.NewFromBytes([]byte("unknown block")))
+	require.Equal(roothash.ErrNotFound, err, "GetBlockByHash should fail for unknown block")
+
 	ch, sub, err := history.WatchBlocks()
 	require.NoError(err)
 	defer sub.Close()
@@ -154,6 +165,37 @@ func TestHistory(t *testing.T) {
 	gotLatestBlk, err = history.GetBlock(ctx, roothash.RoundLatest)
 	require.NoError(err, "GetBlock(RoundLatest)")
 	require.Equal(&putBlk, gotLatestBlk, "GetBlock(RoundLatest) should return the correct block")
+
+	gotBlk, err = history.GetBlockByHash(ctx, putBlk.Header.EncodedHash())
+	require.NoError(err, "GetBlockByHash")
+	require.Equal(&putBlk, gotBlk, "GetBlockByHash should return the correct block")
+
+	// Simulate a database without the block hash index and ensure it gets rebuilt.
+	db := history.(*runtimeHistory).db
+	err = db.db.Update(func(tx *badger.Txn) error {
+		meta, merr := db.queryGetMetadata(tx)
+		if merr != nil {
+			return merr
+		}
+		meta.Version = dbVersionNoHashIndex
+		if merr = tx.Set(metadataKeyFmt.Encode(), cbor.Marshal(meta)); merr != nil {
+			return merr
+		}
+		h := putBlk.Header.EncodedHash()
+		return tx.Delete(blockHashKeyFmt.Encode(&h))
+	})
+	require.NoError(err, "Update")
+	history.Close()
+
+	history, err = New(runtimeID, dataDir, prunerFactory, true)
+	require.NoError(err, "New")
+	defer history.Close()
+
+	err = history.StorageSyncCheckpoint(10)
+	require.NoError(err, "StorageSyncCheckpoint")
+	gotBlk, err = history.GetBlockByHash(ctx, putBlk.Header.EncodedHash())
+	require.NoError(err, "GetBlockByHash after migration")
+	require.Equal(&putBlk, gotBlk, "GetBlockByHash should return the correct block")
 }
 
 func TestCommitBatch(t *testing.T) {
@@ -495,6 +537,17 @@ func TestHistoryPrune(t *testing.T) {
 		require.NoError(err, "GetBlock(%d)", i)
 	}
 
+	// Ensure the block hash index has been pruned as well.
+	db := history.(*runtimeHistory).db
+	for i := 0; i < n; i++ {
+		_, err = db.getRoundByHash(blks[i].Block.Header.EncodedHash())
+		if i <= 40 {
+			require.Equal(roothash.ErrNotFound, err, "block hash index should be pruned for block %d", i)
+			continue
+		}
+		require.NoError(err, "getRoundByHash(%d)", i)
+	}
+
 	// Ensure the prune handler was called.
 	require.Len(ph.prunedRounds, 41)
 	for i := 0; i <= 40; i++ {
@@ -551,3 +604,135 @@ func TestHistoryPruneError(t *testing.T) {
 		require.NoError(err, "GetBlock(%d)", i)
 	}
 }
+
+func TestTagIndex(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// Create a new random temporary directory under /tmp.
+	dataDir, err := os.MkdirTemp("", "oasis-runtime-history-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("history tag index test ns"), 0)
+
+	pruneFactory := NewKeepLastPrunerFactory(10, 100*time.Millisecond)
+	history, err := New(runtimeID, dataDir, pruneFactory, true)
+	require.NoError(err, "New")
+	defer history.Close()
+
+	_, ok, err := history.LastTagIndexedRound()
+	require.NoError(err, "LastTagIndexedRound")
+	require.False(ok, "no rounds should be indexed initially")
+
+	_, err = history.QueryTxns([]byte("key"), []byte("value"), 0)
+	require.ErrorIs(err, roothash.ErrNotFound, "QueryTxns should fail without matches")
+
+	const n = 51
+	for i := 0; i < n; i++ {
+		blk := roothash.AnnotatedBlock{
+			Height: int64(i),
+			Block:  block.NewGenesisBlock(runtimeID, 0),
+		}
+		blk.Block.Header.Round = uint64(i)
+
+		err = history.Commit(&blk, true)
+		require.NoError(err, "Commit")
+
+		err = history.StorageSyncCheckpoint(blk.Block.Header.Round)
+		require.NoError(err, "StorageSyncCheckpoint")
+
+		// Every even round contains a transaction with a matching tag.
+		var tags transaction.Tags
+		if i%2 == 0 {
+			tags = append(tags, &transaction.Tag{
+				Key:    []byte("key"),
+				Value:  []byte("value"),
+				TxHash: hash.NewFromBytes([]byte(fmt.Sprintf("tx %d", i))),
+			})
+		}
+		tags = append(tags, &transaction.Tag{
+			Key:    []byte("key"),
+			Value:  []byte(fmt.Sprintf("value %d", i)),
+			TxHash: hash.NewFromBytes([]byte(fmt.Sprintf("other tx %d", i))),
+		})
+		err = history.IndexTags(uint64(i), tags)
+		require.NoError(err, "IndexTags")
+	}
+
+	err = history.IndexTags(n-1, nil)
+	require.Error(err, "IndexTags should fail for an already indexed round")
+
+	last, ok, err := history.LastTagIndexedRound()
+	require.NoError(err, "LastTagIndexedRound")
+	require.True(ok, "rounds should be indexed")
+	require.EqualValues(n-1, last, "LastTagIndexedRound")
+
+	txns, err := history.QueryTxns([]byte("key"), []byte("value 7"), 0)
+	require.NoError(err, "QueryTxns")
+	require.Len(txns, 1)
+	require.EqualValues(7, txns[0].Round)
+	require.Equal(hash.NewFromBytes([]byte("other tx 7")), txns[0].TxHash)
+
+	txns, err = history.QueryTxns([]byte("key"), []byte("value"), 3)
+	require.NoError(err, "QueryTxns")
+	require.Len(txns, 3, "QueryTxns should respect the limit")
+	for i, txn := range txns {
+		require.EqualValues(2*i, txn.Round, "transactions should be ordered by round")
+	}
+
+	// Wait until the tags of pruned rounds have been removed.
+	ctx, cancel := context.WithTimeout(ctx, recvTimeout)
+	defer cancel()
+	for {
+		txns, err = history.QueryTxns([]byte("key"), []byte("value"), 0)
+		require.NoError(err, "QueryTxns")
+		if txns[0].Round >= n-10 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			t.Fatalf("failed to wait for tags to be pruned")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	require.Len(txns, 5, "only tags of retained rounds should remain")
+
+	_, err = history.QueryTxns([]byte("key"), []byte("value 7"), 0)
+	require.ErrorIs(err, roothash.ErrNotFound, "tags of pruned rounds should be removed")
+}
+
+func TestReadLastBlock(t *testing.T) {
+	require := require.New(t)
+
+	dataDir, err := os.MkdirTemp("", "oasis-runtime-history-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("history test ns 1"), 0)
+
+	history, err := New(runtimeID, dataDir, NewNonePrunerFactory(), false)
+	require.NoError(err, "New")
+	history.Close()
+
+	_, err = ReadLastBlock(dataDir)
+	require.ErrorIs(err, roothash.ErrNotFound, "ReadLastBlock should fail without blocks")
+
+	history, err = New(runtimeID, dataDir, NewNonePrunerFactory(), false)
+	require.NoError(err, "New")
+	for round := uint64(1); round <= 3; round++ {
+		blk := roothash.AnnotatedBlock{
+			Height: int64(round),
+			Block:  block.NewGenesisBlock(runtimeID, 0),
+		}
+		blk.Block.Header.Round = round
+		err = history.Commit(&blk, false)
+		require.NoError(err, "Commit")
+	}
+	history.Close()
+
+	blk, err := ReadLastBlock(dataDir)
+	require.NoError(err, "ReadLastBlock")
+	require.EqualValues(3, blk.Header.Round)
+}
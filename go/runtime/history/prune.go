@@ -8,7 +8,9 @@ import (
 	"github.com/dgraph-io/badger/v4"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 )
 
 const (
@@ -99,7 +101,7 @@ func (p *keepLastPruner) Prune(latestRound uint64) error {
 	lastPrunedRound := latestRound - p.numKept
 
 	return p.db.db.Update(func(tx *badger.Txn) error {
-		// NOTE: Do not prefetch values as we are only looking at keys.
+		// NOTE: Do not prefetch values as they are only needed for the block hash index.
 		it := tx.NewIterator(badger.IteratorOptions{
 			Prefix: blockKeyFmt.Encode(),
 		})
@@ -120,6 +122,22 @@ func (p *keepLastPruner) Prune(latestRound uint64) error {
 				break
 			}
 
+			// Remove the block hash index entry first, so that an interrupted prune can only
+			// leave a block that is about to be pruned without an index entry.
+			var blk roothash.AnnotatedBlock
+			if err := item.Value(func(val []byte) error {
+				return cbor.UnmarshalTrusted(val, &blk)
+			}); err != nil {
+				return err
+			}
+			h := blk.Block.Header.EncodedHash()
+			if err := tx.Delete(blockHashKeyFmt.Encode(&h)); err != nil {
+				if err == badger.ErrTxnTooBig {
+					break
+				}
+				return err
+			}
+
 			if err := tx.Delete(item.KeyCopy(nil)); err != nil {
 				if err == badger.ErrTxnTooBig {
 					// We can't prune any more rounds in this transaction.
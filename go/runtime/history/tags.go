@@ -0,0 +1,201 @@
+package history
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v4"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
+)
+
+var (
+	// txnTagKeyFmt is the transaction tag index key format.
+	//
+	// Key is (tag hash, round, transaction hash), value is empty.
+	txnTagKeyFmt = keyFormat.New(0x05, &hash.Hash{}, uint64(0), &hash.Hash{})
+	// roundTagKeyFmt is the per-round transaction tag index key format, used for pruning.
+	//
+	// Key is (round, tag hash, transaction hash), value is empty.
+	roundTagKeyFmt = keyFormat.New(0x06, uint64(0), &hash.Hash{}, &hash.Hash{})
+	// lastTagIndexedRoundKeyFmt is the last round with indexed transaction tags key format.
+	//
+	// Value is the CBOR-serialized round.
+	lastTagIndexedRoundKeyFmt = keyFormat.New(0x07)
+)
+
+// TaggedTxn is a transaction that emitted a given tag.
+type TaggedTxn struct {
+	// Round is the round of the block containing the transaction.
+	Round uint64
+	// TxHash is the transaction hash.
+	TxHash hash.Hash
+}
+
+type indexedTag struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+func tagHash(key, value []byte) hash.Hash {
+	return hash.NewFrom(&indexedTag{Key: key, Value: value})
+}
+
+func (d *DB) indexTags(round uint64, tags transaction.Tags) error {
+	last, ok, err := d.lastTagIndexedRound()
+	if err != nil {
+		return err
+	}
+	if ok && round <= last {
+		return fmt.Errorf("runtime/history: tag index at lower or equal round (current: %d wanted: %d)",
+			last,
+			round,
+		)
+	}
+
+	// A round may have more tags than fit into a single transaction, so write them in batches.
+	// In case indexing is interrupted, the round is indexed again as it is not yet marked.
+	wb := d.db.NewWriteBatch()
+	defer wb.Cancel()
+
+	for _, tag := range tags {
+		h := tagHash(tag.Key, tag.Value)
+		if err = wb.Set(txnTagKeyFmt.Encode(&h, round, &tag.TxHash), []byte{}); err != nil {
+			return err
+		}
+		if err = wb.Set(roundTagKeyFmt.Encode(round, &h, &tag.TxHash), []byte{}); err != nil {
+			return err
+		}
+	}
+	if err = wb.Set(lastTagIndexedRoundKeyFmt.Encode(), cbor.Marshal(round)); err != nil {
+		return err
+	}
+	return wb.Flush()
+}
+
+func (d *DB) lastTagIndexedRound() (uint64, bool, error) {
+	var round uint64
+	txErr := d.db.View(func(tx *badger.Txn) error {
+		item, err := tx.Get(lastTagIndexedRoundKeyFmt.Encode())
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			return cbor.UnmarshalTrusted(val, &round)
+		})
+	})
+	switch txErr {
+	case nil:
+		return round, true, nil
+	case badger.ErrKeyNotFound:
+		return 0, false, nil
+	default:
+		return 0, false, txErr
+	}
+}
+
+func (d *DB) queryTxns(key, value []byte, limit uint64) ([]*TaggedTxn, error) {
+	h := tagHash(key, value)
+
+	var txns []*TaggedTxn
+	txErr := d.db.View(func(tx *badger.Txn) error {
+		// NOTE: Do not prefetch values as we are only looking at keys.
+		it := tx.NewIterator(badger.IteratorOptions{
+			Prefix: txnTagKeyFmt.Encode(&h),
+		})
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			var (
+				decHash hash.Hash
+				txn     TaggedTxn
+			)
+			if !txnTagKeyFmt.Decode(it.Item().Key(), &decHash, &txn.Round, &txn.TxHash) {
+				// This should not happen as the Badger iterator should take care of it.
+				panic("runtime/history: bad iterator")
+			}
+
+			txns = append(txns, &txn)
+			if limit > 0 && uint64(len(txns)) >= limit {
+				break
+			}
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return txns, nil
+}
+
+// tagPruneHandler removes the transaction tags of pruned rounds from the index.
+type tagPruneHandler struct {
+	db *DB
+}
+
+// Implements PruneHandler.
+func (h *tagPruneHandler) Prune(rounds []uint64) error {
+	wb := h.db.db.NewWriteBatch()
+	defer wb.Cancel()
+
+	err := h.db.db.View(func(tx *badger.Txn) error {
+		for _, round := range rounds {
+			if err := h.pruneRound(tx, wb, round); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return wb.Flush()
+}
+
+func (h *tagPruneHandler) pruneRound(tx *badger.Txn, wb *badger.WriteBatch, round uint64) error {
+	it := tx.NewIterator(badger.IteratorOptions{
+		Prefix: roundTagKeyFmt.Encode(round),
+	})
+	defer it.Close()
+
+	for it.Rewind(); it.Valid(); it.Next() {
+		var (
+			decRound uint64
+			tag      hash.Hash
+			txHash   hash.Hash
+		)
+		if !roundTagKeyFmt.Decode(it.Item().Key(), &decRound, &tag, &txHash) {
+			// This should not happen as the Badger iterator should take care of it.
+			panic("runtime/history: bad iterator")
+		}
+
+		if err := wb.Delete(txnTagKeyFmt.Encode(&tag, round, &txHash)); err != nil {
+			return err
+		}
+		if err := wb.Delete(it.Item().KeyCopy(nil)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *runtimeHistory) IndexTags(round uint64, tags transaction.Tags) error {
+	return h.db.indexTags(round, tags)
+}
+
+func (h *runtimeHistory) LastTagIndexedRound() (uint64, bool, error) {
+	return h.db.lastTagIndexedRound()
+}
+
+func (h *runtimeHistory) QueryTxns(key, value []byte, limit uint64) ([]*TaggedTxn, error) {
+	txns, err := h.db.queryTxns(key, value, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(txns) == 0 {
+		return nil, roothash.ErrNotFound
+	}
+	return txns, nil
+}
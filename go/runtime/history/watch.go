@@ -0,0 +1,111 @@
+package history
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+)
+
+// WatchBlocksSince subscribes to blocks of the given block history, starting with the given round.
+//
+// All retained blocks since the given round are replayed from block history before any new blocks
+// are delivered. In case a block can no longer be replayed (e.g., because it has been pruned in the
+// meantime), the channel is closed and the error is reported by the returned subscription (see
+// pubsub.SubscriptionErr).
+func WatchBlocksSince(ctx context.Context, history roothash.BlockHistory, round uint64, logger *logging.Logger) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	logger = logger.With("runtime_id", history.RuntimeID())
+
+	// Subscribe before replaying history so that no blocks are missed in between.
+	blkCh, blkSub, err := history.WatchBlocks()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Start with the earliest retained block in case the requested round has been pruned.
+	next := round
+	earliest, err := history.GetEarliestBlock(ctx)
+	switch err {
+	case nil:
+		if earliest.Header.Round > next {
+			next = earliest.Header.Round
+		}
+	case roothash.ErrNotFound:
+	default:
+		blkSub.Close()
+		return nil, nil, err
+	}
+
+	ctx, sub := pubsub.NewContextErrorSubscription(ctx)
+	ch := make(chan *roothash.AnnotatedBlock)
+	go func() {
+		defer close(ch)
+		defer blkSub.Close()
+
+		send := func(blk *roothash.AnnotatedBlock) bool {
+			select {
+			case ch <- blk:
+				next = blk.Block.Header.Round + 1
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		// Replays blocks from history up to (excluding) the given round.
+		replay := func(until uint64) bool {
+			for next < until {
+				blk, rerr := history.GetAnnotatedBlock(ctx, next)
+				if rerr != nil {
+					logger.Error("failed to replay block from history",
+						"err", rerr,
+						"round", next,
+					)
+					sub.SetErr(fmt.Errorf("failed to replay round %d: %w", next, rerr))
+					return false
+				}
+				if !send(blk) {
+					return false
+				}
+			}
+			return true
+		}
+
+		// Replay all blocks up to the latest one without waiting for a new block.
+		latest, lerr := history.GetAnnotatedBlock(ctx, roothash.RoundLatest)
+		switch lerr {
+		case nil:
+			if !replay(latest.Block.Header.Round + 1) {
+				return
+			}
+		case roothash.ErrNotFound:
+		default:
+			logger.Error("failed to get latest block from history",
+				"err", lerr,
+			)
+			sub.SetErr(fmt.Errorf("failed to get latest block: %w", lerr))
+			return
+		}
+
+		for {
+			select {
+			case blk, ok := <-blkCh:
+				if !ok {
+					return
+				}
+				if blk.Block.Header.Round < next {
+					continue
+				}
+				if !replay(blk.Block.Header.Round) || !send(blk) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
@@ -0,0 +1,125 @@
+package history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+)
+
+func TestWatchBlocksSince(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("watch blocks since test ns"), 0)
+	h, err := New(runtimeID, t.TempDir(), NewNonePrunerFactory(), false)
+	require.NoError(err, "New")
+	defer h.Close()
+
+	newBlock := func(round uint64) *roothash.AnnotatedBlock {
+		blk := block.NewGenesisBlock(runtimeID, 0)
+		blk.Header.Round = round
+		return &roothash.AnnotatedBlock{Height: int64(round), Block: blk}
+	}
+	expectRounds := func(ch <-chan *roothash.AnnotatedBlock, rounds ...uint64) {
+		for _, round := range rounds {
+			select {
+			case blk := <-ch:
+				require.EqualValues(round, blk.Block.Header.Round)
+			case <-time.After(recvTimeout):
+				t.Fatalf("failed to receive block %d", round)
+			}
+		}
+	}
+
+	for round := uint64(0); round < 5; round++ {
+		err = h.Commit(newBlock(round), false)
+		require.NoError(err, "Commit")
+	}
+
+	// Retained blocks since the requested round should be replayed.
+	ch, sub, err := WatchBlocksSince(ctx, h, 2, logging.GetLogger("test"))
+	require.NoError(err, "WatchBlocksSince")
+	defer sub.Close()
+	expectRounds(ch, 2, 3, 4)
+
+	// New blocks should follow, including any that were committed without notification.
+	err = h.Commit(newBlock(5), false)
+	require.NoError(err, "Commit")
+	err = h.Commit(newBlock(6), true)
+	require.NoError(err, "Commit")
+	expectRounds(ch, 5, 6)
+
+	// Blocks should not be duplicated for rounds in the future.
+	ch2, sub2, err := WatchBlocksSince(ctx, h, 10, logging.GetLogger("test"))
+	require.NoError(err, "WatchBlocksSince")
+	defer sub2.Close()
+	for round := uint64(7); round <= 10; round++ {
+		err = h.Commit(newBlock(round), true)
+		require.NoError(err, "Commit")
+	}
+	expectRounds(ch2, 10)
+	select {
+	case blk := <-ch2:
+		t.Fatalf("unexpected block %d", blk.Block.Header.Round)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// pruningHistory is a block history where the given round is pruned while replaying.
+type pruningHistory struct {
+	History
+
+	pruned uint64
+}
+
+func (h *pruningHistory) GetAnnotatedBlock(ctx context.Context, round uint64) (*roothash.AnnotatedBlock, error) {
+	if round == h.pruned {
+		return nil, roothash.ErrNotFound
+	}
+	return h.History.GetAnnotatedBlock(ctx, round)
+}
+
+func TestWatchBlocksSincePruned(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("watch blocks since pruned test ns"), 0)
+	h, err := New(runtimeID, t.TempDir(), NewNonePrunerFactory(), false)
+	require.NoError(err, "New")
+	defer h.Close()
+
+	for round := uint64(0); round < 5; round++ {
+		blk := block.NewGenesisBlock(runtimeID, 0)
+		blk.Header.Round = round
+		err = h.Commit(&roothash.AnnotatedBlock{Height: int64(round), Block: blk}, false)
+		require.NoError(err, "Commit")
+	}
+
+	ch, sub, err := WatchBlocksSince(ctx, &pruningHistory{History: h, pruned: 3}, 1, logging.GetLogger("test"))
+	require.NoError(err, "WatchBlocksSince")
+	defer sub.Close()
+
+	// Blocks before the pruned round should be replayed, after which the stream should fail.
+	var rounds []uint64
+	for {
+		select {
+		case blk, ok := <-ch:
+			if !ok {
+				require.Equal([]uint64{1, 2}, rounds)
+				require.ErrorIs(pubsub.SubscriptionErr(sub), roothash.ErrNotFound, "pruned rounds should be reported as an error")
+				return
+			}
+			rounds = append(rounds, blk.Block.Header.Round)
+		case <-time.After(recvTimeout):
+			t.Fatalf("stream should be closed after a pruned round")
+		}
+	}
+}
@@ -3,6 +3,7 @@ package host
 
 import (
 	"context"
+	"io"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
@@ -32,6 +33,18 @@ type Config struct {
 
 	// LocalConfig is the node-local runtime configuration.
 	LocalConfig map[string]interface{}
+
+	// LogSink is an optional writer that receives a copy of the runtime output.
+	LogSink io.Writer
+}
+
+// OutputWriter returns the writer that should be used for runtime output. In case a log sink is
+// configured, output is also copied to the sink.
+func (cfg *Config) OutputWriter(w io.Writer) io.Writer {
+	if cfg.LogSink == nil {
+		return w
+	}
+	return io.MultiWriter(w, cfg.LogSink)
 }
 
 // Provisioner is the runtime provisioner interface.
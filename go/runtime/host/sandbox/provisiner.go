@@ -106,6 +106,7 @@ func DefaultGetSandboxConfig(logger *logging.Logger, sandboxBinaryPath string) G
 			"component", cfg.Component.ID(),
 			"provisioner", "sandbox",
 		)
+		output := cfg.OutputWriter(logWrapper)
 
 		executable := cfg.Component.Executable
 		if cfg.Component.ELF != nil {
@@ -115,8 +116,8 @@ func DefaultGetSandboxConfig(logger *logging.Logger, sandboxBinaryPath string) G
 		return process.Config{
 			Path:              cfg.Component.ExplodedPath(executable),
 			SandboxBinaryPath: sandboxBinaryPath,
-			Stdout:            logWrapper,
-			Stderr:            logWrapper,
+			Stdout:            output,
+			Stderr:            output,
 			AllowNetwork:      cfg.Component.IsNetworkAllowed(),
 		}, nil
 	}
@@ -275,6 +275,7 @@ func (p *sgxProvisioner) getSandboxConfig(cfg host.Config, conn sandbox.Connecto
 		"component", cfg.Component.ID(),
 		"provisioner", p.Name(),
 	)
+	output := cfg.OutputWriter(logWrapper)
 
 	args := []string{
 		"--host-socket", us.GetGuestSocketPath(),
@@ -300,8 +301,8 @@ func (p *sgxProvisioner) getSandboxConfig(cfg host.Config, conn sandbox.Connecto
 			signaturePath: bytes.NewReader(sig),
 		},
 		SandboxBinaryPath: p.cfg.SandboxBinaryPath,
-		Stdout:            logWrapper,
-		Stderr:            logWrapper,
+		Stdout:            output,
+		Stderr:            output,
 		AllowNetwork:      cfg.Component.IsNetworkAllowed(),
 	}, nil
 }
@@ -260,8 +260,9 @@ func (p *qemuProvisioner) getSandboxConfig(cfg host.Config, _ sandbox.Connector,
 		"component", cfg.Component.ID(),
 		"provisioner", p.Name(),
 	)
-	pcfg.Stdout = logWrapper
-	pcfg.Stderr = logWrapper
+	output := cfg.OutputWriter(logWrapper)
+	pcfg.Stdout = output
+	pcfg.Stderr = output
 
 	return pcfg, nil
 }
@@ -116,6 +116,7 @@ func New(dataDir, fn string, runtimeID common.Namespace) (LocalStorage, error) {
 	if s.db, err = badger.Open(opts); err != nil {
 		return nil, fmt.Errorf("failed to open local storage database: %w", err)
 	}
+	cmnBadger.RegisterForSnapshots(s.db, false)
 
 	s.gc = cmnBadger.NewGCWorker(s.logger, s.db)
 	s.gc.Start()
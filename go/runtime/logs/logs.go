@@ -0,0 +1,257 @@
+// Package logs implements capturing of hosted runtime output.
+package logs
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+)
+
+const (
+	// DirName is the name of the runtime-specific directory holding captured runtime output.
+	DirName = "logs"
+
+	currentFile  = "current.log"
+	previousFile = "previous.log"
+
+	// maxLineSize is the maximum size of a single line, longer lines are split.
+	maxLineSize = 10_000
+)
+
+// Buffer is a bounded on-disk buffer of captured runtime output.
+//
+// Output is appended to the current segment which is rotated once it reaches half of the
+// maximum size, so that roughly the most recent maxSize bytes of output are retained.
+type Buffer struct {
+	sync.Mutex
+
+	dir     string
+	maxSize int64
+
+	f    *os.File
+	size int64
+	buf  []byte
+	seq  uint64
+
+	notifier *pubsub.Broker
+	logger   *logging.Logger
+}
+
+// Write implements io.Writer.
+//
+// Failures to persist output are logged and never returned, as the buffer is used together with
+// other writers (e.g. via io.MultiWriter) which should keep receiving the runtime output.
+func (b *Buffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.f == nil {
+		// Discard output after the buffer has been closed.
+		return len(p), nil
+	}
+
+	b.buf = append(b.buf, p...)
+	for {
+		idx, skip := bytes.IndexByte(b.buf, '\n'), 1
+		if idx < 0 && len(b.buf) < maxLineSize {
+			// Wait for the rest of the line.
+			break
+		}
+		if idx < 0 || idx > maxLineSize {
+			// Line is too long, split it.
+			idx, skip = maxLineSize, 0
+		}
+
+		line := string(b.buf[:idx])
+		b.buf = b.buf[idx+skip:]
+		if err := b.appendLineLocked(line); err != nil {
+			b.logger.Error("failed to capture runtime output",
+				"err", err,
+			)
+			// Drop the rest of the output to avoid logging the same failure for each line.
+			b.buf = nil
+			break
+		}
+	}
+
+	return len(p), nil
+}
+
+func (b *Buffer) appendLineLocked(line string) error {
+	if b.size+int64(len(line))+1 > b.maxSize/2 {
+		if err := b.rotateLocked(); err != nil {
+			return err
+		}
+	}
+
+	n, err := b.f.WriteString(line + "\n")
+	b.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("runtime/logs: failed to write output: %w", err)
+	}
+
+	b.seq++
+	b.notifier.Broadcast(&entry{seq: b.seq, line: line})
+	return nil
+}
+
+type entry struct {
+	seq  uint64
+	line string
+}
+
+func (b *Buffer) rotateLocked() error {
+	if err := b.f.Close(); err != nil {
+		return fmt.Errorf("runtime/logs: failed to close segment: %w", err)
+	}
+	if err := os.Rename(filepath.Join(b.dir, currentFile), filepath.Join(b.dir, previousFile)); err != nil {
+		return fmt.Errorf("runtime/logs: failed to rotate segment: %w", err)
+	}
+	return b.openLocked()
+}
+
+func (b *Buffer) openLocked() error {
+	f, err := os.OpenFile(filepath.Join(b.dir, currentFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("runtime/logs: failed to open segment: %w", err)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("runtime/logs: failed to stat segment: %w", err)
+	}
+
+	b.f = f
+	b.size = fi.Size()
+	return nil
+}
+
+// Lines returns all retained lines of output, oldest first.
+func (b *Buffer) Lines() ([]string, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	return b.linesLocked()
+}
+
+func (b *Buffer) linesLocked() ([]string, error) {
+	var lines []string
+	for _, name := range []string{previousFile, currentFile} {
+		f, err := os.Open(filepath.Join(b.dir, name))
+		switch {
+		case err == nil:
+		case errors.Is(err, os.ErrNotExist):
+			continue
+		default:
+			return nil, fmt.Errorf("runtime/logs: failed to open segment: %w", err)
+		}
+
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, maxLineSize), 2*maxLineSize)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("runtime/logs: failed to read segment: %w", err)
+		}
+	}
+
+	return lines, nil
+}
+
+// Watch returns all retained lines of output and subscribes to new lines. Lines delivered via
+// the subscription are guaranteed to directly follow the returned lines.
+func (b *Buffer) Watch() ([]string, <-chan string, pubsub.ClosableSubscription, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	lines, err := b.linesLocked()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	lastSeq := b.seq
+
+	sub := &subscription{
+		sub:    b.notifier.Subscribe(),
+		doneCh: make(chan struct{}),
+	}
+
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+
+		for v := range sub.sub.Untyped() {
+			e := v.(*entry)
+			// Skip lines that have already been returned.
+			if e.seq <= lastSeq {
+				continue
+			}
+
+			select {
+			case ch <- e.line:
+			case <-sub.doneCh:
+				return
+			}
+		}
+	}()
+
+	return lines, ch, sub, nil
+}
+
+type subscription struct {
+	sub       *pubsub.Subscription
+	closeOnce sync.Once
+	doneCh    chan struct{}
+}
+
+// Close implements pubsub.ClosableSubscription.
+func (s *subscription) Close() {
+	s.closeOnce.Do(func() {
+		close(s.doneCh)
+		s.sub.Close()
+	})
+}
+
+// Close closes the buffer.
+func (b *Buffer) Close() error {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.f == nil {
+		return nil
+	}
+	err := b.f.Close()
+	b.f = nil
+	return err
+}
+
+// New creates a new runtime output buffer in the given directory, retaining at most roughly
+// maxSize bytes of output.
+func New(dir string, maxSize int64) (*Buffer, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("runtime/logs: maximum size must be greater than zero")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("runtime/logs: failed to create directory: %w", err)
+	}
+
+	b := &Buffer{
+		dir:      dir,
+		maxSize:  maxSize,
+		notifier: pubsub.NewBroker(false),
+		logger:   logging.GetLogger("runtime/logs"),
+	}
+	if err := b.openLocked(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
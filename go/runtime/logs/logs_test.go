@@ -0,0 +1,123 @@
+package logs
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuffer(t *testing.T) {
+	require := require.New(t)
+
+	b, err := New(t.TempDir(), 64*1024)
+	require.NoError(err, "New")
+	defer b.Close()
+
+	// Partial lines should only be recorded once complete.
+	_, err = b.Write([]byte("hello "))
+	require.NoError(err, "Write")
+	lines, err := b.Lines()
+	require.NoError(err, "Lines")
+	require.Empty(lines)
+
+	_, err = b.Write([]byte("world\nsecond line\n"))
+	require.NoError(err, "Write")
+	lines, err = b.Lines()
+	require.NoError(err, "Lines")
+	require.Equal([]string{"hello world", "second line"}, lines)
+
+	// Overly long lines should be split.
+	_, err = b.Write([]byte(strings.Repeat("a", maxLineSize+5) + "\n"))
+	require.NoError(err, "Write")
+	lines, err = b.Lines()
+	require.NoError(err, "Lines")
+	require.Len(lines, 4)
+	require.Len(lines[2], maxLineSize)
+	require.Equal("aaaaa", lines[3])
+}
+
+func TestBufferInvalidSize(t *testing.T) {
+	require := require.New(t)
+
+	_, err := New(t.TempDir(), 0)
+	require.Error(err, "New should fail with zero maximum size")
+}
+
+func TestBufferWriteFailure(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	b, err := New(dir, 64)
+	require.NoError(err, "New")
+	defer b.Close()
+
+	// Remove the directory so that rotating segments fails.
+	require.NoError(os.RemoveAll(dir), "RemoveAll")
+
+	// Failures should not be propagated to other writers.
+	var other bytes.Buffer
+	w := io.MultiWriter(b, &other)
+	for i := 0; i < 10; i++ {
+		n, err := fmt.Fprintf(w, "line %d\n", i)
+		require.NoError(err, "Write should not fail")
+		require.Equal(len(fmt.Sprintf("line %d\n", i)), n)
+	}
+	require.Equal(10, strings.Count(other.String(), "\n"), "other writers should receive all output")
+}
+
+func TestBufferRotation(t *testing.T) {
+	require := require.New(t)
+
+	b, err := New(t.TempDir(), 1024)
+	require.NoError(err, "New")
+	defer b.Close()
+
+	for i := 0; i < 200; i++ {
+		_, err = fmt.Fprintf(b, "line %d\n", i)
+		require.NoError(err, "Write")
+	}
+
+	lines, err := b.Lines()
+	require.NoError(err, "Lines")
+	require.NotEmpty(lines)
+	require.Less(len(lines), 200, "old output should be discarded")
+	require.Equal("line 199", lines[len(lines)-1])
+
+	var size int
+	for _, line := range lines {
+		size += len(line) + 1
+	}
+	require.LessOrEqual(size, 1024, "retained output should be bounded")
+}
+
+func TestBufferWatch(t *testing.T) {
+	require := require.New(t)
+
+	b, err := New(t.TempDir(), 1024)
+	require.NoError(err, "New")
+	defer b.Close()
+
+	_, err = b.Write([]byte("first\n"))
+	require.NoError(err, "Write")
+
+	lines, ch, sub, err := b.Watch()
+	require.NoError(err, "Watch")
+	defer sub.Close()
+	require.Equal([]string{"first"}, lines)
+
+	_, err = b.Write([]byte("second\n"))
+	require.NoError(err, "Write")
+
+	select {
+	case line := <-ch:
+		require.Equal("second", line)
+	case <-time.After(time.Second):
+		t.Fatalf("failed to receive line")
+	}
+}
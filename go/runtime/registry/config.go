@@ -81,6 +81,10 @@ func getConfiguredRuntimeIDs() ([]common.Namespace, error) {
 func createHistoryFactory() (history.Factory, error) {
 	var pruneFactory history.PrunerFactory
 	strategy := config.GlobalConfig.Runtime.Prune.Strategy
+	if config.GlobalConfig.Mode.IsArchive() {
+		// Archive nodes retain the complete history.
+		strategy = history.PrunerStrategyNone
+	}
 	switch strings.ToLower(strategy) {
 	case history.PrunerStrategyNone:
 		pruneFactory = history.NewNonePrunerFactory()
@@ -65,6 +65,7 @@ func (n *RuntimeHostNode) ProvisionHostedRuntimeComponent(comp *bundle.ExplodedC
 		Component:      comp,
 		MessageHandler: handler,
 		LocalConfig:    getLocalConfig(n.runtime.ID()),
+		LogSink:        n.runtime.Logs(),
 	}
 
 	rt, err := n.provisioner.NewRuntime(cfg)
@@ -7,10 +7,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"slices"
 	"sort"
 	"sync"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/beacon/hooks"
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
@@ -25,6 +28,7 @@ import (
 	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/history"
 	"github.com/oasisprotocol/oasis-core/go/runtime/localstorage"
+	"github.com/oasisprotocol/oasis-core/go/runtime/logs"
 	storageAPI "github.com/oasisprotocol/oasis-core/go/storage/api"
 )
 
@@ -33,6 +37,10 @@ const (
 	// by a single node.
 	MaxRuntimeCount = 64
 
+	// maxDefaultLogSizeBytes is the default maximum amount of captured runtime output
+	// retained on disk for each runtime.
+	maxDefaultLogSizeBytes = 4 * 1024 * 1024
+
 	// LocalStorageFile is the filename of the worker's local storage database.
 	LocalStorageFile = "worker-local-storage.badger.db"
 )
@@ -108,6 +116,9 @@ type Runtime interface {
 
 	// LocalStorage returns the per-runtime local storage.
 	LocalStorage() localstorage.LocalStorage
+
+	// Logs returns the captured output of hosted runtime components.
+	Logs() *logs.Buffer
 }
 
 type runtime struct { // nolint: maligned
@@ -124,6 +135,7 @@ type runtime struct { // nolint: maligned
 	consensus    consensus.Backend
 	storage      storageAPI.Backend
 	localStorage localstorage.LocalStorage
+	logs         *logs.Buffer
 
 	history history.History
 
@@ -160,6 +172,17 @@ func newRuntime(
 		return nil, fmt.Errorf("runtime/registry: cannot create local storage for runtime %s: %w", runtimeID, err)
 	}
 
+	// Create runtime output buffer.
+	logSize := int64(maxDefaultLogSizeBytes)
+	if size := config.GlobalConfig.Runtime.MaxLogSize; size != "" {
+		logSize = int64(config.ParseSizeInBytes(size))
+	}
+	logBuffer, err := logs.New(filepath.Join(rtDataDir, logs.DirName), logSize)
+	if err != nil {
+		localStorage.Stop()
+		return nil, fmt.Errorf("runtime/registry: cannot create log buffer for runtime %s: %w", runtimeID, err)
+	}
+
 	return &runtime{
 		startOne:                   cmSync.NewOne(),
 		id:                         runtimeID,
@@ -167,6 +190,7 @@ func newRuntime(
 		managed:                    managed,
 		consensus:                  consensus,
 		localStorage:               localStorage,
+		logs:                       logBuffer,
 		registryDescriptorCh:       make(chan struct{}),
 		registryDescriptorNotifier: pubsub.NewBroker(true),
 		activeDescriptorCh:         make(chan struct{}),
@@ -272,6 +296,11 @@ func (r *runtime) LocalStorage() localstorage.LocalStorage {
 	return r.localStorage
 }
 
+// Logs implements Runtime.
+func (r *runtime) Logs() *logs.Buffer {
+	return r.logs
+}
+
 // start starts the runtime worker.
 func (r *runtime) start() {
 	r.startOne.TryStart(r.run)
@@ -285,6 +314,9 @@ func (r *runtime) stop() {
 	// Close local storage backend.
 	r.localStorage.Stop()
 
+	// Close runtime output buffer.
+	_ = r.logs.Close()
+
 	// Close storage backend.
 	if r.storage != nil {
 		r.storage.Cleanup()
@@ -330,27 +362,11 @@ func (r *runtime) run(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
-		case epoch := <-epoCh:
+		case <-epoCh:
 			if up := r.updateActiveDescriptor(ctx); up && !activeInitialized {
 				close(r.activeDescriptorCh)
 				activeInitialized = true
 			}
-
-			// Trigger clean-up for bundles less than active version.
-			r.RLock()
-			rt := r.activeDescriptor
-			r.RUnlock()
-			if rt == nil {
-				continue
-			}
-
-			// Cleanup runtime bundles.
-			active := rt.ActiveDeployment(epoch)
-			if active == nil {
-				continue
-			}
-
-			r.bundleManager.Cleanup(rt.ID, active.Version)
 		case rt := <-regCh:
 			if !rt.ID.Equal(&r.id) {
 				continue
@@ -653,11 +669,41 @@ func (r *runtimeRegistry) Init(ctx context.Context, runtimeIDs []common.Namespac
 	return nil
 }
 
+// cleanupBundles triggers clean-up of the bundles of all runtimes that are older than the version
+// of the deployment active in the given epoch.
+func (r *runtimeRegistry) cleanupBundles(_ context.Context, epoch beacon.EpochTime) error {
+	r.RLock()
+	runtimes := make([]*runtime, 0, len(r.runtimes))
+	for _, rt := range r.runtimes {
+		runtimes = append(runtimes, rt)
+	}
+	r.RUnlock()
+
+	for _, rt := range runtimes {
+		rt.RLock()
+		desc := rt.activeDescriptor
+		rt.RUnlock()
+		if desc == nil {
+			continue
+		}
+
+		active := desc.ActiveDeployment(epoch)
+		if active == nil {
+			continue
+		}
+
+		r.bundleManager.Cleanup(desc.ID, active.Version)
+	}
+
+	return nil
+}
+
 // New creates a new runtime registry.
 func New(
 	ctx context.Context,
 	dataDir string,
 	consensus consensus.Backend,
+	epochHooks *hooks.Dispatcher,
 ) (Registry, error) {
 	// Get configured runtime IDs.
 	runtimeIDs, err := getConfiguredRuntimeIDs()
@@ -695,5 +741,10 @@ func New(
 		return nil, err
 	}
 
+	// Clean up obsolete runtime bundles on every epoch transition.
+	if err = epochHooks.Register("runtime/registry/bundles", hooks.OrderPruning, r.cleanupBundles); err != nil {
+		return nil, err
+	}
+
 	return r, nil
 }
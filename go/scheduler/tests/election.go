@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+// ElectionScenario is a deterministic committee election scenario.
+type ElectionScenario struct {
+	// Name is the name of the scenario.
+	Name string
+
+	// Beacon is the beacon value used for the election.
+	Beacon []byte
+
+	// Epoch is the epoch of the election.
+	Epoch beacon.EpochTime
+
+	// Runtime is the runtime the committee is elected for.
+	Runtime *registry.Runtime
+
+	// Nodes are the registered nodes.
+	Nodes []*node.Node
+
+	// Expected are the expected committee members in order, or nil if no committee should
+	// be elected.
+	Expected []*api.CommitteeNode
+}
+
+// ElectFunc elects the executor committee for the given scenario and returns it, or nil if no
+// committee has been elected.
+type ElectFunc func(t *testing.T, sc *ElectionScenario) *api.Committee
+
+// ElectionScenarioTests runs the given election scenarios and asserts that exactly the expected
+// committees are elected and that repeating an election yields the same committee.
+func ElectionScenarioTests(t *testing.T, elect ElectFunc, scenarios []*ElectionScenario) {
+	for _, sc := range scenarios {
+		t.Run(sc.Name, func(t *testing.T) {
+			require := require.New(t)
+
+			committee := elect(t, sc)
+			if sc.Expected == nil {
+				require.Nil(committee, "committee should not be elected")
+				return
+			}
+			require.NotNil(committee, "committee should be elected")
+			require.Equal(sc.Runtime.ID, committee.RuntimeID, "committee should be elected for the runtime")
+			require.Equal(sc.Epoch, committee.ValidFor, "committee should be valid for the epoch")
+			require.Equal(sc.Expected, committee.Members, "committee members should match")
+
+			again := elect(t, sc)
+			require.Equal(committee, again, "elections should be deterministic")
+		})
+	}
+}
+
+// ElectionFairnessTests elects committees for the given number of generated beacons and asserts
+// that all nodes are elected as workers with about the same frequency.
+//
+// The tolerance is the maximum allowed relative deviation of the number of times a node was
+// elected from the expected number of elections.
+func ElectionFairnessTests(t *testing.T, elect ElectFunc, rt *registry.Runtime, nodes []*node.Node, rounds int, tolerance float64) {
+	require := require.New(t)
+
+	elected := make(map[signature.PublicKey]int)
+	for i, b := range GenerateBeacons([]byte("ElectionFairnessTests"), rounds) {
+		committee := elect(t, &ElectionScenario{
+			Name:    fmt.Sprintf("fairness/%d", i),
+			Beacon:  b,
+			Epoch:   beacon.EpochTime(i + 1),
+			Runtime: rt,
+			Nodes:   nodes,
+		})
+		require.NotNil(committee, "committee should be elected")
+		for _, m := range committee.Members {
+			if m.Role == api.RoleWorker {
+				elected[m.PublicKey]++
+			}
+		}
+	}
+
+	expected := float64(rounds) * float64(rt.Executor.GroupSize) / float64(len(nodes))
+	for _, n := range nodes {
+		deviation := math.Abs(float64(elected[n.ID])-expected) / expected
+		require.LessOrEqual(deviation, tolerance,
+			"node %s elected %d times (expected: %.1f)", n.ID, elected[n.ID], expected,
+		)
+	}
+}
+
+// GenerateBeacons deterministically generates the given number of beacon values.
+func GenerateBeacons(seed []byte, count int) [][]byte {
+	beacons := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		h := sha512.Sum512_256(append([]byte(fmt.Sprintf("beacon/%d/", i)), seed...))
+		beacons = append(beacons, h[:])
+	}
+	return beacons
+}
+
+// GenerateNodes deterministically generates the given number of compute nodes, each owned by
+// its own entity, that support the given runtime.
+func GenerateNodes(seed []byte, count int, rt *registry.Runtime) []*node.Node {
+	var rtVersion version.Version
+	if len(rt.Deployments) > 0 {
+		rtVersion = rt.Deployments[0].Version
+	}
+
+	nodes := make([]*node.Node, 0, count)
+	for i := 0; i < count; i++ {
+		nodeSigner := memorySigner.NewTestSigner(fmt.Sprintf("%s/node/%d", seed, i))
+		entitySigner := memorySigner.NewTestSigner(fmt.Sprintf("%s/entity/%d", seed, i))
+		nodes = append(nodes, &node.Node{
+			Versioned: cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+			ID:        nodeSigner.Public(),
+			EntityID:  entitySigner.Public(),
+			Runtimes:  []*node.Runtime{{ID: rt.ID, Version: rtVersion}},
+			Roles:     node.RoleComputeWorker,
+		})
+	}
+	return nodes
+}
+
+// NewElectionRuntime returns a compute runtime descriptor with the given executor committee sizes
+// suitable for election scenarios.
+func NewElectionRuntime(seed []byte, groupSize, groupBackupSize uint16) *registry.Runtime {
+	return &registry.Runtime{
+		Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+		ID:        common.NewTestNamespaceFromSeed(seed, 0),
+		Kind:      registry.KindCompute,
+		Executor: registry.ExecutorParameters{
+			GroupSize:       groupSize,
+			GroupBackupSize: groupBackupSize,
+		},
+		Deployments: []*registry.VersionInfo{
+			{},
+		},
+	}
+}
@@ -8,12 +8,21 @@ import (
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
 )
 
 var (
 	// serviceName is the gRPC service name.
 	serviceName = cmnGrpc.NewServiceName("Staking")
 
+	// ServiceCapabilities are the capabilities of the staking gRPC service.
+	ServiceCapabilities = cmnGrpc.Capabilities{
+		Version: version.ConsensusProtocol,
+	}
+
+	// methodGetCapabilities is the GetCapabilities method.
+	methodGetCapabilities, descGetCapabilities = serviceName.NewCapabilitiesMethod(ServiceCapabilities)
+
 	// methodTokenSymbol is the TokenSymbol method.
 	methodTokenSymbol = serviceName.NewMethod("TokenSymbol", int64(0))
 	// methodTokenValueExponent is the TokenValueExponent method.
@@ -63,6 +72,7 @@ var (
 		ServiceName: string(serviceName),
 		HandlerType: (*Backend)(nil),
 		Methods: []grpc.MethodDesc{
+			descGetCapabilities,
 			{
 				MethodName: methodTokenSymbol.ShortName(),
 				Handler:    handlerTokenSymbol,
@@ -854,5 +864,14 @@ func (c *Client) WatchEvents(ctx context.Context) (<-chan *Event, pubsub.Closabl
 	return ch, sub, nil
 }
 
+// GetCapabilities returns the capabilities of the remote staking service.
+func (c *Client) GetCapabilities(ctx context.Context) (*cmnGrpc.Capabilities, error) {
+	var rsp cmnGrpc.Capabilities
+	if err := c.conn.Invoke(ctx, methodGetCapabilities.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *Client) Cleanup() {
 }
@@ -39,6 +39,8 @@ var (
 	ErrUnsupported = errors.New(ModuleName, 4, "storage: method not supported by backend")
 	// ErrLimitReached means that a configured limit has been reached.
 	ErrLimitReached = errors.New(ModuleName, 5, "storage: limit reached")
+	// ErrStatementExpired is the error returned when an availability statement has expired.
+	ErrStatementExpired = errors.New(ModuleName, 6, "storage: availability statement expired")
 
 	// The following errors are reimports from NodeDB.
 
@@ -249,6 +251,26 @@ type WrappedLocalBackend interface {
 	Unwrap() LocalBackend
 }
 
+// ApplyStats are the aggregated payload statistics of successful Apply calls during a single
+// UTC day.
+type ApplyStats struct {
+	// Day is the UTC day in YYYY-MM-DD format.
+	Day string `json:"day"`
+	// Applies is the number of applied write logs.
+	Applies uint64 `json:"applies"`
+	// Entries is the total number of applied write log entries.
+	Entries uint64 `json:"entries"`
+	// Bytes is the total size of applied write log keys and values in bytes.
+	Bytes uint64 `json:"bytes"`
+}
+
+// ApplyStatsProvider is an interface implemented by local storage backends that account for
+// the payload of applied write logs.
+type ApplyStatsProvider interface {
+	// ApplyStats returns the daily Apply payload statistics, oldest first.
+	ApplyStats() []ApplyStats
+}
+
 // ClientBackend is a storage client backend implementation.
 type ClientBackend interface {
 	Backend
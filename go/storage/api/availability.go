@@ -0,0 +1,173 @@
+package api
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+// AvailabilityStatementSignatureContext is the context used for signing availability statements.
+var AvailabilityStatementSignatureContext = signature.NewContext(
+	"oasis-core/storage: availability statement",
+	signature.WithChainSeparation(),
+	signature.WithDynamicSuffix(" for runtime ", common.NamespaceHexSize),
+)
+
+// AvailabilityStatement is a statement that the full subtrees of all the storage roots of the
+// given runtime round are available in storage.
+type AvailabilityStatement struct {
+	// Namespace is the runtime namespace.
+	Namespace common.Namespace `json:"namespace"`
+
+	// Round is the runtime round.
+	Round uint64 `json:"round"`
+
+	// Roots are the storage roots of the runtime round.
+	Roots []Root `json:"roots"`
+
+	// Expiration is the epoch at which the statement expires (0 = never).
+	//
+	// Expiring statements can't be replayed by nodes that have since been deregistered.
+	Expiration beacon.EpochTime `json:"expiration,omitempty"`
+
+	// Roles are the roles the signing node was registered with when making the statement
+	// (empty if the node was not registered).
+	Roles node.RolesMask `json:"roles,omitempty"`
+}
+
+// ValidateBasic performs basic availability statement validity checks.
+func (s *AvailabilityStatement) ValidateBasic() error {
+	if len(s.Roots) == 0 {
+		return fmt.Errorf("no roots")
+	}
+	for _, root := range s.Roots {
+		if !root.Namespace.Equal(&s.Namespace) {
+			return fmt.Errorf("root %s has a different namespace", root)
+		}
+		if root.Version != s.Round {
+			return fmt.Errorf("root %s has a different round", root)
+		}
+	}
+	if s.Roles&node.RoleReserved != 0 {
+		return fmt.Errorf("invalid roles")
+	}
+	return nil
+}
+
+// IsExpired returns true iff the statement has expired at the given epoch.
+func (s *AvailabilityStatement) IsExpired(epoch beacon.EpochTime) bool {
+	return s.Expiration != 0 && epoch >= s.Expiration
+}
+
+// SignedAvailabilityStatement is an availability statement signed by a storage node.
+type SignedAvailabilityStatement struct {
+	// NodeID is the public key of the node that made the statement.
+	NodeID signature.PublicKey `json:"node_id"`
+
+	// Statement is the availability statement.
+	Statement AvailabilityStatement `json:"statement"`
+
+	// Signature is the availability statement signature.
+	Signature signature.RawSignature `json:"sig"`
+}
+
+// Sign signs the availability statement and sets the signature.
+func (s *SignedAvailabilityStatement) Sign(signer signature.Signer) error {
+	if !s.NodeID.Equal(signer.Public()) {
+		return fmt.Errorf("node ID does not match signer (ID: %s signer: %s)", s.NodeID, signer.Public())
+	}
+
+	sigCtx, err := AvailabilityStatementSignatureContext.WithSuffix(s.Statement.Namespace.String())
+	if err != nil {
+		return fmt.Errorf("signature context error: %w", err)
+	}
+	sig, err := signature.Sign(signer, sigCtx, cbor.Marshal(s.Statement))
+	if err != nil {
+		return err
+	}
+	s.Signature = sig.Signature
+	return nil
+}
+
+// Verify verifies that the statement is well-formed, has not expired at the given epoch and that
+// its signature is valid.
+func (s *SignedAvailabilityStatement) Verify(epoch beacon.EpochTime) error {
+	if err := s.Statement.ValidateBasic(); err != nil {
+		return fmt.Errorf("storage: malformed availability statement: %w", err)
+	}
+	if s.Statement.IsExpired(epoch) {
+		return ErrStatementExpired
+	}
+
+	sigCtx, err := AvailabilityStatementSignatureContext.WithSuffix(s.Statement.Namespace.String())
+	if err != nil {
+		return fmt.Errorf("storage: signature context error: %w", err)
+	}
+	if !s.NodeID.Verify(sigCtx, cbor.Marshal(s.Statement), s.Signature[:]) {
+		return fmt.Errorf("storage: availability statement signature verification failed")
+	}
+	return nil
+}
+
+// AvailabilitySampleKeys derives the given number of pseudo-random keys from the given seed.
+//
+// Looking up a pseudo-random key traverses a pseudo-random path from the root of the tree, so
+// the keys can be used to sample the availability of tree nodes. The seed should be chosen by
+// the auditor so that the sampled paths cannot be predicted by the audited node.
+func AvailabilitySampleKeys(seed hash.Hash, samples int) [][]byte {
+	keys := make([][]byte, 0, samples)
+	for i := 0; i < samples; i++ {
+		var index [8]byte
+		binary.BigEndian.PutUint64(index[:], uint64(i))
+		key := hash.NewFromBytes(seed[:], index[:])
+		keys = append(keys, key[:])
+	}
+	return keys
+}
+
+// VerifyAvailability audits the given availability statement by looking up pseudo-random keys
+// derived from the seed in every non-empty root of the statement via the given read syncer,
+// which is usually a client of the storage node that made the statement.
+//
+// All proofs returned by the read syncer are verified against the roots of the statement and
+// an error is returned in case any path cannot be fetched or verified.
+func VerifyAvailability(ctx context.Context, rs syncer.ReadSyncer, statement *AvailabilityStatement, seed hash.Hash, samples int) error {
+	if err := statement.ValidateBasic(); err != nil {
+		return fmt.Errorf("storage: malformed availability statement: %w", err)
+	}
+
+	keys := AvailabilitySampleKeys(seed, samples)
+	for _, root := range statement.Roots {
+		if root.Hash.IsEmpty() {
+			continue
+		}
+
+		if err := verifyRootAvailability(ctx, rs, root, keys); err != nil {
+			return fmt.Errorf("storage: root %s not available: %w", root, err)
+		}
+	}
+	return nil
+}
+
+func verifyRootAvailability(ctx context.Context, rs syncer.ReadSyncer, root Root, keys [][]byte) error {
+	// Each lookup goes through a fresh tree so that every sampled path is fetched in full from
+	// the read syncer instead of being partially served from the cache.
+	for _, key := range keys {
+		tree := mkvs.NewWithRoot(rs, nil, root)
+		_, err := tree.Get(ctx, key)
+		tree.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
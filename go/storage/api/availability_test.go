@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	genesisTestHelpers "github.com/oasisprotocol/oasis-core/go/genesis/tests"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+type failingReadSyncer struct {
+	syncer.ReadSyncer
+}
+
+func (rs *failingReadSyncer) SyncGet(context.Context, *GetRequest) (*ProofResponse, error) {
+	return nil, ErrNodeNotFound
+}
+
+func TestAvailability(t *testing.T) {
+	require := require.New(t)
+
+	genesisTestHelpers.SetTestChainContext()
+
+	ctx := context.Background()
+	ns := common.NewTestNamespaceFromSeed([]byte("storage availability test"), 0)
+	round := uint64(42)
+
+	tree := mkvs.New(nil, nil, RootTypeState)
+	defer tree.Close()
+	for i := 0; i < 100; i++ {
+		err := tree.Insert(ctx, []byte(fmt.Sprintf("key %d", i)), []byte(fmt.Sprintf("value %d", i)))
+		require.NoError(err, "Insert")
+	}
+	_, rootHash, err := tree.Commit(ctx, ns, round)
+	require.NoError(err, "Commit")
+
+	var emptyRoot Root
+	emptyRoot.Empty()
+	emptyRoot.Namespace = ns
+	emptyRoot.Version = round
+	emptyRoot.Type = RootTypeIO
+
+	const epoch = beacon.EpochTime(10)
+	signer := memorySigner.NewTestSigner("storage availability test signer")
+	signed := SignedAvailabilityStatement{
+		NodeID: signer.Public(),
+		Statement: AvailabilityStatement{
+			Namespace: ns,
+			Round:     round,
+			Roots: []Root{
+				emptyRoot,
+				{Namespace: ns, Version: round, Type: RootTypeState, Hash: rootHash},
+			},
+		},
+	}
+	err = signed.Sign(signer)
+	require.NoError(err, "Sign")
+	err = signed.Verify(epoch)
+	require.NoError(err, "Verify")
+
+	seed := hash.NewFromBytes([]byte("seed"))
+	keys := AvailabilitySampleKeys(seed, 16)
+	require.Len(keys, 16)
+	require.Equal(keys, AvailabilitySampleKeys(seed, 16), "sample keys should be deterministic")
+	require.NotEqual(keys, AvailabilitySampleKeys(hash.NewFromBytes([]byte("other seed")), 16))
+
+	err = VerifyAvailability(ctx, tree, &signed.Statement, seed, 16)
+	require.NoError(err, "VerifyAvailability")
+
+	err = VerifyAvailability(ctx, &failingReadSyncer{}, &signed.Statement, seed, 16)
+	require.Error(err, "VerifyAvailability should fail when paths are not available")
+
+	// Tampering with the statement should invalidate the signature.
+	signed.Statement.Roots[1].Hash = hash.NewFromBytes([]byte("other root"))
+	require.Error(signed.Verify(epoch), "Verify should fail for a tampered statement")
+
+	// Proofs not matching the roots of the statement should be rejected.
+	err = VerifyAvailability(ctx, tree, &signed.Statement, seed, 16)
+	require.Error(err, "VerifyAvailability should fail for a different root")
+
+	// Roots of other rounds should be rejected.
+	signed.Statement.Roots[1].Version = round + 1
+	require.Error(signed.Verify(epoch), "Verify should fail for roots of other rounds")
+
+	// Expired statements should be rejected.
+	signed.Statement.Roots[1].Version = round
+	signed.Statement.Expiration = epoch + 1
+	signed.Statement.Roles = node.RoleComputeWorker
+	err = signed.Sign(signer)
+	require.NoError(err, "Sign")
+	require.NoError(signed.Verify(epoch), "Verify")
+	require.ErrorIs(signed.Verify(epoch+1), ErrStatementExpired)
+
+	// Expiration and roles should be covered by the signature.
+	signed.Statement.Expiration = epoch + 2
+	require.Error(signed.Verify(epoch+1), "Verify should fail for a tampered expiration")
+	signed.Statement.Expiration = epoch + 1
+	signed.Statement.Roles = node.RoleValidator
+	require.Error(signed.Verify(epoch), "Verify should fail for tampered roles")
+}
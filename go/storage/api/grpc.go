@@ -10,6 +10,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
 )
@@ -20,6 +21,14 @@ var (
 	// ServiceName is the gRPC service name.
 	ServiceName = cmnGrpc.NewServiceName("Storage")
 
+	// ServiceCapabilities are the capabilities of the storage gRPC service.
+	ServiceCapabilities = cmnGrpc.Capabilities{
+		Version: version.RuntimeCommitteeProtocol,
+	}
+
+	// MethodGetCapabilities is the GetCapabilities method.
+	MethodGetCapabilities, descGetCapabilities = ServiceName.NewCapabilitiesMethod(ServiceCapabilities)
+
 	// MethodSyncGet is the SyncGet method.
 	MethodSyncGet = ServiceName.NewMethod("SyncGet", GetRequest{}).
 			WithNamespaceExtractor(func(_ context.Context, req interface{}) (common.Namespace, error) {
@@ -65,6 +74,7 @@ var (
 		ServiceName: string(ServiceName),
 		HandlerType: (*Backend)(nil),
 		Methods: []grpc.MethodDesc{
+			descGetCapabilities,
 			{
 				MethodName: MethodSyncGet.ShortName(),
 				Handler:    handlerSyncGet,
@@ -401,6 +411,15 @@ func (c *Client) GetCheckpointChunk(ctx context.Context, chunk *checkpoint.Chunk
 	}
 }
 
+// GetCapabilities returns the capabilities of the remote storage service.
+func (c *Client) GetCapabilities(ctx context.Context) (*cmnGrpc.Capabilities, error) {
+	var rsp cmnGrpc.Capabilities
+	if err := c.conn.Invoke(ctx, MethodGetCapabilities.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *Client) Cleanup() {
 }
 
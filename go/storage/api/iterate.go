@@ -0,0 +1,43 @@
+package api
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+// IterateRange calls fn for all key/value pairs with keys in the range [start, end) under the
+// given root, in key order, fetching the tree via the given read syncer.
+//
+// Tree nodes are fetched using SyncIterate, prefetching up to the given number of items in each
+// request, so scanning a range takes far fewer round trips than looking up individual keys. All
+// proofs returned by the read syncer are verified against the root. A nil end key iterates until
+// the end of the tree.
+//
+// Iteration stops at the first error returned by fn and the error is returned.
+func IterateRange(
+	ctx context.Context,
+	rs syncer.ReadSyncer,
+	root Root,
+	start, end []byte,
+	prefetch uint16,
+	fn func(key, value []byte) error,
+) error {
+	tree := mkvs.NewWithRoot(rs, nil, root)
+	defer tree.Close()
+
+	it := tree.NewIterator(ctx, mkvs.IteratorPrefetch(prefetch))
+	defer it.Close()
+
+	for it.Seek(start); it.Valid(); it.Next() {
+		if end != nil && bytes.Compare(it.Key(), end) >= 0 {
+			break
+		}
+		if err := fn(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
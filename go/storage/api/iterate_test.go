@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+type countingReadSyncer struct {
+	syncer.ReadSyncer
+
+	iterateRequests int
+}
+
+func (rs *countingReadSyncer) SyncIterate(ctx context.Context, request *IterateRequest) (*ProofResponse, error) {
+	rs.iterateRequests++
+	return rs.ReadSyncer.SyncIterate(ctx, request)
+}
+
+func TestIterateRange(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	ns := common.NewTestNamespaceFromSeed([]byte("storage iterate range test"), 0)
+
+	tree := mkvs.New(nil, nil, RootTypeState)
+	defer tree.Close()
+	for i := 0; i < 100; i++ {
+		err := tree.Insert(ctx, []byte(fmt.Sprintf("key %02d", i)), []byte(fmt.Sprintf("value %02d", i)))
+		require.NoError(err, "Insert")
+	}
+	_, rootHash, err := tree.Commit(ctx, ns, 1)
+	require.NoError(err, "Commit")
+	root := Root{Namespace: ns, Version: 1, Type: RootTypeState, Hash: rootHash}
+
+	rs := &countingReadSyncer{ReadSyncer: tree}
+	var keys []string
+	err = IterateRange(ctx, rs, root, []byte("key 10"), []byte("key 60"), 20, func(key, value []byte) error {
+		require.Equal("value"+string(key[3:]), string(value), "value should match key")
+		keys = append(keys, string(key))
+		return nil
+	})
+	require.NoError(err, "IterateRange")
+	require.Len(keys, 50, "all keys in range should be returned")
+	require.Equal("key 10", keys[0], "range start should be inclusive")
+	require.Equal("key 59", keys[len(keys)-1], "range end should be exclusive")
+	require.Less(rs.iterateRequests, len(keys), "items should be prefetched")
+
+	// Nil end key should iterate until the end of the tree.
+	var count int
+	err = IterateRange(ctx, tree, root, []byte("key 90"), nil, 10, func([]byte, []byte) error {
+		count++
+		return nil
+	})
+	require.NoError(err, "IterateRange")
+	require.Equal(10, count, "all keys until the end should be returned")
+
+	// Errors returned by the callback should stop iteration.
+	errStop := errors.New("stop")
+	count = 0
+	err = IterateRange(ctx, tree, root, nil, nil, 10, func([]byte, []byte) error {
+		count++
+		return errStop
+	})
+	require.ErrorIs(err, errStop)
+	require.Equal(1, count, "iteration should stop at the first error")
+}
@@ -41,11 +41,30 @@ var (
 		[]string{"call"},
 	)
 
+	storageApplyEntries = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "oasis_storage_apply_write_log_entries",
+			Help:    "Number of write log entries per successful Apply.",
+			Buckets: prometheus.ExponentialBuckets(1, 4, 10),
+		},
+		[]string{"runtime"},
+	)
+	storageApplyBytes = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "oasis_storage_apply_write_log_bytes",
+			Help:    "Size of write log keys and values per successful Apply (bytes).",
+			Buckets: prometheus.ExponentialBuckets(256, 4, 10),
+		},
+		[]string{"runtime"},
+	)
+
 	storageCollectors = []prometheus.Collector{
 		storageFailures,
 		storageCalls,
 		storageLatency,
 		storageValueSize,
+		storageApplyEntries,
+		storageApplyBytes,
 	}
 
 	labelApply           = prometheus.Labels{"call": "apply"}
@@ -113,11 +132,47 @@ func (w *metricsWrapper) SyncIterate(ctx context.Context, request *IterateReques
 	return res, err
 }
 
+// maxApplyStatsDays is the number of days for which Apply payload statistics are retained.
+const maxApplyStatsDays = 7
+
+// applyAccounting aggregates the payload of successful Apply calls per UTC day.
+type applyAccounting struct {
+	sync.Mutex
+
+	days []ApplyStats
+}
+
+func (a *applyAccounting) record(now time.Time, entries, bytes int) {
+	a.Lock()
+	defer a.Unlock()
+
+	day := now.UTC().Format(time.DateOnly)
+	if n := len(a.days); n == 0 || a.days[n-1].Day != day {
+		a.days = append(a.days, ApplyStats{Day: day})
+		if len(a.days) > maxApplyStatsDays {
+			a.days = a.days[len(a.days)-maxApplyStatsDays:]
+		}
+	}
+	stats := &a.days[len(a.days)-1]
+	stats.Applies++
+	stats.Entries += uint64(entries)
+	stats.Bytes += uint64(bytes)
+}
+
+func (a *applyAccounting) stats() []ApplyStats {
+	a.Lock()
+	defer a.Unlock()
+
+	return append([]ApplyStats(nil), a.days...)
+}
+
 type localMetricsWrapper struct {
 	metricsWrapper
+
+	accounting applyAccounting
 }
 
-func (w *metricsWrapper) Apply(ctx context.Context, request *ApplyRequest) error {
+func (w *localMetricsWrapper) Apply(ctx context.Context, request *ApplyRequest) error {
 	start := time.Now()
 	err := w.Backend.(LocalBackend).Apply(ctx, request)
 	storageLatency.With(labelApply).Observe(time.Since(start).Seconds())
@@ -133,9 +188,19 @@ func (w *metricsWrapper) Apply(ctx context.Context, request *ApplyRequest) error
 	}
 
 	storageCalls.With(labelApply).Inc()
+
+	runtimeLabel := prometheus.Labels{"runtime": request.Namespace.String()}
+	storageApplyEntries.With(runtimeLabel).Observe(float64(len(request.WriteLog)))
+	storageApplyBytes.With(runtimeLabel).Observe(float64(size))
+	w.accounting.record(time.Now(), len(request.WriteLog), size)
 	return nil
 }
 
+// ApplyStats implements ApplyStatsProvider.
+func (w *localMetricsWrapper) ApplyStats() []ApplyStats {
+	return w.accounting.stats()
+}
+
 func (w *localMetricsWrapper) Checkpointer() checkpoint.CreateRestorer {
 	return w.Backend.(LocalBackend).Checkpointer()
 }
@@ -165,7 +230,7 @@ func NewMetricsWrapper(base Backend) Backend {
 
 	switch base.(type) {
 	case LocalBackend:
-		return &localMetricsWrapper{w}
+		return &localMetricsWrapper{metricsWrapper: w}
 	case ClientBackend:
 		return &clientMetricsWrapper{w}
 	default:
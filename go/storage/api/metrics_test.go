@@ -0,0 +1,31 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyAccounting(t *testing.T) {
+	require := require.New(t)
+
+	var a applyAccounting
+	require.Empty(a.stats())
+
+	day := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	a.record(day, 2, 100)
+	a.record(day.Add(90*time.Minute), 3, 50)
+	require.Equal([]ApplyStats{
+		{Day: "2024-01-01", Applies: 1, Entries: 2, Bytes: 100},
+		{Day: "2024-01-02", Applies: 1, Entries: 3, Bytes: 50},
+	}, a.stats())
+
+	// Only the most recent days should be retained.
+	for i := 2; i < 2*maxApplyStatsDays; i++ {
+		a.record(day.Add(time.Duration(i)*24*time.Hour), 1, 1)
+	}
+	stats := a.stats()
+	require.Len(stats, maxApplyStatsDays)
+	require.Equal(day.Add(time.Duration(2*maxApplyStatsDays-1)*24*time.Hour).Format(time.DateOnly), stats[len(stats)-1].Day)
+}
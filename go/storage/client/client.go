@@ -0,0 +1,307 @@
+// Package client implements a client for the public storage endpoints of registered storage
+// nodes.
+//
+// This package is used by external consumers of the public storage gRPC endpoint and by debug
+// commands. Storage nodes themselves replicate storage over libp2p where peers are authenticated
+// by their P2P keys.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	cmnTLS "github.com/oasisprotocol/oasis-core/go/common/crypto/tls"
+	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+// registryLookupTimeout is the timeout of node descriptor lookups during TLS handshakes.
+const registryLookupTimeout = 10 * time.Second
+
+var (
+	// ErrCertificateMismatch is the error returned when the TLS certificate presented by a storage
+	// node does not match the TLS public key published in the node's registry descriptor.
+	ErrCertificateMismatch = errors.New("storage/client: TLS certificate does not match node descriptor")
+
+	// ErrNotStorageNode is the error returned when the node's registry descriptor does not
+	// include the public storage RPC role.
+	ErrNotStorageNode = errors.New("storage/client: node is not a registered storage node")
+
+	// ErrInvalidProof is the error returned when a storage node responds with a proof that does
+	// not verify against the requested root.
+	ErrInvalidProof = errors.New("storage/client: invalid proof")
+)
+
+// Client is a client for the public storage endpoint of a registered storage node.
+//
+// All operations are performed according to their call policies, see DefaultCallPolicies.
+type Client struct {
+	*api.Client
+
+	conn     *grpc.ClientConn
+	policies map[Operation]CallPolicy
+}
+
+// Close closes the connection to the storage node.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// SyncGet fetches a single key and returns the corresponding proof.
+//
+// The proof is verified against the requested root and ErrInvalidProof is returned in case the
+// verification fails.
+func (c *Client) SyncGet(ctx context.Context, request *api.GetRequest) (*api.ProofResponse, error) {
+	var rsp *api.ProofResponse
+	_, err := c.call(ctx, OpSyncGet, false, func(ctx context.Context) error {
+		var cerr error
+		if rsp, cerr = c.Client.SyncGet(ctx, request); cerr != nil {
+			return cerr
+		}
+		return verifyProof(ctx, &request.Tree, &rsp.Proof)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// SyncGetPrefixes fetches all keys under the given prefixes and returns the corresponding proofs.
+//
+// The proof is verified against the requested root and ErrInvalidProof is returned in case the
+// verification fails.
+func (c *Client) SyncGetPrefixes(ctx context.Context, request *api.GetPrefixesRequest) (*api.ProofResponse, error) {
+	var rsp *api.ProofResponse
+	_, err := c.call(ctx, OpSyncGetPrefixes, false, func(ctx context.Context) error {
+		var cerr error
+		if rsp, cerr = c.Client.SyncGetPrefixes(ctx, request); cerr != nil {
+			return cerr
+		}
+		return verifyProof(ctx, &request.Tree, &rsp.Proof)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// SyncIterate seeks to a given key and then fetches the specified number of following items
+// based on key iteration order.
+//
+// The proof is verified against the requested root and ErrInvalidProof is returned in case the
+// verification fails.
+func (c *Client) SyncIterate(ctx context.Context, request *api.IterateRequest) (*api.ProofResponse, error) {
+	var rsp *api.ProofResponse
+	_, err := c.call(ctx, OpSyncIterate, false, func(ctx context.Context) error {
+		var cerr error
+		if rsp, cerr = c.Client.SyncIterate(ctx, request); cerr != nil {
+			return cerr
+		}
+		return verifyProof(ctx, &request.Tree, &rsp.Proof)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// GetCheckpoints returns a list of checkpoint metadata for all known checkpoints.
+func (c *Client) GetCheckpoints(ctx context.Context, request *checkpoint.GetCheckpointsRequest) ([]*checkpoint.Metadata, error) {
+	var rsp []*checkpoint.Metadata
+	_, err := c.call(ctx, OpGetCheckpoints, false, func(ctx context.Context) error {
+		var cerr error
+		rsp, cerr = c.Client.GetCheckpoints(ctx, request)
+		return cerr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// GetCheckpointChunk fetches a specific chunk from an existing checkpoint.
+//
+// Failed attempts are only retried if no part of the chunk has been written yet.
+func (c *Client) GetCheckpointChunk(ctx context.Context, chunk *checkpoint.ChunkMetadata, w io.Writer) error {
+	cw := &countingWriter{Writer: w}
+	_, err := c.call(ctx, OpGetCheckpointChunk, false, func(ctx context.Context) error {
+		cerr := c.Client.GetCheckpointChunk(ctx, chunk, cw)
+		if cerr != nil && cw.written > 0 {
+			return backoff.Permanent(cerr)
+		}
+		return cerr
+	})
+	return err
+}
+
+// GetDiff returns an iterator of write log entries that must be applied to get from the first
+// given root to the second one.
+//
+// Only establishing the stream is retried. The call policy timeout covers receiving the complete
+// write log.
+func (c *Client) GetDiff(ctx context.Context, request *api.GetDiffRequest) (api.WriteLogIterator, error) {
+	var it api.WriteLogIterator
+	cancel, err := c.call(ctx, OpGetDiff, true, func(ctx context.Context) error {
+		var cerr error
+		it, cerr = c.Client.GetDiff(ctx, request)
+		return cerr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &cancelingIterator{WriteLogIterator: it, cancel: cancel}, nil
+}
+
+// verifyProof verifies a proof returned for a request against the given tree.
+//
+// The proof must either be for the root of the tree or, when the caller specified its position
+// in the tree, for the subtree rooted at that position.
+func verifyProof(ctx context.Context, tree *syncer.TreeID, proof *syncer.Proof) error {
+	expectedRoot := tree.Root.Hash
+	if proof.UntrustedRoot.Equal(&tree.Position) {
+		expectedRoot = tree.Position
+	}
+
+	var pv syncer.ProofVerifier
+	if _, err := pv.VerifyProof(ctx, expectedRoot, proof); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+	return nil
+}
+
+// registryCreds are client transport credentials that only accept server certificates signed by
+// the TLS public key published in the node's current registry descriptor.
+type registryCreds struct {
+	credentials.TransportCredentials
+
+	mu      *sync.Mutex
+	lastErr *error
+}
+
+func (c *registryCreds) ClientHandshake(ctx context.Context, authority string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	conn, info, err := c.TransportCredentials.ClientHandshake(ctx, authority, rawConn)
+	if errors.Is(err, cmnTLS.ErrBadPublicKey) {
+		err = fmt.Errorf("%w: %w", ErrCertificateMismatch, err)
+	}
+
+	c.mu.Lock()
+	*c.lastErr = err
+	c.mu.Unlock()
+
+	return conn, info, err
+}
+
+func (c *registryCreds) Clone() credentials.TransportCredentials {
+	return &registryCreds{
+		TransportCredentials: c.TransportCredentials.Clone(),
+		mu:                   c.mu,
+		lastErr:              c.lastErr,
+	}
+}
+
+func (c *registryCreds) handshakeErr() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return *c.lastErr
+}
+
+func newRegistryCreds(reg registry.Backend, nodeID signature.PublicKey) (*registryCreds, error) {
+	creds, err := cmnGrpc.NewClientCreds(&cmnGrpc.ClientOptions{
+		CommonName: identity.CommonName,
+		GetServerPubKeys: func() (map[signature.PublicKey]bool, error) {
+			// Look up the descriptor on every handshake so that TLS key rotations are honored.
+			ctx, cancel := context.WithTimeout(context.Background(), registryLookupTimeout)
+			defer cancel()
+
+			n, err := reg.GetNode(ctx, &registry.IDQuery{ID: nodeID, Height: consensus.HeightLatest})
+			if err != nil {
+				return nil, fmt.Errorf("storage/client: failed to look up node descriptor: %w", err)
+			}
+			if !n.HasRoles(node.RoleStorageRPC) {
+				return nil, ErrNotStorageNode
+			}
+			return map[signature.PublicKey]bool{
+				n.TLS.PubKey: true,
+			}, nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	return &registryCreds{
+		TransportCredentials: creds,
+		mu:                   new(sync.Mutex),
+		lastErr:              &lastErr,
+	}, nil
+}
+
+// Dial connects to the public storage endpoint of the given registered storage node.
+//
+// The TLS certificate presented by the node must be signed by the TLS public key published in
+// the node's registry descriptor, otherwise ErrCertificateMismatch is returned. Nodes without the
+// storage RPC role are rejected with ErrNotStorageNode. The certificate is verified against the
+// current descriptor on every (re)connect.
+func Dial(ctx context.Context, reg registry.Backend, nodeID signature.PublicKey, address string, opts ...Option) (*Client, error) {
+	creds, err := newRegistryCreds(reg, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("storage/client: failed to create TLS credentials: %w", err)
+	}
+
+	conn, err := cmnGrpc.Dial(address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("storage/client: failed to dial node: %w", err)
+	}
+
+	// Connect eagerly so that certificate mismatches are reported immediately.
+	conn.Connect()
+	for {
+		state := conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			c := &Client{
+				Client:   api.NewClient(conn),
+				conn:     conn,
+				policies: make(map[Operation]CallPolicy, len(DefaultCallPolicies)),
+			}
+			for op, policy := range DefaultCallPolicies {
+				c.policies[op] = policy
+			}
+			for _, opt := range opts {
+				opt(c)
+			}
+			return c, nil
+		case connectivity.TransientFailure:
+			conn.Close()
+			if err = creds.handshakeErr(); err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("storage/client: failed to connect to node")
+		default:
+		}
+
+		if !conn.WaitForStateChange(ctx, state) {
+			conn.Close()
+			return nil, ctx.Err()
+		}
+	}
+}
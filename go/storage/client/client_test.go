@@ -0,0 +1,238 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	dbApi "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	badgerDb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
+	mkvsNode "github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+type mockRegistry struct {
+	registry.Backend
+
+	tlsPubKey signature.PublicKey
+	roles     node.RolesMask
+
+	// tlsPubKeys are the TLS public keys of individual nodes, overriding tlsPubKey.
+	tlsPubKeys map[signature.PublicKey]signature.PublicKey
+
+	// expirations is the node expirations notifier, if node expirations can be watched.
+	expirations *pubsub.Broker
+}
+
+func (r *mockRegistry) WatchNodeExpirations(context.Context) (<-chan *registry.NodeExpirations, pubsub.ClosableSubscription, error) {
+	if r.expirations == nil {
+		return nil, nil, fmt.Errorf("not supported")
+	}
+
+	typedCh := make(chan *registry.NodeExpirations)
+	sub := r.expirations.Subscribe()
+	sub.Unwrap(typedCh)
+
+	return typedCh, sub, nil
+}
+
+func (r *mockRegistry) GetNode(_ context.Context, query *registry.IDQuery) (*node.Node, error) {
+	tlsPubKey := r.tlsPubKey
+	if pk, ok := r.tlsPubKeys[query.ID]; ok {
+		tlsPubKey = pk
+	}
+	return &node.Node{
+		ID:    query.ID,
+		TLS:   node.TLSInfo{PubKey: tlsPubKey},
+		Roles: r.roles,
+	}, nil
+}
+
+// startTestServer starts a gRPC server with the given identity on a random local port and returns
+// its address. If a storage backend is given, it is served as the public storage service.
+func startTestServer(t *testing.T, ident *identity.Identity, backend api.Backend) string {
+	server, err := cmnGrpc.NewServer(&cmnGrpc.ServerConfig{
+		Name:     "storage-public",
+		Identity: ident,
+	})
+	require.NoError(t, err, "NewServer")
+	if backend != nil {
+		api.RegisterService(server.Server(), backend)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "Listen")
+	go func() {
+		_ = server.Server().Serve(ln)
+	}()
+	t.Cleanup(server.Server().Stop)
+
+	return ln.Addr().String()
+}
+
+func TestDial(t *testing.T) {
+	require := require.New(t)
+
+	ident, err := identity.LoadOrGenerate(t.TempDir(), memorySigner.NewFactory())
+	require.NoError(err, "LoadOrGenerate")
+
+	address := startTestServer(t, ident, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	nodeID := ident.NodeSigner.Public()
+
+	// Certificate matching the registry descriptor should be accepted.
+	reg := &mockRegistry{tlsPubKey: ident.TLSSigner.Public(), roles: node.RoleStorageRPC}
+	client, err := Dial(ctx, reg, nodeID, address)
+	require.NoError(err, "Dial")
+	require.NoError(client.Close())
+
+	// Certificate not matching the registry descriptor should be rejected.
+	reg = &mockRegistry{tlsPubKey: memorySigner.NewTestSigner("other").Public(), roles: node.RoleStorageRPC}
+	_, err = Dial(ctx, reg, nodeID, address)
+	require.ErrorIs(err, ErrCertificateMismatch)
+
+	// Nodes without the storage RPC role should be rejected.
+	reg = &mockRegistry{tlsPubKey: ident.TLSSigner.Public(), roles: node.RoleComputeWorker}
+	_, err = Dial(ctx, reg, nodeID, address)
+	require.ErrorIs(err, ErrNotStorageNode)
+}
+
+// newTestTree creates an in-memory tree with some test keys and returns its root and a read
+// syncer serving the tree.
+func newTestTree(t *testing.T) (mkvsNode.Root, mkvs.Tree) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	ns := common.NewTestNamespaceFromSeed([]byte("storage client proof test ns"), 0)
+
+	ndb, err := badgerDb.New(&dbApi.Config{
+		Namespace:    ns,
+		MaxCacheSize: 16 * 1024 * 1024,
+		NoFsync:      true,
+		MemoryOnly:   true,
+	})
+	require.NoError(err, "badger.New")
+	t.Cleanup(ndb.Close)
+
+	tree := mkvs.New(nil, ndb, mkvsNode.RootTypeState)
+	for i := 0; i < 10; i++ {
+		err = tree.Insert(ctx, []byte(fmt.Sprintf("key %d", i)), []byte(fmt.Sprintf("value %d", i)))
+		require.NoError(err, "Insert")
+	}
+	_, rootHash, err := tree.Commit(ctx, ns, 1)
+	require.NoError(err, "Commit")
+	tree.Close()
+
+	root := mkvsNode.Root{Namespace: ns, Version: 1, Type: mkvsNode.RootTypeState, Hash: rootHash}
+	rs := mkvs.NewWithRoot(nil, ndb, root)
+	t.Cleanup(rs.Close)
+
+	return root, rs
+}
+
+func TestVerifyProof(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	root, rs := newTestTree(t)
+	rootHash := root.Hash
+
+	req := &syncer.GetRequest{
+		Tree: syncer.TreeID{Root: root, Position: rootHash},
+		Key:  []byte("key 5"),
+	}
+	rsp, err := rs.SyncGet(ctx, req)
+	require.NoError(err, "SyncGet")
+
+	// Valid proof should verify.
+	err = verifyProof(ctx, &req.Tree, &rsp.Proof)
+	require.NoError(err, "verifyProof")
+
+	// Proof for a different root should be rejected.
+	otherTree := req.Tree
+	otherTree.Root.Hash.FromBytes([]byte("other root"))
+	otherTree.Position = otherTree.Root.Hash
+	err = verifyProof(ctx, &otherTree, &rsp.Proof)
+	require.ErrorIs(err, ErrInvalidProof)
+
+	// Corrupted proof should be rejected.
+	corrupted := rsp.Proof
+	corrupted.Entries = append([][]byte{}, rsp.Proof.Entries...)
+	corrupted.Entries[len(corrupted.Entries)-1] = []byte{0x02, 0x01, 0x02}
+	err = verifyProof(ctx, &req.Tree, &corrupted)
+	require.ErrorIs(err, ErrInvalidProof)
+}
+
+func TestCallPolicy(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	c := &Client{
+		policies: map[Operation]CallPolicy{
+			OpSyncGet: {Timeout: 50 * time.Millisecond, MaxRetries: 1},
+		},
+	}
+
+	// Transient errors should be retried.
+	var attempts int
+	_, err := c.call(ctx, OpSyncGet, false, func(context.Context) error {
+		attempts++
+		if attempts == 1 {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		return nil
+	})
+	require.NoError(err, "call should succeed after a retry")
+	require.Equal(2, attempts)
+
+	// Attempts should time out and retries should be limited.
+	attempts = 0
+	_, err = c.call(ctx, OpSyncGet, false, func(ctx context.Context) error {
+		attempts++
+		_, ok := ctx.Deadline()
+		require.True(ok, "attempt context should have a deadline")
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.ErrorIs(err, context.DeadlineExceeded)
+	require.Equal(2, attempts)
+
+	// Other errors should not be retried.
+	attempts = 0
+	_, err = c.call(ctx, OpSyncGet, false, func(context.Context) error {
+		attempts++
+		return ErrInvalidProof
+	})
+	require.ErrorIs(err, ErrInvalidProof)
+	require.Equal(1, attempts)
+
+	// Contexts of successful attempts should be kept alive when requested.
+	var attemptCtx context.Context
+	cancel, err := c.call(ctx, OpSyncGet, true, func(ctx context.Context) error {
+		attemptCtx = ctx
+		return nil
+	})
+	require.NoError(err, "call")
+	require.NoError(attemptCtx.Err(), "attempt context should not be cancelled")
+	cancel()
+	require.ErrorIs(attemptCtx.Err(), context.Canceled)
+}
@@ -0,0 +1,335 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+)
+
+// ErrNoNodes is the error returned when no storage node is available.
+var ErrNoNodes = errors.New("storage/client: no storage nodes available")
+
+// Endpoint is the public storage endpoint of a registered storage node.
+type Endpoint struct {
+	// NodeID is the identifier of the storage node.
+	NodeID signature.PublicKey
+	// Address is the address of the node's public storage endpoint.
+	Address string
+}
+
+// UnmarshalText decodes a text marshaled endpoint of the form <node-id>@<address>.
+func (e *Endpoint) UnmarshalText(text []byte) error {
+	nodeID, address, ok := strings.Cut(string(text), "@")
+	if !ok || address == "" {
+		return fmt.Errorf("storage/client: malformed endpoint, expected <node-id>@<address>")
+	}
+	if err := e.NodeID.UnmarshalText([]byte(nodeID)); err != nil {
+		return fmt.Errorf("storage/client: malformed endpoint node ID: %w", err)
+	}
+	e.Address = address
+	return nil
+}
+
+// String returns a string representation of the endpoint.
+func (e Endpoint) String() string {
+	return fmt.Sprintf("%s@%s", e.NodeID, e.Address)
+}
+
+type member struct {
+	nodeID   signature.PublicKey
+	client   *Client
+	expiring bool
+}
+
+// CommitteeClient is a client for the public storage endpoints of multiple registered storage
+// nodes, e.g., the storage committee of a runtime.
+//
+// Requests are sent to one node at a time, starting with the node that served the last successful
+// request. In case a node fails a request (e.g., because it responded with an invalid proof or
+// is unreachable), the request is retried with the next node. Nodes whose registrations are about
+// to expire are only tried after all other nodes.
+type CommitteeClient struct {
+	sync.Mutex
+
+	members   []*member
+	preferred int
+
+	cancel context.CancelFunc
+
+	logger *logging.Logger
+}
+
+// Close closes the connections to all storage nodes.
+func (cc *CommitteeClient) Close() error {
+	cc.cancel()
+
+	cc.Lock()
+	defer cc.Unlock()
+
+	var errs error
+	for _, m := range cc.members {
+		errs = errors.Join(errs, m.client.Close())
+	}
+	cc.members = nil
+	return errs
+}
+
+// Nodes returns the identifiers of all storage nodes the client is connected to.
+func (cc *CommitteeClient) Nodes() []signature.PublicKey {
+	cc.Lock()
+	defer cc.Unlock()
+
+	nodes := make([]signature.PublicKey, 0, len(cc.members))
+	for _, m := range cc.members {
+		nodes = append(nodes, m.nodeID)
+	}
+	return nodes
+}
+
+// try performs the given request with one node at a time until one of them succeeds.
+//
+// Once fn returns false for a failed node, the request is not retried with other nodes.
+func (cc *CommitteeClient) try(ctx context.Context, fn func(c *Client) (bool, error)) error {
+	cc.Lock()
+	members := make([]*member, 0, len(cc.members))
+	if len(cc.members) > 0 {
+		members = append(members, cc.members[cc.preferred])
+	}
+	for i, m := range cc.members {
+		if i != cc.preferred {
+			members = append(members, m)
+		}
+	}
+	cc.Unlock()
+
+	if len(members) == 0 {
+		return ErrNoNodes
+	}
+
+	var errs error
+	for _, m := range members {
+		retry, err := fn(m.client)
+		if err == nil {
+			cc.setPreferred(m)
+			return nil
+		}
+
+		cc.logger.Warn("storage node failed request",
+			"err", err,
+			"node_id", m.nodeID,
+		)
+		errs = errors.Join(errs, fmt.Errorf("node %s: %w", m.nodeID, err))
+
+		if !retry || ctx.Err() != nil {
+			break
+		}
+	}
+	return errs
+}
+
+func (cc *CommitteeClient) setPreferred(m *member) {
+	cc.Lock()
+	defer cc.Unlock()
+
+	for i, other := range cc.members {
+		if other == m {
+			cc.preferred = i
+			return
+		}
+	}
+}
+
+// setExpiring marks the given nodes as expiring and moves them behind all other nodes.
+func (cc *CommitteeClient) setExpiring(nodes []*node.Node) {
+	expiring := make(map[signature.PublicKey]bool, len(nodes))
+	for _, n := range nodes {
+		expiring[n.ID] = true
+	}
+
+	cc.Lock()
+	defer cc.Unlock()
+
+	var preferred *member
+	if cc.preferred < len(cc.members) {
+		preferred = cc.members[cc.preferred]
+	}
+
+	for _, m := range cc.members {
+		m.expiring = expiring[m.nodeID]
+	}
+	sort.SliceStable(cc.members, func(i, j int) bool {
+		return !cc.members[i].expiring && cc.members[j].expiring
+	})
+
+	cc.preferred = 0
+	for i, m := range cc.members {
+		if m == preferred && !m.expiring {
+			cc.preferred = i
+			break
+		}
+	}
+}
+
+// watchExpirations deprioritizes nodes whose registrations are about to expire until the given
+// context is canceled.
+func (cc *CommitteeClient) watchExpirations(ctx context.Context, reg registry.Backend) {
+	ch, sub, err := reg.WatchNodeExpirations(ctx)
+	if err != nil {
+		cc.logger.Warn("failed to watch node expirations, expiring nodes will not be deprioritized",
+			"err", err,
+		)
+		return
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case expirations, ok := <-ch:
+			if !ok {
+				return
+			}
+			cc.setExpiring(expirations.Nodes)
+		}
+	}
+}
+
+// SyncGet fetches a single key and returns the corresponding proof.
+//
+// Nodes responding with proofs that do not verify against the requested root are skipped.
+func (cc *CommitteeClient) SyncGet(ctx context.Context, request *api.GetRequest) (*api.ProofResponse, error) {
+	var rsp *api.ProofResponse
+	err := cc.try(ctx, func(c *Client) (bool, error) {
+		var err error
+		rsp, err = c.SyncGet(ctx, request)
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// SyncGetPrefixes fetches all keys under the given prefixes and returns the corresponding proofs.
+//
+// Nodes responding with proofs that do not verify against the requested root are skipped.
+func (cc *CommitteeClient) SyncGetPrefixes(ctx context.Context, request *api.GetPrefixesRequest) (*api.ProofResponse, error) {
+	var rsp *api.ProofResponse
+	err := cc.try(ctx, func(c *Client) (bool, error) {
+		var err error
+		rsp, err = c.SyncGetPrefixes(ctx, request)
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// SyncIterate seeks to a given key and then fetches the specified number of following items
+// based on key iteration order.
+//
+// Nodes responding with proofs that do not verify against the requested root are skipped.
+func (cc *CommitteeClient) SyncIterate(ctx context.Context, request *api.IterateRequest) (*api.ProofResponse, error) {
+	var rsp *api.ProofResponse
+	err := cc.try(ctx, func(c *Client) (bool, error) {
+		var err error
+		rsp, err = c.SyncIterate(ctx, request)
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// GetCheckpoints returns a list of checkpoint metadata for all known checkpoints.
+func (cc *CommitteeClient) GetCheckpoints(ctx context.Context, request *checkpoint.GetCheckpointsRequest) ([]*checkpoint.Metadata, error) {
+	var rsp []*checkpoint.Metadata
+	err := cc.try(ctx, func(c *Client) (bool, error) {
+		var err error
+		rsp, err = c.GetCheckpoints(ctx, request)
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// GetCheckpointChunk fetches a specific chunk from an existing checkpoint.
+//
+// Failed requests are only retried with other nodes if no part of the chunk has been written yet.
+func (cc *CommitteeClient) GetCheckpointChunk(ctx context.Context, chunk *checkpoint.ChunkMetadata, w io.Writer) error {
+	cw := &countingWriter{Writer: w}
+	return cc.try(ctx, func(c *Client) (bool, error) {
+		err := c.GetCheckpointChunk(ctx, chunk, cw)
+		return cw.written == 0, err
+	})
+}
+
+// GetDiff returns an iterator of write log entries that must be applied to get from the first
+// given root to the second one.
+func (cc *CommitteeClient) GetDiff(ctx context.Context, request *api.GetDiffRequest) (api.WriteLogIterator, error) {
+	var it api.WriteLogIterator
+	err := cc.try(ctx, func(c *Client) (bool, error) {
+		var err error
+		it, err = c.GetDiff(ctx, request)
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// DialCommittee connects to the public storage endpoints of the given registered storage nodes.
+//
+// Each node is dialed as with Dial. Nodes that cannot be connected to are skipped, and ErrNoNodes
+// is returned in case no node could be connected to. Node expirations are watched until the
+// client is closed.
+func DialCommittee(ctx context.Context, reg registry.Backend, endpoints []Endpoint, opts ...Option) (*CommitteeClient, error) {
+	cc := &CommitteeClient{
+		cancel: func() {},
+		logger: logging.GetLogger("storage/client/committee"),
+	}
+
+	var errs error
+	for _, ep := range endpoints {
+		c, err := Dial(ctx, reg, ep.NodeID, ep.Address, opts...)
+		if err != nil {
+			cc.logger.Warn("failed to dial storage node",
+				"err", err,
+				"node_id", ep.NodeID,
+				"address", ep.Address,
+			)
+			errs = errors.Join(errs, fmt.Errorf("node %s: %w", ep.NodeID, err))
+			continue
+		}
+		cc.members = append(cc.members, &member{
+			nodeID: ep.NodeID,
+			client: c,
+		})
+	}
+	if len(cc.members) == 0 {
+		return nil, errors.Join(ErrNoNodes, errs)
+	}
+
+	watchCtx, cancel := context.WithCancel(context.Background())
+	cc.cancel = cancel
+	go cc.watchExpirations(watchCtx, reg)
+
+	return cc, nil
+}
@@ -0,0 +1,217 @@
+package client
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+// testBackend is a storage backend serving proofs from a read syncer, optionally corrupting them.
+type testBackend struct {
+	api.Backend
+
+	rs      syncer.ReadSyncer
+	corrupt bool
+	calls   atomic.Int64
+}
+
+func (b *testBackend) SyncGet(ctx context.Context, request *api.GetRequest) (*api.ProofResponse, error) {
+	b.calls.Add(1)
+
+	rsp, err := b.rs.SyncGet(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if b.corrupt {
+		entries := append([][]byte{}, rsp.Proof.Entries...)
+		entries[len(entries)-1] = []byte{0x02, 0x01, 0x02}
+		rsp.Proof.Entries = entries
+	}
+	return rsp, nil
+}
+
+func TestCommitteeFailover(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	root, rs := newTestTree(t)
+	backendA := &testBackend{rs: rs, corrupt: true}
+	backendB := &testBackend{rs: rs}
+
+	reg := &mockRegistry{
+		roles:      node.RoleStorageRPC,
+		tlsPubKeys: make(map[signature.PublicKey]signature.PublicKey),
+	}
+	var endpoints []Endpoint
+	for _, backend := range []*testBackend{backendA, backendB} {
+		ident, err := identity.LoadOrGenerate(t.TempDir(), memorySigner.NewFactory())
+		require.NoError(err, "LoadOrGenerate")
+
+		nodeID := ident.NodeSigner.Public()
+		reg.tlsPubKeys[nodeID] = ident.TLSSigner.Public()
+		endpoints = append(endpoints, Endpoint{
+			NodeID:  nodeID,
+			Address: startTestServer(t, ident, backend),
+		})
+	}
+
+	cc, err := DialCommittee(ctx, reg, endpoints)
+	require.NoError(err, "DialCommittee")
+	defer cc.Close()
+	require.Equal([]signature.PublicKey{endpoints[0].NodeID, endpoints[1].NodeID}, cc.Nodes())
+
+	req := &api.GetRequest{
+		Tree: syncer.TreeID{Root: root, Position: root.Hash},
+		Key:  []byte("key 5"),
+	}
+
+	// An invalid proof from node A should fall through to node B.
+	_, err = cc.SyncGet(ctx, req)
+	require.NoError(err, "SyncGet")
+	require.EqualValues(1, backendA.calls.Load(), "node A should be queried first")
+	require.EqualValues(1, backendB.calls.Load(), "node B should be queried after node A failed")
+
+	// Further requests should go to the node that served the last successful request.
+	_, err = cc.SyncGet(ctx, req)
+	require.NoError(err, "SyncGet")
+	require.EqualValues(1, backendA.calls.Load(), "failed node should not be preferred")
+	require.EqualValues(2, backendB.calls.Load(), "successful node should be preferred")
+
+	// The request should fail if no node responds with a valid proof.
+	backendB.corrupt = true
+	_, err = cc.SyncGet(ctx, req)
+	require.ErrorIs(err, ErrInvalidProof)
+	require.EqualValues(2, backendA.calls.Load())
+	require.EqualValues(3, backendB.calls.Load())
+}
+
+func TestCommitteeExpirations(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	root, rs := newTestTree(t)
+	reg := &mockRegistry{
+		roles:       node.RoleStorageRPC,
+		tlsPubKeys:  make(map[signature.PublicKey]signature.PublicKey),
+		expirations: pubsub.NewBroker(false),
+	}
+	var (
+		backends  []*testBackend
+		endpoints []Endpoint
+	)
+	for i := 0; i < 3; i++ {
+		ident, err := identity.LoadOrGenerate(t.TempDir(), memorySigner.NewFactory())
+		require.NoError(err, "LoadOrGenerate")
+
+		backend := &testBackend{rs: rs}
+		nodeID := ident.NodeSigner.Public()
+		reg.tlsPubKeys[nodeID] = ident.TLSSigner.Public()
+		backends = append(backends, backend)
+		endpoints = append(endpoints, Endpoint{
+			NodeID:  nodeID,
+			Address: startTestServer(t, ident, backend),
+		})
+	}
+
+	cc, err := DialCommittee(ctx, reg, endpoints)
+	require.NoError(err, "DialCommittee")
+	defer cc.Close()
+
+	req := &api.GetRequest{
+		Tree: syncer.TreeID{Root: root, Position: root.Hash},
+		Key:  []byte("key 5"),
+	}
+	_, err = cc.SyncGet(ctx, req)
+	require.NoError(err, "SyncGet")
+	require.EqualValues(1, backends[0].calls.Load(), "first node should be queried")
+
+	// Nodes about to expire should be tried last.
+	reg.expirations.Broadcast(&registry.NodeExpirations{
+		Epoch: 1,
+		Nodes: []*node.Node{{ID: endpoints[0].NodeID}},
+	})
+	require.Eventually(func() bool {
+		nodes := cc.Nodes()
+		return nodes[len(nodes)-1].Equal(endpoints[0].NodeID)
+	}, 5*time.Second, 10*time.Millisecond, "expiring node should be moved last")
+
+	_, err = cc.SyncGet(ctx, req)
+	require.NoError(err, "SyncGet")
+	require.EqualValues(1, backends[0].calls.Load(), "expiring node should not be preferred")
+	require.EqualValues(1, backends[1].calls.Load(), "next node should be queried")
+}
+
+func TestDialCommittee(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ident, err := identity.LoadOrGenerate(t.TempDir(), memorySigner.NewFactory())
+	require.NoError(err, "LoadOrGenerate")
+	address := startTestServer(t, ident, nil)
+	nodeID := ident.NodeSigner.Public()
+	otherID := memorySigner.NewTestSigner("other node").Public()
+
+	reg := &mockRegistry{
+		roles: node.RoleStorageRPC,
+		tlsPubKeys: map[signature.PublicKey]signature.PublicKey{
+			nodeID:  ident.TLSSigner.Public(),
+			otherID: memorySigner.NewTestSigner("other tls").Public(),
+		},
+	}
+
+	// Nodes that cannot be connected to should be skipped.
+	cc, err := DialCommittee(ctx, reg, []Endpoint{
+		{NodeID: otherID, Address: address},
+		{NodeID: nodeID, Address: address},
+	})
+	require.NoError(err, "DialCommittee")
+	require.Equal([]signature.PublicKey{nodeID}, cc.Nodes())
+	require.NoError(cc.Close())
+
+	// Dialing should fail if no node can be connected to.
+	_, err = DialCommittee(ctx, reg, []Endpoint{
+		{NodeID: otherID, Address: address},
+	})
+	require.ErrorIs(err, ErrNoNodes)
+	require.ErrorIs(err, ErrCertificateMismatch)
+}
+
+func TestEndpointUnmarshalText(t *testing.T) {
+	require := require.New(t)
+
+	nodeID := memorySigner.NewTestSigner("storage node").Public()
+
+	var ep Endpoint
+	err := ep.UnmarshalText([]byte(nodeID.String() + "@node.example.com:9100"))
+	require.NoError(err, "UnmarshalText")
+	require.Equal(Endpoint{NodeID: nodeID, Address: "node.example.com:9100"}, ep)
+	require.Equal(nodeID.String()+"@node.example.com:9100", ep.String())
+
+	for _, raw := range []string{
+		"",
+		"node.example.com:9100",
+		nodeID.String() + "@",
+		"invalid@node.example.com:9100",
+	} {
+		err = ep.UnmarshalText([]byte(raw))
+		require.Error(err, "malformed endpoint %q should be rejected", raw)
+	}
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"fmt"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// Flags has the storage client call policy configuration flags.
+var Flags = flag.NewFlagSet("", flag.ContinueOnError)
+
+func cfgTimeout(op Operation) string {
+	return fmt.Sprintf("storage.client.%s.timeout", op)
+}
+
+func cfgMaxRetries(op Operation) string {
+	return fmt.Sprintf("storage.client.%s.max_retries", op)
+}
+
+// FlagOptions returns the storage client options configuring the call policies of all
+// operations from the flags.
+func FlagOptions() []Option {
+	opts := make([]Option, 0, len(Operations))
+	for _, op := range Operations {
+		opts = append(opts, WithCallPolicy(op, CallPolicy{
+			Timeout:    viper.GetDuration(cfgTimeout(op)),
+			MaxRetries: viper.GetUint64(cfgMaxRetries(op)),
+		}))
+	}
+	return opts
+}
+
+func init() {
+	for _, op := range Operations {
+		policy := DefaultCallPolicies[op]
+		Flags.Duration(cfgTimeout(op), policy.Timeout, fmt.Sprintf("%s attempt timeout (0 = no timeout)", op))
+		Flags.Uint64(cfgMaxRetries(op), policy.MaxRetries, fmt.Sprintf("maximum number of %s retries", op))
+	}
+
+	_ = viper.BindPFlags(Flags)
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagOptions(t *testing.T) {
+	require := require.New(t)
+
+	err := Flags.Parse([]string{
+		"--storage.client.sync_get.timeout", "3s",
+		"--storage.client.get_diff.max_retries", "7",
+	})
+	require.NoError(err, "Parse")
+
+	c := &Client{
+		policies: make(map[Operation]CallPolicy),
+	}
+	for _, opt := range FlagOptions() {
+		opt(c)
+	}
+
+	expected := make(map[Operation]CallPolicy)
+	for op, policy := range DefaultCallPolicies {
+		expected[op] = policy
+	}
+	expected[OpSyncGet] = CallPolicy{Timeout: 3 * time.Second, MaxRetries: DefaultCallPolicies[OpSyncGet].MaxRetries}
+	expected[OpGetDiff] = CallPolicy{Timeout: DefaultCallPolicies[OpGetDiff].Timeout, MaxRetries: 7}
+	require.Equal(expected, c.policies, "flags should override the default call policies")
+}
@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	cmnBackoff "github.com/oasisprotocol/oasis-core/go/common/backoff"
+	"github.com/oasisprotocol/oasis-core/go/storage/api"
+)
+
+// Operation is a storage client operation.
+type Operation string
+
+const (
+	// OpSyncGet is the SyncGet operation.
+	OpSyncGet Operation = "sync_get"
+	// OpSyncGetPrefixes is the SyncGetPrefixes operation.
+	OpSyncGetPrefixes Operation = "sync_get_prefixes"
+	// OpSyncIterate is the SyncIterate operation.
+	OpSyncIterate Operation = "sync_iterate"
+	// OpGetCheckpoints is the GetCheckpoints operation.
+	OpGetCheckpoints Operation = "get_checkpoints"
+	// OpGetCheckpointChunk is the GetCheckpointChunk operation.
+	OpGetCheckpointChunk Operation = "get_checkpoint_chunk"
+	// OpGetDiff is the GetDiff operation.
+	OpGetDiff Operation = "get_diff"
+)
+
+// Operations are all storage client operations with a configurable call policy.
+var Operations = []Operation{
+	OpSyncGet,
+	OpSyncGetPrefixes,
+	OpSyncIterate,
+	OpGetCheckpoints,
+	OpGetCheckpointChunk,
+	OpGetDiff,
+}
+
+// CallPolicy is the timeout and retry policy of a storage client operation.
+type CallPolicy struct {
+	// Timeout is the timeout of a single attempt (0 = no timeout).
+	//
+	// For streaming operations the timeout covers receiving the complete response.
+	Timeout time.Duration
+
+	// MaxRetries is the maximum number of times a failed attempt is retried.
+	//
+	// Retries use exponential backoff with jitter and are only performed for transient errors.
+	// Streaming operations are not retried once any part of the response has been received.
+	MaxRetries uint64
+}
+
+// DefaultCallPolicies are the default storage client call policies.
+//
+// Streaming operations transfer much more data than proof requests, so they get more time.
+var DefaultCallPolicies = map[Operation]CallPolicy{
+	OpSyncGet:            {Timeout: 10 * time.Second, MaxRetries: 3},
+	OpSyncGetPrefixes:    {Timeout: 10 * time.Second, MaxRetries: 3},
+	OpSyncIterate:        {Timeout: 10 * time.Second, MaxRetries: 3},
+	OpGetCheckpoints:     {Timeout: 10 * time.Second, MaxRetries: 3},
+	OpGetCheckpointChunk: {Timeout: 5 * time.Minute, MaxRetries: 3},
+	OpGetDiff:            {Timeout: 5 * time.Minute, MaxRetries: 3},
+}
+
+// Option is a storage client option setter.
+type Option func(c *Client)
+
+// WithCallPolicy overrides the call policy of the given operation.
+func WithCallPolicy(op Operation, policy CallPolicy) Option {
+	return func(c *Client) {
+		c.policies[op] = policy
+	}
+}
+
+// isRetriable returns true iff the given error of a failed attempt is transient.
+func isRetriable(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		// Attempt timed out.
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// call performs the given operation according to its call policy.
+//
+// Each attempt is given a context with the policy timeout. If keepCtx is true, the attempt context
+// of a successful attempt is not cancelled and the returned cancel function must be called once
+// the result is no longer needed.
+func (c *Client) call(ctx context.Context, op Operation, keepCtx bool, fn func(ctx context.Context) error) (context.CancelFunc, error) {
+	policy := c.policies[op]
+
+	var keptCancel context.CancelFunc
+	attempt := func() error {
+		attemptCtx, cancel := ctx, context.CancelFunc(func() {})
+		if policy.Timeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		}
+
+		err := fn(attemptCtx)
+		switch {
+		case err == nil && keepCtx:
+			keptCancel = cancel
+			return nil
+		case err == nil:
+			cancel()
+			return nil
+		default:
+			cancel()
+		}
+
+		if ctx.Err() != nil || !isRetriable(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+
+	sched := backoff.WithMaxRetries(backoff.WithContext(cmnBackoff.NewExponentialBackOff(), ctx), policy.MaxRetries)
+	if err := backoff.Retry(attempt, sched); err != nil {
+		return nil, err
+	}
+	if keptCancel == nil {
+		keptCancel = func() {}
+	}
+	return keptCancel, nil
+}
+
+// cancelingIterator is a write log iterator that cancels its context once exhausted.
+type cancelingIterator struct {
+	api.WriteLogIterator
+
+	cancel context.CancelFunc
+}
+
+func (it *cancelingIterator) Next() (bool, error) {
+	more, err := it.WriteLogIterator.Next()
+	if !more || err != nil {
+		it.cancel()
+	}
+	return more, err
+}
+
+// countingWriter is a writer that records whether anything has been written.
+type countingWriter struct {
+	io.Writer
+
+	written int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.written += n
+	return n, err
+}
@@ -9,11 +9,30 @@ import (
 )
 
 // Backends contains the factories for all the backend implementations.
+//
+// Additional backends can be added via RegisterBackend.
 var Backends = []api.Factory{
 	backendBadger.Factory,
 	backendPathBadger.Factory,
 }
 
+// RegisterBackend registers a new node database backend implementation factory, making it
+// available to New under the factory's name.
+//
+// This is intended to be called during initialization (e.g., from an init function) and is not
+// safe for concurrent use with the other functions in this package.
+func RegisterBackend(factory api.Factory) error {
+	name := factory.Name()
+	if name == "" {
+		return fmt.Errorf("node database backend name must not be empty")
+	}
+	if _, err := GetBackendByName(name); err == nil {
+		return fmt.Errorf("node database backend already registered: %s", name)
+	}
+	Backends = append(Backends, factory)
+	return nil
+}
+
 // GetBackendByName returns the backend implementation factory with the given name.
 func GetBackendByName(name string) (api.Factory, error) {
 	for _, factory := range Backends {
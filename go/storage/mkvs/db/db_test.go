@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -85,3 +86,37 @@ func TestHashedWriteLog(t *testing.T) {
 	}
 	require.Equal(t, i, len(wl))
 }
+
+var errTestBackend = errors.New("test backend")
+
+type testFactory struct{}
+
+func (testFactory) New(*api.Config) (api.NodeDB, error) {
+	return nil, errTestBackend
+}
+
+func (testFactory) Name() string {
+	return "test"
+}
+
+func TestRegisterBackend(t *testing.T) {
+	require := require.New(t)
+
+	backends := Backends
+	defer func() {
+		Backends = backends
+	}()
+
+	_, err := New("test", &api.Config{})
+	require.Error(err, "New should fail for an unregistered backend")
+
+	err = RegisterBackend(testFactory{})
+	require.NoError(err, "RegisterBackend")
+	_, err = New("test", &api.Config{})
+	require.ErrorIs(err, errTestBackend, "New should use the registered backend")
+
+	err = RegisterBackend(testFactory{})
+	require.Error(err, "RegisterBackend should reject duplicate backends")
+	err = RegisterBackend(Backends[0])
+	require.Error(err, "RegisterBackend should reject built-in backend names")
+}
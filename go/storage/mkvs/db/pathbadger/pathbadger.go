@@ -35,6 +35,7 @@ func New(cfg *api.Config) (api.NodeDB, error) {
 	if db.db, err = badger.OpenManaged(opts); err != nil {
 		return nil, fmt.Errorf("mkvs/pathbadger: failed to open database: %w", err)
 	}
+	cmnBadger.RegisterForSnapshots(db.db, true)
 
 	// Make sure that we can discard any deleted/invalid metadata.
 	db.db.SetDiscardTs(tsMetadata)
@@ -0,0 +1,79 @@
+package committee
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/runtime/history"
+	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
+)
+
+// tagIndexerRetryInterval is the interval after which a failed tag indexer is restarted.
+const tagIndexerRetryInterval = 5 * time.Second
+
+// tagIndexer indexes the transaction tags of all blocks, resuming after the last indexed round.
+func (n *Node) tagIndexer(ctx context.Context) {
+	n.logger.Info("starting transaction tag indexer")
+
+	for {
+		err := n.indexTags(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		n.logger.Error("transaction tag indexer failed, restarting",
+			"err", err,
+		)
+
+		select {
+		case <-time.After(tagIndexerRetryInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (n *Node) indexTags(ctx context.Context) error {
+	h := n.commonNode.Runtime.History()
+
+	var round uint64
+	last, ok, err := h.LastTagIndexedRound()
+	if err != nil {
+		return fmt.Errorf("failed to get last indexed round: %w", err)
+	}
+	if ok {
+		round = last + 1
+	}
+
+	blkCh, blkSub, err := history.WatchBlocksSince(ctx, h, round, n.logger)
+	if err != nil {
+		return fmt.Errorf("failed to watch blocks: %w", err)
+	}
+	defer blkSub.Close()
+
+	for blk := range blkCh {
+		if err = n.indexBlock(ctx, h, blk.Block); err != nil {
+			return fmt.Errorf("failed to index round %d: %w", blk.Block.Header.Round, err)
+		}
+	}
+	if err = pubsub.SubscriptionErr(blkSub); err != nil {
+		return fmt.Errorf("block watch failed: %w", err)
+	}
+	return fmt.Errorf("block watch terminated")
+}
+
+func (n *Node) indexBlock(ctx context.Context, h history.History, blk *block.Block) error {
+	var tags transaction.Tags
+	if blk.Header.HeaderType == block.Normal && !blk.Header.IORoot.IsEmpty() {
+		tree := transaction.NewTree(n.commonNode.Runtime.Storage(), blk.Header.StorageRootIO())
+		defer tree.Close()
+
+		var err error
+		if tags, err = tree.GetTags(ctx); err != nil {
+			return err
+		}
+	}
+	return h.IndexTags(blk.Header.Round, tags)
+}
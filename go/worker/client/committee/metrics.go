@@ -0,0 +1,36 @@
+package committee
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	txLatencyPhaseScheduled = "scheduled"
+	txLatencyPhaseExecuted  = "executed"
+	txLatencyPhaseFinalized = "finalized"
+)
+
+var (
+	clientTxLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "oasis_worker_client_tx_latency",
+			Help:    "Latency of submitted transactions by phase (seconds).",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10, 30, 60},
+		},
+		[]string{"runtime", "phase"},
+	)
+
+	clientCollectors = []prometheus.Collector{
+		clientTxLatency,
+	}
+
+	prometheusOnce sync.Once
+)
+
+func initMetrics() {
+	prometheusOnce.Do(func() {
+		prometheus.MustRegister(clientCollectors...)
+	})
+}
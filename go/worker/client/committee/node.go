@@ -8,10 +8,13 @@ import (
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/eapache/channels"
+	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnBackoff "github.com/oasisprotocol/oasis-core/go/common/backoff"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	runtime "github.com/oasisprotocol/oasis-core/go/runtime/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
@@ -25,12 +28,38 @@ import (
 )
 
 type pendingTx struct {
-	chs map[chan *api.SubmitTxResult]struct{}
+	chs map[chan *api.SubmitTxResult]*txTimes
+}
+
+// txTimes are the times at which a submitted transaction reached the phases observable by the
+// client node.
+type txTimes struct {
+	submitted time.Time
+	scheduled time.Time
+}
+
+// latency computes the latency decomposition of a transaction that was included in a block
+// with the given timestamp and whose result was observed at the given time.
+func (t *txTimes) latency(executed, finalized time.Time) *api.TxLatency {
+	// Block timestamps only have a resolution of one second and are subject to clock skew.
+	if executed.Before(t.scheduled) {
+		executed = t.scheduled
+	}
+	if finalized.Before(executed) {
+		finalized = executed
+	}
+
+	return &api.TxLatency{
+		Scheduled: t.scheduled.Sub(t.submitted),
+		Executed:  executed.Sub(t.scheduled),
+		Finalized: finalized.Sub(executed),
+	}
 }
 
 type wantTx struct {
 	txHash hash.Hash
 	ch     chan *api.SubmitTxResult
+	times  *txTimes
 	remove bool
 }
 
@@ -145,6 +174,7 @@ func (n *Node) SubmitTx(ctx context.Context, tx []byte) (*SubmitTxSubscription,
 	}
 
 	// Submit transaction to the pool and wait for it to get checked.
+	submitted := time.Now()
 	result, err := n.commonNode.TxPool.SubmitTx(ctx, tx, &txpool.TransactionMeta{Local: true})
 	if err != nil {
 		return nil, nil, err
@@ -158,6 +188,10 @@ func (n *Node) SubmitTx(ctx context.Context, tx []byte) (*SubmitTxSubscription,
 	n.txCh.In() <- &wantTx{
 		txHash: txHash,
 		ch:     ch,
+		times: &txTimes{
+			submitted: submitted,
+			scheduled: time.Now(),
+		},
 	}
 
 	sub := &SubmitTxSubscription{
@@ -247,15 +281,22 @@ func (n *Node) checkBlock(ctx context.Context, blk *block.Block, pending map[has
 		return fmt.Errorf("error getting block I/O from storage: %w", err)
 	}
 
+	executed := time.Unix(int64(blk.Header.Timestamp), 0)
+	finalized := time.Now()
+
 	var processed []hash.Hash
 	for txHash, tx := range matches {
 		pTx := pending[txHash]
-		for ch := range pTx.chs {
+		for ch, times := range pTx.chs {
+			latency := times.latency(executed, finalized)
+			observeTxLatency(n.commonNode.Runtime.ID(), latency)
+
 			ch <- &api.SubmitTxResult{
 				Result: &api.SubmitTxMetaResponse{
 					Round:      blk.Header.Round,
 					BatchOrder: tx.BatchOrder,
 					Output:     tx.Output,
+					Latency:    latency,
 				},
 			}
 			close(ch)
@@ -270,6 +311,20 @@ func (n *Node) checkBlock(ctx context.Context, blk *block.Block, pending map[has
 	return nil
 }
 
+// observeTxLatency records the latency decomposition of a transaction of the given runtime.
+func observeTxLatency(runtimeID common.Namespace, latency *api.TxLatency) {
+	for phase, d := range map[string]time.Duration{
+		txLatencyPhaseScheduled: latency.Scheduled,
+		txLatencyPhaseExecuted:  latency.Executed,
+		txLatencyPhaseFinalized: latency.Finalized,
+	} {
+		clientTxLatency.With(prometheus.Labels{
+			"runtime": runtimeID.String(),
+			"phase":   phase,
+		}).Observe(d.Seconds())
+	}
+}
+
 func (n *Node) worker() {
 	defer close(n.quitCh)
 
@@ -300,6 +355,10 @@ func (n *Node) worker() {
 	// We are initialized.
 	close(n.initCh)
 
+	if config.GlobalConfig.Runtime.IndexTags {
+		go n.tagIndexer(ctx)
+	}
+
 	var (
 		recheckTicker *backoff.Ticker
 		blocks        []*block.Block
@@ -324,12 +383,12 @@ func (n *Node) worker() {
 				// Interest in the transaction.
 				if !ok {
 					existingTx = &pendingTx{
-						chs: make(map[chan *api.SubmitTxResult]struct{}),
+						chs: make(map[chan *api.SubmitTxResult]*txTimes),
 					}
 					pending[tx.txHash] = existingTx
 				}
 
-				existingTx.chs[tx.ch] = struct{}{}
+				existingTx.chs[tx.ch] = tx.times
 			case true:
 				// Removal of interest in the transaction.
 				if !ok {
@@ -379,6 +438,8 @@ func (n *Node) worker() {
 
 // NewNode creates a new client node.
 func NewNode(commonNode *committee.Node, roleProvider registration.RoleProvider) (*Node, error) {
+	initMetrics()
+
 	n := &Node{
 		commonNode:   commonNode,
 		roleProvider: roleProvider,
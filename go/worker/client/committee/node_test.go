@@ -0,0 +1,101 @@
+package committee
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+)
+
+func TestTxLatency(t *testing.T) {
+	submitted := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	times := &txTimes{
+		submitted: submitted,
+		scheduled: submitted.Add(200 * time.Millisecond),
+	}
+
+	for _, tc := range []struct {
+		name      string
+		executed  time.Time
+		finalized time.Time
+		expected  api.TxLatency
+	}{
+		{
+			name:      "Ordered",
+			executed:  submitted.Add(2 * time.Second),
+			finalized: submitted.Add(3 * time.Second),
+			expected: api.TxLatency{
+				Scheduled: 200 * time.Millisecond,
+				Executed:  1800 * time.Millisecond,
+				Finalized: time.Second,
+			},
+		},
+		{
+			// Block timestamps are truncated to seconds, so the block may appear to have been
+			// produced before the transaction was scheduled.
+			name:      "ExecutedBeforeScheduled",
+			executed:  submitted,
+			finalized: submitted.Add(time.Second),
+			expected: api.TxLatency{
+				Scheduled: 200 * time.Millisecond,
+				Executed:  0,
+				Finalized: 800 * time.Millisecond,
+			},
+		},
+		{
+			// Clock skew between the block proposer and the client node.
+			name:      "FinalizedBeforeExecuted",
+			executed:  submitted.Add(3 * time.Second),
+			finalized: submitted.Add(2 * time.Second),
+			expected: api.TxLatency{
+				Scheduled: 200 * time.Millisecond,
+				Executed:  2800 * time.Millisecond,
+				Finalized: 0,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			latency := times.latency(tc.executed, tc.finalized)
+			require.Equal(tc.expected, *latency)
+		})
+	}
+}
+
+func TestObserveTxLatency(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID common.Namespace
+	err := runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(err, "UnmarshalHex")
+
+	observeTxLatency(runtimeID, &api.TxLatency{
+		Scheduled: 100 * time.Millisecond,
+		Executed:  2 * time.Second,
+		Finalized: 500 * time.Millisecond,
+	})
+
+	for phase, expected := range map[string]float64{
+		txLatencyPhaseScheduled: 0.1,
+		txLatencyPhaseExecuted:  2,
+		txLatencyPhaseFinalized: 0.5,
+	} {
+		observer, err := clientTxLatency.GetMetricWith(prometheus.Labels{
+			"runtime": runtimeID.String(),
+			"phase":   phase,
+		})
+		require.NoError(err, "GetMetricWith")
+
+		var m dto.Metric
+		err = observer.(prometheus.Metric).Write(&m)
+		require.NoError(err, "Write")
+		require.EqualValues(1, m.GetHistogram().GetSampleCount(), "sample count (%s)", phase)
+		require.InDelta(expected, m.GetHistogram().GetSampleSum(), 1e-9, "sample sum (%s)", phase)
+	}
+}
@@ -8,10 +8,12 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/history"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
 	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
 	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
@@ -116,6 +118,15 @@ func (s *service) WatchBlocks(_ context.Context, runtimeID common.Namespace) (<-
 	return rt.History().WatchBlocks()
 }
 
+// Implements api.RuntimeClient.
+func (s *service) WatchBlocksSince(ctx context.Context, request *api.WatchBlocksSinceRequest) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	rt, err := s.w.commonWorker.RuntimeRegistry.GetRuntime(request.RuntimeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return history.WatchBlocksSince(ctx, rt.History(), request.Round, s.w.logger)
+}
+
 // Implements api.RuntimeClient.
 func (s *service) GetGenesisBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error) {
 	return s.w.commonWorker.Consensus.RootHash().GetGenesisBlock(ctx, &roothash.RuntimeRequest{
@@ -133,6 +144,15 @@ func (s *service) GetBlock(ctx context.Context, request *api.GetBlockRequest) (*
 	return rt.History().GetBlock(ctx, request.Round)
 }
 
+// Implements api.RuntimeClient.
+func (s *service) GetBlockByHash(ctx context.Context, request *api.GetBlockByHashRequest) (*block.Block, error) {
+	rt, err := s.w.commonWorker.RuntimeRegistry.GetRuntime(request.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+	return rt.History().GetBlockByHash(ctx, request.Hash)
+}
+
 // Implements api.RuntimeClient.
 func (s *service) GetLastRetainedBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error) {
 	rt, err := s.w.commonWorker.RuntimeRegistry.GetRuntime(runtimeID)
@@ -292,6 +312,43 @@ func (s *service) GetEvents(ctx context.Context, request *api.GetEventsRequest)
 	return events, nil
 }
 
+func (s *service) queryTxns(runtimeID common.Namespace, key, value []byte, limit uint64) ([]*api.TxnLocation, error) {
+	if !config.GlobalConfig.Runtime.IndexTags {
+		return nil, api.ErrIndexingDisabled
+	}
+	rt, err := s.w.commonWorker.RuntimeRegistry.GetRuntime(runtimeID)
+	if err != nil {
+		return nil, err
+	}
+	txns, err := rt.History().QueryTxns(key, value, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	locs := make([]*api.TxnLocation, 0, len(txns))
+	for _, txn := range txns {
+		locs = append(locs, &api.TxnLocation{
+			Round:  txn.Round,
+			TxHash: txn.TxHash,
+		})
+	}
+	return locs, nil
+}
+
+// Implements api.RuntimeClient.
+func (s *service) QueryTxn(_ context.Context, request *api.QueryTxnRequest) (*api.TxnLocation, error) {
+	locs, err := s.queryTxns(request.RuntimeID, request.Key, request.Value, 1)
+	if err != nil {
+		return nil, err
+	}
+	return locs[0], nil
+}
+
+// Implements api.RuntimeClient.
+func (s *service) QueryTxns(_ context.Context, request *api.QueryTxnsRequest) ([]*api.TxnLocation, error) {
+	return s.queryTxns(request.RuntimeID, request.Key, request.Value, request.Limit)
+}
+
 // Implements api.RuntimeClient.
 func (s *service) Query(ctx context.Context, request *api.QueryRequest) (*api.QueryResponse, error) {
 	rt := s.w.runtimes[request.RuntimeID]
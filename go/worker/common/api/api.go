@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
@@ -134,6 +135,13 @@ type Status struct {
 type HostStatus struct {
 	// Versions are the locally supported versions.
 	Versions []version.Version `json:"versions"`
+
+	// LastAttestation is the time of the last successful TEE attestation of the hosted runtime,
+	// as reported by the attestation quote. For quotes without a timestamp, this is the time of
+	// the consensus block the attestation was made at.
+	LastAttestation *time.Time `json:"last_attestation,omitempty"`
+	// AttestationAge is the age of the last TEE attestation in seconds.
+	AttestationAge uint64 `json:"attestation_age,omitempty"`
 }
 
 // LivenessStatus is the liveness status for the current epoch.
@@ -14,6 +14,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	cmSync "github.com/oasisprotocol/oasis-core/go/common/sync"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
 	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
@@ -47,6 +48,7 @@ type KeyManagerClientWrapper struct {
 	chainContext string
 	cli          keymanagerP2P.Client
 	nt           *nodeTracker
+	cache        *keyManagerCache
 	logger       *logging.Logger
 
 	lastPeerFeedback rpc.PeerFeedback
@@ -93,12 +95,13 @@ func (km *KeyManagerClientWrapper) SetKeyManagerID(id *common.Namespace) {
 		km.nt = nil
 	default:
 		km.cli = keymanagerP2P.NewClient(km.p2p, km.chainContext, *id)
-		km.nt = newKeyManagerNodeTracker(km.p2p, km.consensus, *id)
+		km.nt = newKeyManagerNodeTracker(km.p2p, km.consensus, *id, km.cache)
 		km.nt.Start()
 	}
 
 	km.lastPeerFeedback = nil
 	km.peerFeedbacks.Clear()
+	km.cache.Clear()
 }
 
 // CallEnclaveDeprecated implements runtimeKeymanager.Client.
@@ -188,6 +191,30 @@ func (km *KeyManagerClientWrapper) CallEnclave(
 		return nil, err
 	}
 
+	// Responses to insecure queries only contain non-secret metadata and can be served from
+	// the local cache in case no specific nodes were requested.
+	cacheable := kind == enclaverpc.KindInsecureQuery && len(nodes) == 0
+	if cacheable {
+		if entry, ok := km.cache.Get(data); ok {
+			// Route feedback for cached responses to the peer that originally served them so
+			// that a response later found to be bad still penalizes the peer and gets evicted.
+			// Success was already recorded when the response got cached.
+			info := peerFeedbackInfo{
+				requestID: requestID,
+				feedback:  entry.feedback,
+				timestamp: time.Now(),
+				node:      entry.rsp.Node,
+				data:      data,
+				cached:    true,
+			}
+
+			// Put is expected to never fail since byte capacity is not enabled.
+			_ = km.peerFeedbacks.Put(requestID, &info)
+
+			return entry.rsp, nil
+		}
+	}
+
 	// Call only members of the key manager committee. If no nodes are given, use all members.
 	kmNodes := km.nt.Nodes(nodes)
 	if len(kmNodes) == 0 && len(nodes) > 0 {
@@ -210,19 +237,27 @@ func (km *KeyManagerClientWrapper) CallEnclave(
 		return nil, fmt.Errorf("unknown peer id")
 	}
 
+	kmRsp := &runtimeKeymanager.EnclaveResponse{
+		Data: rsp.Data,
+		Node: node,
+	}
+
 	info := peerFeedbackInfo{
 		requestID: requestID,
 		feedback:  feedback,
 		timestamp: time.Now(),
+		node:      node,
+	}
+	if cacheable {
+		// Responses are only cached once the runtime reports them as valid.
+		info.data = data
+		info.rsp = kmRsp
 	}
 
 	// Put is expected to never fail since byte capacity is not enabled.
 	_ = km.peerFeedbacks.Put(requestID, &info)
 
-	return &runtimeKeymanager.EnclaveResponse{
-		Data: rsp.Data,
-		Node: node,
-	}, nil
+	return kmRsp, nil
 }
 
 // SubmitPeerFeedback implements runtimeKeymanager.Client.
@@ -251,11 +286,22 @@ func (km *KeyManagerClientWrapper) SubmitPeerFeedback(requestID uint64, feedback
 
 	switch feedback {
 	case enclaverpc.PeerFeedbackSuccess:
+		if info.cached {
+			// The peer was not contacted, do not reward it again.
+			return
+		}
 		info.feedback.RecordSuccess()
+		if info.rsp != nil {
+			km.cache.Put(info.data, info.rsp, info.feedback)
+		}
 	case enclaverpc.PeerFeedbackFailure:
 		info.feedback.RecordFailure()
+		if info.data != nil {
+			km.cache.Remove(info.data)
+		}
 	case enclaverpc.PeerFeedbackBadPeer:
 		info.feedback.RecordBadPeer()
+		km.cache.RemoveNode(info.node)
 	default:
 	}
 }
@@ -278,6 +324,7 @@ func NewKeyManagerClientWrapper(p2p p2p.Service, consensus consensus.Backend, ch
 		chainContext:  chainContext,
 		logger:        logger,
 		peerFeedbacks: lru.New(lru.Capacity(peerFeedbackCacheSize, false)),
+		cache:         newKeyManagerCache(config.GlobalConfig.Runtime.KeyManagerCache.Size, config.GlobalConfig.Runtime.KeyManagerCache.TTL),
 	}
 }
 
@@ -287,6 +334,7 @@ type nodeTracker struct {
 	p2p          p2p.Service
 	consensus    consensus.Backend
 	keymanagerID common.Namespace
+	cache        *keyManagerCache
 
 	nodes map[signature.PublicKey]core.PeerID
 
@@ -361,6 +409,9 @@ func (nt *nodeTracker) trackKeymanagerNodes(ctx context.Context) {
 			status = st
 		}
 
+		// Invalidate cached key manager metadata if the status has changed.
+		nt.cache.UpdateStatus(status)
+
 		// It's not possible to service requests for this key manager.
 		if !status.IsInitialized || len(status.Nodes) == 0 {
 			nt.logger.Warn("key manager not initialized or has no nodes",
@@ -424,11 +475,12 @@ func (nt *nodeTracker) trackKeymanagerNodes(ctx context.Context) {
 
 // newKeyManagerNodeTracker creates a new tracker that is responsible for keeping the list
 // of key manager nodes and their peer identities up-to-date.
-func newKeyManagerNodeTracker(p2p p2p.Service, consensus consensus.Backend, keymanagerID common.Namespace) *nodeTracker {
+func newKeyManagerNodeTracker(p2p p2p.Service, consensus consensus.Backend, keymanagerID common.Namespace, cache *keyManagerCache) *nodeTracker {
 	return &nodeTracker{
 		p2p:          p2p,
 		consensus:    consensus,
 		keymanagerID: keymanagerID,
+		cache:        cache,
 		initCh:       make(chan struct{}),
 		startOne:     cmSync.NewOne(),
 		logger:       logging.GetLogger("worker/common/committee/keymanager/nodetracker"),
@@ -443,4 +495,12 @@ type peerFeedbackInfo struct {
 	feedback rpc.PeerFeedback
 	// timestamp is the time when the feedback was added to the cache.
 	timestamp time.Time
+	// node is the key manager node that generated the response.
+	node signature.PublicKey
+	// data is the request data of a cacheable request.
+	data []byte
+	// rsp is the response to a cacheable request that is pending validation by the runtime.
+	rsp *runtimeKeymanager.EnclaveResponse
+	// cached is true iff the response was served from the local cache.
+	cached bool
 }
@@ -0,0 +1,152 @@
+package committee
+
+import (
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
+	runtimeKeymanager "github.com/oasisprotocol/oasis-core/go/runtime/keymanager/api"
+)
+
+// keyManagerCache caches key manager responses to insecure queries. Such responses only contain
+// non-secret metadata (e.g. public keys and policy checksums), so they can be safely reused by
+// compute nodes instead of querying the key manager for each transaction.
+//
+// Responses are only inserted once the runtime has validated them (see SubmitPeerFeedback) and
+// are evicted as soon as the node that served them is reported as a bad peer. The cache is
+// invalidated whenever the key manager status changes.
+type keyManagerCache struct {
+	sync.Mutex
+
+	ttl     time.Duration
+	entries *lru.Cache
+
+	statusHash hash.Hash
+}
+
+type keyManagerCacheEntry struct {
+	// rsp is the cached response.
+	rsp *runtimeKeymanager.EnclaveResponse
+	// feedback is the peer feedback of the request that produced the response.
+	feedback rpc.PeerFeedback
+	// expiresAt is the time after which the entry is no longer valid.
+	expiresAt time.Time
+}
+
+// Get returns the cached entry for the given request, if any.
+func (c *keyManagerCache) Get(data []byte) (*keyManagerCacheEntry, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	key := hash.NewFromBytes(data)
+	item, ok := c.entries.Get(key)
+	if !ok {
+		return nil, false
+	}
+	entry := item.(*keyManagerCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		_ = c.entries.Remove(key)
+		return nil, false
+	}
+	return entry, true
+}
+
+// Put caches the validated response to the given request.
+func (c *keyManagerCache) Put(data []byte, rsp *runtimeKeymanager.EnclaveResponse, feedback rpc.PeerFeedback) {
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	// Put is expected to never fail since byte capacity is not enabled.
+	_ = c.entries.Put(hash.NewFromBytes(data), &keyManagerCacheEntry{
+		rsp:       rsp,
+		feedback:  feedback,
+		expiresAt: time.Now().Add(c.ttl),
+	})
+}
+
+// Remove removes the cached response to the given request.
+func (c *keyManagerCache) Remove(data []byte) {
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	_ = c.entries.Remove(hash.NewFromBytes(data))
+}
+
+// RemoveNode removes all cached responses generated by the given node.
+func (c *keyManagerCache) RemoveNode(node signature.PublicKey) {
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	for _, key := range c.entries.Keys() {
+		item, ok := c.entries.Peek(key)
+		if !ok {
+			continue
+		}
+		if item.(*keyManagerCacheEntry).rsp.Node.Equal(node) {
+			_ = c.entries.Remove(key)
+		}
+	}
+}
+
+// Clear removes all cached responses.
+func (c *keyManagerCache) Clear() {
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.entries.Clear()
+	c.statusHash = hash.Hash{}
+}
+
+// UpdateStatus invalidates the cache in case the key manager status has changed.
+func (c *keyManagerCache) UpdateStatus(status *secrets.Status) {
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	h := hash.NewFrom(status)
+	if h.Equal(&c.statusHash) {
+		return
+	}
+	c.entries.Clear()
+	c.statusHash = h
+}
+
+// newKeyManagerCache creates a new key manager response cache. If the size is zero, caching
+// is disabled and nil is returned.
+func newKeyManagerCache(size uint64, ttl time.Duration) *keyManagerCache {
+	if size == 0 {
+		return nil
+	}
+
+	return &keyManagerCache{
+		ttl:     ttl,
+		entries: lru.New(lru.Capacity(size, false)),
+	}
+}
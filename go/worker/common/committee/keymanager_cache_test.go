@@ -0,0 +1,224 @@
+package committee
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
+	enclaverpc "github.com/oasisprotocol/oasis-core/go/runtime/enclaverpc/api"
+	runtimeKeymanager "github.com/oasisprotocol/oasis-core/go/runtime/keymanager/api"
+	keymanagerP2P "github.com/oasisprotocol/oasis-core/go/worker/keymanager/p2p"
+)
+
+// testKeyManagerClient is a key manager client that fails all calls.
+type testKeyManagerClient struct{}
+
+func (c *testKeyManagerClient) CallEnclave(context.Context, *keymanagerP2P.CallEnclaveRequest, []core.PeerID) (*keymanagerP2P.CallEnclaveResponse, rpc.PeerFeedback, error) {
+	return nil, nil, fmt.Errorf("not available")
+}
+
+type testPeerFeedback struct {
+	success int
+	failure int
+	badPeer int
+}
+
+func (f *testPeerFeedback) RecordSuccess() {
+	f.success++
+}
+
+func (f *testPeerFeedback) RecordFailure() {
+	f.failure++
+}
+
+func (f *testPeerFeedback) RecordBadPeer() {
+	f.badPeer++
+}
+
+func (f *testPeerFeedback) PeerID() core.PeerID {
+	return ""
+}
+
+func newTestEnclaveResponse(node signature.PublicKey) *runtimeKeymanager.EnclaveResponse {
+	return &runtimeKeymanager.EnclaveResponse{
+		Data: []byte("response"),
+		Node: node,
+	}
+}
+
+func TestKeyManagerCacheDisabled(t *testing.T) {
+	require := require.New(t)
+
+	cache := newKeyManagerCache(0, time.Minute)
+	require.Nil(cache, "zero size should disable the cache")
+
+	// All methods should be safe to call on a disabled cache.
+	cache.Put([]byte("request"), newTestEnclaveResponse(signature.PublicKey{}), &testPeerFeedback{})
+	_, ok := cache.Get([]byte("request"))
+	require.False(ok)
+	cache.Remove([]byte("request"))
+	cache.RemoveNode(signature.PublicKey{})
+	cache.UpdateStatus(&secrets.Status{})
+	cache.Clear()
+}
+
+func TestKeyManagerCacheHitMiss(t *testing.T) {
+	require := require.New(t)
+
+	cache := newKeyManagerCache(16, time.Minute)
+	node := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001")
+	rsp := newTestEnclaveResponse(node)
+	feedback := &testPeerFeedback{}
+
+	_, ok := cache.Get([]byte("request"))
+	require.False(ok, "empty cache should miss")
+
+	cache.Put([]byte("request"), rsp, feedback)
+	entry, ok := cache.Get([]byte("request"))
+	require.True(ok, "cached request should hit")
+	require.Equal(rsp, entry.rsp)
+	require.Equal(feedback, entry.feedback)
+
+	_, ok = cache.Get([]byte("other request"))
+	require.False(ok, "different request should miss")
+
+	cache.Remove([]byte("request"))
+	_, ok = cache.Get([]byte("request"))
+	require.False(ok, "removed request should miss")
+}
+
+func TestKeyManagerCacheExpiry(t *testing.T) {
+	require := require.New(t)
+
+	cache := newKeyManagerCache(16, 50*time.Millisecond)
+	cache.Put([]byte("request"), newTestEnclaveResponse(signature.PublicKey{}), &testPeerFeedback{})
+
+	_, ok := cache.Get([]byte("request"))
+	require.True(ok, "fresh entry should hit")
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, ok = cache.Get([]byte("request"))
+	require.False(ok, "expired entry should miss")
+	require.EqualValues(0, cache.entries.Size(), "expired entry should be removed")
+}
+
+func TestKeyManagerCacheEviction(t *testing.T) {
+	require := require.New(t)
+
+	cache := newKeyManagerCache(16, time.Minute)
+	node1 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001")
+	node2 := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000002")
+
+	cache.Put([]byte("request 1"), newTestEnclaveResponse(node1), &testPeerFeedback{})
+	cache.Put([]byte("request 2"), newTestEnclaveResponse(node1), &testPeerFeedback{})
+	cache.Put([]byte("request 3"), newTestEnclaveResponse(node2), &testPeerFeedback{})
+
+	cache.RemoveNode(node1)
+	_, ok := cache.Get([]byte("request 1"))
+	require.False(ok, "responses from evicted node should be removed")
+	_, ok = cache.Get([]byte("request 2"))
+	require.False(ok, "responses from evicted node should be removed")
+	_, ok = cache.Get([]byte("request 3"))
+	require.True(ok, "responses from other nodes should be kept")
+
+	// Status changes should invalidate the cache.
+	cache.UpdateStatus(&secrets.Status{IsInitialized: true})
+	_, ok = cache.Get([]byte("request 3"))
+	require.False(ok, "status change should invalidate the cache")
+}
+
+func TestKeyManagerCachePeerFeedback(t *testing.T) {
+	require := require.New(t)
+
+	km := &KeyManagerClientWrapper{
+		cache:         newKeyManagerCache(16, time.Minute),
+		logger:        logging.GetLogger("worker/common/committee/keymanager/test"),
+		peerFeedbacks: lru.New(lru.Capacity(peerFeedbackCacheSize, false)),
+	}
+	node := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001")
+	rsp := newTestEnclaveResponse(node)
+	feedback := &testPeerFeedback{}
+	pending := func(requestID uint64) {
+		_ = km.peerFeedbacks.Put(requestID, &peerFeedbackInfo{
+			requestID: requestID,
+			feedback:  feedback,
+			timestamp: time.Now(),
+			node:      node,
+			data:      []byte("request"),
+			rsp:       rsp,
+		})
+	}
+
+	// Responses should not be cached before being validated.
+	pending(1)
+	_, ok := km.cache.Get([]byte("request"))
+	require.False(ok, "unvalidated response should not be cached")
+
+	// Failed responses should not be cached.
+	km.SubmitPeerFeedback(1, enclaverpc.PeerFeedbackFailure)
+	_, ok = km.cache.Get([]byte("request"))
+	require.False(ok, "failed response should not be cached")
+	require.Equal(1, feedback.failure)
+
+	// Validated responses should be cached.
+	pending(2)
+	km.SubmitPeerFeedback(2, enclaverpc.PeerFeedbackSuccess)
+	_, ok = km.cache.Get([]byte("request"))
+	require.True(ok, "validated response should be cached")
+	require.Equal(1, feedback.success)
+
+	// Bad peer feedback should evict all responses from the peer.
+	_ = km.peerFeedbacks.Put(uint64(3), &peerFeedbackInfo{
+		requestID: 3,
+		feedback:  feedback,
+		timestamp: time.Now(),
+		node:      node,
+		data:      []byte("request"),
+	})
+	km.SubmitPeerFeedback(3, enclaverpc.PeerFeedbackBadPeer)
+	_, ok = km.cache.Get([]byte("request"))
+	require.False(ok, "responses from bad peer should be evicted")
+	require.Equal(1, feedback.badPeer)
+}
+
+func TestKeyManagerCachedPeerFeedback(t *testing.T) {
+	require := require.New(t)
+
+	km := &KeyManagerClientWrapper{
+		cli:           &testKeyManagerClient{},
+		cache:         newKeyManagerCache(16, time.Minute),
+		logger:        logging.GetLogger("worker/common/committee/keymanager/test"),
+		peerFeedbacks: lru.New(lru.Capacity(peerFeedbackCacheSize, false)),
+	}
+	node := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001")
+	feedback := &testPeerFeedback{}
+	km.cache.Put([]byte("request"), newTestEnclaveResponse(node), feedback)
+
+	// Repeated cache hits should not reward the original peer again.
+	for requestID := uint64(1); requestID <= 3; requestID++ {
+		_, err := km.CallEnclave(context.Background(), requestID, []byte("request"), nil, enclaverpc.KindInsecureQuery)
+		require.NoError(err, "CallEnclave")
+		km.SubmitPeerFeedback(requestID, enclaverpc.PeerFeedbackSuccess)
+	}
+	require.Equal(0, feedback.success, "cache hits should not record success")
+	_, ok := km.cache.Get([]byte("request"))
+	require.True(ok, "response should remain cached")
+
+	// Failures should still penalize the original peer and evict the response.
+	_, err := km.CallEnclave(context.Background(), 4, []byte("request"), nil, enclaverpc.KindInsecureQuery)
+	require.NoError(err, "CallEnclave")
+	km.SubmitPeerFeedback(4, enclaverpc.PeerFeedbackFailure)
+	require.Equal(1, feedback.failure)
+	_, ok = km.cache.Get([]byte("request"))
+	require.False(ok, "failed response should be evicted")
+}
@@ -11,9 +11,13 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/common/watchdog"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
@@ -173,6 +177,8 @@ type Node struct {
 
 	hooks []NodeHooks
 
+	watchdog *watchdog.Watchdog
+
 	// Status states.
 	consensusSynced           uint32
 	runtimeRegistryDescriptor uint32
@@ -190,6 +196,14 @@ type Node struct {
 	CurrentDescriptor     *registry.Runtime
 	CurrentEpoch          beacon.EpochTime
 
+	// lastAttestation is the time of the last hosted runtime TEE attestation.
+	// Guarded by .CrossNode.
+	lastAttestation time.Time
+	// lastAttestationHeight is the consensus height of the last hosted runtime TEE attestation
+	// whose quote does not include a timestamp, pending resolution to a time.
+	// Guarded by .CrossNode.
+	lastAttestationHeight int64
+
 	logger *logging.Logger
 }
 
@@ -323,6 +337,18 @@ func (n *Node) GetStatus() (*api.Status, error) {
 	status.Peers = n.P2P.Peers(n.Runtime.ID())
 
 	status.Host.Versions = n.RuntimeRegistry.GetBundleRegistry().GetVersions(n.Runtime.ID())
+	if n.lastAttestation.IsZero() && n.lastAttestationHeight > 0 {
+		blk, err := n.Consensus.GetBlock(n.ctx, n.lastAttestationHeight)
+		if err == nil {
+			n.lastAttestation = blk.Time
+			n.lastAttestationHeight = 0
+		}
+	}
+	if !n.lastAttestation.IsZero() {
+		lastAttestation := n.lastAttestation
+		status.Host.LastAttestation = &lastAttestation
+		status.Host.AttestationAge = uint64(time.Since(lastAttestation).Seconds())
+	}
 
 	return &status, nil
 }
@@ -587,6 +613,61 @@ func (n *Node) handleNewBlockLocked(blk *block.Block, height int64) {
 	}
 }
 
+// Guarded by n.CrossNode.
+func (n *Node) updateAttestationLocked(capTEE *node.CapabilityTEE) {
+	n.lastAttestation = time.Time{}
+	n.lastAttestationHeight = 0
+	if capTEE == nil {
+		return
+	}
+
+	ts, height, err := attestationTime(capTEE)
+	if err != nil {
+		n.logger.Warn("failed to determine hosted runtime attestation time",
+			"err", err,
+		)
+		return
+	}
+	n.lastAttestation = ts
+	n.lastAttestationHeight = height
+}
+
+// attestationTime returns the time at which the given TEE attestation was made, as reported by
+// the attestation quote.
+//
+// PCS quotes do not include a timestamp, in which case the consensus height the attestation was
+// made at is returned instead.
+func attestationTime(capTEE *node.CapabilityTEE) (time.Time, int64, error) {
+	if capTEE.Hardware != node.TEEHardwareIntelSGX {
+		return time.Time{}, 0, fmt.Errorf("unsupported TEE hardware: %s", capTEE.Hardware)
+	}
+
+	var sa node.SGXAttestation
+	if err := cbor.Unmarshal(capTEE.Attestation, &sa); err != nil {
+		return time.Time{}, 0, fmt.Errorf("malformed SGX attestation: %w", err)
+	}
+
+	switch {
+	case sa.Quote.IAS != nil:
+		avr, err := ias.UnsafeDecodeAVR(sa.Quote.IAS.Body)
+		if err != nil {
+			return time.Time{}, 0, fmt.Errorf("malformed AVR: %w", err)
+		}
+		ts, err := time.Parse(ias.TimestampFormat, avr.Timestamp)
+		if err != nil {
+			return time.Time{}, 0, fmt.Errorf("malformed AVR timestamp: %w", err)
+		}
+		return ts, 0, nil
+	case sa.Quote.PCS != nil:
+		if sa.Height == 0 {
+			return time.Time{}, 0, fmt.Errorf("attestation height not available")
+		}
+		return time.Time{}, int64(sa.Height), nil
+	default:
+		return time.Time{}, 0, fmt.Errorf("missing SGX quote")
+	}
+}
+
 // Guarded by n.CrossNode.
 func (n *Node) handleRuntimeHostEventLocked(ev *host.Event) {
 	n.logger.Debug("got runtime event", "ev", ev)
@@ -594,8 +675,14 @@ func (n *Node) handleRuntimeHostEventLocked(ev *host.Event) {
 	switch {
 	case ev.Started != nil:
 		atomic.StoreUint32(&n.hostedRuntimeProvisioned, 1)
+		n.updateAttestationLocked(ev.Started.CapabilityTEE)
+	case ev.Updated != nil:
+		if ev.Updated.CapabilityTEE != nil {
+			n.updateAttestationLocked(ev.Updated.CapabilityTEE)
+		}
 	case ev.FailedToStart != nil, ev.Stopped != nil:
 		atomic.StoreUint32(&n.hostedRuntimeProvisioned, 0)
+		n.updateAttestationLocked(nil)
 	}
 
 	for _, hooks := range n.hooks {
@@ -718,6 +805,10 @@ func (n *Node) worker() {
 	n.notifier.Start()
 	defer n.notifier.Stop()
 
+	// Start watching the main processing loop for stalls.
+	n.watchdog.Start()
+	defer n.watchdog.Stop()
+
 	// Enter the main processing loop.
 	initialized := false
 	for {
@@ -750,20 +841,25 @@ func (n *Node) worker() {
 			}
 
 			// Received a block (annotated).
+			n.watchdog.Begin("block")
 			func() {
 				n.CrossNode.Lock()
 				defer n.CrossNode.Unlock()
 				n.handleNewBlockLocked(blk.Block, blk.Height)
 			}()
+			n.watchdog.End()
 		case ev := <-hrtEventCh:
 			// Received a hosted runtime event.
+			n.watchdog.Begin("runtime_event")
 			func() {
 				n.CrossNode.Lock()
 				defer n.CrossNode.Unlock()
 				n.handleRuntimeHostEventLocked(ev)
 			}()
+			n.watchdog.End()
 		case comp := <-compCh:
 			// Received a new version of a runtime component.
+			n.watchdog.Begin("component")
 			if err := n.ProvisionHostedRuntimeComponent(comp); err != nil {
 				n.logger.Error("failed to provision hosted runtime",
 					"err", err,
@@ -777,7 +873,7 @@ func (n *Node) worker() {
 				defer n.CrossNode.Unlock()
 				n.updateHostedRuntimeVersionLocked()
 			}()
-
+			n.watchdog.End()
 		}
 	}
 }
@@ -900,6 +996,7 @@ func NewNode(
 		stopCh:          make(chan struct{}),
 		quitCh:          make(chan struct{}),
 		initCh:          make(chan struct{}),
+		watchdog:        watchdog.New("worker/common/committee/"+runtime.ID().String(), config.GlobalConfig.Runtime.Watchdog.StallTimeout),
 		logger:          logging.GetLogger("worker/common/committee").With("runtime_id", runtime.ID()),
 	}
 
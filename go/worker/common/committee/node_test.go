@@ -0,0 +1,67 @@
+package committee
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/pcs"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/quote"
+)
+
+func TestAttestationTime(t *testing.T) {
+	require := require.New(t)
+
+	newCapabilityTEE := func(sa *node.SGXAttestation) *node.CapabilityTEE {
+		return &node.CapabilityTEE{
+			Hardware:    node.TEEHardwareIntelSGX,
+			Attestation: cbor.Marshal(sa),
+		}
+	}
+
+	// IAS quotes report the time of the attestation.
+	ias.SetAllowDebugEnclaves()
+	defer ias.UnsetAllowDebugEnclaves()
+
+	body, err := os.ReadFile("../../../common/sgx/ias/testdata/avr_v4_body_sw_hardening_needed.json")
+	require.NoError(err, "ReadFile")
+	ts, height, err := attestationTime(newCapabilityTEE(&node.SGXAttestation{
+		Versioned: cbor.NewVersioned(node.LatestSGXAttestationVersion),
+		Quote:     quote.Quote{IAS: &ias.AVRBundle{Body: body}},
+	}))
+	require.NoError(err, "attestationTime IAS")
+	require.Equal(time.Date(2020, 5, 11, 9, 21, 15, 454051000, time.UTC), ts)
+	require.Zero(height)
+
+	// PCS quotes report the consensus height of the attestation.
+	ts, height, err = attestationTime(newCapabilityTEE(&node.SGXAttestation{
+		Versioned: cbor.NewVersioned(node.LatestSGXAttestationVersion),
+		Quote:     quote.Quote{PCS: &pcs.QuoteBundle{}},
+		Height:    42,
+	}))
+	require.NoError(err, "attestationTime PCS")
+	require.True(ts.IsZero())
+	require.EqualValues(42, height)
+
+	// PCS quotes without a height cannot be timed.
+	_, _, err = attestationTime(newCapabilityTEE(&node.SGXAttestation{
+		Versioned: cbor.NewVersioned(node.LatestSGXAttestationVersion),
+		Quote:     quote.Quote{PCS: &pcs.QuoteBundle{}},
+	}))
+	require.Error(err, "attestationTime should fail without a height")
+
+	// Malformed attestations should be rejected.
+	_, _, err = attestationTime(&node.CapabilityTEE{
+		Hardware:    node.TEEHardwareIntelSGX,
+		Attestation: []byte("malformed"),
+	})
+	require.Error(err, "attestationTime should fail for malformed attestations")
+
+	_, _, err = attestationTime(&node.CapabilityTEE{Hardware: node.TEEHardwareInvalid})
+	require.Error(err, "attestationTime should fail for unsupported hardware")
+}
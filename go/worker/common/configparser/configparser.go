@@ -10,6 +10,8 @@ import (
 )
 
 // ParseAddressList parses addresses.
+//
+// Addresses referring to hostnames are preserved and not resolved.
 func ParseAddressList(addresses []string) ([]node.Address, error) {
 	var output []node.Address
 	for _, rawAddress := range addresses {
@@ -23,14 +25,13 @@ func ParseAddressList(addresses []string) ([]node.Address, error) {
 			return nil, fmt.Errorf("malformed port: %s", rawPort)
 		}
 
-		ip := net.ParseIP(rawIP)
-		if ip == nil {
-			return nil, fmt.Errorf("malformed ip address: %s", rawIP)
-		}
-
 		var address node.Address
-		if err := address.FromIP(ip, uint16(port)); err != nil {
-			return nil, fmt.Errorf("unknown address family: %s", rawIP)
+		if ip := net.ParseIP(rawIP); ip != nil {
+			if err := address.FromIP(ip, uint16(port)); err != nil {
+				return nil, fmt.Errorf("unknown address family: %s", rawIP)
+			}
+		} else if err := address.FromHostname(rawIP, uint16(port)); err != nil {
+			return nil, fmt.Errorf("malformed ip address or hostname: %s", rawIP)
 		}
 
 		output = append(output, address)
@@ -15,6 +15,8 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/watchdog"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
 	p2pProtocol "github.com/oasisprotocol/oasis-core/go/p2p/protocol"
@@ -99,6 +101,8 @@ type Node struct { // nolint: maligned
 	poolRank      uint64
 	proposedBatch *proposedBatch
 
+	watchdog *watchdog.Watchdog
+
 	logger *logging.Logger
 }
 
@@ -1343,6 +1347,10 @@ func (n *Node) worker() {
 		}
 	}()
 
+	// Start watching the round worker for stalls.
+	n.watchdog.Start()
+	defer n.watchdog.Stop()
+
 	// Restart the round worker every time a runtime block is finalized.
 	for {
 		var bi *runtime.BlockInfo
@@ -1365,7 +1373,11 @@ func (n *Node) worker() {
 			case <-n.stopCh:
 			case bi = <-n.blockInfoCh:
 			}
+
+			// The round worker should stop promptly once canceled.
+			n.watchdog.Begin("round_worker_stop")
 		}()
+		n.watchdog.End()
 
 		// Round worker stopped, so it is safe to update the last block info.
 		n.blockInfo = bi
@@ -1568,6 +1580,7 @@ func NewNode(
 		processedBatchCh: make(chan *processedBatch, 1),
 		reselectCh:       make(chan struct{}, 1),
 		missingTxCh:      make(chan [][]byte, 1),
+		watchdog:         watchdog.New("worker/executor/committee/"+commonNode.Runtime.ID().String(), config.GlobalConfig.Runtime.Watchdog.StallTimeout),
 		logger:           logging.GetLogger("worker/executor/committee").With("runtime_id", commonNode.Runtime.ID()),
 	}
 
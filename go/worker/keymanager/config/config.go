@@ -1,6 +1,8 @@
 // Package config implements global configuration options.
 package config
 
+import "fmt"
+
 // ChurpConfig holds configuration details for the CHURP extension.
 type ChurpConfig struct {
 	// Schemes is a list of CHURP scheme configurations.
@@ -13,6 +15,35 @@ type ChurpSchemeConfig struct {
 	ID uint8 `yaml:"id,omitempty"`
 }
 
+// RPCRateLimitConfig holds configuration details for enclave RPC rate limiting.
+type RPCRateLimitConfig struct {
+	// Rate is the maximum sustained number of enclave RPC requests per second (0 disables rate
+	// limiting).
+	Rate float64 `yaml:"rate,omitempty"`
+	// Burst is the maximum number of enclave RPC requests that can be served at once.
+	Burst uint64 `yaml:"burst,omitempty"`
+	// PriorityReserve is the fraction of the burst that is reserved for peers on the access
+	// list, e.g., members of currently elected compute committees.
+	PriorityReserve float64 `yaml:"priority_reserve,omitempty"`
+}
+
+// Validate validates the configuration settings.
+func (c *RPCRateLimitConfig) Validate() error {
+	if c.Rate < 0 {
+		return fmt.Errorf("rate must not be negative")
+	}
+	if c.Rate == 0 {
+		return nil
+	}
+	if c.Burst == 0 {
+		return fmt.Errorf("burst must be positive when rate limiting is enabled")
+	}
+	if c.PriorityReserve < 0 || c.PriorityReserve >= 1 {
+		return fmt.Errorf("priority reserve must be in range [0, 1)")
+	}
+	return nil
+}
+
 // Config is the keymanager worker configuration structure.
 type Config struct {
 	// Key manager runtime ID.
@@ -22,10 +53,16 @@ type Config struct {
 
 	// Churp holds configuration details for the CHURP extension.
 	Churp ChurpConfig `yaml:"churp,omitempty"`
+
+	// RPCRateLimit holds configuration details for enclave RPC rate limiting.
+	RPCRateLimit RPCRateLimitConfig `yaml:"rpc_rate_limit,omitempty"`
 }
 
 // Validate validates the configuration settings.
 func (c *Config) Validate() error {
+	if err := c.RPCRateLimit.Validate(); err != nil {
+		return fmt.Errorf("rpc_rate_limit: %w", err)
+	}
 	return nil
 }
 
@@ -37,5 +74,10 @@ func DefaultConfig() Config {
 		Churp: ChurpConfig{
 			Schemes: []ChurpSchemeConfig{},
 		},
+		RPCRateLimit: RPCRateLimitConfig{
+			Rate:            0,
+			Burst:           100,
+			PriorityReserve: 0.5,
+		},
 	}
 }
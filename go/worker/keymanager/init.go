@@ -61,6 +61,7 @@ func New(
 		return nil, fmt.Errorf("worker/keymanager: failed to parse runtime ID: %w", err)
 	}
 	w.runtimeLabel = w.runtimeID.String()
+	w.rpcLimiter = newRPCLimiter(&config.GlobalConfig.Keymanager.RPCRateLimit)
 
 	var err error
 	w.roleProvider, err = r.NewRuntimeRoleProvider(node.RoleKeyManager, w.runtimeID)
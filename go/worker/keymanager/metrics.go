@@ -189,7 +189,16 @@ var (
 		[]string{"runtime", "churp", "method"},
 	)
 
+	enclaveRPCRateLimitedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_keymanager_enclave_rpc_rate_limited_count",
+			Help: "Number of enclave RPC requests rejected due to rate limiting.",
+		},
+		[]string{"runtime", "priority"},
+	)
+
 	keymanagerWorkerCollectors = []prometheus.Collector{
+		enclaveRPCRateLimitedCount,
 		computeRuntimeCount,
 		policyUpdateCount,
 		consensusEphemeralSecretEpochNumber,
@@ -0,0 +1,72 @@
+package keymanager
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/worker/keymanager/config"
+)
+
+// rpcLimiter is a token bucket rate limiter for enclave RPC requests.
+//
+// Part of the bucket is reserved for prioritized callers so that peers on the access list,
+// e.g. members of currently elected compute committees, can still be served while other
+// callers are flooding the key manager.
+type rpcLimiter struct {
+	mu sync.Mutex
+
+	rate    float64
+	burst   float64
+	reserve float64
+
+	tokens float64   // Guarded by mutex.
+	last   time.Time // Guarded by mutex.
+
+	now func() time.Time
+}
+
+// Allow returns true iff a request of a caller with the given priority may be served.
+//
+// A nil rate limiter allows all requests.
+func (l *rpcLimiter) Allow(prioritized bool) bool {
+	if l == nil {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	// Non-prioritized callers may not use the reserved tokens.
+	threshold := 1.0
+	if !prioritized {
+		threshold += l.reserve
+	}
+	if l.tokens < threshold {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// newRPCLimiter creates a new enclave RPC rate limiter, or returns nil if rate limiting is
+// disabled.
+func newRPCLimiter(cfg *config.RPCRateLimitConfig) *rpcLimiter {
+	if cfg.Rate <= 0 {
+		return nil
+	}
+
+	burst := float64(cfg.Burst)
+	return &rpcLimiter{
+		rate:    cfg.Rate,
+		burst:   burst,
+		reserve: math.Floor(burst * cfg.PriorityReserve),
+		tokens:  burst,
+		last:    time.Now(),
+		now:     time.Now,
+	}
+}
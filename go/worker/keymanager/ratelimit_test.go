@@ -0,0 +1,48 @@
+package keymanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/worker/keymanager/config"
+)
+
+func TestRPCLimiter(t *testing.T) {
+	require := require.New(t)
+
+	var disabled *rpcLimiter
+	require.Nil(newRPCLimiter(&config.RPCRateLimitConfig{}), "zero rate should disable rate limiting")
+	require.True(disabled.Allow(false), "disabled rate limiter should allow all requests")
+
+	now := time.Now()
+	l := newRPCLimiter(&config.RPCRateLimitConfig{
+		Rate:            1,
+		Burst:           4,
+		PriorityReserve: 0.5,
+	})
+	l.last = now
+	l.now = func() time.Time { return now }
+
+	// Non-prioritized callers may only use the unreserved part of the bucket.
+	require.True(l.Allow(false))
+	require.True(l.Allow(false))
+	require.False(l.Allow(false), "reserved tokens should not be used by non-prioritized callers")
+
+	// Prioritized callers may use the reserved part of the bucket.
+	require.True(l.Allow(true))
+	require.True(l.Allow(true))
+	require.False(l.Allow(true), "bucket should be empty")
+
+	// Tokens are replenished over time, but not above the burst.
+	now = now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		require.True(l.Allow(false))
+	}
+	require.False(l.Allow(false))
+	for i := 0; i < 2; i++ {
+		require.True(l.Allow(true))
+	}
+	require.False(l.Allow(true))
+}
@@ -73,6 +73,7 @@ type Worker struct { // nolint: maligned
 
 	peerMap    *PeerMap
 	accessList *AccessList
+	rpcLimiter *rpcLimiter
 
 	commonWorker     *workerCommon.Worker
 	roleProvider     registration.RoleProvider
@@ -178,6 +179,17 @@ func (w *Worker) CallEnclave(ctx context.Context, data []byte, kind enclaverpc.K
 		}
 	}
 
+	// Handle rate limiting, prioritizing peers on the access list.
+	prioritized := !w.accessList.Runtimes(peerID).Empty()
+	if !w.rpcLimiter.Allow(prioritized) {
+		priority := "low"
+		if prioritized {
+			priority = "high"
+		}
+		enclaveRPCRateLimitedCount.WithLabelValues(w.runtimeLabel, priority).Inc()
+		return nil, fmt.Errorf("rate limited")
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, rpcCallTimeout)
 	defer cancel()
 
@@ -15,6 +15,27 @@ type Config struct {
 
 	// EntityID to use as the node owner in registrations (public key).
 	EntityID string `yaml:"entity_id"`
+
+	// Capacity contains the capacity hints advertised in the node descriptor.
+	Capacity CapacityConfig `yaml:"capacity,omitempty"`
+
+	// MaxConsensusLag is the maximum number of blocks the local consensus node may lag behind
+	// its peers for registrations to be submitted. Registrations are deferred until the lag is
+	// within the limit (0 means registrations are never deferred).
+	MaxConsensusLag uint64 `yaml:"max_consensus_lag,omitempty"`
+}
+
+// CapacityConfig is the node capacity advertisement configuration structure.
+type CapacityConfig struct {
+	// MaxRuntimes is the maximum number of runtimes the node can be
+	// concurrently elected for (0 means no limit).
+	//
+	// Capacity hints are only advertised when enabled by the registry consensus parameters.
+	MaxRuntimes uint16 `yaml:"max_runtimes,omitempty"`
+
+	// TEEMemory is the amount of TEE memory (in bytes) available for hosting
+	// runtimes (0 means unknown).
+	TEEMemory uint64 `yaml:"tee_memory,omitempty"`
 }
 
 // Validate validates the configuration settings.
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/beacon/hooks"
 	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnBackoff "github.com/oasisprotocol/oasis-core/go/common/backoff"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
@@ -42,6 +44,17 @@ const (
 	DBBucketName = "worker/registration"
 
 	periodicMetricsInterval = 60 * time.Second
+
+	// consensusLagQueryTimeout is the timeout for querying the latest height known to peers.
+	consensusLagQueryTimeout = 10 * time.Second
+	// deferredRegistrationRetryInterval is the interval at which deferred registrations are
+	// retried.
+	deferredRegistrationRetryInterval = 10 * time.Second
+	// maxRegistrationDeferral is the maximum duration for which a registration is deferred due
+	// to consensus lag. As heights reported by peers are not verified, this bounds the impact of
+	// peers reporting bogus heights. Registrations are also never deferred past the expiry of
+	// the current node descriptor.
+	maxRegistrationDeferral = 30 * time.Minute
 )
 
 var (
@@ -67,6 +80,12 @@ var (
 			Help: "Is oasis node eligible for registration (binary).",
 		},
 	)
+	workerNodeRegistrationFailures = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_node_registration_failures",
+			Help: "Number of failed node (re-)registration attempts.",
+		},
+	)
 	workerNodeStatusFaults = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "oasis_worker_node_status_runtime_faults",
@@ -86,6 +105,7 @@ var (
 		workerNodeRegistered,
 		workerNodeStatusFrozen,
 		workerNodeRegistrationEligible,
+		workerNodeRegistrationFailures,
 		workerNodeStatusFaults,
 		workerNodeRuntimeSuspended,
 	}
@@ -191,6 +211,7 @@ type Worker struct { // nolint: maligned
 
 	runtimeRegistry runtimeRegistry.Registry
 	beacon          beacon.Backend
+	epochCh         chan beacon.EpochTime
 	registry        registry.Backend
 	identity        *identity.Identity
 	p2p             p2p.Service
@@ -203,8 +224,9 @@ type Worker struct { // nolint: maligned
 	initialRegCh chan struct{} // closed after initial registration
 	stopRegCh    chan struct{} // closed internally to trigger clean registration lapse
 
-	logger    *logging.Logger
-	consensus consensus.Backend
+	logger      *logging.Logger
+	consensus   consensus.Backend
+	lightClient consensus.LightClient
 
 	roleProviders []*roleProvider
 	registerCh    chan struct{}
@@ -217,6 +239,18 @@ func DebugForceAllowUnroutableAddresses() {
 	allowUnroutableAddresses = true
 }
 
+// onEpochTransition is the epoch transition hook which triggers node
+// re-registration.
+func (w *Worker) onEpochTransition(_ context.Context, epoch beacon.EpochTime) error {
+	// Only the latest epoch is relevant, replace any pending one.
+	select {
+	case <-w.epochCh:
+	default:
+	}
+	w.epochCh <- epoch
+	return nil
+}
+
 func (w *Worker) registrationLoop() { // nolint: gocyclo
 	// Delay node registration till after the consensus service has
 	// finished initial synchronization if applicable.
@@ -276,18 +310,11 @@ func (w *Worker) registrationLoop() { // nolint: gocyclo
 		}
 	}
 
-	// (re-)register the node on each epoch transition. This doesn't
-	// need to be strict block-epoch time, since it just serves to
-	// extend the node's expiration, and we add a randomized delay
-	// anyway.
-	ch, sub, err := w.beacon.WatchLatestEpoch(w.ctx)
-	if err != nil {
-		w.logger.Error("failed to watch epochs",
-			"err", err,
-		)
-		return
-	}
-	defer sub.Close()
+	// (re-)register the node on each epoch transition, as delivered by
+	// the epoch transition hook. This doesn't need to be strict
+	// block-epoch time, since it just serves to extend the node's
+	// expiration, and we add a randomized delay anyway.
+	ch := w.epochCh
 
 	regFn := func(epoch beacon.EpochTime, hook RegisterNodeHook, retry bool) error {
 		var off backoff.BackOff
@@ -341,6 +368,9 @@ func (w *Worker) registrationLoop() { // nolint: gocyclo
 
 		reregisterHeight int64 = math.MaxInt64
 
+		retryCh       <-chan time.Time
+		deferredSince time.Time
+
 		first = true
 	)
 Loop:
@@ -389,6 +419,9 @@ Loop:
 			}
 		case <-w.registerCh:
 			// Notification that a role provider has been updated.
+		case <-retryCh:
+			// Retry a deferred registration.
+			retryCh = nil
 		}
 
 		// We need to know the current epoch before we can register.
@@ -483,6 +516,23 @@ Loop:
 			continue
 		}
 
+		// Defer registration while the local consensus node is lagging behind its peers as any
+		// registration would likely be based on stale state.
+		if deferredSince.IsZero() {
+			deferredSince = time.Now()
+		}
+		if lag, deferred := w.shouldDeferRegistration(epoch, deferredSince); deferred {
+			w.logger.Warn("deferring registration as local consensus is lagging behind",
+				"lag", lag,
+				"max_lag", config.GlobalConfig.Registration.MaxConsensusLag,
+			)
+			w.setDeferred(true, lag)
+			retryCh = time.After(deferredRegistrationRetryInterval)
+			continue
+		}
+		deferredSince = time.Time{}
+		w.setDeferred(false, 0)
+
 		// Package all per-role/runtime hooks into a metahook.
 		hook := func(n *node.Node) error {
 			for _, hook := range hooks {
@@ -540,6 +590,78 @@ Loop:
 	}
 }
 
+// consensusLag returns the number of blocks the local consensus node is lagging behind the latest
+// height reported by its peers.
+func (w *Worker) consensusLag() (uint64, error) {
+	ctx, cancel := context.WithTimeout(w.ctx, consensusLagQueryTimeout)
+	defer cancel()
+
+	blk, err := w.consensus.GetBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query local height: %w", err)
+	}
+	lb, _, err := w.lightClient.GetLightBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query peer height: %w", err)
+	}
+	if lb.Height <= blk.Height {
+		return 0, nil
+	}
+	return uint64(lb.Height - blk.Height), nil
+}
+
+// shouldDeferRegistration checks whether the registration should be deferred due to the local
+// consensus node lagging behind its peers and returns the observed lag.
+//
+// The registration is not deferred once the current node descriptor is in the last epoch of its
+// validity, as the node would otherwise expire while deferring.
+func (w *Worker) shouldDeferRegistration(epoch beacon.EpochTime, deferredSince time.Time) (uint64, bool) {
+	maxLag := config.GlobalConfig.Registration.MaxConsensusLag
+	if maxLag == 0 || w.lightClient == nil {
+		return 0, false
+	}
+	if time.Since(deferredSince) > maxRegistrationDeferral {
+		w.logger.Warn("maximum registration deferral reached, registering anyway")
+		return 0, false
+	}
+	if expiration, ok := w.descriptorExpiration(); ok && uint64(epoch) >= expiration {
+		w.logger.Warn("node descriptor about to expire, registering anyway",
+			"epoch", epoch,
+			"expiration", expiration,
+		)
+		return 0, false
+	}
+
+	lag, err := w.consensusLag()
+	if err != nil {
+		// Do not block registration in case the lag cannot be determined.
+		w.logger.Warn("failed to determine consensus lag",
+			"err", err,
+		)
+		return 0, false
+	}
+	return lag, lag > maxLag
+}
+
+// descriptorExpiration returns the expiration epoch of the current node descriptor, if any.
+func (w *Worker) descriptorExpiration() (uint64, bool) {
+	w.RLock()
+	defer w.RUnlock()
+
+	if w.status.Descriptor == nil {
+		return 0, false
+	}
+	return w.status.Descriptor.Expiration, true
+}
+
+func (w *Worker) setDeferred(deferred bool, lag uint64) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.status.Deferred = deferred
+	w.status.ConsensusLag = lag
+}
+
 func (w *Worker) metricsWorker() {
 	w.logger.Info("delaying metrics worker start until initial registration")
 	select {
@@ -830,7 +952,37 @@ func (w *Worker) gatherConsensusAddresses(sentryConsensusAddrs []node.ConsensusA
 	return validatedAddrs, nil
 }
 
+func (w *Worker) buildInfo() *node.BuildInfo {
+	info := &node.BuildInfo{
+		Commit:    version.GitCommit,
+		Toolchain: version.Toolchain,
+	}
+
+	// Report build-time known enclave identities of all hosted runtime components.
+	bundleRegistry := w.runtimeRegistry.GetBundleRegistry()
+	for _, rt := range w.runtimeRegistry.Runtimes() {
+		for _, comp := range bundleRegistry.Components(rt.ID()) {
+			for _, id := range comp.Identities {
+				if len(info.Enclaves) >= node.MaxBuildEnclaves {
+					return info
+				}
+				if !slices.Contains(info.Enclaves, id.Enclave) {
+					info.Enclaves = append(info.Enclaves, id.Enclave)
+				}
+			}
+		}
+	}
+
+	return info
+}
+
 func (w *Worker) registerNode(epoch beacon.EpochTime, hook RegisterNodeHook) (err error) {
+	defer func() {
+		if err != nil {
+			workerNodeRegistrationFailures.Inc()
+		}
+	}()
+
 	identityPublic := w.identity.NodeSigner.Public()
 	w.logger.Info("performing node (re-)registration",
 		"epoch", epoch,
@@ -885,6 +1037,25 @@ func (w *Worker) registerNode(epoch beacon.EpochTime, hook RegisterNodeHook) (er
 		return err
 	}
 
+	// Only advertise optional descriptor fields that are enabled by the registry.
+	regParams, qerr := w.registry.ConsensusParameters(w.ctx, consensus.HeightLatest)
+	if qerr != nil {
+		return fmt.Errorf("failed to query registry consensus parameters: %w", qerr)
+	}
+	if regParams.EnableNodeBuildInfo {
+		nodeDesc.Build = w.buildInfo()
+	}
+	if capCfg := config.GlobalConfig.Registration.Capacity; capCfg.MaxRuntimes > 0 || capCfg.TEEMemory > 0 {
+		if regParams.EnableNodeCapacity {
+			nodeDesc.Capacity = &node.CapacityInfo{
+				MaxRuntimes: capCfg.MaxRuntimes,
+				TEEMemory:   capCfg.TEEMemory,
+			}
+		} else {
+			w.logger.Warn("not advertising node capacity: capacity hints are not enabled")
+		}
+	}
+
 	// Make sure there is at least one role to register for.
 	if nodeDesc.Roles.IsEmptyRole() {
 		w.logger.Error("not registering: no roles to register for",
@@ -1009,6 +1180,10 @@ func (w *Worker) RequestDeregistration() error {
 
 // WillNeverRegister returns true iff the worker will never register.
 func (w *Worker) WillNeverRegister() bool {
+	if config.GlobalConfig.Mode.IsArchive() {
+		// Archive nodes never register for committees.
+		return true
+	}
 	return !w.entityID.IsValid() || w.registrationSigner == nil
 }
 
@@ -1055,10 +1230,12 @@ func GetRegistrationSigner(identity *identity.Identity) (signature.PublicKey, si
 
 // New constructs a new worker node registration service.
 func New(
-	beacon beacon.Backend,
+	beaconBackend beacon.Backend,
+	epochHooks *hooks.Dispatcher,
 	registry registry.Backend,
 	identity *identity.Identity,
 	consensus consensus.Backend,
+	lightClient consensus.LightClient,
 	p2p p2p.Service,
 	workerCommonCfg *workerCommon.Config,
 	store *persistent.CommonStore,
@@ -1088,7 +1265,8 @@ func New(
 		sentryAddresses:    workerCommonCfg.SentryAddresses,
 		registrationSigner: registrationSigner,
 		runtimeRegistry:    runtimeRegistry,
-		beacon:             beacon,
+		beacon:             beaconBackend,
+		epochCh:            make(chan beacon.EpochTime, 1),
 		registry:           registry,
 		identity:           identity,
 		stopCh:             make(chan struct{}),
@@ -1098,12 +1276,20 @@ func New(
 		ctx:                context.Background(),
 		logger:             logger,
 		consensus:          consensus,
+		lightClient:        lightClient,
 		p2p:                p2p,
 		registerCh:         make(chan struct{}, 1),
 	}
 
 	w.storedDeregister = storedDeregister
 
+	if epochHooks == nil {
+		return nil, fmt.Errorf("worker/registration: epoch hooks dispatcher is required")
+	}
+	if err = epochHooks.Register("worker/registration", hooks.OrderRegistration, w.onEpochTransition); err != nil {
+		return nil, err
+	}
+
 	if config.GlobalConfig.Consensus.Validator || config.GlobalConfig.Mode == config.ModeValidator {
 		rp, err := w.NewRoleProvider(node.RoleValidator)
 		if err != nil {
@@ -1128,7 +1314,11 @@ func (w *Worker) Start() error {
 
 	// HACK: This can be ok in certain configurations.
 	if w.WillNeverRegister() {
-		w.logger.Warn("no entity/signer for this node, registration will NEVER succeed")
+		if config.GlobalConfig.Mode.IsArchive() {
+			w.logger.Info("archive mode enabled, node will never register")
+		} else {
+			w.logger.Warn("no entity/signer for this node, registration will NEVER succeed")
+		}
 		// Make sure the node is stopped on quit and that it can still respond to
 		// shutdown requests from the control api.
 		go func() {
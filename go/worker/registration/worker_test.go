@@ -0,0 +1,99 @@
+package registration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
+)
+
+type testConsensus struct {
+	consensus.Backend
+
+	height int64
+}
+
+func (c *testConsensus) GetBlock(context.Context, int64) (*consensus.Block, error) {
+	return &consensus.Block{Height: c.height}, nil
+}
+
+type testLightClient struct {
+	consensus.LightClient
+
+	height int64
+}
+
+func (lc *testLightClient) GetLightBlock(context.Context, int64) (*consensus.LightBlock, rpc.PeerFeedback, error) {
+	return &consensus.LightBlock{Height: lc.height}, nil, nil
+}
+
+func TestWillNeverRegister(t *testing.T) {
+	require := require.New(t)
+
+	defer func(mode config.NodeMode) {
+		config.GlobalConfig.Mode = mode
+	}(config.GlobalConfig.Mode)
+
+	signer := memorySigner.NewTestSigner("worker/registration: test signer")
+	w := &Worker{
+		entityID:           signer.Public(),
+		registrationSigner: signer,
+	}
+
+	config.GlobalConfig.Mode = config.ModeClient
+	require.False(w.WillNeverRegister(), "client nodes with an entity should register")
+
+	config.GlobalConfig.Mode = config.ModeArchive
+	require.True(w.WillNeverRegister(), "archive nodes should never register")
+
+	config.GlobalConfig.Mode = config.ModeClient
+	w.registrationSigner = nil
+	require.True(w.WillNeverRegister(), "nodes without a registration signer should never register")
+}
+
+func TestShouldDeferRegistration(t *testing.T) {
+	require := require.New(t)
+
+	defer func(maxLag uint64) {
+		config.GlobalConfig.Registration.MaxConsensusLag = maxLag
+	}(config.GlobalConfig.Registration.MaxConsensusLag)
+
+	w := &Worker{
+		ctx:         context.Background(),
+		consensus:   &testConsensus{height: 100},
+		lightClient: &testLightClient{height: 120},
+		logger:      logging.GetLogger("worker/registration/test"),
+	}
+	now := time.Now()
+
+	config.GlobalConfig.Registration.MaxConsensusLag = 0
+	_, deferred := w.shouldDeferRegistration(10, now)
+	require.False(deferred, "registration should not be deferred when disabled")
+
+	config.GlobalConfig.Registration.MaxConsensusLag = 10
+	lag, deferred := w.shouldDeferRegistration(10, now)
+	require.True(deferred, "registration should be deferred when lagging")
+	require.EqualValues(20, lag)
+
+	config.GlobalConfig.Registration.MaxConsensusLag = 20
+	_, deferred = w.shouldDeferRegistration(10, now)
+	require.False(deferred, "registration should not be deferred when lag is within bounds")
+
+	config.GlobalConfig.Registration.MaxConsensusLag = 10
+	_, deferred = w.shouldDeferRegistration(10, now.Add(-maxRegistrationDeferral-time.Minute))
+	require.False(deferred, "registration should not be deferred past the maximum deferral")
+
+	w.status.Descriptor = &node.Node{Expiration: 12}
+	_, deferred = w.shouldDeferRegistration(11, now)
+	require.True(deferred, "registration should be deferred while the descriptor is valid")
+	_, deferred = w.shouldDeferRegistration(12, now)
+	require.False(deferred, "registration should not be deferred past descriptor expiry")
+}
@@ -115,6 +115,7 @@ func New(backend api.Backend, identity *identity.Identity) (*Worker, error) {
 			Port:     config.GlobalConfig.Sentry.Control.Port,
 			Identity: identity,
 			AuthFunc: peerPubkeyAuth.AuthFunc,
+			Config:   &config.GlobalConfig.GRPC,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("worker/sentry: failed to create a new gRPC server: %w", err)
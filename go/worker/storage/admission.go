@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"context"
+	"crypto/ed25519"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/storage/api"
+	storageWorkerAPI "github.com/oasisprotocol/oasis-core/go/worker/storage/api"
+)
+
+// committeePeerFunc returns true iff the given TLS public key belongs to a member of the current
+// executor committee of the given runtime.
+type committeePeerFunc func(runtimeID common.Namespace, tlsKey signature.PublicKey) bool
+
+// admissionController bounds the number of concurrently served requests of the public storage
+// endpoint.
+//
+// Priority requests (availability attestations requested by authenticated members of the runtime
+// committees) are granted free slots before any queued external requests. Each class of requests
+// is rejected once as many of them are queued as there are slots.
+type admissionController struct {
+	sync.Mutex
+
+	isCommitteePeer committeePeerFunc
+
+	maxInFlight uint64
+	inFlight    uint64
+
+	priority []chan struct{}
+	normal   []chan struct{}
+}
+
+// Acquire waits for a free slot. On success, Release must be called once the request is done.
+func (a *admissionController) Acquire(ctx context.Context, priority bool) error {
+	a.Lock()
+	if a.inFlight < a.maxInFlight && (priority || len(a.priority) == 0) {
+		a.inFlight++
+		a.Unlock()
+		return nil
+	}
+	queue := &a.normal
+	if priority {
+		queue = &a.priority
+	}
+	if uint64(len(*queue)) >= a.maxInFlight {
+		a.Unlock()
+		return api.ErrLimitReached
+	}
+
+	ch := make(chan struct{})
+	*queue = append(*queue, ch)
+	a.Unlock()
+
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+	}
+
+	a.Lock()
+	defer a.Unlock()
+
+	if !a.dequeue(ch, priority) {
+		// The slot was granted concurrently with cancellation, pass it on.
+		a.releaseLocked()
+	}
+	return ctx.Err()
+}
+
+// Release releases a slot acquired by Acquire.
+func (a *admissionController) Release() {
+	a.Lock()
+	defer a.Unlock()
+
+	a.releaseLocked()
+}
+
+func (a *admissionController) releaseLocked() {
+	for _, queue := range []*[]chan struct{}{&a.priority, &a.normal} {
+		if len(*queue) > 0 {
+			// Hand the slot over to the first waiter.
+			close((*queue)[0])
+			*queue = (*queue)[1:]
+			return
+		}
+	}
+	a.inFlight--
+}
+
+// dequeue removes the given waiter from its queue and returns true iff it was still queued.
+func (a *admissionController) dequeue(ch chan struct{}, priority bool) bool {
+	queue := &a.normal
+	if priority {
+		queue = &a.priority
+	}
+	for i, c := range *queue {
+		if c == ch {
+			*queue = append((*queue)[:i], (*queue)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// isPriority returns true iff the request is an availability attestation request made by an
+// authenticated member of the runtime's executor committee.
+func (a *admissionController) isPriority(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo) bool {
+	if a.isCommitteePeer == nil || info.FullMethod != storageWorkerAPI.MethodGetAvailabilityStatement.FullName() {
+		return false
+	}
+	rq, ok := req.(*storageWorkerAPI.GetAvailabilityStatementRequest)
+	if !ok {
+		return false
+	}
+	tlsKey, ok := peerTLSPublicKey(ctx)
+	if !ok {
+		return false
+	}
+	return a.isCommitteePeer(rq.RuntimeID, tlsKey)
+}
+
+// UnaryInterceptor admits requests according to their priority.
+func (a *admissionController) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.Acquire(ctx, a.isPriority(ctx, req, info)); err != nil {
+		return nil, err
+	}
+	defer a.Release()
+
+	return handler(ctx, req)
+}
+
+// peerTLSPublicKey returns the public key of the client certificate presented in the TLS
+// handshake, if any.
+func peerTLSPublicKey(ctx context.Context) (signature.PublicKey, bool) {
+	var key signature.PublicKey
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return key, false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) != 1 {
+		return key, false
+	}
+	pk, ok := tlsInfo.State.PeerCertificates[0].PublicKey.(ed25519.PublicKey)
+	if !ok {
+		return key, false
+	}
+	if err := key.UnmarshalBinary(pk); err != nil {
+		return key, false
+	}
+	return key, true
+}
+
+func newAdmissionController(maxInFlight uint64, isCommitteePeer committeePeerFunc) *admissionController {
+	return &admissionController{
+		isCommitteePeer: isCommitteePeer,
+		maxInFlight:     maxInFlight,
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
@@ -22,6 +23,7 @@ const (
 	StatusSyncStartCheck      StorageWorkerStatus = "sync start check"
 	StatusSyncingCheckpoints  StorageWorkerStatus = "syncing checkpoints"
 	StatusSyncingRounds       StorageWorkerStatus = "syncing rounds"
+	StatusRepairingState      StorageWorkerStatus = "repairing state"
 )
 
 var (
@@ -30,6 +32,18 @@ var (
 	// ErrCantPauseCheckpointer is the error returned when trying to pause the checkpointer without
 	// setting the debug flag.
 	ErrCantPauseCheckpointer = errors.New(ModuleName, 2, "worker/storage: pausing checkpointer only available in debug mode")
+	// ErrRoundNotAvailable is the error returned when the storage roots of the requested round are
+	// not available in local storage.
+	ErrRoundNotAvailable = errors.New(ModuleName, 3, "worker/storage: round not available")
+	// ErrRuntimeNotAttested is the error returned when the attestation policy does not allow
+	// signing availability statements for the requested runtime.
+	ErrRuntimeNotAttested = errors.New(ModuleName, 4, "worker/storage: runtime not allowed by attestation policy")
+	// ErrTooManyWrites is the error returned when the write logs of the requested round have more
+	// entries than allowed by the attestation policy.
+	ErrTooManyWrites = errors.New(ModuleName, 5, "worker/storage: round exceeds attestation policy write limit")
+	// ErrWriteLogTooLarge is the error returned when the write logs of the requested round are
+	// larger than allowed by the attestation policy.
+	ErrWriteLogTooLarge = errors.New(ModuleName, 6, "worker/storage: round exceeds attestation policy write log size limit")
 )
 
 // StorageWorker is the storage worker control API interface.
@@ -41,6 +55,21 @@ type StorageWorker interface {
 	PauseCheckpointer(ctx context.Context, request *PauseCheckpointerRequest) error
 }
 
+// AvailabilityAttester is the storage availability attestation API interface.
+type AvailabilityAttester interface {
+	// GetAvailabilityStatement returns a statement signed by the node that it holds the full
+	// subtrees of all the storage roots of the given runtime round.
+	//
+	// The statement can be audited by third parties using storage.VerifyAvailability.
+	GetAvailabilityStatement(ctx context.Context, request *GetAvailabilityStatementRequest) (*storage.SignedAvailabilityStatement, error)
+}
+
+// GetAvailabilityStatementRequest is a GetAvailabilityStatement request.
+type GetAvailabilityStatementRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Round     uint64           `json:"round"`
+}
+
 // GetLastSyncedRoundRequest is a GetLastSyncedRound request.
 type GetLastSyncedRoundRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
@@ -66,4 +95,45 @@ type Status struct {
 
 	// LastFinalizedRound is the last synced and finalized round.
 	LastFinalizedRound uint64 `json:"last_finalized_round"`
+
+	// FaultyPeers are the peers that served data which failed verification. These peers are
+	// ignored during peer selection.
+	FaultyPeers []PeerFaults `json:"faulty_peers,omitempty"`
+
+	// ApplyStats are the daily payload statistics of write logs applied to local storage,
+	// oldest first.
+	ApplyStats []storage.ApplyStats `json:"apply_stats,omitempty"`
+
+	// LastScrub is the result of the last storage integrity scrub, if scrubbing is enabled.
+	LastScrub *ScrubStatus `json:"last_scrub,omitempty"`
+}
+
+// ScrubStatus is the result of a storage integrity scrub.
+type ScrubStatus struct {
+	// Time is the time when the scrub finished.
+	Time time.Time `json:"time"`
+
+	// Round is the scrubbed round.
+	Round uint64 `json:"round"`
+
+	// Corrupted is true iff corruption of local storage has been detected.
+	Corrupted bool `json:"corrupted"`
+
+	// Error is the error encountered while verifying local storage, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// PeerFaults are the verification faults observed for a storage sync peer.
+type PeerFaults struct {
+	// PeerID is the P2P identifier of the peer.
+	PeerID string `json:"peer_id"`
+
+	// Faults is the number of observed faults.
+	Faults uint64 `json:"faults"`
+
+	// LastReason is the reason of the last observed fault.
+	LastReason string `json:"last_reason"`
+
+	// LastTime is the time of the last observed fault.
+	LastTime time.Time `json:"last_time"`
 }
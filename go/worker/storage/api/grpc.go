@@ -6,6 +6,7 @@ import (
 	"google.golang.org/grpc"
 
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 )
 
 var (
@@ -15,7 +16,7 @@ var (
 	// methodGetLastSyncedRound is the GetLastSyncedRound method.
 	methodGetLastSyncedRound = serviceName.NewMethod("GetLastSyncedRound", &GetLastSyncedRoundRequest{})
 	// methodPauseCheckpointer is the PauseCheckpointer method.
-	methodPauseCheckpointer = serviceName.NewMethod("PauseCheckpointer", &PauseCheckpointerRequest{})
+	methodPauseCheckpointer = serviceName.NewMethod("PauseCheckpointer", &PauseCheckpointerRequest{}).WithMutating()
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -35,6 +36,28 @@ var (
 	}
 )
 
+var (
+	// availabilityServiceName is the gRPC service name of the availability attestation service.
+	availabilityServiceName = cmnGrpc.NewServiceName("StorageAvailability")
+
+	// MethodGetAvailabilityStatement is the GetAvailabilityStatement method.
+	MethodGetAvailabilityStatement = availabilityServiceName.NewMethod("GetAvailabilityStatement", &GetAvailabilityStatementRequest{})
+
+	// availabilityServiceDesc is the gRPC service descriptor of the availability attestation
+	// service.
+	availabilityServiceDesc = grpc.ServiceDesc{
+		ServiceName: string(availabilityServiceName),
+		HandlerType: (*AvailabilityAttester)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: MethodGetAvailabilityStatement.ShortName(),
+				Handler:    handlerGetAvailabilityStatement,
+			},
+		},
+		Streams: []grpc.StreamDesc{},
+	}
+)
+
 func handlerGetLastSyncedRound(
 	srv interface{},
 	ctx context.Context,
@@ -81,6 +104,35 @@ func handlerPauseCheckpointer(
 	return interceptor(ctx, rq, info, handler)
 }
 
+func handlerGetAvailabilityStatement(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	rq := new(GetAvailabilityStatementRequest)
+	if err := dec(rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AvailabilityAttester).GetAvailabilityStatement(ctx, rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MethodGetAvailabilityStatement.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AvailabilityAttester).GetAvailabilityStatement(ctx, req.(*GetAvailabilityStatementRequest))
+	}
+	return interceptor(ctx, rq, info, handler)
+}
+
+// RegisterAvailabilityService registers a new availability attestation service with the given
+// gRPC server.
+func RegisterAvailabilityService(server *grpc.Server, service AvailabilityAttester) {
+	server.RegisterService(&availabilityServiceDesc, service)
+}
+
 // RegisterService registers a new storage worker service with the given gRPC server.
 func RegisterService(server *grpc.Server, service StorageWorker) {
 	server.RegisterService(&serviceDesc, service)
@@ -109,3 +161,11 @@ func (c *Client) GetLastSyncedRound(ctx context.Context, req *GetLastSyncedRound
 func (c *Client) PauseCheckpointer(ctx context.Context, req *PauseCheckpointerRequest) error {
 	return c.conn.Invoke(ctx, methodPauseCheckpointer.FullName(), req, nil)
 }
+
+func (c *Client) GetAvailabilityStatement(ctx context.Context, req *GetAvailabilityStatementRequest) (*storage.SignedAvailabilityStatement, error) {
+	var rsp storage.SignedAvailabilityStatement
+	if err := c.conn.Invoke(ctx, MethodGetAvailabilityStatement.FullName(), req, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
@@ -137,11 +137,11 @@ func (n *Node) checkpointChunkFetcher(
 				pf.RecordFailure()
 				chunkReturnCh <- chunk
 			case errors.Is(err, checkpoint.ErrChunkProofVerificationFailed):
-				pf.RecordBadPeer()
+				n.recordPeerFault(pf, faultChunkProofInvalid)
 
 				// Also punish all peers that advertised this checkpoint.
 				for _, cpPeer := range chunk.checkpoint.Peers {
-					cpPeer.RecordBadPeer()
+					n.recordPeerFault(cpPeer, faultCheckpointAdvertised)
 				}
 
 				errorCh <- checkpointStatusNext
@@ -0,0 +1,91 @@
+package committee
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
+	"github.com/oasisprotocol/oasis-core/go/worker/storage/api"
+)
+
+const (
+	// faultDiffRootMismatch is the fault of serving a write log that doesn't result in the
+	// expected root.
+	faultDiffRootMismatch = "diff_root_mismatch"
+	// faultChunkProofInvalid is the fault of serving a checkpoint chunk with an invalid proof.
+	faultChunkProofInvalid = "chunk_proof_invalid"
+	// faultCheckpointAdvertised is the fault of advertising a checkpoint with invalid chunks.
+	faultCheckpointAdvertised = "checkpoint_advertised"
+
+	// maxTrackedFaultyPeers is the maximum number of faulty peers tracked for status reporting.
+	maxTrackedFaultyPeers = 1024
+)
+
+// peerFaults tracks the verification faults observed for storage sync peers.
+type peerFaults struct {
+	sync.Mutex
+
+	peers map[core.PeerID]*api.PeerFaults
+}
+
+func (pf *peerFaults) record(peerID core.PeerID, reason string) uint64 {
+	pf.Lock()
+	defer pf.Unlock()
+
+	if pf.peers == nil {
+		pf.peers = make(map[core.PeerID]*api.PeerFaults)
+	}
+	faults, ok := pf.peers[peerID]
+	if !ok {
+		if len(pf.peers) >= maxTrackedFaultyPeers {
+			return 0
+		}
+		faults = &api.PeerFaults{
+			PeerID: peerID.String(),
+		}
+		pf.peers[peerID] = faults
+	}
+	faults.Faults++
+	faults.LastReason = reason
+	faults.LastTime = time.Now()
+	return faults.Faults
+}
+
+func (pf *peerFaults) list() []api.PeerFaults {
+	pf.Lock()
+	defer pf.Unlock()
+
+	if len(pf.peers) == 0 {
+		return nil
+	}
+	list := make([]api.PeerFaults, 0, len(pf.peers))
+	for _, faults := range pf.peers {
+		list = append(list, *faults)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].PeerID < list[j].PeerID
+	})
+	return list
+}
+
+// recordPeerFault records that the given peer served data which failed verification and evicts
+// the peer so that it is ignored during peer selection.
+func (n *Node) recordPeerFault(pf rpc.PeerFeedback, reason string) {
+	faults := n.peerFaults.record(pf.PeerID(), reason)
+	storageWorkerPeerFaults.With(prometheus.Labels{
+		"runtime": n.commonNode.Runtime.ID().String(),
+		"reason":  reason,
+	}).Inc()
+
+	n.logger.Warn("evicting faulty storage sync peer",
+		"peer_id", pf.PeerID(),
+		"reason", reason,
+		"faults", faults,
+	)
+
+	pf.RecordBadPeer()
+}
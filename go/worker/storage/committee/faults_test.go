@@ -0,0 +1,30 @@
+package committee
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerFaults(t *testing.T) {
+	require := require.New(t)
+
+	var pf peerFaults
+	require.Nil(pf.list(), "no faults should be reported initially")
+
+	peerA := core.PeerID("peer-a")
+	peerB := core.PeerID("peer-b")
+
+	require.EqualValues(1, pf.record(peerB, faultChunkProofInvalid))
+	require.EqualValues(1, pf.record(peerA, faultDiffRootMismatch))
+	require.EqualValues(2, pf.record(peerA, faultCheckpointAdvertised))
+
+	list := pf.list()
+	require.Len(list, 2)
+	require.Equal(peerA.String(), list[0].PeerID, "faulty peers should be sorted")
+	require.EqualValues(2, list[0].Faults)
+	require.Equal(faultCheckpointAdvertised, list[0].LastReason)
+	require.Equal(peerB.String(), list[1].PeerID)
+	require.EqualValues(1, list[1].Faults)
+}
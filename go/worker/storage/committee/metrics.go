@@ -39,11 +39,38 @@ var (
 		[]string{"runtime"},
 	)
 
+	storageWorkerPeerFaults = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_storage_peer_faults",
+			Help: "Number of storage sync peer responses that failed verification.",
+		},
+		[]string{"runtime", "reason"},
+	)
+
+	storageWorkerStateRepairs = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_storage_state_repairs",
+			Help: "Number of local state repairs after detecting local state corruption.",
+		},
+		[]string{"runtime"},
+	)
+
+	storageWorkerScrubCorruptions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_storage_scrub_corruptions",
+			Help: "Number of local state corruptions detected by the storage integrity scrubber.",
+		},
+		[]string{"runtime"},
+	)
+
 	storageWorkerCollectors = []prometheus.Collector{
 		storageWorkerLastFullRound,
 		storageWorkerLastSyncedRound,
 		storageWorkerLastPendingRound,
 		storageWorkerRoundSyncLatency,
+		storageWorkerPeerFaults,
+		storageWorkerStateRepairs,
+		storageWorkerScrubCorruptions,
 	}
 
 	prometheusOnce sync.Once
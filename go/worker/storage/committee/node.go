@@ -10,14 +10,18 @@ import (
 	"time"
 
 	"github.com/eapache/channels"
+	"github.com/libp2p/go-libp2p/core"
+	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/workerpool"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	commonFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	p2pAPI "github.com/oasisprotocol/oasis-core/go/p2p/api"
 	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
 	registryApi "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothashApi "github.com/oasisprotocol/oasis-core/go/roothash/api"
@@ -139,6 +143,12 @@ type Node struct { // nolint: maligned
 	statusLock sync.RWMutex
 	status     api.StorageWorkerStatus
 
+	peerFaults peerFaults
+
+	attestationPolicy *attestationPolicy
+
+	lastScrub *api.ScrubStatus
+
 	blockCh    *channels.InfiniteChannel
 	diffCh     chan *fetchedDiff
 	finalizeCh chan finalizeResult
@@ -159,6 +169,7 @@ func NewNode(
 	workerCommonCfg workerCommon.Config,
 	localStorage storageApi.LocalBackend,
 	checkpointSyncCfg *CheckpointSyncConfig,
+	commonStore *persistent.CommonStore,
 ) (*Node, error) {
 	initMetrics()
 
@@ -198,6 +209,13 @@ func NewNode(
 		return nil, fmt.Errorf("bad checkpoint sync configuration: %w", err)
 	}
 
+	// Create the availability attestation policy.
+	var err error
+	n.attestationPolicy, err = newAttestationPolicy(&config.GlobalConfig.Storage.AttestationPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("bad attestation policy configuration: %w", err)
+	}
+
 	// Initialize sync state.
 	n.syncedState.Round = defaultUndefinedRound
 
@@ -244,7 +262,6 @@ func NewNode(
 			return blk.Header.StorageRoots(), nil
 		},
 	}
-	var err error
 	n.checkpointer, err = checkpoint.NewCheckpointer(
 		n.ctx,
 		localStorage.NodeDB(),
@@ -263,7 +280,27 @@ func NewNode(
 
 	// Register storage sync service.
 	commonNode.P2P.RegisterProtocolServer(storageSync.NewServer(commonNode.ChainContext, commonNode.Runtime.ID(), localStorage))
-	n.storageSync = storageSync.NewClient(commonNode.P2P, commonNode.ChainContext, commonNode.Runtime.ID())
+	var syncOpts []storageSync.ClientOption
+	if commonStore != nil {
+		syncOpts = append(syncOpts, storageSync.WithPeerStatsStore(n.ctx, commonStore))
+	}
+	if addrs := config.GlobalConfig.Storage.ReplicateFrom; len(addrs) > 0 {
+		var upstream []peer.AddrInfo
+		upstream, err = p2pAPI.AddrInfosFromConsensusAddrs(addrs)
+		if err != nil {
+			return nil, fmt.Errorf("bad replicate_from configuration: %w", err)
+		}
+		peers := make([]core.PeerID, 0, len(upstream))
+		for _, info := range upstream {
+			peers = append(peers, info.ID)
+		}
+		syncOpts = append(syncOpts, storageSync.WithUpstreamPeers(peers))
+
+		n.logger.Info("replicating runtime state from upstream storage nodes",
+			"upstream", addrs,
+		)
+	}
+	n.storageSync = storageSync.NewClient(commonNode.P2P, commonNode.ChainContext, commonNode.Runtime.ID(), syncOpts...)
 
 	// Register storage pub service if configured.
 	if rpcRoleProvider != nil {
@@ -287,6 +324,9 @@ func (n *Node) Start() error {
 	if config.GlobalConfig.Storage.Checkpointer.Enabled {
 		go n.consensusCheckpointSyncer()
 	}
+	if interval := config.GlobalConfig.Storage.Scrub.Interval; interval > 0 {
+		go n.scrubber(interval)
+	}
 	return nil
 }
 
@@ -324,9 +364,17 @@ func (n *Node) GetStatus(context.Context) (*api.Status, error) {
 	n.statusLock.RLock()
 	defer n.statusLock.RUnlock()
 
+	var applyStats []storageApi.ApplyStats
+	if p, ok := n.localStorage.(storageApi.ApplyStatsProvider); ok {
+		applyStats = p.ApplyStats()
+	}
+
 	return &api.Status{
 		LastFinalizedRound: n.syncedState.Round,
 		Status:             n.status,
+		FaultyPeers:        n.peerFaults.list(),
+		ApplyStats:         applyStats,
+		LastScrub:          n.lastScrub,
 	}, nil
 }
 
@@ -381,6 +429,41 @@ func (n *Node) GetLastSynced() (uint64, storageApi.Root, storageApi.Root) {
 	return n.syncedState.Round, io, state
 }
 
+// GetAvailabilityStatement returns a statement that the full subtrees of all the storage roots of
+// the given round are available in local storage.
+func (n *Node) GetAvailabilityStatement(ctx context.Context, round uint64) (*storageApi.AvailabilityStatement, error) {
+	if lastSynced, _, _ := n.GetLastSynced(); round > lastSynced || lastSynced == n.undefinedRound {
+		return nil, api.ErrRoundNotAvailable
+	}
+
+	blk, err := n.commonNode.Runtime.History().GetCommittedBlock(ctx, round)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block for round %d: %w", round, err)
+	}
+
+	roots := blk.Header.StorageRoots()
+	for _, root := range roots {
+		if !n.localStorage.NodeDB().HasRoot(root) {
+			return nil, api.ErrRoundNotAvailable
+		}
+	}
+	if err = n.checkAttestationPolicy(ctx, round, roots); err != nil {
+		return nil, err
+	}
+
+	epoch, err := n.commonNode.Consensus.Beacon().GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current epoch: %w", err)
+	}
+
+	return &storageApi.AvailabilityStatement{
+		Namespace:  n.commonNode.Runtime.ID(),
+		Round:      round,
+		Roots:      roots,
+		Expiration: n.attestationPolicy.statementExpiration(epoch),
+	}, nil
+}
+
 func (n *Node) fetchDiff(round uint64, prevRoot, thisRoot storageApi.Root) {
 	result := &fetchedDiff{
 		fetched:  false,
@@ -967,6 +1050,9 @@ func (n *Node) worker() { // nolint: gocyclo
 
 	heap.Init(outOfOrderDoneDiffs)
 
+	// Root that was detected to be corrupted in local storage, if any.
+	var corruptedRoot *storageApi.Root
+
 	// Try to perform initial sync from state and io checkpoints if either:
 	//
 	// - Checkpoint sync has been forced because there is insufficient information available to use
@@ -1107,6 +1193,32 @@ func (n *Node) worker() { // nolint: gocyclo
 		}
 	}
 
+	// Determine if we need to fetch any old block summaries up to the given round. In case the
+	// first round is an undefined round, we need to start with the following round since the
+	// undefined round may be unsigned -1 and in this case the loop would not do any iterations.
+	fetchSummaries := func(round uint64) {
+		startSummaryRound := lastFullyAppliedRound
+		if startSummaryRound == n.undefinedRound {
+			startSummaryRound++
+		}
+		for i := startSummaryRound; i < round; i++ {
+			if _, ok := hashCache[i]; ok {
+				continue
+			}
+			var oldBlock *block.Block
+			oldBlock, err = n.commonNode.Runtime.History().GetCommittedBlock(n.ctx, i)
+			if err != nil {
+				n.logger.Error("can't get block for round",
+					"err", err,
+					"round", i,
+					"current_round", round,
+				)
+				panic("can't get block in storage worker")
+			}
+			hashCache[i] = summaryFromBlock(oldBlock)
+		}
+	}
+
 	n.statusLock.Lock()
 	n.status = api.StatusSyncingRounds
 	n.statusLock.Unlock()
@@ -1144,7 +1256,17 @@ mainLoop:
 				case err == nil:
 					lastDiff.pf.RecordSuccess()
 				case errors.Is(err, storageApi.ErrExpectedRootMismatch):
-					lastDiff.pf.RecordBadPeer()
+					n.recordPeerFault(lastDiff.pf, faultDiffRootMismatch)
+				case errors.Is(err, storageApi.ErrNodeNotFound):
+					// The write log could not be applied as parts of the local source root are
+					// missing, which means that the local state is corrupted.
+					n.logger.Error("local state corruption detected",
+						logging.LogEvent, LogEventStateCorruptionDetected,
+						"err", err,
+						"root", lastDiff.prevRoot,
+					)
+					lastDiff.pf.RecordSuccess()
+					corruptedRoot = &lastDiff.prevRoot
 				default:
 					n.logger.Error("can't apply write log",
 						"err", err,
@@ -1156,9 +1278,34 @@ mainLoop:
 			}
 
 			syncing := syncingRounds[lastDiff.round]
-			if err != nil {
+			switch {
+			case corruptedRoot != nil && !n.checkpointSyncCfg.Disabled:
+				// Restore the state from checkpoints and resume syncing from there.
+				var summary *blockSummary
+				summary, err = n.repairState(*corruptedRoot, &fetcherGroup)
+				if err != nil {
+					n.logger.Error("failed to repair local state",
+						"err", err,
+					)
+					break mainLoop
+				}
+				corruptedRoot = nil
+
+				cachedLastRound = summary.Round
+				lastFullyAppliedRound = summary.Round
+				outOfOrderDoneDiffs = &outOfOrderRoundQueue{}
+				outOfOrderFinalizable = &outOfOrderRoundQueue{}
+				syncingRounds = make(map[uint64]*inFlight)
+				hashCache = make(map[uint64]*blockSummary)
+				if latestBlockRound < lastFullyAppliedRound {
+					latestBlockRound = lastFullyAppliedRound
+				}
+				fetchSummaries(latestBlockRound + 1)
+				n.nudgeAvailability(cachedLastRound, latestBlockRound)
+				triggerRoundFetches()
+			case err != nil:
 				syncing.retry(lastDiff.thisRoot.Type)
-			} else {
+			default:
 				// Check if we have fully synced the given round. If we have, we can proceed
 				// with the Finalize operation.
 				syncing.outstanding.remove(lastDiff.thisRoot.Type)
@@ -1228,30 +1375,7 @@ mainLoop:
 				dummy.Roots[1].Empty()
 				hashCache[lastFullyAppliedRound] = &dummy
 			}
-			// Determine if we need to fetch any old block summaries. In case the first
-			// round is an undefined round, we need to start with the following round
-			// since the undefined round may be unsigned -1 and in this case the loop
-			// would not do any iterations.
-			startSummaryRound := lastFullyAppliedRound
-			if startSummaryRound == n.undefinedRound {
-				startSummaryRound++
-			}
-			for i := startSummaryRound; i < blk.Header.Round; i++ {
-				if _, ok := hashCache[i]; ok {
-					continue
-				}
-				var oldBlock *block.Block
-				oldBlock, err = n.commonNode.Runtime.History().GetCommittedBlock(n.ctx, i)
-				if err != nil {
-					n.logger.Error("can't get block for round",
-						"err", err,
-						"round", i,
-						"current_round", blk.Header.Round,
-					)
-					panic("can't get block in storage worker")
-				}
-				hashCache[i] = summaryFromBlock(oldBlock)
-			}
+			fetchSummaries(blk.Header.Round)
 			if _, ok := hashCache[blk.Header.Round]; !ok {
 				hashCache[blk.Header.Round] = summaryFromBlock(blk)
 			}
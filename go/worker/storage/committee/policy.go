@@ -0,0 +1,160 @@
+package committee
+
+import (
+	"context"
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	storageApi "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
+	"github.com/oasisprotocol/oasis-core/go/worker/storage/api"
+	workerStorage "github.com/oasisprotocol/oasis-core/go/worker/storage/config"
+)
+
+// attestationPolicy restricts the rounds for which the node signs availability statements.
+type attestationPolicy struct {
+	runtimes map[common.Namespace]struct{}
+
+	maxWriteLogEntries uint64
+	maxWriteLogSize    uint64
+
+	statementValidity beacon.EpochTime
+}
+
+func newAttestationPolicy(cfg *workerStorage.AttestationPolicyConfig) (*attestationPolicy, error) {
+	p := &attestationPolicy{
+		maxWriteLogEntries: cfg.MaxWriteLogEntries,
+		statementValidity:  beacon.EpochTime(cfg.StatementValidity),
+	}
+	if cfg.MaxWriteLogSize != "" {
+		p.maxWriteLogSize = uint64(config.ParseSizeInBytes(cfg.MaxWriteLogSize))
+	}
+	if len(cfg.Runtimes) > 0 {
+		p.runtimes = make(map[common.Namespace]struct{}, len(cfg.Runtimes))
+		for _, id := range cfg.Runtimes {
+			var ns common.Namespace
+			if err := ns.UnmarshalHex(id); err != nil {
+				return nil, fmt.Errorf("malformed runtime identifier %s: %w", id, err)
+			}
+			p.runtimes[ns] = struct{}{}
+		}
+	}
+	return p, nil
+}
+
+// checkRuntime checks whether the policy allows attesting rounds of the given runtime.
+func (p *attestationPolicy) checkRuntime(id common.Namespace) error {
+	if p.runtimes == nil {
+		return nil
+	}
+	if _, ok := p.runtimes[id]; !ok {
+		return api.ErrRuntimeNotAttested
+	}
+	return nil
+}
+
+// statementExpiration returns the expiration epoch of statements made at the given epoch.
+func (p *attestationPolicy) statementExpiration(epoch beacon.EpochTime) beacon.EpochTime {
+	if p.statementValidity == 0 {
+		return 0
+	}
+	return epoch + p.statementValidity
+}
+
+// limitsWrites returns true iff the policy limits the write logs of attested rounds.
+func (p *attestationPolicy) limitsWrites() bool {
+	return p.maxWriteLogEntries > 0 || p.maxWriteLogSize > 0
+}
+
+// checkWriteLogs checks the given write logs of a round against the policy limits.
+func (p *attestationPolicy) checkWriteLogs(its []writelog.Iterator) error {
+	var entries, size uint64
+	for _, it := range its {
+		for {
+			more, err := it.Next()
+			if err != nil {
+				return fmt.Errorf("failed to iterate write log: %w", err)
+			}
+			if !more {
+				break
+			}
+			entry, err := it.Value()
+			if err != nil {
+				return fmt.Errorf("failed to get write log entry: %w", err)
+			}
+
+			entries++
+			size += uint64(len(entry.Key) + len(entry.Value))
+			if p.maxWriteLogEntries > 0 && entries > p.maxWriteLogEntries {
+				return api.ErrTooManyWrites
+			}
+			if p.maxWriteLogSize > 0 && size > p.maxWriteLogSize {
+				return api.ErrWriteLogTooLarge
+			}
+		}
+	}
+	return nil
+}
+
+// writeLogBaseRoot returns the root that the write log of the given root starts at, given the
+// roots of the previous round.
+//
+// IO roots are not chained and neither are any roots of the first round after the undefined
+// round, as there is no previous round. In these cases the write log starts at an empty root.
+func writeLogBaseRoot(root storageApi.Root, prevRoots []storageApi.Root) storageApi.Root {
+	if root.Type != storageApi.RootTypeIO {
+		for _, r := range prevRoots {
+			if r.Type == root.Type {
+				return r
+			}
+		}
+	}
+
+	emptyRoot := storageApi.Root{
+		Namespace: root.Namespace,
+		Version:   root.Version,
+		Type:      root.Type,
+	}
+	emptyRoot.Hash.Empty()
+	return emptyRoot
+}
+
+// checkAttestationPolicy checks whether the attestation policy allows signing an availability
+// statement for the given round with the given roots.
+func (n *Node) checkAttestationPolicy(ctx context.Context, round uint64, roots []storageApi.Root) error {
+	if err := n.attestationPolicy.checkRuntime(n.commonNode.Runtime.ID()); err != nil {
+		return err
+	}
+	if !n.attestationPolicy.limitsWrites() {
+		return nil
+	}
+
+	// The first round after the undefined round has no previous round (and for a genesis
+	// round of zero, round-1 would underflow), so all of its write logs start at empty roots.
+	var prevRoots []storageApi.Root
+	if round != n.undefinedRound+1 {
+		prevBlk, err := n.commonNode.Runtime.History().GetCommittedBlock(ctx, round-1)
+		if err != nil {
+			return fmt.Errorf("failed to get block for round %d: %w", round-1, err)
+		}
+		prevRoots = prevBlk.Header.StorageRoots()
+	}
+
+	ndb := n.localStorage.NodeDB()
+	its := make([]writelog.Iterator, 0, len(roots))
+	for _, root := range roots {
+		prevRoot := writeLogBaseRoot(root, prevRoots)
+		if prevRoot.Hash.Equal(&root.Hash) {
+			continue
+		}
+
+		it, err := ndb.GetWriteLog(ctx, prevRoot, root)
+		if err != nil {
+			return fmt.Errorf("failed to get write log of root %s: %w", root, err)
+		}
+		its = append(its, it)
+	}
+	return n.attestationPolicy.checkWriteLogs(its)
+}
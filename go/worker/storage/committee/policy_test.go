@@ -0,0 +1,108 @@
+package committee
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	storageApi "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
+	"github.com/oasisprotocol/oasis-core/go/worker/storage/api"
+	workerStorage "github.com/oasisprotocol/oasis-core/go/worker/storage/config"
+)
+
+func TestAttestationPolicy(t *testing.T) {
+	require := require.New(t)
+
+	allowed := common.NewTestNamespaceFromSeed([]byte("attestation policy allowed"), 0)
+	other := common.NewTestNamespaceFromSeed([]byte("attestation policy other"), 0)
+
+	writeLogs := func() []writelog.Iterator {
+		return []writelog.Iterator{
+			writelog.NewStaticIterator(writelog.WriteLog{
+				{Key: []byte("key 1"), Value: []byte("value 1")},
+				{Key: []byte("key 2"), Value: []byte("value 2")},
+			}),
+			writelog.NewStaticIterator(writelog.WriteLog{
+				{Key: []byte("key 3"), Value: []byte("value 3")},
+			}),
+		}
+	}
+
+	// An empty policy should allow everything.
+	p, err := newAttestationPolicy(&workerStorage.AttestationPolicyConfig{})
+	require.NoError(err, "newAttestationPolicy")
+	require.NoError(p.checkRuntime(other))
+	require.False(p.limitsWrites())
+	require.NoError(p.checkWriteLogs(writeLogs()))
+	require.EqualValues(0, p.statementExpiration(10), "statements should not expire by default")
+
+	// Malformed runtime identifiers should be rejected.
+	_, err = newAttestationPolicy(&workerStorage.AttestationPolicyConfig{
+		Runtimes: []string{"not a runtime"},
+	})
+	require.Error(err, "newAttestationPolicy should fail for malformed runtime identifiers")
+
+	p, err = newAttestationPolicy(&workerStorage.AttestationPolicyConfig{
+		Runtimes:           []string{allowed.Hex()},
+		MaxWriteLogEntries: 3,
+		MaxWriteLogSize:    "36",
+		StatementValidity:  2,
+	})
+	require.NoError(err, "newAttestationPolicy")
+	require.NoError(p.checkRuntime(allowed))
+	require.ErrorIs(p.checkRuntime(other), api.ErrRuntimeNotAttested)
+	require.True(p.limitsWrites())
+	require.EqualValues(12, p.statementExpiration(10))
+	require.NoError(p.checkWriteLogs(writeLogs()))
+
+	// Limits should apply across all write logs of a round.
+	p.maxWriteLogEntries = 2
+	require.ErrorIs(p.checkWriteLogs(writeLogs()), api.ErrTooManyWrites)
+
+	p.maxWriteLogEntries = 0
+	p.maxWriteLogSize = 35
+	require.ErrorIs(p.checkWriteLogs(writeLogs()), api.ErrWriteLogTooLarge)
+}
+
+func TestWriteLogBaseRoot(t *testing.T) {
+	require := require.New(t)
+
+	ns := common.NewTestNamespaceFromSeed([]byte("write log base root"), 0)
+	newRoot := func(typ storageApi.RootType, version uint64, seed string) storageApi.Root {
+		root := storageApi.Root{
+			Namespace: ns,
+			Version:   version,
+			Type:      typ,
+		}
+		root.Hash = hash.NewFromBytes([]byte(seed))
+		return root
+	}
+	emptyRoot := func(typ storageApi.RootType, version uint64) storageApi.Root {
+		root := storageApi.Root{
+			Namespace: ns,
+			Version:   version,
+			Type:      typ,
+		}
+		root.Hash.Empty()
+		return root
+	}
+
+	stateRoot := newRoot(storageApi.RootTypeState, 5, "state 5")
+	ioRoot := newRoot(storageApi.RootTypeIO, 5, "io 5")
+	prevRoots := []storageApi.Root{
+		newRoot(storageApi.RootTypeIO, 4, "io 4"),
+		newRoot(storageApi.RootTypeState, 4, "state 4"),
+	}
+
+	// State roots are chained to the previous round.
+	require.Equal(prevRoots[1], writeLogBaseRoot(stateRoot, prevRoots))
+	// IO roots are not chained.
+	require.Equal(emptyRoot(storageApi.RootTypeIO, 5), writeLogBaseRoot(ioRoot, prevRoots))
+
+	// The first round has no previous round, so all roots start at empty roots.
+	require.Equal(emptyRoot(storageApi.RootTypeState, 0), writeLogBaseRoot(newRoot(storageApi.RootTypeState, 0, "state 0"), nil))
+	require.Equal(emptyRoot(storageApi.RootTypeIO, 0), writeLogBaseRoot(newRoot(storageApi.RootTypeIO, 0, "io 0"), nil))
+}
@@ -0,0 +1,136 @@
+package committee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	cmnBackoff "github.com/oasisprotocol/oasis-core/go/common/backoff"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	storageApi "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/worker/storage/api"
+)
+
+const (
+	// LogEventStateCorruptionDetected is a log event value that signals that local state
+	// corruption has been detected.
+	LogEventStateCorruptionDetected = "worker/storage/state-corruption-detected"
+	// LogEventStateRepaired is a log event value that signals that local state has been repaired.
+	LogEventStateRepaired = "worker/storage/state-repaired"
+
+	// maxStateRepairAttempts is the maximum number of attempts at restoring corrupted local state
+	// from checkpoints before giving up.
+	maxStateRepairAttempts = 10
+	// maxStateRepairRetryDelay is the maximum delay between state repair attempts.
+	maxStateRepairRetryDelay = 5 * time.Minute
+)
+
+// ErrStateRepairFailed is the error returned when corrupted local state could not be restored
+// from checkpoints, e.g., because no peer serves a usable checkpoint.
+var ErrStateRepairFailed = errors.New("storage: failed to repair local state from checkpoints")
+
+// repairState recovers from corruption of the given local root by restoring the state from the
+// most recent checkpoint available from peers.
+//
+// All in-flight fetches and finalizations must be tracked by the given wait group and are waited
+// for before the repair starts. Their results are discarded.
+//
+// Failed restores are retried with an exponential back-off. After maxStateRepairAttempts failed
+// attempts, ErrStateRepairFailed is returned.
+func (n *Node) repairState(root storageApi.Root, fetcherGroup *sync.WaitGroup) (*blockSummary, error) {
+	storageWorkerStateRepairs.With(n.getMetricLabels()).Inc()
+
+	n.statusLock.Lock()
+	prevStatus := n.status
+	n.status = api.StatusRepairingState
+	n.statusLock.Unlock()
+	defer func() {
+		n.statusLock.Lock()
+		n.status = prevStatus
+		n.statusLock.Unlock()
+	}()
+
+	n.logger.Warn("repairing corrupted local state from checkpoints",
+		"root", root,
+	)
+
+	// Wait for all in-flight operations to complete.
+	doneCh := make(chan struct{})
+	go func() {
+		fetcherGroup.Wait()
+		close(doneCh)
+	}()
+Drain:
+	for {
+		select {
+		case <-n.diffCh:
+		case finalized := <-n.finalizeCh:
+			// Make sure the synced state reflects all finalized rounds as otherwise checkpoints
+			// which have already been finalized could be selected for the restore.
+			if finalized.err != nil {
+				continue
+			}
+			if _, err := n.flushSyncedState(finalized.summary); err != nil {
+				return nil, fmt.Errorf("failed to flush synced state: %w", err)
+			}
+		case <-doneCh:
+			break Drain
+		}
+	}
+
+	off := cmnBackoff.NewExponentialBackOff()
+	off.InitialInterval = checkpointSyncRetryDelay
+	off.MaxInterval = maxStateRepairRetryDelay
+	summary, err := retryStateRepair(n.ctx, off, n.logger, func() (*blockSummary, error) {
+		return n.syncCheckpoints(n.undefinedRound+1, false)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = n.flushSyncedState(summary); err != nil {
+		return nil, fmt.Errorf("failed to flush synced state: %w", err)
+	}
+	n.logger.Info("local state repaired from checkpoints",
+		logging.LogEvent, LogEventStateRepaired,
+		"corrupted_root", root,
+		"round", summary.Round,
+	)
+	return summary, nil
+}
+
+// retryStateRepair calls restore until it succeeds, backing off between attempts. It gives up
+// after maxStateRepairAttempts failed attempts.
+func retryStateRepair(
+	ctx context.Context,
+	off backoff.BackOff,
+	logger *logging.Logger,
+	restore func() (*blockSummary, error),
+) (*blockSummary, error) {
+	off.Reset()
+	for attempt := 1; ; attempt++ {
+		summary, err := restore()
+		if err == nil {
+			return summary, nil
+		}
+		if attempt >= maxStateRepairAttempts {
+			return nil, fmt.Errorf("%w: giving up after %d attempts: %w", ErrStateRepairFailed, attempt, err)
+		}
+
+		delay := off.NextBackOff()
+		logger.Info("state repair failed, retrying",
+			"err", err,
+			"attempt", attempt,
+			"delay", delay,
+		)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
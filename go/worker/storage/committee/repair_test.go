@@ -0,0 +1,55 @@
+package committee
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+func TestRetryStateRepair(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	logger := logging.GetLogger("worker/storage/committee/test")
+	errRestore := fmt.Errorf("no usable checkpoints")
+
+	// Restores should be retried until they succeed.
+	var attempts int
+	summary, err := retryStateRepair(ctx, &backoff.ZeroBackOff{}, logger, func() (*blockSummary, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errRestore
+		}
+		return &blockSummary{Round: 42}, nil
+	})
+	require.NoError(err, "retryStateRepair")
+	require.EqualValues(42, summary.Round)
+	require.Equal(3, attempts)
+
+	// Restores should not be retried forever.
+	attempts = 0
+	_, err = retryStateRepair(ctx, &backoff.ZeroBackOff{}, logger, func() (*blockSummary, error) {
+		attempts++
+		return nil, errRestore
+	})
+	require.ErrorIs(err, ErrStateRepairFailed, "retryStateRepair should give up")
+	require.ErrorIs(err, errRestore, "retryStateRepair should return the last error")
+	require.Equal(maxStateRepairAttempts, attempts)
+
+	// Retries should stop once the context is cancelled.
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	attempts = 0
+	_, err = retryStateRepair(cctx, &backoff.ConstantBackOff{Interval: time.Hour}, logger, func() (*blockSummary, error) {
+		attempts++
+		return nil, errRestore
+	})
+	require.ErrorIs(err, context.Canceled, "retryStateRepair should stop on cancellation")
+	require.Equal(1, attempts)
+}
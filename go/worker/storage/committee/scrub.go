@@ -0,0 +1,106 @@
+package committee
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	storageApi "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	dbApi "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/worker/storage/api"
+)
+
+// scrubPrefetch is the number of items fetched from local storage in each iteration request
+// while scrubbing.
+const scrubPrefetch = 1000
+
+// scrubRoot walks the complete tree under the given root in the local node database and verifies
+// that the hashes of all nodes match.
+//
+// The tree is read through a verifying tree backed by the local node database so that the hashes
+// of all fetched nodes are recomputed and checked against the root.
+func scrubRoot(ctx context.Context, ndb dbApi.NodeDB, root storageApi.Root) error {
+	rs := mkvs.NewWithRoot(nil, ndb, root)
+	defer rs.Close()
+
+	return storageApi.IterateRange(ctx, rs, root, nil, nil, scrubPrefetch, func([]byte, []byte) error {
+		return nil
+	})
+}
+
+// scrub verifies the integrity of all non-empty roots of the last finalized round.
+func (n *Node) scrub() {
+	round, ioRoot, stateRoot := n.GetLastSynced()
+	if round == n.undefinedRound {
+		return
+	}
+
+	ndb := n.localStorage.NodeDB()
+	status := &api.ScrubStatus{
+		Round: round,
+	}
+	for _, root := range []storageApi.Root{ioRoot, stateRoot} {
+		if root.Hash.IsEmpty() {
+			continue
+		}
+
+		err := scrubRoot(n.ctx, ndb, root)
+		if err == nil {
+			continue
+		}
+		if n.ctx.Err() != nil {
+			return
+		}
+		if !ndb.HasRoot(root) {
+			// The root has been pruned while it was being scrubbed.
+			n.logger.Debug("scrubbed root no longer exists",
+				"root", root,
+			)
+			return
+		}
+
+		n.logger.Error("local state corruption detected by the storage scrubber",
+			"err", err,
+			"root", root,
+			logging.LogEvent, LogEventStateCorruptionDetected,
+		)
+		storageWorkerScrubCorruptions.With(n.getMetricLabels()).Inc()
+
+		status.Corrupted = true
+		status.Error = fmt.Sprintf("root %s: %s", root, err)
+		break
+	}
+	status.Time = time.Now()
+
+	n.statusLock.Lock()
+	n.lastScrub = status
+	n.statusLock.Unlock()
+}
+
+// scrubber periodically verifies the integrity of the last finalized round in local storage.
+func (n *Node) scrubber(interval time.Duration) {
+	select {
+	case <-n.ctx.Done():
+		return
+	case <-n.initCh:
+	}
+
+	n.logger.Info("starting storage integrity scrubber",
+		"interval", interval,
+	)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n.scrub()
+	}
+}
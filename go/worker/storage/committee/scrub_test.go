@@ -0,0 +1,70 @@
+package committee
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	dbApi "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	badgerDb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+// corruptingNodeDB is a node database that corrupts the value of the leaf with the given key.
+type corruptingNodeDB struct {
+	dbApi.NodeDB
+
+	key []byte
+}
+
+func (d *corruptingNodeDB) GetNode(root node.Root, ptr *node.Pointer) (node.Node, error) {
+	n, err := d.NodeDB.GetNode(root, ptr)
+	if err != nil {
+		return nil, err
+	}
+	if leaf, ok := n.(*node.LeafNode); ok && string(leaf.Key) == string(d.key) {
+		corrupted := *leaf
+		corrupted.Value = []byte("corrupted")
+		return &corrupted, nil
+	}
+	return n, nil
+}
+
+func TestScrubRoot(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	ns := common.NewTestNamespaceFromSeed([]byte("storage scrub test ns"), 0)
+
+	ndb, err := badgerDb.New(&dbApi.Config{
+		Namespace:    ns,
+		MaxCacheSize: 16 * 1024 * 1024,
+		NoFsync:      true,
+		MemoryOnly:   true,
+	})
+	require.NoError(err, "badger.New")
+	defer ndb.Close()
+
+	tree := mkvs.New(nil, ndb, node.RootTypeState)
+	for i := 0; i < 2000; i++ {
+		err = tree.Insert(ctx, []byte(fmt.Sprintf("key %d", i)), []byte(fmt.Sprintf("value %d", i)))
+		require.NoError(err, "Insert")
+	}
+	_, rootHash, err := tree.Commit(ctx, ns, 1)
+	require.NoError(err, "Commit")
+	tree.Close()
+	err = ndb.Finalize([]node.Root{{Namespace: ns, Version: 1, Type: node.RootTypeState, Hash: rootHash}})
+	require.NoError(err, "Finalize")
+
+	root := node.Root{Namespace: ns, Version: 1, Type: node.RootTypeState, Hash: rootHash}
+
+	err = scrubRoot(ctx, ndb, root)
+	require.NoError(err, "scrubRoot should succeed for intact state")
+
+	err = scrubRoot(ctx, &corruptingNodeDB{NodeDB: ndb, key: []byte("key 1234")}, root)
+	require.Error(err, "scrubRoot should detect corrupted state")
+}
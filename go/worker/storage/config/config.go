@@ -2,8 +2,10 @@
 package config
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db"
 )
 
@@ -23,6 +25,76 @@ type Config struct {
 
 	// Storage checkpointer configuration.
 	Checkpointer CheckpointerConfig `yaml:"checkpointer,omitempty"`
+
+	// List of upstream storage node addresses in format P2Ppubkey@IP:port to replicate from.
+	// When set, runtime state is synced only from the given nodes.
+	ReplicateFrom []string `yaml:"replicate_from,omitempty"`
+
+	// Public read-only storage gRPC endpoint configuration.
+	PublicGRPC PublicGRPCConfig `yaml:"public_grpc,omitempty"`
+
+	// Availability attestation policy configuration.
+	AttestationPolicy AttestationPolicyConfig `yaml:"attestation_policy,omitempty"`
+
+	// Storage integrity scrubber configuration.
+	Scrub ScrubConfig `yaml:"scrub,omitempty"`
+
+	// Storage debug configuration.
+	Debug DebugConfig `yaml:"debug,omitempty"`
+}
+
+// DebugConfig is the storage worker debug configuration structure.
+type DebugConfig struct {
+	// Keep all runtime state in memory only, losing it on restart (UNSAFE).
+	//
+	// This is intended for unit tests and short-lived local networks.
+	MemoryOnly bool `yaml:"memory_only,omitempty"`
+}
+
+// AttestationPolicyConfig is the availability attestation policy configuration structure.
+//
+// The policy restricts the rounds for which the node signs availability statements.
+type AttestationPolicyConfig struct {
+	// Hex-encoded identifiers of runtimes for which availability statements are signed
+	// (empty = all runtimes).
+	Runtimes []string `yaml:"runtimes,omitempty"`
+	// Maximum number of write log entries of a round, across all its roots (0 = unlimited).
+	MaxWriteLogEntries uint64 `yaml:"max_write_log_entries,omitempty"`
+	// Maximum size of write log keys and values of a round, across all its roots
+	// (empty = unlimited).
+	MaxWriteLogSize string `yaml:"max_write_log_size,omitempty"`
+	// Number of epochs for which signed availability statements remain valid (0 = forever).
+	StatementValidity uint64 `yaml:"statement_validity,omitempty"`
+}
+
+// ScrubConfig is the storage integrity scrubber configuration structure.
+type ScrubConfig struct {
+	// Interval between storage integrity scrubs of the last finalized round (0 disables).
+	//
+	// Each scrub walks the complete state and I/O trees of the last finalized round in local
+	// storage and recomputes the hashes of all nodes.
+	Interval time.Duration `yaml:"interval,omitempty"`
+}
+
+// PublicGRPCConfig is the public read-only storage gRPC endpoint configuration structure.
+type PublicGRPCConfig struct {
+	// Enable the public read-only storage gRPC endpoint.
+	Enabled bool `yaml:"enabled"`
+	// Port of the public read-only storage gRPC endpoint.
+	Port uint16 `yaml:"port"`
+	// Maximum sustained number of requests per second per client address.
+	ClientRate float64 `yaml:"client_rate"`
+	// Maximum number of requests a single client address can make at once.
+	ClientBurst uint64 `yaml:"client_burst"`
+	// Maximum sustained number of requests per second across all clients.
+	TotalRate float64 `yaml:"total_rate"`
+	// Maximum number of requests across all clients that can be made at once.
+	TotalBurst uint64 `yaml:"total_burst"`
+	// Maximum number of requests served concurrently (0 = unlimited).
+	//
+	// Availability attestation requests of authenticated executor committee members are admitted
+	// before queued read requests.
+	MaxConcurrentRequests uint64 `yaml:"max_concurrent_requests"`
 }
 
 // CheckpointerConfig is the storage worker checkpointer configuration structure.
@@ -36,8 +108,26 @@ type CheckpointerConfig struct {
 // Validate validates the configuration settings.
 func (c *Config) Validate() error {
 	if c.Backend != "auto" {
-		_, err := db.GetBackendByName(c.Backend)
-		return err
+		if _, err := db.GetBackendByName(c.Backend); err != nil {
+			return err
+		}
+	}
+	for _, id := range c.AttestationPolicy.Runtimes {
+		var ns common.Namespace
+		if err := ns.UnmarshalHex(id); err != nil {
+			return fmt.Errorf("malformed runtime identifier in attestation_policy.runtimes: %s", id)
+		}
+	}
+	if c.PublicGRPC.Enabled {
+		if c.PublicGRPC.Port == 0 {
+			return fmt.Errorf("public_grpc.port must be set when the public endpoint is enabled")
+		}
+		if c.PublicGRPC.ClientRate <= 0 || c.PublicGRPC.ClientBurst == 0 {
+			return fmt.Errorf("public_grpc.client_rate and public_grpc.client_burst must be > 0")
+		}
+		if c.PublicGRPC.TotalRate <= 0 || c.PublicGRPC.TotalBurst == 0 {
+			return fmt.Errorf("public_grpc.total_rate and public_grpc.total_burst must be > 0")
+		}
 	}
 	return nil
 }
@@ -54,5 +144,19 @@ func DefaultConfig() Config {
 			Enabled:       false,
 			CheckInterval: 1 * time.Minute,
 		},
+		ReplicateFrom: []string{},
+		AttestationPolicy: AttestationPolicyConfig{
+			StatementValidity: 2,
+		},
+		PublicGRPC: PublicGRPCConfig{
+			Enabled:     false,
+			Port:        0,
+			ClientRate:  5,
+			ClientBurst: 20,
+			TotalRate:   100,
+			TotalBurst:  200,
+
+			MaxConcurrentRequests: 64,
+		},
 	}
 }
@@ -2,6 +2,7 @@
 package storage
 
 import (
+	"fmt"
 	"path/filepath"
 	"strings"
 
@@ -31,12 +32,18 @@ func NewLocalBackend(
 	dataDir string,
 	namespace common.Namespace,
 ) (api.LocalBackend, error) {
+	memoryOnly := config.GlobalConfig.Storage.Debug.MemoryOnly
+	if memoryOnly && !cmdFlags.DebugDontBlameOasis() {
+		return nil, fmt.Errorf("storage: memory-only storage requires debug.dont_blame_oasis")
+	}
+
 	cfg := &api.Config{
 		Backend:      strings.ToLower(config.GlobalConfig.Storage.Backend),
 		DB:           dataDir,
 		Namespace:    namespace,
 		MaxCacheSize: int64(config.ParseSizeInBytes(config.GlobalConfig.Storage.MaxCacheSize)),
 		NoFsync:      true, // Should be safe, storage will be re-applied on crashes.
+		MemoryOnly:   memoryOnly,
 	}
 
 	cfg.DB = GetLocalBackendDBDir(dataDir, cfg.Backend)
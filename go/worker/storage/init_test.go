@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+)
+
+func TestNewLocalBackendMemoryOnly(t *testing.T) {
+	require := require.New(t)
+
+	testNs := common.NewTestNamespaceFromSeed([]byte("memory-only backend test ns"), 0)
+	dataDir := t.TempDir()
+
+	config.GlobalConfig.Storage.Backend = "pathbadger"
+	config.GlobalConfig.Storage.MaxCacheSize = "16mb"
+	config.GlobalConfig.Storage.Debug.MemoryOnly = true
+	defer func() {
+		config.GlobalConfig.Storage.Debug.MemoryOnly = false
+	}()
+
+	// Memory-only storage is a debug option.
+	_, err := NewLocalBackend(dataDir, testNs)
+	require.Error(err, "NewLocalBackend should fail without debug.dont_blame_oasis")
+
+	viper.Set(cmdFlags.CfgDebugDontBlameOasis, true)
+	defer viper.Set(cmdFlags.CfgDebugDontBlameOasis, false)
+
+	backend, err := NewLocalBackend(dataDir, testNs)
+	require.NoError(err, "NewLocalBackend")
+	defer backend.Cleanup()
+
+	_, err = os.Stat(GetLocalBackendDBDir(dataDir, "pathbadger"))
+	require.True(os.IsNotExist(err), "memory-only storage should not create a database directory")
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
 	"github.com/oasisprotocol/oasis-core/go/p2p/protocol"
 	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
@@ -17,6 +18,9 @@ const (
 	// for StorageSync protocol.
 	minProtocolPeers = 5
 
+	// peerStatsBucketName is the name of the common store bucket holding persisted peer statistics.
+	peerStatsBucketName = "worker/storage/p2p/sync/peer_stats"
+
 	// totalProtocolPeers is the number of peers we want to have connected for StorageSync protocol.
 	totalProtocolPeers = 10
 )
@@ -46,16 +50,46 @@ type Checkpoint struct {
 	Peers []rpc.PeerFeedback
 }
 
+// ClientOption is a storage sync protocol client option setter.
+type ClientOption func(c *client)
+
+// WithUpstreamPeers limits all requests to the given set of upstream peers.
+func WithUpstreamPeers(peers []core.PeerID) ClientOption {
+	return func(c *client) {
+		c.upstream = peers
+	}
+}
+
+// WithPeerStatsStore persists the statistics used for peer scoring in the given common store,
+// so that peer scoring survives restarts. Persisting stops once the given context is canceled.
+func WithPeerStatsStore(ctx context.Context, store *persistent.CommonStore) ClientOption {
+	return func(c *client) {
+		c.statsCtx = ctx
+		c.statsStore = store
+	}
+}
+
 type client struct {
 	rcC  rpc.Client
 	rcD  rpc.Client
 	mgrC rpc.PeerManager
 	mgrD rpc.PeerManager
+
+	upstream   []core.PeerID
+	statsCtx   context.Context
+	statsStore *persistent.CommonStore
+}
+
+func (c *client) getBestPeers(mgr rpc.PeerManager, opts ...rpc.BestPeersOption) []core.PeerID {
+	if len(c.upstream) > 0 {
+		opts = append([]rpc.BestPeersOption{rpc.WithLimitPeers(c.upstream)}, opts...)
+	}
+	return mgr.GetBestPeers(opts...)
 }
 
 func (c *client) GetDiff(ctx context.Context, request *GetDiffRequest) (*GetDiffResponse, rpc.PeerFeedback, error) {
 	var rsp GetDiffResponse
-	pf, err := c.rcD.CallOne(ctx, c.mgrD.GetBestPeers(), MethodGetDiff, request, &rsp,
+	pf, err := c.rcD.CallOne(ctx, c.getBestPeers(c.mgrD), MethodGetDiff, request, &rsp,
 		rpc.WithMaxPeerResponseTime(MaxGetDiffResponseTime),
 	)
 	if err != nil {
@@ -66,7 +100,7 @@ func (c *client) GetDiff(ctx context.Context, request *GetDiffRequest) (*GetDiff
 
 func (c *client) GetCheckpoints(ctx context.Context, request *GetCheckpointsRequest) ([]*Checkpoint, error) {
 	var rsp GetCheckpointsResponse
-	rsps, pfs, err := c.rcC.CallMulti(ctx, c.mgrC.GetBestPeers(), MethodGetCheckpoints, request, rsp)
+	rsps, pfs, err := c.rcC.CallMulti(ctx, c.getBestPeers(c.mgrC), MethodGetCheckpoints, request, rsp)
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +149,7 @@ func (c *client) GetCheckpointChunk(
 	}
 
 	var rsp GetCheckpointChunkResponse
-	pf, err := c.rcC.CallOne(ctx, c.mgrC.GetBestPeers(opts...), MethodGetCheckpointChunk, request, &rsp,
+	pf, err := c.rcC.CallOne(ctx, c.getBestPeers(c.mgrC, opts...), MethodGetCheckpointChunk, request, &rsp,
 		rpc.WithMaxPeerResponseTime(MaxGetCheckpointChunkResponseTime),
 	)
 	if err != nil {
@@ -125,26 +159,33 @@ func (c *client) GetCheckpointChunk(
 }
 
 // NewClient creates a new storage sync protocol client.
-func NewClient(p2p rpc.P2P, chainContext string, runtimeID common.Namespace) Client {
+func NewClient(p2p rpc.P2P, chainContext string, runtimeID common.Namespace, opts ...ClientOption) Client {
 	// Use two separate clients and managers for the same protocol. This is to make sure that peers
 	// are scored differently between the two use cases (syncing diffs vs. syncing checkpoints). We
 	// could consider separating this into two protocols in the future.
 	pid := protocol.NewRuntimeProtocolID(chainContext, runtimeID, StorageSyncProtocolID, StorageSyncProtocolVersion)
 
-	rcC := rpc.NewClient(p2p.Host(), pid)
-	mgrC := rpc.NewPeerManager(p2p, pid)
-	rcC.RegisterListener(mgrC)
+	c := &client{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	var optsC, optsD []rpc.PeerManagerOption
+	if c.statsStore != nil {
+		store := c.statsStore.GetServiceStore(peerStatsBucketName)
+		optsC = append(optsC, rpc.WithStatsStore(c.statsCtx, store, string(pid)+"/checkpoints"))
+		optsD = append(optsD, rpc.WithStatsStore(c.statsCtx, store, string(pid)+"/diffs"))
+	}
+
+	c.rcC = rpc.NewClient(p2p.Host(), pid)
+	c.mgrC = rpc.NewPeerManager(p2p, pid, optsC...)
+	c.rcC.RegisterListener(c.mgrC)
 
-	rcD := rpc.NewClient(p2p.Host(), pid)
-	mgrD := rpc.NewPeerManager(p2p, pid)
-	rcD.RegisterListener(mgrD)
+	c.rcD = rpc.NewClient(p2p.Host(), pid)
+	c.mgrD = rpc.NewPeerManager(p2p, pid, optsD...)
+	c.rcD.RegisterListener(c.mgrD)
 
 	p2p.RegisterProtocol(pid, minProtocolPeers, totalProtocolPeers)
 
-	return &client{
-		rcC:  rcC,
-		rcD:  rcD,
-		mgrC: mgrC,
-		mgrD: mgrD,
-	}
+	return c
 }
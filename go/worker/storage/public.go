@@ -0,0 +1,226 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	grpcConfig "github.com/oasisprotocol/oasis-core/go/common/grpc/config"
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	"github.com/oasisprotocol/oasis-core/go/worker/storage/config"
+)
+
+// maxPublicClients is the maximum number of client addresses tracked by the public storage
+// endpoint rate limiter.
+const maxPublicClients = 4096
+
+// tokenBucket is a token bucket rate limiter.
+type tokenBucket struct {
+	rate  float64
+	burst float64
+
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if now.Before(b.last) {
+		return
+	}
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+func (b *tokenBucket) full() bool {
+	return b.tokens >= b.burst
+}
+
+func newTokenBucket(rate float64, burst uint64, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   now,
+	}
+}
+
+// publicRateLimiter limits the rate of requests to the public storage endpoint per client
+// address and across all clients.
+type publicRateLimiter struct {
+	sync.Mutex
+
+	cfg *config.PublicGRPCConfig
+
+	total   *tokenBucket
+	clients map[string]*tokenBucket
+
+	now func() time.Time
+}
+
+// Allow returns true iff a request from the given client address may be served.
+func (l *publicRateLimiter) Allow(client string) bool {
+	l.Lock()
+	defer l.Unlock()
+
+	now := l.now()
+	bucket, ok := l.clients[client]
+	if !ok {
+		if len(l.clients) >= maxPublicClients {
+			l.evictIdle(now)
+		}
+		if len(l.clients) >= maxPublicClients {
+			return false
+		}
+		bucket = newTokenBucket(l.cfg.ClientRate, l.cfg.ClientBurst, now)
+		l.clients[client] = bucket
+	}
+
+	bucket.refill(now)
+	l.total.refill(now)
+	if bucket.tokens < 1 || l.total.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	l.total.tokens--
+	return true
+}
+
+// evictIdle removes clients whose buckets have been fully replenished, as tracking them no
+// longer has any effect.
+func (l *publicRateLimiter) evictIdle(now time.Time) {
+	for client, bucket := range l.clients {
+		bucket.refill(now)
+		if bucket.full() {
+			delete(l.clients, client)
+		}
+	}
+}
+
+// AuthFunc rejects requests of clients that exceeded their rate limits.
+func (l *publicRateLimiter) AuthFunc(ctx context.Context, _ interface{}) error {
+	var client string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		client = p.Addr.String()
+		if host, _, err := net.SplitHostPort(client); err == nil {
+			client = host
+		}
+	}
+	if !l.Allow(client) {
+		return api.ErrLimitReached
+	}
+	return nil
+}
+
+func newPublicRateLimiter(cfg *config.PublicGRPCConfig) *publicRateLimiter {
+	now := time.Now()
+	return &publicRateLimiter{
+		cfg:     cfg,
+		total:   newTokenBucket(cfg.TotalRate, cfg.TotalBurst, now),
+		clients: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// publicStorage is a read-only storage backend that serves SyncGet requests for all runtimes
+// configured on the node and rejects all other requests.
+type publicStorage struct {
+	sync.RWMutex
+
+	backends map[common.Namespace]api.Backend
+}
+
+func (s *publicStorage) register(id common.Namespace, backend api.Backend) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.backends[id] = backend
+}
+
+func (s *publicStorage) SyncGet(ctx context.Context, request *api.GetRequest) (*api.ProofResponse, error) {
+	s.RLock()
+	backend, ok := s.backends[request.Tree.Root.Namespace]
+	s.RUnlock()
+	if !ok {
+		return nil, api.ErrUnsupported
+	}
+	return backend.SyncGet(ctx, request)
+}
+
+func (s *publicStorage) SyncGetPrefixes(context.Context, *api.GetPrefixesRequest) (*api.ProofResponse, error) {
+	return nil, api.ErrUnsupported
+}
+
+func (s *publicStorage) SyncIterate(context.Context, *api.IterateRequest) (*api.ProofResponse, error) {
+	return nil, api.ErrUnsupported
+}
+
+func (s *publicStorage) GetDiff(context.Context, *api.GetDiffRequest) (api.WriteLogIterator, error) {
+	return nil, api.ErrUnsupported
+}
+
+func (s *publicStorage) GetCheckpoints(context.Context, *checkpoint.GetCheckpointsRequest) ([]*checkpoint.Metadata, error) {
+	return nil, api.ErrUnsupported
+}
+
+func (s *publicStorage) GetCheckpointChunk(context.Context, *checkpoint.ChunkMetadata, io.Writer) error {
+	return api.ErrUnsupported
+}
+
+func (s *publicStorage) Cleanup() {
+}
+
+func (s *publicStorage) Initialized() <-chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}
+
+// newPublicServer creates a new public read-only storage gRPC server authenticated by the node's
+// TLS identity.
+func newPublicServer(
+	cfg *config.PublicGRPCConfig,
+	grpcCfg *grpcConfig.Config,
+	identity *identity.Identity,
+	storage *publicStorage,
+	isCommitteePeer committeePeerFunc,
+	logger *logging.Logger,
+) (*cmnGrpc.Server, error) {
+	limiter := newPublicRateLimiter(cfg)
+	var opts []grpc.ServerOption
+	if cfg.MaxConcurrentRequests > 0 {
+		admission := newAdmissionController(cfg.MaxConcurrentRequests, isCommitteePeer)
+		opts = append(opts, grpc.ChainUnaryInterceptor(admission.UnaryInterceptor))
+	}
+	server, err := cmnGrpc.NewServer(&cmnGrpc.ServerConfig{
+		Name:          "storage-public",
+		Port:          cfg.Port,
+		Identity:      identity,
+		AuthFunc:      limiter.AuthFunc,
+		CustomOptions: opts,
+		Config:        grpcCfg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	api.RegisterService(server.Server(), storage)
+
+	logger.Info("public read-only storage endpoint enabled",
+		"port", cfg.Port,
+		"client_rate", cfg.ClientRate,
+		"total_rate", cfg.TotalRate,
+		"max_concurrent_requests", cfg.MaxConcurrentRequests,
+	)
+
+	return server, nil
+}
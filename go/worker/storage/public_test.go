@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+	storageWorkerAPI "github.com/oasisprotocol/oasis-core/go/worker/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/worker/storage/config"
+)
+
+func TestPublicRateLimiter(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now()
+	l := newPublicRateLimiter(&config.PublicGRPCConfig{
+		ClientRate:  1,
+		ClientBurst: 2,
+		TotalRate:   10,
+		TotalBurst:  3,
+	})
+	l.now = func() time.Time { return now }
+
+	// Clients are limited individually.
+	require.True(l.Allow("a"))
+	require.True(l.Allow("a"))
+	require.False(l.Allow("a"), "client burst should be exhausted")
+
+	// Clients are limited in total.
+	require.True(l.Allow("b"))
+	require.False(l.Allow("b"), "total burst should be exhausted")
+
+	// Tokens are replenished over time.
+	now = now.Add(time.Second)
+	require.True(l.Allow("a"))
+
+	// Idle clients are evicted when the number of tracked clients is exceeded.
+	now = now.Add(time.Hour)
+	for i := 0; i < maxPublicClients; i++ {
+		l.clients[fmt.Sprintf("idle-%d", i)] = newTokenBucket(1, 2, now)
+	}
+	require.True(l.Allow("c"), "idle clients should be evicted")
+	require.Len(l.clients, 1)
+}
+
+func TestPublicStorage(t *testing.T) {
+	require := require.New(t)
+
+	s := &publicStorage{
+		backends: make(map[common.Namespace]api.Backend),
+	}
+
+	var ns common.Namespace
+	_, err := s.SyncGet(context.Background(), &api.GetRequest{
+		Tree: syncer.TreeID{Root: api.Root{Namespace: ns}},
+	})
+	require.ErrorIs(err, api.ErrUnsupported, "unknown runtimes should be rejected")
+
+	_, err = s.SyncIterate(context.Background(), &api.IterateRequest{})
+	require.ErrorIs(err, api.ErrUnsupported, "only SyncGet should be supported")
+	_, err = s.GetDiff(context.Background(), &api.GetDiffRequest{})
+	require.ErrorIs(err, api.ErrUnsupported, "only SyncGet should be supported")
+}
+
+func TestAdmissionController(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	a := newAdmissionController(1, nil)
+
+	require.NoError(a.Acquire(ctx, false))
+
+	// External requests are queued up to the number of slots, and rejected afterwards.
+	normalCh := make(chan error, 1)
+	go func() {
+		normalCh <- a.Acquire(ctx, false)
+	}()
+	require.Eventually(func() bool {
+		a.Lock()
+		defer a.Unlock()
+		return len(a.normal) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.ErrorIs(a.Acquire(ctx, false), api.ErrLimitReached)
+
+	// Priority requests are queued and granted a slot first.
+	priorityCh := make(chan error, 1)
+	go func() {
+		priorityCh <- a.Acquire(ctx, true)
+	}()
+	require.Eventually(func() bool {
+		a.Lock()
+		defer a.Unlock()
+		return len(a.priority) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.ErrorIs(a.Acquire(ctx, true), api.ErrLimitReached, "priority queue should be bounded")
+
+	a.Release()
+	require.NoError(<-priorityCh)
+	require.Len(normalCh, 0, "external request should still be queued")
+
+	a.Release()
+	require.NoError(<-normalCh)
+
+	// Cancelled waiters are removed from the queue.
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(a.Acquire(cctx, true), context.Canceled)
+	require.Empty(a.priority)
+
+	a.Release()
+	require.EqualValues(0, a.inFlight)
+}
+
+func TestAdmissionControllerPriority(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID common.Namespace
+	memberKey := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000001")
+	otherKey := signature.NewPublicKey("0000000000000000000000000000000000000000000000000000000000000002")
+	a := newAdmissionController(1, func(id common.Namespace, tlsKey signature.PublicKey) bool {
+		return id.Equal(&runtimeID) && tlsKey.Equal(memberKey)
+	})
+
+	peerCtx := func(key signature.PublicKey) context.Context {
+		cert := &x509.Certificate{PublicKey: ed25519.PublicKey(key[:])}
+		return peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{
+				State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}},
+			},
+		})
+	}
+	req := &storageWorkerAPI.GetAvailabilityStatementRequest{RuntimeID: runtimeID}
+	info := &grpc.UnaryServerInfo{FullMethod: storageWorkerAPI.MethodGetAvailabilityStatement.FullName()}
+
+	require.True(a.isPriority(peerCtx(memberKey), req, info), "committee members should get priority")
+	require.False(a.isPriority(peerCtx(otherKey), req, info), "other peers should not get priority")
+	require.False(a.isPriority(context.Background(), req, info), "unauthenticated peers should not get priority")
+
+	var otherRuntimeID common.Namespace
+	otherRuntimeID[0] = 1
+	otherReq := &storageWorkerAPI.GetAvailabilityStatementRequest{RuntimeID: otherRuntimeID}
+	require.False(a.isPriority(peerCtx(memberKey), otherReq, info), "members of other committees should not get priority")
+
+	otherInfo := &grpc.UnaryServerInfo{FullMethod: api.MethodSyncGet.FullName()}
+	require.False(a.isPriority(peerCtx(memberKey), req, otherInfo), "other methods should not get priority")
+}
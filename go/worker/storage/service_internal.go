@@ -2,11 +2,19 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	storageAPI "github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/storage/api"
 )
 
-var _ api.StorageWorker = (*Worker)(nil)
+var (
+	_ api.StorageWorker        = (*Worker)(nil)
+	_ api.AvailabilityAttester = (*Worker)(nil)
+)
 
 func (w *Worker) GetLastSyncedRound(_ context.Context, request *api.GetLastSyncedRoundRequest) (*api.GetLastSyncedRoundResponse, error) {
 	node := w.runtimes[request.RuntimeID]
@@ -30,3 +38,39 @@ func (w *Worker) PauseCheckpointer(_ context.Context, request *api.PauseCheckpoi
 
 	return node.PauseCheckpointer(request.Pause)
 }
+
+func (w *Worker) GetAvailabilityStatement(ctx context.Context, request *api.GetAvailabilityStatementRequest) (*storageAPI.SignedAvailabilityStatement, error) {
+	node := w.runtimes[request.RuntimeID]
+	if node == nil {
+		return nil, api.ErrRuntimeNotFound
+	}
+
+	statement, err := node.GetAvailabilityStatement(ctx, request.Round)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := w.commonWorker.Identity.NodeSigner
+
+	// Bind the statement to the roles the node is currently registered with.
+	nodeDesc, err := w.commonWorker.Consensus.Registry().GetNode(ctx, &registry.IDQuery{
+		Height: consensus.HeightLatest,
+		ID:     signer.Public(),
+	})
+	switch {
+	case err == nil:
+		statement.Roles = nodeDesc.Roles
+	case errors.Is(err, registry.ErrNoSuchNode):
+	default:
+		return nil, fmt.Errorf("failed to get node descriptor: %w", err)
+	}
+
+	signed := &storageAPI.SignedAvailabilityStatement{
+		NodeID:    signer.Public(),
+		Statement: *statement,
+	}
+	if err = signed.Sign(signer); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
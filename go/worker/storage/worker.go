@@ -4,10 +4,13 @@ import (
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
 	"github.com/oasisprotocol/oasis-core/go/config"
+	storageAPI "github.com/oasisprotocol/oasis-core/go/storage/api"
 	workerCommon "github.com/oasisprotocol/oasis-core/go/worker/common"
 	committeeCommon "github.com/oasisprotocol/oasis-core/go/worker/common/committee"
 	"github.com/oasisprotocol/oasis-core/go/worker/registration"
@@ -21,12 +24,16 @@ type Worker struct {
 
 	commonWorker *workerCommon.Worker
 	registration *registration.Worker
+	commonStore  *persistent.CommonStore
 	logger       *logging.Logger
 
 	initCh chan struct{}
 	quitCh chan struct{}
 
 	runtimes map[common.Namespace]*committee.Node
+
+	publicStorage *publicStorage
+	publicServer  *grpc.Server
 }
 
 // New constructs a new storage worker.
@@ -34,6 +41,7 @@ func New(
 	grpcInternal *grpc.Server,
 	commonWorker *workerCommon.Worker,
 	registration *registration.Worker,
+	commonStore *persistent.CommonStore,
 ) (*Worker, error) {
 	enabled := config.GlobalConfig.Mode.HasLocalStorage() && len(commonWorker.GetRuntimes()) > 0
 
@@ -41,6 +49,7 @@ func New(
 		enabled:      enabled,
 		commonWorker: commonWorker,
 		registration: registration,
+		commonStore:  commonStore,
 		logger:       logging.GetLogger("worker/storage"),
 		initCh:       make(chan struct{}),
 		quitCh:       make(chan struct{}),
@@ -51,6 +60,17 @@ func New(
 		return s, nil
 	}
 
+	if cfg := &config.GlobalConfig.Storage.PublicGRPC; cfg.Enabled {
+		s.publicStorage = &publicStorage{
+			backends: make(map[common.Namespace]storageAPI.Backend),
+		}
+		var err error
+		if s.publicServer, err = newPublicServer(cfg, &config.GlobalConfig.GRPC, commonWorker.Identity, s.publicStorage, s.isCommitteePeer, s.logger); err != nil {
+			return nil, fmt.Errorf("failed to create public storage gRPC server: %w", err)
+		}
+		storageWorkerAPI.RegisterAvailabilityService(s.publicServer.Server(), s)
+	}
+
 	// Start storage node for every runtime.
 	for id, rt := range s.commonWorker.GetRuntimes() {
 		if err := s.registerRuntime(rt); err != nil {
@@ -100,11 +120,15 @@ func (w *Worker) registerRuntime(commonNode *committeeCommon.Node) error {
 			Disabled:          config.GlobalConfig.Storage.CheckpointSyncDisabled,
 			ChunkFetcherCount: config.GlobalConfig.Storage.FetcherCount,
 		},
+		w.commonStore,
 	)
 	if err != nil {
 		return err
 	}
 	commonNode.Runtime.RegisterStorage(localStorage)
+	if w.publicStorage != nil {
+		w.publicStorage.register(id, localStorage)
+	}
 	commonNode.AddHooks(node)
 	w.runtimes[id] = node
 
@@ -149,6 +173,9 @@ func (w *Worker) Start() error {
 		for _, r := range w.runtimes {
 			<-r.Quit()
 		}
+		if w.publicServer != nil {
+			<-w.publicServer.Quit()
+		}
 	}()
 
 	// Start all runtimes and wait for initialization.
@@ -166,6 +193,15 @@ func (w *Worker) Start() error {
 
 		w.logger.Info("storage worker started")
 
+		// Only serve public requests once local storage has been synced.
+		if w.publicServer != nil {
+			if err := w.publicServer.Start(); err != nil {
+				w.logger.Error("failed to start public storage gRPC server",
+					"err", err,
+				)
+			}
+		}
+
 		close(w.initCh)
 	}()
 
@@ -182,6 +218,9 @@ func (w *Worker) Stop() {
 	for _, r := range w.runtimes {
 		r.Stop()
 	}
+	if w.publicServer != nil {
+		w.publicServer.Stop()
+	}
 }
 
 // Quit returns a channel that will be closed when the service terminates.
@@ -191,6 +230,9 @@ func (w *Worker) Quit() <-chan struct{} {
 
 // Cleanup performs the service specific post-termination cleanup.
 func (w *Worker) Cleanup() {
+	if w.publicServer != nil {
+		w.publicServer.Cleanup()
+	}
 }
 
 // GetRuntime returns a storage committee node for the given runtime (if available).
@@ -199,3 +241,23 @@ func (w *Worker) Cleanup() {
 func (w *Worker) GetRuntime(id common.Namespace) *committee.Node {
 	return w.runtimes[id]
 }
+
+// isCommitteePeer returns true iff the given TLS public key belongs to a member of the current
+// executor committee of the given runtime.
+func (w *Worker) isCommitteePeer(runtimeID common.Namespace, tlsKey signature.PublicKey) bool {
+	rt := w.commonWorker.GetRuntime(runtimeID)
+	if rt == nil {
+		return false
+	}
+	epoch := rt.Group.GetEpochSnapshot()
+	if !epoch.IsValid() {
+		return false
+	}
+	for _, member := range epoch.GetExecutorCommittee().Committee.Members {
+		n := epoch.Nodes().Lookup(member.PublicKey)
+		if n != nil && n.TLS.PubKey.Equal(tlsKey) {
+			return true
+		}
+	}
+	return false
+}
@@ -41,12 +41,16 @@ pub const ENDORSE_CAPABILITY_TEE_SIGNATURE_CONTEXT: &[u8] =
 /// Represents the address of a TCP endpoint.
 #[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
 pub struct TCPAddress {
+    /// IP address. Not set for addresses referring to hostnames.
     #[cbor(rename = "IP")]
-    pub ip: Vec<u8>,
+    pub ip: Option<Vec<u8>>,
     #[cbor(rename = "Port")]
     pub port: i64,
     #[cbor(rename = "Zone")]
     pub zone: String,
+    /// Hostname the address refers to. If set, the IP and zone are empty.
+    #[cbor(optional, rename = "Host")]
+    pub host: String,
 }
 
 /// Represents an Oasis committee address that includes a TLS public key and a TCP address.
@@ -115,6 +119,33 @@ pub struct VRFInfo {
     pub id: signature::PublicKey,
 }
 
+/// Contains hints about the node's capacity for hosting runtimes.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct CapacityInfo {
+    /// Maximum number of runtimes the node can be concurrently elected for (0 means no limit).
+    #[cbor(optional)]
+    pub max_runtimes: u16,
+
+    /// Amount of TEE memory (in bytes) available for hosting runtimes (0 means unknown).
+    #[cbor(optional)]
+    pub tee_memory: u64,
+}
+
+/// Contains build metadata of the node's software.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct BuildInfo {
+    /// Git commit the oasis-node binary was built from.
+    #[cbor(optional)]
+    pub commit: String,
+
+    /// Version of the Go toolchain the oasis-node binary was built with.
+    pub toolchain: Version,
+
+    /// Enclave identities of the hosted runtime components, when known at build time.
+    #[cbor(optional)]
+    pub enclaves: Vec<sgx::EnclaveIdentity>,
+}
+
 /// Represents the node's TEE capability.
 #[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
 pub struct CapabilityTEE {
@@ -388,6 +419,14 @@ pub struct Node {
     /// Node's oasis-node software version.
     #[cbor(optional)]
     pub software_version: Option<String>,
+
+    /// Hints about the node's capacity for hosting runtimes.
+    #[cbor(optional)]
+    pub capacity: Option<CapacityInfo>,
+
+    /// Build metadata of the node's software.
+    #[cbor(optional)]
+    pub build: Option<BuildInfo>,
 }
 
 impl Node {
@@ -525,6 +564,9 @@ pub struct SchedulingConstraints {
 
     #[cbor(optional)]
     pub min_pool_size: Option<MinPoolSizeConstraint>,
+
+    #[cbor(optional)]
+    pub tee_memory: Option<TEEMemoryConstraint>,
 }
 
 /// A constraint which specifies that the entity must have a node that is part of the validator set.
@@ -544,6 +586,12 @@ pub struct MinPoolSizeConstraint {
     pub limit: u16,
 }
 
+/// A constraint which specifies the amount of TEE memory (in bytes) a node needs to host the runtime.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct TEEMemoryConstraint {
+    pub amount: u64,
+}
+
 /// Stake-related parameters for a runtime.
 #[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
 pub struct RuntimeStakingParameters {
@@ -1089,6 +1137,7 @@ mod tests {
                                     }
                                 ),
                                 validator_set: Some(ValidatorSetConstraint{}),
+                                tee_memory: None,
                             },
                         }
                     },
@@ -1196,6 +1245,38 @@ mod tests {
         }
     }
 
+    #[test]
+    fn test_consistent_tcp_address() {
+        // NOTE: These tests MUST be synced with go/common/node/address_test.go.
+        let tcs = vec![
+            (
+                "o2JJUER/AAABZFBvcnQZaCBkWm9uZWA=",
+                TCPAddress {
+                    ip: Some(vec![127, 0, 0, 1]),
+                    port: 26656,
+                    ..Default::default()
+                },
+            ),
+            (
+                "pGJJUPZkSG9zdHBub2RlLmV4YW1wbGUuY29tZFBvcnQZaCBkWm9uZWA=",
+                TCPAddress {
+                    port: 26656,
+                    host: "node.example.com".to_string(),
+                    ..Default::default()
+                },
+            ),
+        ];
+        for (encoded_base64, addr) in tcs {
+            let dec: TCPAddress =
+                cbor::from_slice(&BASE64_STANDARD.decode(encoded_base64).unwrap())
+                    .expect("address should deserialize correctly");
+            assert_eq!(dec, addr, "decoded address should match the expected value");
+
+            let ser = BASE64_STANDARD.encode(cbor::to_vec(dec));
+            assert_eq!(ser, encoded_base64, "address should serialize correctly");
+        }
+    }
+
     #[test]
     fn test_deserialize_node_v2() {
         // NOTE: These tests MUST be synced with go/common/node/node_test.go.
@@ -1226,15 +1307,15 @@ mod tests {
                         _deprecated_addresses: Some(vec![
                             TLSAddress{
                                 pub_key: signature::PublicKey::from("fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff4"),
-                                address: TCPAddress { ip: Ipv4Addr::new(127, 0, 0, 1).to_ipv6_mapped().octets().to_vec(), port: 123, ..Default::default() }
+                                address: TCPAddress { ip: Some(Ipv4Addr::new(127, 0, 0, 1).to_ipv6_mapped().octets().to_vec()), port: 123, ..Default::default() }
                             },
                             TLSAddress{
                                 pub_key: signature::PublicKey::from("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffc4"),
-                                address: TCPAddress { ip: Ipv4Addr::new(192, 168, 1, 1).to_ipv6_mapped().octets().to_vec(), port: 4000, ..Default::default() }
+                                address: TCPAddress { ip: Some(Ipv4Addr::new(192, 168, 1, 1).to_ipv6_mapped().octets().to_vec()), port: 4000, ..Default::default() }
                             },
                             TLSAddress{
                                 pub_key: signature::PublicKey::from("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffd4"),
-                                address: TCPAddress { ip: Ipv4Addr::new(234, 100, 99, 88).to_ipv6_mapped().octets().to_vec(), port: 8000, ..Default::default() }
+                                address: TCPAddress { ip: Some(Ipv4Addr::new(234, 100, 99, 88).to_ipv6_mapped().octets().to_vec()), port: 8000, ..Default::default() }
                             },
 
                             ])
@@ -1254,7 +1335,7 @@ mod tests {
                         _deprecated_next_pub_key: Some(signature::PublicKey::from("fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff3")),
                         _deprecated_addresses: Some(vec![TLSAddress{
                                 pub_key: signature::PublicKey::from("fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff4"),
-                                address: TCPAddress { ip: Ipv4Addr::new(127, 0, 0, 1).to_ipv6_mapped().octets().to_vec(), port: 123, ..Default::default() }
+                                address: TCPAddress { ip: Some(Ipv4Addr::new(127, 0, 0, 1).to_ipv6_mapped().octets().to_vec()), port: 123, ..Default::default() }
                             }])
                     },
                     p2p: P2PInfo{
@@ -1303,7 +1384,7 @@ mod tests {
                         _deprecated_next_pub_key: Some(signature::PublicKey::from("fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff3")),
                         _deprecated_addresses: Some(vec![TLSAddress{
                                 pub_key: signature::PublicKey::from("fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff4"),
-                                address: TCPAddress { ip: Ipv4Addr::new(127, 0, 0, 1).to_ipv6_mapped().octets().to_vec(), port: 123, ..Default::default() }
+                                address: TCPAddress { ip: Some(Ipv4Addr::new(127, 0, 0, 1).to_ipv6_mapped().octets().to_vec()), port: 123, ..Default::default() }
                             }])
                     },
                     p2p: P2PInfo{
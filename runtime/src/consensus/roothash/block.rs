@@ -32,6 +32,7 @@ impl Block {
                 state_root: Hash::empty_hash(),
                 messages_hash: Hash::empty_hash(),
                 in_msgs_hash: Hash::empty_hash(),
+                shard_roots: vec![],
             },
         }
     }
@@ -51,6 +52,17 @@ impl Block {
                 state_root: child.header.state_root,
                 messages_hash: Hash::empty_hash(),
                 in_msgs_hash: Hash::empty_hash(),
+                // State roots of other executor groups are unchanged as well.
+                shard_roots: child
+                    .header
+                    .shard_roots
+                    .iter()
+                    .map(|sr| ShardRoots {
+                        group: sr.group,
+                        io_root: Hash::empty_hash(),
+                        state_root: sr.state_root,
+                    })
+                    .collect(),
             },
         }
     }
@@ -99,6 +111,31 @@ pub struct Header {
     pub messages_hash: Hash,
     /// Hash of processed incoming messages.
     pub in_msgs_hash: Hash,
+    /// Roots of executor groups other than the primary one, ordered by group.
+    ///
+    /// Only present in headers with version `HEADER_VERSION_SHARD_ROOTS` or later.
+    #[cbor(optional)]
+    pub shard_roots: Vec<ShardRoots>,
+}
+
+/// Header version where a block has a single I/O and state root.
+pub const HEADER_VERSION_SINGLE_ROOT: u16 = 0;
+/// Header version where a block also carries the roots of other executor groups.
+pub const HEADER_VERSION_SHARD_ROOTS: u16 = 1;
+
+/// Storage roots produced by a single executor group.
+///
+/// # Note
+///
+/// This should be kept in sync with go/roothash/api/block/header.go.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct ShardRoots {
+    /// Executor group index. The primary executor group has index zero.
+    pub group: u16,
+    /// I/O merkle root of the executor group.
+    pub io_root: Hash,
+    /// State merkle root of the executor group.
+    pub state_root: Hash,
 }
 
 impl Header {
@@ -110,6 +147,8 @@ impl Header {
 
 #[cfg(test)]
 mod tests {
+    use rustc_hex::FromHex;
+
     use super::*;
 
     #[test]
@@ -132,10 +171,66 @@ mod tests {
             state_root: Hash::empty_hash(),
             messages_hash: Hash::empty_hash(),
             in_msgs_hash: Hash::empty_hash(),
+            ..Default::default()
         };
         assert_eq!(
             populated.encoded_hash(),
             Hash::from("b17374d9b36796752a787d0726ef44826bfdb3ece52545e126c8e7592663544d")
         );
+
+        let sharded = Header {
+            version: HEADER_VERSION_SHARD_ROOTS,
+            shard_roots: vec![
+                ShardRoots {
+                    group: 1,
+                    io_root: Hash::empty_hash(),
+                    state_root: Hash::empty_hash(),
+                },
+                ShardRoots {
+                    group: 3,
+                    io_root: Hash::empty_hash(),
+                    state_root: empty.encoded_hash(),
+                },
+            ],
+            ..populated
+        };
+        assert_eq!(
+            sharded.encoded_hash(),
+            Hash::from("cea3c81eb664ff87d2b7a7aeb8452dec4341e8c90906ee4b04d412e1773e98b6")
+        );
+    }
+
+    #[test]
+    fn test_header_vectors() {
+        // NOTE: The test vectors are generated by go/roothash/gen_vectors.
+        const RAW_VECTORS: &str = include_str!("../../../testdata/roothash_header_vectors.json");
+
+        #[derive(serde::Deserialize)]
+        struct TestVector {
+            kind: String,
+            encoded: String,
+            hash: String,
+        }
+
+        let vectors: Vec<TestVector> = serde_json::from_str(RAW_VECTORS).unwrap();
+        assert!(!vectors.is_empty());
+
+        for v in vectors {
+            let encoded: Vec<u8> = v.encoded.from_hex().unwrap();
+            let header: Header = cbor::from_slice(&encoded)
+                .unwrap_or_else(|err| panic!("failed to decode header ({}): {}", v.kind, err));
+            assert_eq!(
+                cbor::to_vec(header.clone()),
+                encoded,
+                "encoding should round-trip ({})",
+                v.kind
+            );
+            assert_eq!(
+                header.encoded_hash(),
+                v.hash.parse::<Hash>().unwrap(),
+                "encoded hash ({})",
+                v.kind
+            );
+        }
     }
 }
@@ -1,5 +1,11 @@
 //! Secure channel session.
-use std::{collections::HashSet, io::Write, mem, sync::Arc};
+use std::{
+    collections::HashSet,
+    io::Write,
+    mem,
+    num::NonZeroUsize,
+    sync::{Arc, Mutex},
+};
 
 use anyhow::Result;
 use thiserror::Error;
@@ -7,13 +13,17 @@ use thiserror::Error;
 use super::types::Message;
 use crate::{
     common::{
-        crypto::signature::{self, PublicKey, Signature, Signer},
+        crypto::{
+            hash::Hash,
+            signature::{self, PublicKey, Signature, Signer},
+        },
         namespace::Namespace,
         sgx::{ias, EnclaveIdentity, Quote, QuotePolicy},
     },
     consensus::{
+        beacon::EpochTime,
         registry::{EndorsedCapabilityTEE, VerifiedAttestation, VerifiedEndorsedCapabilityTEE},
-        state::registry::ImmutableState as RegistryState,
+        state::{beacon::ImmutableState as BeaconState, registry::ImmutableState as RegistryState},
         verifier::Verifier,
     },
     identity::Identity,
@@ -23,6 +33,8 @@ use crate::{
 const NOISE_PATTERN: &str = "Noise_XX_25519_ChaChaPoly_SHA256";
 /// RAK signature session binding context.
 const RAK_SESSION_BINDING_CONTEXT: [u8; 8] = *b"EkRakRpc";
+/// Maximum number of verified remote attestations kept for session resumption.
+const RESUMPTION_CACHE_SIZE: usize = 128;
 
 /// Session-related error.
 #[derive(Error, Debug)]
@@ -252,14 +264,40 @@ impl Session {
             .ok_or(SessionError::MissingQuotePolicy)?;
 
         let rak_binding: RAKBinding = cbor::from_slice(rak_binding)?;
-        let vect = rak_binding.verify(remote_static, &self.cfg.remote_enclaves, policy)?;
+        let resumption_key = rak_binding.resumption_key(&self.remote_node, policy);
+
+        // Verified remote attestations are only reused within the epoch in which they were
+        // verified, so resumption requires node identity verification to be enabled.
+        let epoch = match self.cfg.consensus_verifier {
+            Some(_) => Some(self.current_epoch().await?),
+            None => None,
+        };
+        let resumed = epoch
+            .and_then(|epoch| self.cfg.resumption_cache.get(&resumption_key, epoch));
+        let is_resumed = resumed.is_some();
+
+        let vect = match resumed {
+            Some(vect) => {
+                // The quote has already been verified against the same policy during this epoch
+                // (e.g. the session is being re-established after a transient error), so only
+                // re-check the enclave identity and the binding of the new static key.
+                rak_binding.verify_resumed(&vect, remote_static, &self.cfg.remote_enclaves)?;
+                vect
+            }
+            None => rak_binding.verify(remote_static, &self.cfg.remote_enclaves, policy)?,
+        };
 
-        // Verify node identity if verification is enabled.
+        // Verify node identity if verification is enabled. This is also done when resuming, so
+        // that nodes which are no longer registered with the given RAK are rejected.
         if self.cfg.consensus_verifier.is_some() {
             let rak = rak_binding.rak_pub();
             self.verify_node_identity(rak).await?;
         }
 
+        if let (Some(epoch), false) = (epoch, is_resumed) {
+            self.cfg.resumption_cache.put(resumption_key, epoch, vect.clone());
+        }
+
         Ok(Some(Arc::new(SessionInfo {
             rak_binding,
             verified_attestation: vect.verified_attestation,
@@ -344,6 +382,23 @@ impl Session {
         }
         Ok(())
     }
+
+    async fn current_epoch(&self) -> Result<EpochTime> {
+        let consensus_verifier = self
+            .cfg
+            .consensus_verifier
+            .as_ref()
+            .expect("consensus verifier should be set");
+
+        let consensus_state = consensus_verifier.latest_state().await?;
+        // TODO: Make this access async.
+        let epoch = tokio::task::block_in_place(move || -> Result<_> {
+            let beacon_state = BeaconState::new(&consensus_state);
+            Ok(beacon_state.epoch()?)
+        })?;
+
+        Ok(epoch)
+    }
 }
 
 /// Binding of the session's static public key to a remote attestation
@@ -416,17 +471,76 @@ impl RAKBinding {
         Identity::verify_binding(&vect.verified_attestation.quote, &self.rak_pub())?;
 
         // Verify MRENCLAVE/MRSIGNER.
+        Self::verify_enclave_identity(&vect, remote_enclaves)?;
+
+        // Verify remote static key binding.
+        self.verify_binding(remote_static)?;
+
+        Ok(vect)
+    }
+
+    /// Verify the RAK binding of a resumed session using a previously verified attestation.
+    fn verify_resumed(
+        &self,
+        vect: &VerifiedEndorsedCapabilityTEE,
+        remote_static: &[u8],
+        remote_enclaves: &Option<HashSet<EnclaveIdentity>>,
+    ) -> Result<()> {
+        // Verify MRENCLAVE/MRSIGNER.
+        Self::verify_enclave_identity(vect, remote_enclaves)?;
+
+        // Verify remote static key binding.
+        self.verify_binding(remote_static)
+    }
+
+    /// Verify that the attested enclave identity is one of the allowed identities.
+    fn verify_enclave_identity(
+        vect: &VerifiedEndorsedCapabilityTEE,
+        remote_enclaves: &Option<HashSet<EnclaveIdentity>>,
+    ) -> Result<()> {
         if let Some(ref remote_enclaves) = remote_enclaves {
             if !remote_enclaves.contains(&vect.verified_attestation.quote.identity) {
                 return Err(SessionError::MismatchedEnclaveIdentity.into());
             }
         }
+        Ok(())
+    }
 
-        // Verify remote static key binding.
+    /// Verify that the session's static public key is bound to RAK.
+    fn verify_binding(&self, remote_static: &[u8]) -> Result<()> {
         self.binding()
             .verify(&self.rak_pub(), &RAK_SESSION_BINDING_CONTEXT, remote_static)?;
+        Ok(())
+    }
 
-        Ok(vect)
+    /// Key under which the verified remote attestation is stored for session resumption.
+    ///
+    /// The key covers everything except the session-specific static key binding, together with
+    /// the quote policy the attestation was verified against.
+    fn resumption_key(
+        &self,
+        remote_node: &Option<signature::PublicKey>,
+        policy: &QuotePolicy,
+    ) -> Hash {
+        let mut attestation = self.clone();
+        match attestation {
+            Self::V0 {
+                ref mut binding, ..
+            }
+            | Self::V1 {
+                ref mut binding, ..
+            }
+            | Self::V2 {
+                ref mut binding, ..
+            } => *binding = Signature::default(),
+        }
+
+        let remote_node = remote_node.as_ref().map(|node| node.as_ref().to_vec());
+        Hash::digest_bytes_list(&[
+            &cbor::to_vec(attestation)[..],
+            &remote_node.unwrap_or_default()[..],
+            &cbor::to_vec(policy.clone())[..],
+        ])
     }
 
     fn verify_inner(&self, policy: &QuotePolicy) -> Result<VerifiedEndorsedCapabilityTEE> {
@@ -440,6 +554,51 @@ impl RAKBinding {
     }
 }
 
+/// Cache of recently verified remote attestations.
+///
+/// Re-establishing a session with a previously verified peer (e.g. after a transient network
+/// error) reuses the cached quote verification result instead of verifying the quote again.
+/// Entries are only valid during the epoch in which they were verified, and the enclave identity,
+/// the static key binding and the node identity are still checked on every resumption.
+struct ResumptionCache {
+    entries: Mutex<lru::LruCache<Hash, ResumptionEntry>>,
+}
+
+struct ResumptionEntry {
+    vect: VerifiedEndorsedCapabilityTEE,
+    epoch: EpochTime,
+}
+
+impl ResumptionCache {
+    fn get(&self, key: &Hash, epoch: EpochTime) -> Option<VerifiedEndorsedCapabilityTEE> {
+        let mut entries = self.entries.lock().unwrap();
+        match entries.get(key) {
+            Some(entry) if entry.epoch == epoch => return Some(entry.vect.clone()),
+            Some(_) => {}
+            None => return None,
+        }
+
+        // Discard entry verified in a different epoch.
+        entries.pop(key);
+        None
+    }
+
+    fn put(&self, key: Hash, epoch: EpochTime, vect: VerifiedEndorsedCapabilityTEE) {
+        let mut entries = self.entries.lock().unwrap();
+        entries.put(key, ResumptionEntry { vect, epoch });
+    }
+}
+
+impl Default for ResumptionCache {
+    fn default() -> Self {
+        Self {
+            entries: Mutex::new(lru::LruCache::new(
+                NonZeroUsize::new(RESUMPTION_CACHE_SIZE).unwrap(),
+            )),
+        }
+    }
+}
+
 /// Session configuration.
 #[derive(Clone, Default)]
 struct Config {
@@ -449,6 +608,7 @@ struct Config {
     remote_runtime_id: Option<Namespace>,
     use_endorsement: bool,
     policy: Option<Arc<QuotePolicy>>,
+    resumption_cache: Arc<ResumptionCache>,
 }
 
 /// Session builder.
@@ -466,6 +626,7 @@ impl Builder {
     /// Enable remote enclave identity verification.
     pub fn remote_enclaves(mut self, enclaves: Option<HashSet<EnclaveIdentity>>) -> Self {
         self.cfg.remote_enclaves = enclaves;
+        self.cfg.resumption_cache = Default::default();
         self
     }
 
@@ -477,12 +638,14 @@ impl Builder {
     /// Set remote runtime ID for node identity verification.
     pub fn remote_runtime_id(mut self, id: Option<Namespace>) -> Self {
         self.cfg.remote_runtime_id = id;
+        self.cfg.resumption_cache = Default::default();
         self
     }
 
     /// Enable remote node identity verification.
     pub fn consensus_verifier(mut self, verifier: Option<Arc<dyn Verifier>>) -> Self {
         self.cfg.consensus_verifier = verifier;
+        self.cfg.resumption_cache = Default::default();
         self
     }
 
@@ -494,6 +657,7 @@ impl Builder {
     /// Configure quote policy used for remote quote verification.
     pub fn quote_policy(mut self, policy: Option<Arc<QuotePolicy>>) -> Self {
         self.cfg.policy = policy;
+        self.cfg.resumption_cache = Default::default();
         self
     }
 
@@ -542,3 +706,63 @@ impl Builder {
         Session::new(session, keypair.public, cfg)
     }
 }
+
+#[cfg(test)]
+mod test {
+    use super::*;
+
+    fn test_rak_binding(binding: Signature) -> RAKBinding {
+        RAKBinding::V0 {
+            rak_pub: PublicKey::from(
+                "0000000000000000000000000000000000000000000000000000000000000001",
+            ),
+            binding,
+            avr: Default::default(),
+        }
+    }
+
+    #[test]
+    fn test_resumption_key() {
+        let policy = QuotePolicy::default();
+        let other_policy = QuotePolicy {
+            ias: Some(ias::QuotePolicy {
+                disabled: true,
+                ..Default::default()
+            }),
+            ..Default::default()
+        };
+        let node = Some(PublicKey::from(
+            "0000000000000000000000000000000000000000000000000000000000000002",
+        ));
+
+        let rb = test_rak_binding(Signature::default());
+        let key = rb.resumption_key(&node, &policy);
+
+        // The session-specific binding should not affect the key.
+        let other_rb = test_rak_binding(Signature::from(vec![1; 64]));
+        assert_eq!(key, other_rb.resumption_key(&node, &policy));
+
+        // The remote node and the quote policy should affect the key.
+        assert_ne!(key, rb.resumption_key(&None, &policy));
+        assert_ne!(key, rb.resumption_key(&node, &other_policy));
+    }
+
+    #[test]
+    fn test_resumption_cache() {
+        let cache = ResumptionCache::default();
+        let key = Hash::digest_bytes(b"resumption key");
+        let other_key = Hash::digest_bytes(b"other resumption key");
+
+        assert!(cache.get(&key, 1).is_none(), "empty cache should miss");
+
+        cache.put(key, 1, Default::default());
+        assert!(cache.get(&key, 1).is_some(), "same epoch should hit");
+        assert!(cache.get(&other_key, 1).is_none(), "different key should miss");
+
+        assert!(cache.get(&key, 2).is_none(), "different epoch should miss");
+        assert!(
+            cache.get(&key, 1).is_none(),
+            "entry from a different epoch should be discarded"
+        );
+    }
+}